@@ -1,19 +1,27 @@
 package main
 
 import (
+	"context"
+	"expvar"
 	"log"
 	"runtime"
 	"runtime/debug"
 	"time"
 
 	"github.com/gin-contrib/pprof"
+	"github.com/myacey/avito-shop/internal/authn"
 	"github.com/myacey/avito-shop/internal/backconfig"
 	"github.com/myacey/avito-shop/internal/controller"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/fraud"
 	"github.com/myacey/avito-shop/internal/hasher"
 	"github.com/myacey/avito-shop/internal/jwttoken"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/oidc"
 	"github.com/myacey/avito-shop/internal/repository/postgresrepo"
 	"github.com/myacey/avito-shop/internal/repository/redisrepo"
 	"github.com/myacey/avito-shop/internal/service"
+	"github.com/myacey/avito-shop/internal/worker"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
@@ -53,18 +61,275 @@ func main() {
 	}
 	sessionRepo := redisrepo.NewRedisSessionRepo(redisConn)
 
-	srv := service.NewService(dbConn, usrRepo, trxRepo, inventoryRepo, storeRepo, sessionRepo, tokenMaker, &hasher.BcryptHasher{})
+	var srvOpts []service.Option
+
+	credentialPolicy, err := credentials.NewPolicy(credentials.Config{
+		UsernameMinLength:  cfg.UsernameMinLength,
+		UsernameMaxLength:  cfg.UsernameMaxLength,
+		UsernamePattern:    cfg.UsernamePattern,
+		ReservedUsernames:  cfg.ReservedUsernames,
+		PasswordMinLength:  cfg.PasswordMinLength,
+		PasswordMinClasses: cfg.PasswordMinClasses,
+		BreachedFile:       cfg.BreachedPasswordsFile,
+	})
+	if err != nil {
+		panic(err)
+	}
+	srvOpts = append(srvOpts, service.WithCredentialPolicy(credentialPolicy))
+
+	loginAttemptRepo := redisrepo.NewRedisLoginAttemptRepo(redisConn)
+	srvOpts = append(srvOpts, service.WithLoginThrottle(loginAttemptRepo, models.LoginThrottle{
+		UserFreeAttempts: cfg.LoginUserFreeAttempts,
+		IPFreeAttempts:   cfg.LoginIPFreeAttempts,
+		BaseDelay:        cfg.LoginBackoffBase,
+		MaxDelay:         cfg.LoginBackoffMax,
+		Window:           cfg.LoginFailureWindow,
+	}))
+	if cfg.OIDCIssuer != "" {
+		discoveryCtx, cancelDiscovery := context.WithTimeout(context.Background(), 10*time.Second)
+		provider, err := oidc.NewProvider(discoveryCtx, oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}, nil)
+		cancelDiscovery()
+		if err != nil {
+			panic(err)
+		}
+
+		oidcStateRepo := redisrepo.NewRedisOIDCStateRepo(redisConn)
+		externalIdentityRepo := postgresrepo.NewPostgresExternalIdentityRepo(psqlQueries)
+		srvOpts = append(srvOpts, service.WithOIDC(provider, oidcStateRepo, externalIdentityRepo))
+	}
+
+	if cfg.CoinLifetimeMonths > 0 {
+		coinLotRepo := postgresrepo.NewPostgresCoinLotRepo(psqlQueries)
+		srvOpts = append(srvOpts, service.WithCoinLots(coinLotRepo, cfg.CoinLifetimeMonths, cfg.CoinExpiryNotice))
+	}
+
+	transferLimitRepo := postgresrepo.NewPostgresTransferLimitRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithTransferLimits(transferLimitRepo, models.TransferLimits{
+		PerTransaction: cfg.TransferLimitPerTransaction,
+		Daily:          cfg.TransferLimitDaily,
+		Monthly:        cfg.TransferLimitMonthly,
+		PerRecipient:   cfg.TransferLimitPerRecipient,
+	}))
+
+	if cfg.FraudDetection {
+		detector := fraud.NewDetector(
+			&fraud.CycleRule{
+				Transfers: trxRepo,
+				Window:    cfg.FraudWindow,
+				MaxDepth:  cfg.FraudCycleMaxDepth,
+				Action:    fraud.ParseAction(cfg.FraudCycleAction),
+			},
+			&fraud.FanInRule{
+				Users:      usrRepo,
+				Transfers:  trxRepo,
+				Window:     cfg.FraudWindow,
+				AccountAge: cfg.FraudNewAccountAge,
+				MinSenders: cfg.FraudFanInMinSenders,
+				Action:     fraud.ParseAction(cfg.FraudFanInAction),
+			},
+			&fraud.VelocityRule{
+				Transfers: trxRepo,
+				Window:    cfg.FraudVelocityWindow,
+				MaxCount:  cfg.FraudVelocityMaxCount,
+				Action:    fraud.ParseAction(cfg.FraudVelocityAction),
+			},
+		)
+		fraudCaseRepo := postgresrepo.NewPostgresFraudCaseRepo(psqlQueries)
+		srvOpts = append(srvOpts, service.WithFraudDetector(detector, fraudCaseRepo))
+	}
+
+	if cfg.TransferApprovalThreshold > 0 {
+		approvalRepo := postgresrepo.NewPostgresTransferApprovalRepo(psqlQueries)
+		srvOpts = append(srvOpts, service.WithTransferApprovals(approvalRepo, cfg.TransferApprovalThreshold, cfg.TransferApprovalTimeout))
+	}
+
+	notificationRepo := postgresrepo.NewPostgresNotificationRepo(psqlQueries)
+	giftRepo := postgresrepo.NewPostgresGiftRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithNotifications(notificationRepo), service.WithGifts(giftRepo))
+
+	itemTransferRepo := postgresrepo.NewPostgresItemTransferRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithItemTransfers(itemTransferRepo))
+
+	listingRepo := postgresrepo.NewPostgresListingRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithMarketplace(listingRepo, cfg.MarketFeePercent, cfg.MarketListingTTL))
+
+	auctionRepo := postgresrepo.NewPostgresAuctionRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithAuctions(auctionRepo))
+
+	orderRepo := postgresrepo.NewPostgresOrderRepo(psqlQueries)
+	promoCodeRepo := postgresrepo.NewPostgresPromoCodeRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithOrders(orderRepo), service.WithPromoCodes(promoCodeRepo))
+	if len(cfg.PickupLocations) > 0 {
+		srvOpts = append(srvOpts, service.WithPickupLocations(cfg.PickupLocations))
+	}
+
+	bundleRepo := postgresrepo.NewPostgresBundleRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithBundles(bundleRepo))
+
+	purchaseLimitRepo := postgresrepo.NewPostgresPurchaseLimitRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithPurchaseLimits(purchaseLimitRepo))
+
+	wishlistRepo := postgresrepo.NewPostgresWishlistRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithWishlists(wishlistRepo))
+
+	preorderRepo := postgresrepo.NewPostgresPreorderRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithPreorders(preorderRepo))
+
+	raffleRepo := postgresrepo.NewPostgresRaffleRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithRaffles(raffleRepo))
+
+	passwordResetRepo := postgresrepo.NewPostgresPasswordResetRepo(psqlQueries)
+	srvOpts = append(srvOpts, service.WithPasswordResets(passwordResetRepo, cfg.PasswordResetTTL))
+
+	apiKeyRepo := postgresrepo.NewPostgresAPIKeyRepo(psqlQueries)
+	rateLimitRepo := redisrepo.NewRedisRateLimitRepo(redisConn)
+	srvOpts = append(srvOpts, service.WithAPIKeys(apiKeyRepo, rateLimitRepo, cfg.APIKeyRateLimit))
+
+	hashLimiter := hasher.NewLimiter(cfg.HashMaxConcurrent, cfg.HashMaxWait)
+	expvar.Publish("password_hashing", expvar.Func(func() any { return hashLimiter.Stats() }))
+
+	passwordHasher, err := hasher.New(hasher.Config{
+		Algorithm:  cfg.PasswordHasher,
+		BcryptCost: cfg.BcryptCost,
+		Argon2: hasher.Argon2Params{
+			Memory:  cfg.Argon2Memory,
+			Time:    cfg.Argon2Time,
+			Threads: cfg.Argon2Threads,
+		},
+		Limiter: hashLimiter,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	authenticator, err := authn.New(authn.Config{
+		Backend: cfg.AuthBackend,
+		LDAP: authn.LDAPConfig{
+			URL:             cfg.LDAPURL,
+			StartTLS:        cfg.LDAPStartTLS,
+			Insecure:        cfg.LDAPInsecure,
+			CAFile:          cfg.LDAPCAFile,
+			BindDN:          cfg.LDAPBindDN,
+			BindPassword:    cfg.LDAPBindPassword,
+			BaseDN:          cfg.LDAPBaseDN,
+			UsernameAttr:    cfg.LDAPUsernameAttr,
+			DisplayNameAttr: cfg.LDAPDisplayNameAttr,
+			DepartmentAttr:  cfg.LDAPDepartmentAttr,
+			Timeout:         cfg.LDAPTimeout,
+		},
+	}, passwordHasher)
+	if err != nil {
+		panic(err)
+	}
+	srvOpts = append(srvOpts, service.WithAuthenticator(authenticator))
+
+	srv := service.NewService(dbConn, usrRepo, trxRepo, inventoryRepo, storeRepo, sessionRepo, tokenMaker, passwordHasher, srvOpts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.CoinLifetimeMonths > 0 {
+		go worker.Run(ctx, "coin expiry", cfg.CoinExpiryInterval, srv.ExpireCoins)
+	}
+	if cfg.TransferApprovalThreshold > 0 {
+		go worker.Run(ctx, "transfer holds", cfg.TransferApprovalInterval, srv.ReleaseExpiredHolds)
+	}
+	go worker.Run(ctx, "listing expiry", cfg.MarketExpiryInterval, srv.ExpireListings)
+	go worker.Run(ctx, "auction close", cfg.AuctionCloseInterval, srv.CloseAuctions)
+	go worker.Run(ctx, "wishlist notifications", cfg.WishlistCheckInterval, srv.NotifyWishlists)
+	go worker.Run(ctx, "preorder expiry", cfg.PreorderExpiryInterval, srv.ExpirePreorders)
+	go worker.Run(ctx, "raffle draw", cfg.RaffleDrawInterval, srv.DrawRaffles)
 
 	handler := controller.NewController(srv)
 
-	r := gin.New()
+	r, err := controller.NewRouter(cfg.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
 	pprof.Register(r)
 	r.POST("/api/auth", handler.Authorize)
+	r.POST("/api/password/reset", handler.ResetPassword)
+	r.GET("/api/oidc/login", handler.StartOIDCLogin)
+	r.GET("/api/oidc/callback", handler.FinishOIDCLogin)
 
 	r.Use(handler.AuthMiddleware())
+	r.GET("/debug/vars", handler.AdminMiddleware(cfg.AdminUsernames), gin.WrapH(expvar.Handler()))
 	r.GET("/api/info", handler.GetFullUserInfo)
+	r.POST("/api/password", handler.ChangePassword)
 	r.POST("/api/sendCoin", handler.SendCoins)
+	r.GET("/api/items", handler.GetCatalog)
 	r.GET("/api/buy/:item", handler.BuyItem)
+	r.POST("/api/sendItem", handler.SendItem)
+	r.POST("/api/gift", handler.BuyGift)
+	r.GET("/api/market/listings", handler.GetListings)
+	r.POST("/api/market/listings", handler.ListItem)
+	r.POST("/api/market/listings/:id/buy", handler.BuyListing)
+	r.DELETE("/api/market/listings/:id", handler.CancelListing)
+	r.GET("/api/bundles", handler.GetBundles)
+	r.POST("/api/bundles/:name/buy", handler.BuyBundle)
+	r.GET("/api/auctions", handler.GetAuctions)
+	r.GET("/api/auctions/:id", handler.GetAuction)
+	r.POST("/api/auctions/:id/bids", handler.PlaceBid)
+	r.GET("/api/orders", handler.GetOrders)
+	r.PUT("/api/orders/:id/pickup", handler.SetPickupLocation)
+	r.POST("/api/orders/:id/cancel", handler.CancelOrder)
+	r.GET("/api/pickup-locations", handler.GetPickupLocations)
+	r.GET("/api/preorder-batches", handler.GetPreorderBatches)
+	r.POST("/api/preorder-batches/:id/preorders", handler.Preorder)
+	r.GET("/api/preorders", handler.GetPreorders)
+	r.DELETE("/api/preorders/:id", handler.CancelPreorder)
+	r.GET("/api/raffles", handler.GetRaffles)
+	r.GET("/api/raffles/:id", handler.GetRaffle)
+	r.GET("/api/raffles/:id/tickets", handler.GetRaffleTickets)
+	r.POST("/api/raffles/:id/tickets", handler.BuyRaffleTickets)
+	r.GET("/api/wishlist", handler.GetWishlist)
+	r.POST("/api/wishlist", handler.AddToWishlist)
+	r.DELETE("/api/wishlist/:item", handler.RemoveFromWishlist)
+	r.GET("/api/notifications", handler.GetNotifications)
+	r.POST("/api/notifications/read", handler.ReadNotifications)
+
+	admin := r.Group("/api/admin", handler.AdminMiddleware(cfg.AdminUsernames))
+	admin.POST("/users/:username/password-reset", handler.CreatePasswordReset)
+	admin.POST("/users/:username/unlock", handler.UnlockUser)
+	admin.POST("/users/:username/grants", handler.GrantCoins)
+	admin.GET("/api-keys", handler.ListAPIKeys)
+	admin.POST("/api-keys", handler.CreateAPIKey)
+	admin.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+	admin.GET("/limits/:username", handler.GetTransferLimits)
+	admin.PUT("/limits/:username", handler.SetTransferLimits)
+	admin.DELETE("/limits/:username", handler.DeleteTransferLimits)
+	admin.POST("/auctions", handler.CreateAuction)
+	admin.POST("/bundles", handler.CreateBundle)
+	admin.DELETE("/bundles/:name", handler.DeactivateBundle)
+	admin.POST("/items/:item/variants", handler.CreateVariant)
+	admin.PUT("/items/:item/limit", handler.SetPurchaseLimit)
+	admin.DELETE("/items/:item/limit", handler.DeletePurchaseLimit)
+	admin.PUT("/variants/:sku/stock", handler.SetVariantStock)
+	admin.GET("/price-schedules", handler.ListPriceSchedules)
+	admin.POST("/price-schedules", handler.CreatePriceSchedule)
+	admin.DELETE("/price-schedules/:id", handler.CancelPriceSchedule)
+	admin.POST("/preorder-batches", handler.CreatePreorderBatch)
+	admin.POST("/preorder-batches/:id/arrived", handler.MarkPreorderStockArrived)
+	admin.POST("/raffles", handler.CreateRaffle)
+	admin.GET("/orders", handler.ListOrders)
+	admin.PUT("/orders/:id/status", handler.UpdateOrderStatus)
+	admin.GET("/promo-codes", handler.ListPromoCodes)
+	admin.POST("/promo-codes", handler.CreatePromoCode)
+	admin.DELETE("/promo-codes/:code", handler.DisablePromoCode)
+	admin.GET("/fraud/cases", handler.ListFraudCases)
+	admin.POST("/fraud/cases/:id/approve", handler.ApproveFraudCase)
+	admin.POST("/fraud/cases/:id/reject", handler.RejectFraudCase)
+
+	finance := r.Group("/api/finance", handler.AdminMiddleware(cfg.FinanceUsernames))
+	finance.GET("/approvals", handler.ListTransferApprovals)
+	finance.POST("/approvals/:id/approve", handler.ApproveTransfer)
+	finance.POST("/approvals/:id/reject", handler.RejectTransfer)
 
 	log.Printf("start listening on port :%s", cfg.ServerPort)
 	if err = r.Run(":" + cfg.ServerPort); err != nil {
@@ -0,0 +1,2 @@
+DROP TABLE CoinExpirations;
+DROP TABLE CoinLots;
@@ -0,0 +1,22 @@
+CREATE TABLE CoinLots (
+    "lot_id" serial PRIMARY KEY,
+    "user_id" int REFERENCES Users(user_id) NOT NULL,
+    "amount" int NOT NULL,
+    "granted_at" timestamptz NOT NULL DEFAULT now(),
+    "expires_at" timestamptz NOT NULL
+);
+CREATE INDEX idx_coin_lots_user_expires ON CoinLots (user_id, expires_at);
+CREATE INDEX idx_coin_lots_expires ON CoinLots (expires_at);
+
+-- existing balances become a single lot granted at migration time
+INSERT INTO CoinLots (user_id, amount, expires_at)
+SELECT user_id, coins, now() + interval '12 months' FROM Users
+WHERE coins > 0;
+
+CREATE TABLE CoinExpirations (
+    "expiration_id" serial PRIMARY KEY,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "amount" int NOT NULL,
+    "expired_at" timestamptz NOT NULL
+);
+CREATE INDEX idx_coin_expirations_username ON CoinExpirations(username);
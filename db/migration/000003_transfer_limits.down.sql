@@ -0,0 +1,3 @@
+DROP TABLE TransferLimitOverrides;
+DROP INDEX idx_transfers_from_username_created;
+ALTER TABLE Transfers DROP COLUMN "created_at";
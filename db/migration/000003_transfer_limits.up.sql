@@ -0,0 +1,11 @@
+ALTER TABLE Transfers ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT now();
+CREATE INDEX idx_transfers_from_username_created ON Transfers(from_username, created_at);
+
+-- NULL means "use default limit from config", 0 means "unlimited"
+CREATE TABLE TransferLimitOverrides (
+    "username" varchar PRIMARY KEY REFERENCES Users(username),
+    "per_transaction" int,
+    "daily" int,
+    "monthly" int,
+    "per_recipient" int
+);
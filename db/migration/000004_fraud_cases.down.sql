@@ -0,0 +1,3 @@
+DROP TABLE FraudCases;
+DROP INDEX idx_transfers_to_username_created;
+ALTER TABLE Users DROP COLUMN "created_at";
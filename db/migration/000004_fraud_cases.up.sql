@@ -0,0 +1,17 @@
+ALTER TABLE Users ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT now();
+CREATE INDEX idx_transfers_to_username_created ON Transfers(to_username, created_at);
+
+CREATE TABLE FraudCases (
+    "case_id" serial PRIMARY KEY,
+    "from_username" varchar REFERENCES Users(username) NOT NULL,
+    "to_username" varchar REFERENCES Users(username) NOT NULL,
+    "amount" int NOT NULL,
+    "action" varchar(10) NOT NULL, -- flagged, held
+    "reasons" varchar NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'open', -- open, approved, rejected
+    "transfer_id" int REFERENCES Transfers(transfer_id),
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "resolved_by" varchar,
+    "resolved_at" timestamptz
+);
+CREATE INDEX idx_fraud_cases_status ON FraudCases(status);
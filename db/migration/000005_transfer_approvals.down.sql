@@ -0,0 +1,2 @@
+DROP TABLE TransferApprovals;
+ALTER TABLE Users DROP COLUMN "held_coins";
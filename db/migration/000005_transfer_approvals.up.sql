@@ -0,0 +1,15 @@
+ALTER TABLE Users ADD COLUMN "held_coins" int NOT NULL DEFAULT 0;
+
+CREATE TABLE TransferApprovals (
+    "approval_id" serial PRIMARY KEY,
+    "from_username" varchar REFERENCES Users(username) NOT NULL,
+    "to_username" varchar REFERENCES Users(username) NOT NULL,
+    "amount" int NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'pending', -- pending, approved, rejected, expired
+    "transfer_id" int REFERENCES Transfers(transfer_id),
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "expires_at" timestamptz NOT NULL,
+    "resolved_by" varchar,
+    "resolved_at" timestamptz
+);
+CREATE INDEX idx_transfer_approvals_status_expires ON TransferApprovals(status, expires_at);
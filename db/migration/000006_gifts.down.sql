@@ -0,0 +1,2 @@
+DROP TABLE Notifications;
+DROP TABLE Gifts;
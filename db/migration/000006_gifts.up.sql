@@ -0,0 +1,20 @@
+CREATE TABLE Gifts (
+    "gift_id" serial PRIMARY KEY,
+    "from_username" varchar REFERENCES Users(username) NOT NULL,
+    "to_username" varchar REFERENCES Users(username) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "message" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_gifts_from_username ON Gifts(from_username);
+CREATE INDEX idx_gifts_to_username ON Gifts(to_username);
+
+CREATE TABLE Notifications (
+    "notification_id" serial PRIMARY KEY,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "kind" varchar(20) NOT NULL,
+    "message" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "read_at" timestamptz
+);
+CREATE INDEX idx_notifications_username ON Notifications(username);
@@ -0,0 +1,10 @@
+CREATE TABLE ItemTransfers (
+    "item_transfer_id" serial PRIMARY KEY,
+    "from_username" varchar REFERENCES Users(username) NOT NULL,
+    "to_username" varchar REFERENCES Users(username) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "quantity" int NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_item_transfers_from_username ON ItemTransfers(from_username);
+CREATE INDEX idx_item_transfers_to_username ON ItemTransfers(to_username);
@@ -0,0 +1,14 @@
+CREATE TABLE Listings (
+    "listing_id" serial PRIMARY KEY,
+    "seller_username" varchar REFERENCES Users(username) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "quantity" int NOT NULL,
+    "price" int NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'active', -- active, sold, cancelled, expired
+    "buyer_username" varchar REFERENCES Users(username),
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "expires_at" timestamptz NOT NULL,
+    "closed_at" timestamptz
+);
+CREATE INDEX idx_listings_status_expires ON Listings(status, expires_at);
+CREATE INDEX idx_listings_item_type ON Listings(item_type);
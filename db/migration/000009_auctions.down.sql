@@ -0,0 +1,2 @@
+DROP TABLE Bids;
+DROP TABLE Auctions;
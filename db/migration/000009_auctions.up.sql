@@ -0,0 +1,22 @@
+CREATE TABLE Auctions (
+    "auction_id" serial PRIMARY KEY,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "quantity" int NOT NULL,
+    "min_bid" int NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'open', -- open, closed, failed
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "ends_at" timestamptz NOT NULL,
+    "closed_at" timestamptz
+);
+CREATE INDEX idx_auctions_status_ends ON Auctions(status, ends_at);
+
+CREATE TABLE Bids (
+    "bid_id" serial PRIMARY KEY,
+    "auction_id" int REFERENCES Auctions(auction_id) NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "amount" int NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'active', -- active, outbid, won, rejected
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_bids_auction_status ON Bids(auction_id, status);
@@ -0,0 +1,2 @@
+DROP TABLE Orders;
+DROP TABLE PromoCodes;
@@ -0,0 +1,26 @@
+CREATE TABLE PromoCodes (
+    "code" varchar(32) PRIMARY KEY,
+    "kind" varchar(10) NOT NULL, -- percent, fixed
+    "value" int NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type), -- NULL for whole catalog
+    "max_uses" int NOT NULL DEFAULT 0, -- 0 for unlimited
+    "used_count" int NOT NULL DEFAULT 0,
+    "starts_at" timestamptz NOT NULL DEFAULT now(),
+    "ends_at" timestamptz,
+    "disabled" boolean NOT NULL DEFAULT false,
+    "created_by" varchar REFERENCES Users(username) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE Orders (
+    "order_id" serial PRIMARY KEY,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "price" int NOT NULL, -- charged price, discount already subtracted
+    "discount" int NOT NULL DEFAULT 0,
+    "promo_code" varchar(32) REFERENCES PromoCodes(code),
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_orders_username ON Orders(username);
+-- promo code can be used once per user
+CREATE UNIQUE INDEX idx_orders_username_promo ON Orders(username, promo_code) WHERE promo_code IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE Orders DROP COLUMN "price_schedule_id";
+DROP TABLE PriceSchedules;
@@ -0,0 +1,15 @@
+-- schedules are never deleted and serve as price history
+CREATE TABLE PriceSchedules (
+    "schedule_id" serial PRIMARY KEY,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "sale_price" int NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "created_by" varchar REFERENCES Users(username) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "cancelled_at" timestamptz
+);
+CREATE INDEX idx_price_schedules_item_period ON PriceSchedules(item_type, starts_at, ends_at);
+
+-- NULL if item was sold at regular price
+ALTER TABLE Orders ADD COLUMN "price_schedule_id" int REFERENCES PriceSchedules(schedule_id);
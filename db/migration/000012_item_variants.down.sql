@@ -0,0 +1,8 @@
+ALTER TABLE Orders DROP COLUMN "variant";
+
+ALTER TABLE Inventory DROP CONSTRAINT inventory_user_id_item_type_variant_key;
+DELETE FROM Inventory WHERE variant <> '';
+ALTER TABLE Inventory DROP COLUMN "variant";
+ALTER TABLE Inventory ADD CONSTRAINT inventory_user_id_item_type_key UNIQUE (user_id, item_type);
+
+DROP TABLE ItemVariants;
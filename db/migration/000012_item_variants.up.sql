@@ -0,0 +1,17 @@
+CREATE TABLE ItemVariants (
+    "sku" varchar(64) PRIMARY KEY,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "size" varchar(10) NOT NULL DEFAULT '',
+    "color" varchar(20) NOT NULL DEFAULT '',
+    "price_delta" int NOT NULL DEFAULT 0,
+    "stock" int NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    UNIQUE (item_type, size, color)
+);
+CREATE INDEX idx_item_variants_item_type ON ItemVariants(item_type);
+
+-- empty variant for items without variants
+ALTER TABLE Inventory ADD COLUMN "variant" varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE Inventory DROP CONSTRAINT inventory_user_id_item_type_key;
+ALTER TABLE Inventory ADD CONSTRAINT inventory_user_id_item_type_variant_key UNIQUE (user_id, item_type, variant);
+
+ALTER TABLE Orders ADD COLUMN "variant" varchar(64) REFERENCES ItemVariants(sku);
@@ -0,0 +1,7 @@
+DELETE FROM Orders WHERE bundle IS NOT NULL;
+ALTER TABLE Orders DROP CONSTRAINT orders_item_or_bundle;
+ALTER TABLE Orders DROP COLUMN "bundle";
+ALTER TABLE Orders ALTER COLUMN "item_type" SET NOT NULL;
+
+DROP TABLE BundleItems;
+DROP TABLE Bundles;
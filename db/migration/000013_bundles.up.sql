@@ -0,0 +1,21 @@
+CREATE TABLE Bundles (
+    "name" varchar(50) PRIMARY KEY,
+    "price" int NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_by" varchar REFERENCES Users(username) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE BundleItems (
+    "bundle_item_id" serial PRIMARY KEY,
+    "bundle_name" varchar(50) REFERENCES Bundles(name) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "variant" varchar(64) REFERENCES ItemVariants(sku), -- NULL for items without variants
+    "quantity" int NOT NULL DEFAULT 1
+);
+CREATE INDEX idx_bundle_items_bundle_name ON BundleItems(bundle_name);
+
+-- order is either for single item or for bundle
+ALTER TABLE Orders ALTER COLUMN "item_type" DROP NOT NULL;
+ALTER TABLE Orders ADD COLUMN "bundle" varchar(50) REFERENCES Bundles(name);
+ALTER TABLE Orders ADD CONSTRAINT orders_item_or_bundle CHECK ((item_type IS NULL) <> (bundle IS NULL));
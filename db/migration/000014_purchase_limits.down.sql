@@ -0,0 +1,2 @@
+DROP INDEX idx_orders_username_item_created;
+DROP TABLE PurchaseLimits;
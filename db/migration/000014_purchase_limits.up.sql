@@ -0,0 +1,9 @@
+-- 0 means "no cap"
+CREATE TABLE PurchaseLimits (
+    "item_type" varchar(50) PRIMARY KEY REFERENCES Items(item_type),
+    "lifetime" int NOT NULL DEFAULT 0,
+    "per_period" int NOT NULL DEFAULT 0,
+    "period_days" int NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_orders_username_item_created ON Orders(username, item_type, created_at);
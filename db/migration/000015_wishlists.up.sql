@@ -0,0 +1,10 @@
+-- state columns remember what user was already notified about
+CREATE TABLE Wishlists (
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "affordable" boolean NOT NULL DEFAULT false,
+    "in_stock" boolean NOT NULL DEFAULT true,
+    "sale_schedule_id" int REFERENCES PriceSchedules(schedule_id), -- last sale user was notified about
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY ("username", "item_type")
+);
@@ -0,0 +1,4 @@
+DROP INDEX idx_orders_status;
+ALTER TABLE Orders DROP COLUMN "updated_at";
+ALTER TABLE Orders DROP COLUMN "pickup_location";
+ALTER TABLE Orders DROP COLUMN "status";
@@ -0,0 +1,5 @@
+-- placed -> packed -> ready -> delivered, anything but delivered can be cancelled
+ALTER TABLE Orders ADD COLUMN "status" varchar(20) NOT NULL DEFAULT 'placed';
+ALTER TABLE Orders ADD COLUMN "pickup_location" varchar(50) NOT NULL DEFAULT ''; -- office chosen by user
+ALTER TABLE Orders ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT now();
+CREATE INDEX idx_orders_status ON Orders(status);
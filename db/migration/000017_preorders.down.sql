@@ -0,0 +1,2 @@
+DROP TABLE Preorders;
+DROP TABLE PreorderBatches;
@@ -0,0 +1,25 @@
+CREATE TABLE PreorderBatches (
+    "batch_id" serial PRIMARY KEY,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "variant" varchar(64) REFERENCES ItemVariants(sku), -- NULL for items without variants
+    "price" int NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'open', -- open, arrived, expired
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "expires_at" timestamptz NOT NULL, -- holds are released if stock hasn't arrived by then
+    "closed_at" timestamptz
+);
+CREATE INDEX idx_preorder_batches_status_expires ON PreorderBatches(status, expires_at);
+
+-- price of batch is held on user's balance while preorder is pending
+CREATE TABLE Preorders (
+    "preorder_id" serial PRIMARY KEY,
+    "batch_id" int REFERENCES PreorderBatches(batch_id) NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'pending', -- pending, fulfilled, released, cancelled
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_preorders_batch_status ON Preorders(batch_id, status);
+CREATE INDEX idx_preorders_username ON Preorders(username);
+-- one pending preorder per user in batch
+CREATE UNIQUE INDEX idx_preorders_batch_username_pending ON Preorders(batch_id, username) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP TABLE RaffleTickets;
+DROP TABLE Raffles;
@@ -0,0 +1,28 @@
+-- sha256 of seed is published on creation, seed itself is revealed
+-- after draw so anyone can recompute winners from tickets
+CREATE TABLE Raffles (
+    "raffle_id" serial PRIMARY KEY,
+    "item_type" varchar(50) REFERENCES Items(item_type) NOT NULL,
+    "prizes" int NOT NULL,
+    "ticket_price" int NOT NULL,
+    "max_tickets" int NOT NULL DEFAULT 0, -- per user, 0 - unlimited
+    "seed" varchar(64) NOT NULL,
+    "seed_hash" varchar(64) NOT NULL,
+    "status" varchar(10) NOT NULL DEFAULT 'open', -- open, drawn
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "draws_at" timestamptz NOT NULL, -- ticket sales close at draw
+    "drawn_at" timestamptz
+);
+CREATE INDEX idx_raffles_status_draws ON Raffles(status, draws_at);
+
+CREATE TABLE RaffleTickets (
+    "ticket_id" serial PRIMARY KEY,
+    "raffle_id" int REFERENCES Raffles(raffle_id) NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "price" int NOT NULL,
+    "won" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_raffle_tickets_raffle ON RaffleTickets(raffle_id, ticket_id);
+CREATE INDEX idx_raffle_tickets_raffle_username ON RaffleTickets(raffle_id, username);
@@ -0,0 +1,10 @@
+-- one-time reset tokens issued by admins, only sha256 of token is stored
+CREATE TABLE PasswordResets (
+    "token_hash" varchar(64) PRIMARY KEY,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz
+);
+CREATE INDEX idx_password_resets_username ON PasswordResets(username);
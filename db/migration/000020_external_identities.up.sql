@@ -0,0 +1,8 @@
+-- users logged in with external identity provider, they have no password
+CREATE TABLE ExternalIdentities (
+    "issuer" varchar NOT NULL,
+    "subject" varchar NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY ("issuer", "subject")
+);
@@ -0,0 +1,3 @@
+ALTER TABLE Users
+    DROP COLUMN "display_name",
+    DROP COLUMN "department";
@@ -0,0 +1,4 @@
+-- synced from directory on login
+ALTER TABLE Users
+    ADD COLUMN "display_name" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "department" varchar NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+-- keys of bots and integrations, only sha256 of key is stored
+CREATE TABLE ApiKeys (
+    "key_id" serial PRIMARY KEY,
+    "key_hash" varchar(64) NOT NULL UNIQUE,
+    "prefix" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "scopes" varchar NOT NULL,
+    "rate_limit" int NOT NULL CHECK (rate_limit > 0),
+    "created_by" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "revoked_at" timestamptz
+);
@@ -0,0 +1,8 @@
+-- parts of coin lots an order was paid from, cancelled order
+-- returns coins with their original expiry
+CREATE TABLE OrderCoinLots (
+    "order_id" int REFERENCES Orders(order_id) NOT NULL,
+    "amount" int NOT NULL,
+    "expires_at" timestamptz NOT NULL
+);
+CREATE INDEX idx_order_coin_lots_order_id ON OrderCoinLots(order_id);
@@ -0,0 +1,12 @@
+-- promo code can be used once per user, the use is claimed before payment
+CREATE TABLE PromoCodeUses (
+    "code" varchar(32) REFERENCES PromoCodes(code) NOT NULL,
+    "username" varchar REFERENCES Users(username) NOT NULL,
+    "used_at" timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY ("code", "username")
+);
+
+INSERT INTO PromoCodeUses (code, username, used_at)
+SELECT promo_code, username, MIN(created_at) FROM Orders
+WHERE promo_code IS NOT NULL
+GROUP BY promo_code, username;
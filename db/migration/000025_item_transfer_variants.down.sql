@@ -0,0 +1 @@
+ALTER TABLE ItemTransfers DROP COLUMN "variant";
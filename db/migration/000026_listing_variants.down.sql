@@ -0,0 +1 @@
+ALTER TABLE Listings DROP COLUMN "variant";
@@ -0,0 +1 @@
+ALTER TABLE Listings ADD COLUMN "variant" varchar(64) REFERENCES ItemVariants(sku); -- NULL for items without variants
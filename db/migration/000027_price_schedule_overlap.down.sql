@@ -0,0 +1,2 @@
+ALTER TABLE PriceSchedules DROP CONSTRAINT price_schedules_no_overlap;
+DROP EXTENSION btree_gist;
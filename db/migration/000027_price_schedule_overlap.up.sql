@@ -0,0 +1,7 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- not cancelled sales of one item can't intersect, concurrent
+-- inserts are checked by postgres
+ALTER TABLE PriceSchedules ADD CONSTRAINT price_schedules_no_overlap
+    EXCLUDE USING gist (item_type WITH =, tstzrange(starts_at, ends_at) WITH &&)
+    WHERE (cancelled_at IS NULL);
@@ -0,0 +1 @@
+ALTER TABLE Orders ADD COLUMN "recipient_username" varchar REFERENCES Users(username); -- NULL if order isn't a gift
@@ -0,0 +1,18 @@
+-- name: CreateApiKey :one
+INSERT INTO ApiKeys (key_hash, prefix, name, username, scopes, rate_limit, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM ApiKeys
+WHERE key_hash = $1
+LIMIT 1;
+
+-- name: ListApiKeys :many
+SELECT * FROM ApiKeys
+ORDER BY key_id DESC;
+
+-- name: RevokeApiKey :execrows
+UPDATE ApiKeys
+SET revoked_at = now()
+WHERE key_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,52 @@
+-- name: CreateAuction :one
+INSERT INTO Auctions (item_type, quantity, min_bid, created_by, ends_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAuction :one
+SELECT * FROM Auctions
+WHERE auction_id = $1
+LIMIT 1;
+
+-- name: GetAuctionForUpdate :one
+SELECT * FROM Auctions
+WHERE auction_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListOpenAuctions :many
+SELECT * FROM Auctions
+WHERE status = 'open'
+ORDER BY ends_at, auction_id;
+
+-- name: GetEndedAuctions :many
+SELECT * FROM Auctions
+WHERE status = 'open' AND ends_at <= $1
+ORDER BY auction_id;
+
+-- name: CloseAuction :exec
+UPDATE Auctions
+SET status = $2,
+    closed_at = now()
+WHERE auction_id = $1 AND status = 'open';
+
+-- name: CreateBid :one
+INSERT INTO Bids (auction_id, username, amount)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetActiveBids :many
+SELECT * FROM Bids
+WHERE auction_id = $1 AND status = 'active'
+ORDER BY amount DESC, bid_id;
+
+-- name: UpdateBidAmount :one
+UPDATE Bids
+SET amount = $2
+WHERE bid_id = $1
+RETURNING *;
+
+-- name: SetBidStatus :exec
+UPDATE Bids
+SET status = $2
+WHERE bid_id = $1;
@@ -0,0 +1,36 @@
+-- name: CreateBundle :one
+INSERT INTO Bundles (name, price, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: AddBundleItem :one
+INSERT INTO BundleItems (bundle_name, item_type, variant, quantity)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetBundle :one
+SELECT * FROM Bundles
+WHERE name = $1
+LIMIT 1;
+
+-- name: GetBundleItems :many
+SELECT * FROM BundleItems
+WHERE bundle_name = $1
+ORDER BY bundle_item_id;
+
+-- name: ListActiveBundles :many
+SELECT * FROM Bundles
+WHERE active
+ORDER BY name;
+
+-- name: ListActiveBundleItems :many
+SELECT bi.* FROM BundleItems bi
+JOIN Bundles b ON b.name = bi.bundle_name
+WHERE b.active
+ORDER BY bi.bundle_name, bi.bundle_item_id;
+
+-- name: DeactivateBundle :one
+UPDATE Bundles
+SET active = false
+WHERE name = $1
+RETURNING *;
@@ -0,0 +1,75 @@
+-- name: CreateCoinLot :one
+INSERT INTO CoinLots (user_id, amount, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCoinLotsForUpdate :many
+SELECT * FROM CoinLots
+WHERE user_id = $1 AND amount > 0
+ORDER BY expires_at, lot_id
+FOR UPDATE;
+
+-- name: UpdateCoinLotAmount :exec
+UPDATE CoinLots
+SET amount = $2
+WHERE lot_id = $1;
+
+-- name: DeleteCoinLot :exec
+DELETE FROM CoinLots
+WHERE lot_id = $1;
+
+-- name: GetExpiringCoinLots :many
+SELECT * FROM CoinLots
+WHERE user_id = $1 AND amount > 0 AND expires_at <= $2
+ORDER BY expires_at, lot_id;
+
+-- name: LockUsersWithExpiredLots :exec
+SELECT user_id FROM Users
+WHERE user_id IN (
+    SELECT user_id FROM CoinLots
+    WHERE expires_at <= $1
+)
+ORDER BY user_id
+FOR UPDATE;
+
+-- name: ExpireCoinLots :many
+-- Burns expired coins not backing held ones: held coins are
+-- taken from the oldest lots, so each lot burns only the part
+-- beyond held_coins of the running total.
+WITH expired AS (
+    SELECT l.lot_id, l.user_id, l.amount,
+        SUM(l.amount) OVER w - u.held_coins AS unheld
+    FROM CoinLots l
+    JOIN Users u ON u.user_id = l.user_id
+    WHERE l.expires_at <= sqlc.arg(now)
+    WINDOW w AS (PARTITION BY l.user_id ORDER BY l.expires_at, l.lot_id)
+), burn AS (
+    SELECT lot_id, user_id, amount, GREATEST(LEAST(amount, unheld), 0) AS burned FROM expired
+), deleted AS (
+    DELETE FROM CoinLots
+    USING burn
+    WHERE CoinLots.lot_id = burn.lot_id AND burn.burned = burn.amount
+), shrunk AS (
+    UPDATE CoinLots
+    SET amount = CoinLots.amount - burn.burned
+    FROM burn
+    WHERE CoinLots.lot_id = burn.lot_id AND burn.burned > 0 AND burn.burned < burn.amount
+), burned AS (
+    SELECT user_id, SUM(burned)::int AS amount FROM burn
+    GROUP BY user_id
+), updated AS (
+    UPDATE Users
+    SET coins = Users.coins - burned.amount
+    FROM burned
+    WHERE Users.user_id = burned.user_id
+    RETURNING Users.username, burned.amount
+)
+INSERT INTO CoinExpirations (username, amount, expired_at)
+SELECT username, amount, sqlc.arg(now) FROM updated
+WHERE amount > 0
+RETURNING *;
+
+-- name: GetCoinExpirations :many
+SELECT * FROM CoinExpirations
+WHERE username = $1
+ORDER BY expired_at;
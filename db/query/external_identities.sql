@@ -0,0 +1,9 @@
+-- name: CreateExternalIdentity :one
+INSERT INTO ExternalIdentities (issuer, subject, username)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetExternalIdentity :one
+SELECT * FROM ExternalIdentities
+WHERE issuer = $1 AND subject = $2
+LIMIT 1;
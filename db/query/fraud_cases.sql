@@ -0,0 +1,24 @@
+-- name: CreateFraudCase :one
+INSERT INTO FraudCases (from_username, to_username, amount, action, reasons, transfer_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetFraudCaseForUpdate :one
+SELECT * FROM FraudCases
+WHERE case_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListFraudCases :many
+SELECT * FROM FraudCases
+WHERE status = $1
+ORDER BY case_id;
+
+-- name: ResolveFraudCase :one
+UPDATE FraudCases
+SET status = $2,
+    resolved_by = $3,
+    resolved_at = now(),
+    transfer_id = COALESCE(sqlc.narg(transfer_id), transfer_id)
+WHERE case_id = $1
+RETURNING *;
@@ -0,0 +1,9 @@
+-- name: CreateGift :one
+INSERT INTO Gifts (from_username, to_username, item_type, message)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetGiftsWithUser :many
+SELECT * FROM Gifts
+WHERE from_username = sqlc.arg(username) OR to_username = sqlc.arg(username)
+ORDER BY gift_id;
@@ -1,10 +1,31 @@
 -- name: BuyItem :exec
-INSERT INTO Inventory (user_id, item_type)
-VALUES ($1, $2)
-ON CONFLICT (user_id, item_type)
+INSERT INTO Inventory (user_id, item_type, variant)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, item_type, variant)
 DO UPDATE SET quantity = Inventory.quantity + 1;
 
 -- name: GetInventory :many
 SELECT * FROM Inventory
 WHERE user_id=$1
 FOR SHARE;
+
+-- name: AddItemsToInventory :exec
+INSERT INTO Inventory (user_id, item_type, variant, quantity)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, item_type, variant)
+DO UPDATE SET quantity = Inventory.quantity + EXCLUDED.quantity;
+
+-- name: GetInventoryItem :one
+SELECT * FROM Inventory
+WHERE user_id = $1 AND item_type = $2 AND variant = $3
+LIMIT 1;
+
+-- name: TakeInventoryItems :one
+UPDATE Inventory
+SET quantity = quantity - $4
+WHERE user_id = $1 AND item_type = $2 AND variant = $3 AND quantity >= $4
+RETURNING *;
+
+-- name: DeleteEmptyInventoryItem :exec
+DELETE FROM Inventory
+WHERE inventory_id = $1 AND quantity = 0;
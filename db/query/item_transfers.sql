@@ -0,0 +1,9 @@
+-- name: CreateItemTransfer :one
+INSERT INTO ItemTransfers (from_username, to_username, item_type, quantity, variant)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetItemTransfersWithUser :many
+SELECT * FROM ItemTransfers
+WHERE from_username = sqlc.arg(username) OR to_username = sqlc.arg(username)
+ORDER BY item_transfer_id;
@@ -0,0 +1,29 @@
+-- name: CreateItemVariant :one
+INSERT INTO ItemVariants (sku, item_type, size, color, price_delta, stock)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListItemVariants :many
+SELECT * FROM ItemVariants
+WHERE item_type = $1
+ORDER BY sku;
+
+-- name: ListAllItemVariants :many
+SELECT * FROM ItemVariants
+ORDER BY item_type, sku;
+
+-- name: TakeItemVariantStock :execrows
+UPDATE ItemVariants
+SET stock = stock - $2
+WHERE sku = $1 AND stock >= $2;
+
+-- name: AddItemVariantStock :execrows
+UPDATE ItemVariants
+SET stock = stock + $2
+WHERE sku = $1;
+
+-- name: UpdateItemVariantStock :one
+UPDATE ItemVariants
+SET stock = $2
+WHERE sku = $1
+RETURNING *;
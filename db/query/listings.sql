@@ -0,0 +1,30 @@
+-- name: CreateListing :one
+INSERT INTO Listings (seller_username, item_type, quantity, price, expires_at, variant)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetListing :one
+SELECT * FROM Listings
+WHERE listing_id = $1
+LIMIT 1;
+
+-- name: ListActiveListings :many
+SELECT * FROM Listings
+WHERE status = 'active' AND expires_at > sqlc.arg(now)
+    AND (sqlc.arg(item_type)::varchar = '' OR item_type = sqlc.arg(item_type))
+ORDER BY price, listing_id;
+
+-- name: ExpireListings :many
+UPDATE Listings
+SET status = 'expired',
+    closed_at = now()
+WHERE status = 'active' AND expires_at <= $1
+RETURNING *;
+
+-- name: CloseListing :one
+UPDATE Listings
+SET status = $2,
+    buyer_username = $3,
+    closed_at = now()
+WHERE listing_id = $1 AND status = 'active'
+RETURNING *;
@@ -0,0 +1,15 @@
+-- name: CreateNotification :one
+INSERT INTO Notifications (username, kind, message)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListNotifications :many
+SELECT * FROM Notifications
+WHERE username = $1
+ORDER BY notification_id DESC
+LIMIT $2;
+
+-- name: MarkNotificationsRead :execrows
+UPDATE Notifications
+SET read_at = now()
+WHERE username = $1 AND read_at IS NULL;
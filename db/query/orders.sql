@@ -0,0 +1,55 @@
+-- name: CreateOrder :one
+INSERT INTO Orders (username, item_type, price, discount, promo_code, price_schedule_id, variant, bundle, recipient_username)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: CountUserItemOrders :one
+-- Bundle orders count every bundled unit of the item.
+SELECT
+    COALESCE(SUM(units), 0)::bigint AS total,
+    COALESCE(SUM(units) FILTER (WHERE created_at >= sqlc.arg(since)), 0)::bigint AS since_count
+FROM (
+    SELECT created_at, 1 AS units FROM Orders
+    WHERE username = sqlc.arg(username) AND item_type = sqlc.arg(item_type) AND status <> 'cancelled'
+    UNION ALL
+    SELECT o.created_at, bi.quantity AS units FROM Orders o
+    JOIN BundleItems bi ON bi.bundle_name = o.bundle
+    WHERE o.username = sqlc.arg(username) AND bi.item_type = sqlc.arg(item_type) AND o.status <> 'cancelled'
+) AS purchases;
+
+-- name: GetOrderForUpdate :one
+SELECT * FROM Orders
+WHERE order_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListUserOrders :many
+SELECT * FROM Orders
+WHERE username = $1
+ORDER BY order_id DESC;
+
+-- name: ListOrders :many
+SELECT * FROM Orders
+WHERE sqlc.arg(status)::varchar = '' OR status = sqlc.arg(status)
+ORDER BY order_id;
+
+-- name: UpdateOrderStatus :one
+UPDATE Orders
+SET status = $2, updated_at = now()
+WHERE order_id = $1
+RETURNING *;
+
+-- name: SetOrderPickupLocation :one
+UPDATE Orders
+SET pickup_location = $2, updated_at = now()
+WHERE order_id = $1
+RETURNING *;
+
+-- name: CreateOrderCoinLot :exec
+INSERT INTO OrderCoinLots (order_id, amount, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: GetOrderCoinLots :many
+SELECT * FROM OrderCoinLots
+WHERE order_id = $1
+ORDER BY expires_at;
@@ -0,0 +1,15 @@
+-- name: CreatePasswordReset :one
+INSERT INTO PasswordResets (token_hash, username, created_by, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UsePasswordReset :one
+UPDATE PasswordResets
+SET used_at = now()
+WHERE token_hash = sqlc.arg(token_hash) AND used_at IS NULL AND expires_at > sqlc.arg(now)
+RETURNING *;
+
+-- name: InvalidatePasswordResets :exec
+UPDATE PasswordResets
+SET used_at = now()
+WHERE username = $1 AND used_at IS NULL;
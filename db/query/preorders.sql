@@ -0,0 +1,64 @@
+-- name: CreatePreorderBatch :one
+INSERT INTO PreorderBatches (item_type, variant, price, created_by, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetPreorderBatch :one
+SELECT * FROM PreorderBatches
+WHERE batch_id = $1
+LIMIT 1;
+
+-- name: GetPreorderBatchForUpdate :one
+SELECT * FROM PreorderBatches
+WHERE batch_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListOpenPreorderBatches :many
+SELECT * FROM PreorderBatches
+WHERE status = 'open'
+ORDER BY expires_at, batch_id;
+
+-- name: GetExpiredPreorderBatches :many
+SELECT * FROM PreorderBatches
+WHERE status = 'open' AND expires_at <= $1
+ORDER BY batch_id
+FOR UPDATE SKIP LOCKED;
+
+-- name: ClosePreorderBatch :one
+UPDATE PreorderBatches
+SET status = $2,
+    closed_at = now()
+WHERE batch_id = $1 AND status = 'open'
+RETURNING *;
+
+-- name: CreatePreorder :one
+INSERT INTO Preorders (batch_id, username)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetPreorderForUpdate :one
+SELECT * FROM Preorders
+WHERE preorder_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetPendingPreorders :many
+SELECT * FROM Preorders
+WHERE batch_id = $1 AND status = 'pending'
+ORDER BY preorder_id
+FOR UPDATE;
+
+-- name: CountPendingPreorders :one
+SELECT COUNT(*) FROM Preorders
+WHERE batch_id = $1 AND status = 'pending';
+
+-- name: ListUserPreorders :many
+SELECT * FROM Preorders
+WHERE username = $1
+ORDER BY preorder_id DESC;
+
+-- name: SetPreorderStatus :execrows
+UPDATE Preorders
+SET status = $2
+WHERE preorder_id = $1 AND status = 'pending';
@@ -0,0 +1,26 @@
+-- name: CreatePriceSchedule :one
+INSERT INTO PriceSchedules (item_type, sale_price, starts_at, ends_at, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActivePriceSchedule :one
+SELECT * FROM PriceSchedules
+WHERE item_type = sqlc.arg(item_type) AND cancelled_at IS NULL
+    AND starts_at <= sqlc.arg(now) AND ends_at > sqlc.arg(now)
+LIMIT 1;
+
+-- name: ListActivePriceSchedules :many
+SELECT * FROM PriceSchedules
+WHERE cancelled_at IS NULL AND starts_at <= $1 AND ends_at > $1
+ORDER BY item_type;
+
+-- name: ListPriceSchedules :many
+SELECT * FROM PriceSchedules
+WHERE sqlc.arg(item_type)::varchar = '' OR item_type = sqlc.arg(item_type)
+ORDER BY starts_at, schedule_id;
+
+-- name: CancelPriceSchedule :one
+UPDATE PriceSchedules
+SET cancelled_at = now()
+WHERE schedule_id = $1 AND cancelled_at IS NULL
+RETURNING *;
@@ -0,0 +1,47 @@
+-- name: CreatePromoCode :one
+INSERT INTO PromoCodes (code, kind, value, item_type, max_uses, starts_at, ends_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetPromoCode :one
+SELECT * FROM PromoCodes
+WHERE code = $1
+LIMIT 1;
+
+-- name: ListPromoCodes :many
+SELECT * FROM PromoCodes
+ORDER BY created_at, code;
+
+-- name: UsePromoCode :one
+-- Counts a use only if code is active, applicable to item and not exhausted.
+UPDATE PromoCodes
+SET used_count = used_count + 1
+WHERE code = sqlc.arg(code)
+    AND NOT disabled
+    AND starts_at <= sqlc.arg(now)
+    AND (ends_at IS NULL OR ends_at > sqlc.arg(now))
+    AND (item_type IS NULL OR item_type = sqlc.arg(item_type)::varchar)
+    AND (max_uses = 0 OR used_count < max_uses)
+RETURNING *;
+
+-- name: ClaimPromoCodeUse :execrows
+INSERT INTO PromoCodeUses (code, username)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: ReleasePromoCodeUse :execrows
+-- Deletes user's use of code and uncounts it, so the code can be used again.
+WITH released AS (
+    DELETE FROM PromoCodeUses
+    WHERE code = $1 AND username = $2
+    RETURNING code
+)
+UPDATE PromoCodes
+SET used_count = used_count - 1
+WHERE code IN (SELECT code FROM released);
+
+-- name: DisablePromoCode :one
+UPDATE PromoCodes
+SET disabled = true
+WHERE code = $1
+RETURNING *;
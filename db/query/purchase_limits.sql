@@ -0,0 +1,22 @@
+-- name: GetPurchaseLimit :one
+SELECT * FROM PurchaseLimits
+WHERE item_type = $1
+LIMIT 1;
+
+-- name: ListPurchaseLimits :many
+SELECT * FROM PurchaseLimits
+ORDER BY item_type;
+
+-- name: UpsertPurchaseLimit :one
+INSERT INTO PurchaseLimits (item_type, lifetime, per_period, period_days)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_type)
+DO UPDATE SET
+    lifetime = EXCLUDED.lifetime,
+    per_period = EXCLUDED.per_period,
+    period_days = EXCLUDED.period_days
+RETURNING *;
+
+-- name: DeletePurchaseLimit :execrows
+DELETE FROM PurchaseLimits
+WHERE item_type = $1;
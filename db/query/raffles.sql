@@ -0,0 +1,53 @@
+-- name: CreateRaffle :one
+INSERT INTO Raffles (item_type, prizes, ticket_price, max_tickets, seed, seed_hash, created_by, draws_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetRaffle :one
+SELECT * FROM Raffles
+WHERE raffle_id = $1
+LIMIT 1;
+
+-- name: GetRaffleForUpdate :one
+SELECT * FROM Raffles
+WHERE raffle_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListRaffles :many
+SELECT * FROM Raffles
+ORDER BY raffle_id DESC;
+
+-- name: GetDueRaffles :many
+SELECT * FROM Raffles
+WHERE status = 'open' AND draws_at <= $1
+ORDER BY raffle_id;
+
+-- name: SetRaffleDrawn :exec
+UPDATE Raffles
+SET status = 'drawn',
+    drawn_at = now()
+WHERE raffle_id = $1;
+
+-- name: CreateRaffleTicket :one
+INSERT INTO RaffleTickets (raffle_id, username, price)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CountRaffleTickets :one
+SELECT COUNT(*) FROM RaffleTickets
+WHERE raffle_id = $1;
+
+-- name: CountUserRaffleTickets :one
+SELECT COUNT(*) FROM RaffleTickets
+WHERE raffle_id = $1 AND username = $2;
+
+-- name: ListRaffleTickets :many
+SELECT * FROM RaffleTickets
+WHERE raffle_id = $1
+ORDER BY ticket_id;
+
+-- name: SetRaffleTicketWon :exec
+UPDATE RaffleTickets
+SET won = true
+WHERE ticket_id = $1;
@@ -2,4 +2,8 @@
 SELECT * FROM Items
 WHERE item_type = $1
 LIMIT 1 
-FOR SHARE;
\ No newline at end of file
+FOR SHARE;
+
+-- name: ListItems :many
+SELECT * FROM Items
+ORDER BY item_type;
@@ -0,0 +1,30 @@
+-- name: CreateTransferApproval :one
+INSERT INTO TransferApprovals (from_username, to_username, amount, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetTransferApprovalForUpdate :one
+SELECT * FROM TransferApprovals
+WHERE approval_id = $1
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListTransferApprovals :many
+SELECT * FROM TransferApprovals
+WHERE status = $1
+ORDER BY approval_id;
+
+-- name: GetExpiredTransferApprovals :many
+SELECT * FROM TransferApprovals
+WHERE status = 'pending' AND expires_at <= $1
+ORDER BY approval_id
+FOR UPDATE SKIP LOCKED;
+
+-- name: ResolveTransferApproval :one
+UPDATE TransferApprovals
+SET status = $2,
+    resolved_by = $3,
+    resolved_at = now(),
+    transfer_id = $4
+WHERE approval_id = $1
+RETURNING *;
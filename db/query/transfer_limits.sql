@@ -0,0 +1,19 @@
+-- name: GetTransferLimitOverride :one
+SELECT * FROM TransferLimitOverrides
+WHERE username = $1
+LIMIT 1;
+
+-- name: UpsertTransferLimitOverride :one
+INSERT INTO TransferLimitOverrides (username, per_transaction, daily, monthly, per_recipient)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (username)
+DO UPDATE SET
+    per_transaction = EXCLUDED.per_transaction,
+    daily = EXCLUDED.daily,
+    monthly = EXCLUDED.monthly,
+    per_recipient = EXCLUDED.per_recipient
+RETURNING *;
+
+-- name: DeleteTransferLimitOverride :execrows
+DELETE FROM TransferLimitOverrides
+WHERE username = $1;
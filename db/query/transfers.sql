@@ -7,4 +7,45 @@ RETURNING *;
 -- name: GetTransfersWithUser :many
 SELECT * FROM Transfers
 WHERE from_username=sqlc.arg(username) OR to_username=sqlc.arg(username)
-FOR SHARE;
\ No newline at end of file
+FOR SHARE;
+
+-- name: GetSentAmountSince :one
+SELECT COALESCE(SUM(amount), 0)::int AS total FROM Transfers
+WHERE from_username = $1 AND created_at >= $2;
+
+-- name: GetSentToUserAmountSince :one
+SELECT COALESCE(SUM(amount), 0)::int AS total FROM Transfers
+WHERE from_username = $1 AND to_username = $2 AND created_at >= $3;
+
+-- name: GetReservedTransferAmount :one
+-- Coins of transfers waiting for finance approval or fraud review.
+SELECT (
+    (SELECT COALESCE(SUM(amount), 0) FROM TransferApprovals
+     WHERE from_username = $1 AND status = 'pending') +
+    (SELECT COALESCE(SUM(amount), 0) FROM FraudCases
+     WHERE from_username = $1 AND status = 'open' AND action = 'held')
+)::int AS total;
+
+-- name: GetReservedTransferToUserAmount :one
+SELECT (
+    (SELECT COALESCE(SUM(amount), 0) FROM TransferApprovals
+     WHERE from_username = $1 AND to_username = $2 AND status = 'pending') +
+    (SELECT COALESCE(SUM(amount), 0) FROM FraudCases
+     WHERE from_username = $1 AND to_username = $2 AND status = 'open' AND action = 'held')
+)::int AS total;
+
+-- name: GetRecipientsSince :many
+SELECT DISTINCT to_username FROM Transfers
+WHERE from_username = $1 AND created_at >= $2;
+
+-- name: CountSentSince :one
+SELECT COUNT(*)::int AS total FROM Transfers
+WHERE from_username = $1 AND created_at >= $2;
+
+-- name: CountNewSendersSince :one
+SELECT COUNT(DISTINCT Transfers.from_username)::int AS total FROM Transfers
+JOIN Users ON Users.username = Transfers.from_username
+WHERE Transfers.to_username = sqlc.arg(to_username)
+    AND Transfers.from_username <> sqlc.arg(exclude_username)
+    AND Transfers.created_at >= sqlc.arg(since)
+    AND Users.created_at >= sqlc.arg(registered_after);
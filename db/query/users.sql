@@ -17,6 +17,14 @@ WHERE username = $1
 LIMIT 1
 FOR UPDATE;
 
+-- name: LockTwoUsers :many
+-- Locks both users in user_id order, so transactions of
+-- the same pair of users in any direction can't deadlock.
+SELECT * FROM Users
+WHERE username IN (sqlc.arg(first_username), sqlc.arg(second_username))
+ORDER BY user_id
+FOR UPDATE;
+
 -- name: GetUserViaID :one
 SELECT * FROM Users
 WHERE user_id = $1
@@ -37,3 +45,34 @@ UPDATE Users
 SET coins = $2
 WHERE user_id = $1
 RETURNING *;
+
+-- name: AddUserCoins :one
+UPDATE Users
+SET coins = coins + sqlc.arg(amount)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: HoldUserCoins :one
+UPDATE Users
+SET coins = coins - sqlc.arg(amount),
+    held_coins = held_coins + sqlc.arg(amount)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: ReleaseUserCoins :one
+UPDATE Users
+SET coins = coins + sqlc.arg(amount),
+    held_coins = held_coins - sqlc.arg(amount)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: UpdateUserPassword :exec
+UPDATE Users
+SET password = $2
+WHERE username = $1;
+
+-- name: UpdateUserProfile :exec
+UPDATE Users
+SET display_name = $2,
+    department = $3
+WHERE username = $1;
@@ -0,0 +1,23 @@
+-- name: AddWishlistItem :one
+INSERT INTO Wishlists (username, item_type, affordable, in_stock, sale_schedule_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: RemoveWishlistItem :execrows
+DELETE FROM Wishlists
+WHERE username = $1 AND item_type = $2;
+
+-- name: ListWishlist :many
+SELECT * FROM Wishlists
+WHERE username = $1
+ORDER BY created_at, item_type;
+
+-- name: ListWishlistsWithCoins :many
+SELECT w.*, u.coins FROM Wishlists w
+JOIN Users u ON u.username = w.username
+ORDER BY w.username, w.item_type;
+
+-- name: UpdateWishlistState :exec
+UPDATE Wishlists
+SET affordable = $3, in_stock = $4, sale_schedule_id = $5
+WHERE username = $1 AND item_type = $2;
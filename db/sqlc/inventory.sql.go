@@ -9,25 +9,60 @@ import (
 	"context"
 )
 
+const addItemsToInventory = `-- name: AddItemsToInventory :exec
+INSERT INTO Inventory (user_id, item_type, variant, quantity)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, item_type, variant)
+DO UPDATE SET quantity = Inventory.quantity + EXCLUDED.quantity
+`
+
+type AddItemsToInventoryParams struct {
+	UserID   int32  `json:"user_id"`
+	ItemType string `json:"item_type"`
+	Variant  string `json:"variant"`
+	Quantity int32  `json:"quantity"`
+}
+
+func (q *Queries) AddItemsToInventory(ctx context.Context, arg AddItemsToInventoryParams) error {
+	_, err := q.db.ExecContext(ctx, addItemsToInventory,
+		arg.UserID,
+		arg.ItemType,
+		arg.Variant,
+		arg.Quantity,
+	)
+	return err
+}
+
 const buyItem = `-- name: BuyItem :exec
-INSERT INTO Inventory (user_id, item_type)
-VALUES ($1, $2)
-ON CONFLICT (user_id, item_type)
+INSERT INTO Inventory (user_id, item_type, variant)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, item_type, variant)
 DO UPDATE SET quantity = Inventory.quantity + 1
 `
 
 type BuyItemParams struct {
 	UserID   int32  `json:"user_id"`
 	ItemType string `json:"item_type"`
+	Variant  string `json:"variant"`
 }
 
 func (q *Queries) BuyItem(ctx context.Context, arg BuyItemParams) error {
-	_, err := q.db.ExecContext(ctx, buyItem, arg.UserID, arg.ItemType)
+	_, err := q.db.ExecContext(ctx, buyItem, arg.UserID, arg.ItemType, arg.Variant)
+	return err
+}
+
+const deleteEmptyInventoryItem = `-- name: DeleteEmptyInventoryItem :exec
+DELETE FROM Inventory
+WHERE inventory_id = $1 AND quantity = 0
+`
+
+func (q *Queries) DeleteEmptyInventoryItem(ctx context.Context, inventoryID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteEmptyInventoryItem, inventoryID)
 	return err
 }
 
 const getInventory = `-- name: GetInventory :many
-SELECT inventory_id, user_id, item_type, quantity FROM Inventory
+SELECT inventory_id, user_id, item_type, quantity, variant FROM Inventory
 WHERE user_id=$1
 FOR SHARE
 `
@@ -46,6 +81,7 @@ func (q *Queries) GetInventory(ctx context.Context, userID int32) ([]Inventory,
 			&i.UserID,
 			&i.ItemType,
 			&i.Quantity,
+			&i.Variant,
 		); err != nil {
 			return nil, err
 		}
@@ -59,3 +95,60 @@ func (q *Queries) GetInventory(ctx context.Context, userID int32) ([]Inventory,
 	}
 	return items, nil
 }
+
+const getInventoryItem = `-- name: GetInventoryItem :one
+SELECT inventory_id, user_id, item_type, quantity, variant FROM Inventory
+WHERE user_id = $1 AND item_type = $2 AND variant = $3
+LIMIT 1
+`
+
+type GetInventoryItemParams struct {
+	UserID   int32  `json:"user_id"`
+	ItemType string `json:"item_type"`
+	Variant  string `json:"variant"`
+}
+
+func (q *Queries) GetInventoryItem(ctx context.Context, arg GetInventoryItemParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, getInventoryItem, arg.UserID, arg.ItemType, arg.Variant)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.UserID,
+		&i.ItemType,
+		&i.Quantity,
+		&i.Variant,
+	)
+	return i, err
+}
+
+const takeInventoryItems = `-- name: TakeInventoryItems :one
+UPDATE Inventory
+SET quantity = quantity - $4
+WHERE user_id = $1 AND item_type = $2 AND variant = $3 AND quantity >= $4
+RETURNING inventory_id, user_id, item_type, quantity, variant
+`
+
+type TakeInventoryItemsParams struct {
+	UserID   int32  `json:"user_id"`
+	ItemType string `json:"item_type"`
+	Variant  string `json:"variant"`
+	Quantity int32  `json:"quantity"`
+}
+
+func (q *Queries) TakeInventoryItems(ctx context.Context, arg TakeInventoryItemsParams) (Inventory, error) {
+	row := q.db.QueryRowContext(ctx, takeInventoryItems,
+		arg.UserID,
+		arg.ItemType,
+		arg.Variant,
+		arg.Quantity,
+	)
+	var i Inventory
+	err := row.Scan(
+		&i.InventoryID,
+		&i.UserID,
+		&i.ItemType,
+		&i.Quantity,
+		&i.Variant,
+	)
+	return i, err
+}
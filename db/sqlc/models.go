@@ -4,11 +4,112 @@
 
 package db
 
+import (
+	"database/sql"
+	"time"
+)
+
+type ApiKey struct {
+	KeyID     int32        `json:"key_id"`
+	KeyHash   string       `json:"key_hash"`
+	Prefix    string       `json:"prefix"`
+	Name      string       `json:"name"`
+	Username  string       `json:"username"`
+	Scopes    string       `json:"scopes"`
+	RateLimit int32        `json:"rate_limit"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+}
+
+type Auction struct {
+	AuctionID int32        `json:"auction_id"`
+	ItemType  string       `json:"item_type"`
+	Quantity  int32        `json:"quantity"`
+	MinBid    int32        `json:"min_bid"`
+	Status    string       `json:"status"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	EndsAt    time.Time    `json:"ends_at"`
+	ClosedAt  sql.NullTime `json:"closed_at"`
+}
+
+type Bid struct {
+	BidID     int32     `json:"bid_id"`
+	AuctionID int32     `json:"auction_id"`
+	Username  string    `json:"username"`
+	Amount    int32     `json:"amount"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Bundle struct {
+	Name      string    `json:"name"`
+	Price     int32     `json:"price"`
+	Active    bool      `json:"active"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BundleItem struct {
+	BundleItemID int32          `json:"bundle_item_id"`
+	BundleName   string         `json:"bundle_name"`
+	ItemType     string         `json:"item_type"`
+	Variant      sql.NullString `json:"variant"`
+	Quantity     int32          `json:"quantity"`
+}
+
+type CoinExpiration struct {
+	ExpirationID int32     `json:"expiration_id"`
+	Username     string    `json:"username"`
+	Amount       int32     `json:"amount"`
+	ExpiredAt    time.Time `json:"expired_at"`
+}
+
+type CoinLot struct {
+	LotID     int32     `json:"lot_id"`
+	UserID    int32     `json:"user_id"`
+	Amount    int32     `json:"amount"`
+	GrantedAt time.Time `json:"granted_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type ExternalIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FraudCase struct {
+	CaseID       int32          `json:"case_id"`
+	FromUsername string         `json:"from_username"`
+	ToUsername   string         `json:"to_username"`
+	Amount       int32          `json:"amount"`
+	Action       string         `json:"action"`
+	Reasons      string         `json:"reasons"`
+	Status       string         `json:"status"`
+	TransferID   sql.NullInt32  `json:"transfer_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	ResolvedBy   sql.NullString `json:"resolved_by"`
+	ResolvedAt   sql.NullTime   `json:"resolved_at"`
+}
+
+type Gift struct {
+	GiftID       int32     `json:"gift_id"`
+	FromUsername string    `json:"from_username"`
+	ToUsername   string    `json:"to_username"`
+	ItemType     string    `json:"item_type"`
+	Message      string    `json:"message"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type Inventory struct {
 	InventoryID int32  `json:"inventory_id"`
 	UserID      int32  `json:"user_id"`
 	ItemType    string `json:"item_type"`
 	Quantity    int32  `json:"quantity"`
+	Variant     string `json:"variant"`
 }
 
 type Item struct {
@@ -17,16 +118,207 @@ type Item struct {
 	ItemPrice int16  `json:"item_price"`
 }
 
+type ItemTransfer struct {
+	ItemTransferID int32          `json:"item_transfer_id"`
+	FromUsername   string         `json:"from_username"`
+	ToUsername     string         `json:"to_username"`
+	ItemType       string         `json:"item_type"`
+	Quantity       int32          `json:"quantity"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Variant        sql.NullString `json:"variant"`
+}
+
+type ItemVariant struct {
+	Sku        string `json:"sku"`
+	ItemType   string `json:"item_type"`
+	Size       string `json:"size"`
+	Color      string `json:"color"`
+	PriceDelta int32  `json:"price_delta"`
+	Stock      int32  `json:"stock"`
+}
+
+type Listing struct {
+	ListingID      int32          `json:"listing_id"`
+	SellerUsername string         `json:"seller_username"`
+	ItemType       string         `json:"item_type"`
+	Quantity       int32          `json:"quantity"`
+	Price          int32          `json:"price"`
+	Status         string         `json:"status"`
+	BuyerUsername  sql.NullString `json:"buyer_username"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	ClosedAt       sql.NullTime   `json:"closed_at"`
+	Variant        sql.NullString `json:"variant"`
+}
+
+type Notification struct {
+	NotificationID int32        `json:"notification_id"`
+	Username       string       `json:"username"`
+	Kind           string       `json:"kind"`
+	Message        string       `json:"message"`
+	CreatedAt      time.Time    `json:"created_at"`
+	ReadAt         sql.NullTime `json:"read_at"`
+}
+
+type Order struct {
+	OrderID           int32          `json:"order_id"`
+	Username          string         `json:"username"`
+	ItemType          sql.NullString `json:"item_type"`
+	Price             int32          `json:"price"`
+	Discount          int32          `json:"discount"`
+	PromoCode         sql.NullString `json:"promo_code"`
+	CreatedAt         time.Time      `json:"created_at"`
+	PriceScheduleID   sql.NullInt32  `json:"price_schedule_id"`
+	Variant           sql.NullString `json:"variant"`
+	Bundle            sql.NullString `json:"bundle"`
+	Status            string         `json:"status"`
+	PickupLocation    string         `json:"pickup_location"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	RecipientUsername sql.NullString `json:"recipient_username"`
+}
+
+type OrderCoinLot struct {
+	OrderID   int32     `json:"order_id"`
+	Amount    int32     `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PasswordReset struct {
+	TokenHash string       `json:"token_hash"`
+	Username  string       `json:"username"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+}
+
+type Preorder struct {
+	PreorderID int32     `json:"preorder_id"`
+	BatchID    int32     `json:"batch_id"`
+	Username   string    `json:"username"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PreorderBatch struct {
+	BatchID   int32          `json:"batch_id"`
+	ItemType  string         `json:"item_type"`
+	Variant   sql.NullString `json:"variant"`
+	Price     int32          `json:"price"`
+	Status    string         `json:"status"`
+	CreatedBy string         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	ClosedAt  sql.NullTime   `json:"closed_at"`
+}
+
+type PriceSchedule struct {
+	ScheduleID  int32        `json:"schedule_id"`
+	ItemType    string       `json:"item_type"`
+	SalePrice   int32        `json:"sale_price"`
+	StartsAt    time.Time    `json:"starts_at"`
+	EndsAt      time.Time    `json:"ends_at"`
+	CreatedBy   string       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CancelledAt sql.NullTime `json:"cancelled_at"`
+}
+
+type PromoCode struct {
+	Code      string         `json:"code"`
+	Kind      string         `json:"kind"`
+	Value     int32          `json:"value"`
+	ItemType  sql.NullString `json:"item_type"`
+	MaxUses   int32          `json:"max_uses"`
+	UsedCount int32          `json:"used_count"`
+	StartsAt  time.Time      `json:"starts_at"`
+	EndsAt    sql.NullTime   `json:"ends_at"`
+	Disabled  bool           `json:"disabled"`
+	CreatedBy string         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type PromoCodeUse struct {
+	Code     string    `json:"code"`
+	Username string    `json:"username"`
+	UsedAt   time.Time `json:"used_at"`
+}
+
+type PurchaseLimit struct {
+	ItemType   string `json:"item_type"`
+	Lifetime   int32  `json:"lifetime"`
+	PerPeriod  int32  `json:"per_period"`
+	PeriodDays int32  `json:"period_days"`
+}
+
+type Raffle struct {
+	RaffleID    int32        `json:"raffle_id"`
+	ItemType    string       `json:"item_type"`
+	Prizes      int32        `json:"prizes"`
+	TicketPrice int32        `json:"ticket_price"`
+	MaxTickets  int32        `json:"max_tickets"`
+	Seed        string       `json:"seed"`
+	SeedHash    string       `json:"seed_hash"`
+	Status      string       `json:"status"`
+	CreatedBy   string       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+	DrawsAt     time.Time    `json:"draws_at"`
+	DrawnAt     sql.NullTime `json:"drawn_at"`
+}
+
+type RaffleTicket struct {
+	TicketID  int32     `json:"ticket_id"`
+	RaffleID  int32     `json:"raffle_id"`
+	Username  string    `json:"username"`
+	Price     int32     `json:"price"`
+	Won       bool      `json:"won"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Transfer struct {
-	TransferID   int32  `json:"transfer_id"`
-	FromUsername string `json:"from_username"`
-	ToUsername   string `json:"to_username"`
-	Amount       int32  `json:"amount"`
+	TransferID   int32     `json:"transfer_id"`
+	FromUsername string    `json:"from_username"`
+	ToUsername   string    `json:"to_username"`
+	Amount       int32     `json:"amount"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type TransferApproval struct {
+	ApprovalID   int32          `json:"approval_id"`
+	FromUsername string         `json:"from_username"`
+	ToUsername   string         `json:"to_username"`
+	Amount       int32          `json:"amount"`
+	Status       string         `json:"status"`
+	TransferID   sql.NullInt32  `json:"transfer_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	ExpiresAt    time.Time      `json:"expires_at"`
+	ResolvedBy   sql.NullString `json:"resolved_by"`
+	ResolvedAt   sql.NullTime   `json:"resolved_at"`
+}
+
+type TransferLimitOverride struct {
+	Username       string        `json:"username"`
+	PerTransaction sql.NullInt32 `json:"per_transaction"`
+	Daily          sql.NullInt32 `json:"daily"`
+	Monthly        sql.NullInt32 `json:"monthly"`
+	PerRecipient   sql.NullInt32 `json:"per_recipient"`
 }
 
 type User struct {
-	UserID   int32  `json:"user_id"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Coins    int32  `json:"coins"`
+	UserID      int32     `json:"user_id"`
+	Username    string    `json:"username"`
+	Password    string    `json:"password"`
+	Coins       int32     `json:"coins"`
+	CreatedAt   time.Time `json:"created_at"`
+	HeldCoins   int32     `json:"held_coins"`
+	DisplayName string    `json:"display_name"`
+	Department  string    `json:"department"`
+}
+
+type Wishlist struct {
+	Username       string        `json:"username"`
+	ItemType       string        `json:"item_type"`
+	Affordable     bool          `json:"affordable"`
+	InStock        bool          `json:"in_stock"`
+	SaleScheduleID sql.NullInt32 `json:"sale_schedule_id"`
+	CreatedAt      time.Time     `json:"created_at"`
 }
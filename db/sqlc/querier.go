@@ -6,20 +6,165 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	AddBundleItem(ctx context.Context, arg AddBundleItemParams) (BundleItem, error)
+	AddItemVariantStock(ctx context.Context, arg AddItemVariantStockParams) (int64, error)
+	AddItemsToInventory(ctx context.Context, arg AddItemsToInventoryParams) error
+	AddUserCoins(ctx context.Context, arg AddUserCoinsParams) (User, error)
+	AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) (Wishlist, error)
 	BuyItem(ctx context.Context, arg BuyItemParams) error
+	CancelPriceSchedule(ctx context.Context, scheduleID int32) (PriceSchedule, error)
+	ClaimPromoCodeUse(ctx context.Context, arg ClaimPromoCodeUseParams) (int64, error)
+	CloseAuction(ctx context.Context, arg CloseAuctionParams) error
+	CloseListing(ctx context.Context, arg CloseListingParams) (Listing, error)
+	ClosePreorderBatch(ctx context.Context, arg ClosePreorderBatchParams) (PreorderBatch, error)
+	CountNewSendersSince(ctx context.Context, arg CountNewSendersSinceParams) (int32, error)
+	CountPendingPreorders(ctx context.Context, batchID int32) (int64, error)
+	CountRaffleTickets(ctx context.Context, raffleID int32) (int64, error)
+	CountSentSince(ctx context.Context, arg CountSentSinceParams) (int32, error)
+	// Bundle orders count every bundled unit of the item.
+	CountUserItemOrders(ctx context.Context, arg CountUserItemOrdersParams) (CountUserItemOrdersRow, error)
+	CountUserRaffleTickets(ctx context.Context, arg CountUserRaffleTicketsParams) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuction(ctx context.Context, arg CreateAuctionParams) (Auction, error)
+	CreateBid(ctx context.Context, arg CreateBidParams) (Bid, error)
+	CreateBundle(ctx context.Context, arg CreateBundleParams) (Bundle, error)
+	CreateCoinLot(ctx context.Context, arg CreateCoinLotParams) (CoinLot, error)
+	CreateExternalIdentity(ctx context.Context, arg CreateExternalIdentityParams) (ExternalIdentity, error)
+	CreateFraudCase(ctx context.Context, arg CreateFraudCaseParams) (FraudCase, error)
+	CreateGift(ctx context.Context, arg CreateGiftParams) (Gift, error)
+	CreateItemTransfer(ctx context.Context, arg CreateItemTransferParams) (ItemTransfer, error)
+	CreateItemVariant(ctx context.Context, arg CreateItemVariantParams) (ItemVariant, error)
+	CreateListing(ctx context.Context, arg CreateListingParams) (Listing, error)
 	CreateMoneyTransfer(ctx context.Context, arg CreateMoneyTransferParams) (Transfer, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderCoinLot(ctx context.Context, arg CreateOrderCoinLotParams) error
+	CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (PasswordReset, error)
+	CreatePreorder(ctx context.Context, arg CreatePreorderParams) (Preorder, error)
+	CreatePreorderBatch(ctx context.Context, arg CreatePreorderBatchParams) (PreorderBatch, error)
+	CreatePriceSchedule(ctx context.Context, arg CreatePriceScheduleParams) (PriceSchedule, error)
+	CreatePromoCode(ctx context.Context, arg CreatePromoCodeParams) (PromoCode, error)
+	CreateRaffle(ctx context.Context, arg CreateRaffleParams) (Raffle, error)
+	CreateRaffleTicket(ctx context.Context, arg CreateRaffleTicketParams) (RaffleTicket, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (TransferApproval, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeactivateBundle(ctx context.Context, name string) (Bundle, error)
+	DeleteCoinLot(ctx context.Context, lotID int32) error
+	DeleteEmptyInventoryItem(ctx context.Context, inventoryID int32) error
+	DeletePurchaseLimit(ctx context.Context, itemType string) (int64, error)
+	DeleteTransferLimitOverride(ctx context.Context, username string) (int64, error)
+	DisablePromoCode(ctx context.Context, code string) (PromoCode, error)
+	// Burns expired coins not backing held ones: held coins are
+	// taken from the oldest lots, so each lot burns only the part
+	// beyond held_coins of the running total.
+	ExpireCoinLots(ctx context.Context, now time.Time) ([]CoinExpiration, error)
+	ExpireListings(ctx context.Context, expiresAt time.Time) ([]Listing, error)
+	GetActiveBids(ctx context.Context, auctionID int32) ([]Bid, error)
+	GetActivePriceSchedule(ctx context.Context, arg GetActivePriceScheduleParams) (PriceSchedule, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetAuction(ctx context.Context, auctionID int32) (Auction, error)
+	GetAuctionForUpdate(ctx context.Context, auctionID int32) (Auction, error)
+	GetBundle(ctx context.Context, name string) (Bundle, error)
+	GetBundleItems(ctx context.Context, bundleName string) ([]BundleItem, error)
+	GetCoinExpirations(ctx context.Context, username string) ([]CoinExpiration, error)
+	GetCoinLotsForUpdate(ctx context.Context, userID int32) ([]CoinLot, error)
+	GetDueRaffles(ctx context.Context, drawsAt time.Time) ([]Raffle, error)
+	GetEndedAuctions(ctx context.Context, endsAt time.Time) ([]Auction, error)
+	GetExpiredPreorderBatches(ctx context.Context, expiresAt time.Time) ([]PreorderBatch, error)
+	GetExpiredTransferApprovals(ctx context.Context, expiresAt time.Time) ([]TransferApproval, error)
+	GetExpiringCoinLots(ctx context.Context, arg GetExpiringCoinLotsParams) ([]CoinLot, error)
+	GetExternalIdentity(ctx context.Context, arg GetExternalIdentityParams) (ExternalIdentity, error)
+	GetFraudCaseForUpdate(ctx context.Context, caseID int32) (FraudCase, error)
+	GetGiftsWithUser(ctx context.Context, username string) ([]Gift, error)
 	GetInventory(ctx context.Context, userID int32) ([]Inventory, error)
+	GetInventoryItem(ctx context.Context, arg GetInventoryItemParams) (Inventory, error)
 	GetItemFromStore(ctx context.Context, itemType string) (Item, error)
+	GetItemTransfersWithUser(ctx context.Context, username string) ([]ItemTransfer, error)
+	GetListing(ctx context.Context, listingID int32) (Listing, error)
+	GetOrderCoinLots(ctx context.Context, orderID int32) ([]OrderCoinLot, error)
+	GetOrderForUpdate(ctx context.Context, orderID int32) (Order, error)
+	GetPendingPreorders(ctx context.Context, batchID int32) ([]Preorder, error)
+	GetPreorderBatch(ctx context.Context, batchID int32) (PreorderBatch, error)
+	GetPreorderBatchForUpdate(ctx context.Context, batchID int32) (PreorderBatch, error)
+	GetPreorderForUpdate(ctx context.Context, preorderID int32) (Preorder, error)
+	GetPromoCode(ctx context.Context, code string) (PromoCode, error)
+	GetPurchaseLimit(ctx context.Context, itemType string) (PurchaseLimit, error)
+	GetRaffle(ctx context.Context, raffleID int32) (Raffle, error)
+	GetRaffleForUpdate(ctx context.Context, raffleID int32) (Raffle, error)
+	GetRecipientsSince(ctx context.Context, arg GetRecipientsSinceParams) ([]string, error)
+	// Coins of transfers waiting for finance approval or fraud review.
+	GetReservedTransferAmount(ctx context.Context, fromUsername string) (int32, error)
+	GetReservedTransferToUserAmount(ctx context.Context, arg GetReservedTransferToUserAmountParams) (int32, error)
+	GetSentAmountSince(ctx context.Context, arg GetSentAmountSinceParams) (int32, error)
+	GetSentToUserAmountSince(ctx context.Context, arg GetSentToUserAmountSinceParams) (int32, error)
+	GetTransferApprovalForUpdate(ctx context.Context, approvalID int32) (TransferApproval, error)
+	GetTransferLimitOverride(ctx context.Context, username string) (TransferLimitOverride, error)
 	GetTransfersWithUser(ctx context.Context, username string) ([]Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
 	GetUserForUpdate(ctx context.Context, username string) (User, error)
 	GetUserViaID(ctx context.Context, userID int32) (User, error)
+	HoldUserCoins(ctx context.Context, arg HoldUserCoinsParams) (User, error)
+	InvalidatePasswordResets(ctx context.Context, username string) error
+	ListActiveBundleItems(ctx context.Context) ([]BundleItem, error)
+	ListActiveBundles(ctx context.Context) ([]Bundle, error)
+	ListActiveListings(ctx context.Context, arg ListActiveListingsParams) ([]Listing, error)
+	ListActivePriceSchedules(ctx context.Context, now time.Time) ([]PriceSchedule, error)
+	ListAllItemVariants(ctx context.Context) ([]ItemVariant, error)
+	ListApiKeys(ctx context.Context) ([]ApiKey, error)
+	ListFraudCases(ctx context.Context, status string) ([]FraudCase, error)
+	ListItemVariants(ctx context.Context, itemType string) ([]ItemVariant, error)
+	ListItems(ctx context.Context) ([]Item, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOpenAuctions(ctx context.Context) ([]Auction, error)
+	ListOpenPreorderBatches(ctx context.Context) ([]PreorderBatch, error)
+	ListOrders(ctx context.Context, status string) ([]Order, error)
+	ListPriceSchedules(ctx context.Context, itemType string) ([]PriceSchedule, error)
+	ListPromoCodes(ctx context.Context) ([]PromoCode, error)
+	ListPurchaseLimits(ctx context.Context) ([]PurchaseLimit, error)
+	ListRaffleTickets(ctx context.Context, raffleID int32) ([]RaffleTicket, error)
+	ListRaffles(ctx context.Context) ([]Raffle, error)
+	ListTransferApprovals(ctx context.Context, status string) ([]TransferApproval, error)
+	ListUserOrders(ctx context.Context, username string) ([]Order, error)
+	ListUserPreorders(ctx context.Context, username string) ([]Preorder, error)
+	ListWishlist(ctx context.Context, username string) ([]Wishlist, error)
+	ListWishlistsWithCoins(ctx context.Context) ([]ListWishlistsWithCoinsRow, error)
+	// Locks both users in user_id order, so transactions of
+	// the same pair of users in any direction can't deadlock.
+	LockTwoUsers(ctx context.Context, arg LockTwoUsersParams) ([]User, error)
+	LockUsersWithExpiredLots(ctx context.Context, expiresAt time.Time) error
+	MarkNotificationsRead(ctx context.Context, username string) (int64, error)
+	// Deletes user's use of code and uncounts it, so the code can be used again.
+	ReleasePromoCodeUse(ctx context.Context, arg ReleasePromoCodeUseParams) (int64, error)
+	ReleaseUserCoins(ctx context.Context, arg ReleaseUserCoinsParams) (User, error)
+	RemoveWishlistItem(ctx context.Context, arg RemoveWishlistItemParams) (int64, error)
+	ResolveFraudCase(ctx context.Context, arg ResolveFraudCaseParams) (FraudCase, error)
+	ResolveTransferApproval(ctx context.Context, arg ResolveTransferApprovalParams) (TransferApproval, error)
+	RevokeApiKey(ctx context.Context, keyID int32) (int64, error)
+	SetBidStatus(ctx context.Context, arg SetBidStatusParams) error
+	SetOrderPickupLocation(ctx context.Context, arg SetOrderPickupLocationParams) (Order, error)
+	SetPreorderStatus(ctx context.Context, arg SetPreorderStatusParams) (int64, error)
+	SetRaffleDrawn(ctx context.Context, raffleID int32) error
+	SetRaffleTicketWon(ctx context.Context, ticketID int32) error
+	TakeInventoryItems(ctx context.Context, arg TakeInventoryItemsParams) (Inventory, error)
+	TakeItemVariantStock(ctx context.Context, arg TakeItemVariantStockParams) (int64, error)
+	UpdateBidAmount(ctx context.Context, arg UpdateBidAmountParams) (Bid, error)
+	UpdateCoinLotAmount(ctx context.Context, arg UpdateCoinLotAmountParams) error
+	UpdateItemVariantStock(ctx context.Context, arg UpdateItemVariantStockParams) (ItemVariant, error)
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
 	UpdateTwoUsersBalance(ctx context.Context, arg UpdateTwoUsersBalanceParams) ([]User, error)
 	UpdateUserBalance(ctx context.Context, arg UpdateUserBalanceParams) (User, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error
+	UpdateWishlistState(ctx context.Context, arg UpdateWishlistStateParams) error
+	UpsertPurchaseLimit(ctx context.Context, arg UpsertPurchaseLimitParams) (PurchaseLimit, error)
+	UpsertTransferLimitOverride(ctx context.Context, arg UpsertTransferLimitOverrideParams) (TransferLimitOverride, error)
+	UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (PasswordReset, error)
+	// Counts a use only if code is active, applicable to item and not exhausted.
+	UsePromoCode(ctx context.Context, arg UsePromoCodeParams) (PromoCode, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -22,3 +22,31 @@ func (q *Queries) GetItemFromStore(ctx context.Context, itemType string) (Item,
 	err := row.Scan(&i.ItemID, &i.ItemType, &i.ItemPrice)
 	return i, err
 }
+
+const listItems = `-- name: ListItems :many
+SELECT item_id, item_type, item_price FROM Items
+ORDER BY item_type
+`
+
+func (q *Queries) ListItems(ctx context.Context) ([]Item, error) {
+	rows, err := q.db.QueryContext(ctx, listItems)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Item{}
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(&i.ItemID, &i.ItemType, &i.ItemPrice); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
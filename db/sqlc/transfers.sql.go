@@ -7,12 +7,58 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
+const countNewSendersSince = `-- name: CountNewSendersSince :one
+SELECT COUNT(DISTINCT Transfers.from_username)::int AS total FROM Transfers
+JOIN Users ON Users.username = Transfers.from_username
+WHERE Transfers.to_username = $1
+    AND Transfers.from_username <> $2
+    AND Transfers.created_at >= $3
+    AND Users.created_at >= $4
+`
+
+type CountNewSendersSinceParams struct {
+	ToUsername      string    `json:"to_username"`
+	ExcludeUsername string    `json:"exclude_username"`
+	Since           time.Time `json:"since"`
+	RegisteredAfter time.Time `json:"registered_after"`
+}
+
+func (q *Queries) CountNewSendersSince(ctx context.Context, arg CountNewSendersSinceParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, countNewSendersSince,
+		arg.ToUsername,
+		arg.ExcludeUsername,
+		arg.Since,
+		arg.RegisteredAfter,
+	)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countSentSince = `-- name: CountSentSince :one
+SELECT COUNT(*)::int AS total FROM Transfers
+WHERE from_username = $1 AND created_at >= $2
+`
+
+type CountSentSinceParams struct {
+	FromUsername string    `json:"from_username"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (q *Queries) CountSentSince(ctx context.Context, arg CountSentSinceParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, countSentSince, arg.FromUsername, arg.CreatedAt)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
 const createMoneyTransfer = `-- name: CreateMoneyTransfer :one
 INSERT INTO Transfers (from_username, to_username, amount)
 VALUES ($1, $2, $3)
-RETURNING transfer_id, from_username, to_username, amount
+RETURNING transfer_id, from_username, to_username, amount, created_at
 `
 
 type CreateMoneyTransferParams struct {
@@ -29,12 +75,119 @@ func (q *Queries) CreateMoneyTransfer(ctx context.Context, arg CreateMoneyTransf
 		&i.FromUsername,
 		&i.ToUsername,
 		&i.Amount,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const getRecipientsSince = `-- name: GetRecipientsSince :many
+SELECT DISTINCT to_username FROM Transfers
+WHERE from_username = $1 AND created_at >= $2
+`
+
+type GetRecipientsSinceParams struct {
+	FromUsername string    `json:"from_username"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetRecipientsSince(ctx context.Context, arg GetRecipientsSinceParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getRecipientsSince, arg.FromUsername, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var to_username string
+		if err := rows.Scan(&to_username); err != nil {
+			return nil, err
+		}
+		items = append(items, to_username)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReservedTransferAmount = `-- name: GetReservedTransferAmount :one
+SELECT (
+    (SELECT COALESCE(SUM(amount), 0) FROM TransferApprovals
+     WHERE from_username = $1 AND status = 'pending') +
+    (SELECT COALESCE(SUM(amount), 0) FROM FraudCases
+     WHERE from_username = $1 AND status = 'open' AND action = 'held')
+)::int AS total
+`
+
+// Coins of transfers waiting for finance approval or fraud review.
+func (q *Queries) GetReservedTransferAmount(ctx context.Context, fromUsername string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getReservedTransferAmount, fromUsername)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getReservedTransferToUserAmount = `-- name: GetReservedTransferToUserAmount :one
+SELECT (
+    (SELECT COALESCE(SUM(amount), 0) FROM TransferApprovals
+     WHERE from_username = $1 AND to_username = $2 AND status = 'pending') +
+    (SELECT COALESCE(SUM(amount), 0) FROM FraudCases
+     WHERE from_username = $1 AND to_username = $2 AND status = 'open' AND action = 'held')
+)::int AS total
+`
+
+type GetReservedTransferToUserAmountParams struct {
+	FromUsername string `json:"from_username"`
+	ToUsername   string `json:"to_username"`
+}
+
+func (q *Queries) GetReservedTransferToUserAmount(ctx context.Context, arg GetReservedTransferToUserAmountParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getReservedTransferToUserAmount, arg.FromUsername, arg.ToUsername)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getSentAmountSince = `-- name: GetSentAmountSince :one
+SELECT COALESCE(SUM(amount), 0)::int AS total FROM Transfers
+WHERE from_username = $1 AND created_at >= $2
+`
+
+type GetSentAmountSinceParams struct {
+	FromUsername string    `json:"from_username"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetSentAmountSince(ctx context.Context, arg GetSentAmountSinceParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getSentAmountSince, arg.FromUsername, arg.CreatedAt)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getSentToUserAmountSince = `-- name: GetSentToUserAmountSince :one
+SELECT COALESCE(SUM(amount), 0)::int AS total FROM Transfers
+WHERE from_username = $1 AND to_username = $2 AND created_at >= $3
+`
+
+type GetSentToUserAmountSinceParams struct {
+	FromUsername string    `json:"from_username"`
+	ToUsername   string    `json:"to_username"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetSentToUserAmountSince(ctx context.Context, arg GetSentToUserAmountSinceParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getSentToUserAmountSince, arg.FromUsername, arg.ToUsername, arg.CreatedAt)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
 const getTransfersWithUser = `-- name: GetTransfersWithUser :many
-SELECT transfer_id, from_username, to_username, amount FROM Transfers
+SELECT transfer_id, from_username, to_username, amount, created_at FROM Transfers
 WHERE from_username=$1 OR to_username=$1
 FOR SHARE
 `
@@ -53,6 +206,7 @@ func (q *Queries) GetTransfersWithUser(ctx context.Context, username string) ([]
 			&i.FromUsername,
 			&i.ToUsername,
 			&i.Amount,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -9,13 +9,41 @@ import (
 	"context"
 )
 
+const addUserCoins = `-- name: AddUserCoins :one
+UPDATE Users
+SET coins = coins + $1
+WHERE username = $2
+RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
+`
+
+type AddUserCoinsParams struct {
+	Amount   int32  `json:"amount"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) AddUserCoins(ctx context.Context, arg AddUserCoinsParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, addUserCoins, arg.Amount, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.Password,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO Users (
     username,
     password
 ) VALUES (
     $1, $2
-) RETURNING user_id, username, password, coins
+) RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
 `
 
 type CreateUserParams struct {
@@ -31,12 +59,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Username,
 		&i.Password,
 		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT user_id, username, password, coins FROM Users
+SELECT user_id, username, password, coins, created_at, held_coins, display_name, department FROM Users
 WHERE username = $1
 LIMIT 1 FOR SHARE
 `
@@ -49,12 +81,16 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Username,
 		&i.Password,
 		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
 	)
 	return i, err
 }
 
 const getUserForUpdate = `-- name: GetUserForUpdate :one
-SELECT user_id, username, password, coins FROM Users
+SELECT user_id, username, password, coins, created_at, held_coins, display_name, department FROM Users
 WHERE username = $1
 LIMIT 1
 FOR UPDATE
@@ -68,12 +104,16 @@ func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User,
 		&i.Username,
 		&i.Password,
 		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
 	)
 	return i, err
 }
 
 const getUserViaID = `-- name: GetUserViaID :one
-SELECT user_id, username, password, coins FROM Users
+SELECT user_id, username, password, coins, created_at, held_coins, display_name, department FROM Users
 WHERE user_id = $1
 LIMIT 1 FOR SHARE
 `
@@ -86,6 +126,114 @@ func (q *Queries) GetUserViaID(ctx context.Context, userID int32) (User, error)
 		&i.Username,
 		&i.Password,
 		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
+	)
+	return i, err
+}
+
+const holdUserCoins = `-- name: HoldUserCoins :one
+UPDATE Users
+SET coins = coins - $1,
+    held_coins = held_coins + $1
+WHERE username = $2
+RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
+`
+
+type HoldUserCoinsParams struct {
+	Amount   int32  `json:"amount"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) HoldUserCoins(ctx context.Context, arg HoldUserCoinsParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, holdUserCoins, arg.Amount, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.Password,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
+	)
+	return i, err
+}
+
+const lockTwoUsers = `-- name: LockTwoUsers :many
+SELECT user_id, username, password, coins, created_at, held_coins, display_name, department FROM Users
+WHERE username IN ($1, $2)
+ORDER BY user_id
+FOR UPDATE
+`
+
+type LockTwoUsersParams struct {
+	FirstUsername  string `json:"first_username"`
+	SecondUsername string `json:"second_username"`
+}
+
+// Locks both users in user_id order, so transactions of
+// the same pair of users in any direction can't deadlock.
+func (q *Queries) LockTwoUsers(ctx context.Context, arg LockTwoUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, lockTwoUsers, arg.FirstUsername, arg.SecondUsername)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Username,
+			&i.Password,
+			&i.Coins,
+			&i.CreatedAt,
+			&i.HeldCoins,
+			&i.DisplayName,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseUserCoins = `-- name: ReleaseUserCoins :one
+UPDATE Users
+SET coins = coins + $1,
+    held_coins = held_coins - $1
+WHERE username = $2
+RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
+`
+
+type ReleaseUserCoinsParams struct {
+	Amount   int32  `json:"amount"`
+	Username string `json:"username"`
+}
+
+func (q *Queries) ReleaseUserCoins(ctx context.Context, arg ReleaseUserCoinsParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, releaseUserCoins, arg.Amount, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.Password,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
 	)
 	return i, err
 }
@@ -97,7 +245,7 @@ SET coins = CASE
     WHEN username = $3 THEN coins + $1
 END
 WHERE USERNAME IN ($2, $3)
-RETURNING user_id, username, password, coins
+RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
 `
 
 type UpdateTwoUsersBalanceParams struct {
@@ -120,6 +268,10 @@ func (q *Queries) UpdateTwoUsersBalance(ctx context.Context, arg UpdateTwoUsersB
 			&i.Username,
 			&i.Password,
 			&i.Coins,
+			&i.CreatedAt,
+			&i.HeldCoins,
+			&i.DisplayName,
+			&i.Department,
 		); err != nil {
 			return nil, err
 		}
@@ -138,7 +290,7 @@ const updateUserBalance = `-- name: UpdateUserBalance :one
 UPDATE Users
 SET coins = $2
 WHERE user_id = $1
-RETURNING user_id, username, password, coins
+RETURNING user_id, username, password, coins, created_at, held_coins, display_name, department
 `
 
 type UpdateUserBalanceParams struct {
@@ -154,6 +306,44 @@ func (q *Queries) UpdateUserBalance(ctx context.Context, arg UpdateUserBalancePa
 		&i.Username,
 		&i.Password,
 		&i.Coins,
+		&i.CreatedAt,
+		&i.HeldCoins,
+		&i.DisplayName,
+		&i.Department,
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE Users
+SET password = $2
+WHERE username = $1
+`
+
+type UpdateUserPasswordParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.Username, arg.Password)
+	return err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :exec
+UPDATE Users
+SET display_name = $2,
+    department = $3
+WHERE username = $1
+`
+
+type UpdateUserProfileParams struct {
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Department  string `json:"department"`
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserProfile, arg.Username, arg.DisplayName, arg.Department)
+	return err
+}
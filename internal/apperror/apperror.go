@@ -3,12 +3,16 @@ package apperror
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type AppError struct {
 	HTTPCode int    // for user
 	Message  string // for user
 	Err      error  // for internal logging
+
+	Details    interface{}   // optional structured info for user
+	RetryAfter time.Duration // sent in Retry-After header if set
 }
 
 func (e *AppError) Error() string {
@@ -19,6 +23,19 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// WithDetails attaches structured info
+// returned to user next to the message.
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	e.Details = details
+	return e
+}
+
+// WithRetryAfter tells user when request can be repeated.
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	e.RetryAfter = d
+	return e
+}
+
 // NewBadReq used to create errors with
 // statusCode = 400.
 func NewBadReq(message string, err error) *AppError {
@@ -31,14 +48,38 @@ func NewUnauthorized(message string, err error) *AppError {
 	return &AppError{HTTPCode: http.StatusUnauthorized, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
 }
 
+// NewForbidden used to create errors with
+// statusCode = 403.
+func NewForbidden(message string, err error) *AppError {
+	return &AppError{HTTPCode: http.StatusForbidden, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
+}
+
 // NewNotFound used to create errors with
 // statusCode = 404.
 func NewNotFound(message string, err error) *AppError {
 	return &AppError{HTTPCode: http.StatusNotFound, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
 }
 
+// NewConflict used to create errors with
+// statusCode = 409.
+func NewConflict(message string, err error) *AppError {
+	return &AppError{HTTPCode: http.StatusConflict, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
+}
+
+// NewTooManyRequests used to create errors with
+// statusCode = 429.
+func NewTooManyRequests(message string, err error) *AppError {
+	return &AppError{HTTPCode: http.StatusTooManyRequests, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
+}
+
 // NewInternal used to craete errors with
 // statusCode = 500.
 func NewInternal(message string, err error) *AppError {
 	return &AppError{HTTPCode: http.StatusInternalServerError, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
 }
+
+// NewServiceUnavailable used to create errors with
+// statusCode = 503.
+func NewServiceUnavailable(message string, err error) *AppError {
+	return &AppError{HTTPCode: http.StatusServiceUnavailable, Message: message, Err: fmt.Errorf("%s: %w", message, err)}
+}
@@ -0,0 +1,84 @@
+// Package authn checks passwords of users logging in,
+// against local hashes or external directory.
+package authn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/hasher"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrUnknownUser means user can sign up with given password.
+	ErrUnknownUser = errors.New("unknown user")
+)
+
+const (
+	BackendLocal = "local"
+	BackendLDAP  = "ldap"
+)
+
+// Profile is user info kept in directory.
+type Profile struct {
+	DisplayName string
+	Department  string
+}
+
+// Authenticator checks password of user, dbUsr is local
+// user or nil if there is none.
+type Authenticator interface {
+	// Authenticate returns directory profile of user,
+	// nil profile means user is managed locally.
+	Authenticate(ctx context.Context, username, password string, dbUsr *db.User) (*Profile, error)
+}
+
+type Config struct {
+	Backend string // local or ldap, empty - local
+	LDAP    LDAPConfig
+}
+
+// New returns authenticator of configured backend,
+// local one compares hashes with h.
+func New(cfg Config, h hasher.Hasher) (Authenticator, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case BackendLocal, "":
+		return NewLocal(h), nil
+	case BackendLDAP:
+		if cfg.LDAP.URL == "" || cfg.LDAP.BaseDN == "" {
+			return nil, errors.New("ldap url and base dn are required")
+		}
+		return NewLDAP(cfg.LDAP)
+	default:
+		return nil, fmt.Errorf("unknown auth backend %q", cfg.Backend)
+	}
+}
+
+// LDAPConfig of directory, empty attributes and timeout use defaults.
+type LDAPConfig struct {
+	URL string // ldaps://host:636, or ldap://host:389 with StartTLS
+	// StartTLS upgrades ldap:// connection before binding.
+	StartTLS bool
+	// Insecure allows ldap:// without StartTLS, passwords
+	// are sent in plaintext then.
+	Insecure bool
+	// CAFile has PEM certificates directory is verified
+	// with, empty - system roots.
+	CAFile string
+	// BindDN and BindPassword of service account users are
+	// searched with, empty - anonymous search.
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+
+	UsernameAttr    string
+	DisplayNameAttr string
+	DepartmentAttr  string
+
+	Timeout time.Duration
+}
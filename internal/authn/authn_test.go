@@ -0,0 +1,208 @@
+package authn
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/hasher"
+	"github.com/myacey/avito-shop/internal/ldap/ldaptest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	baseDN     = "dc=corp,dc=test"
+	serviceDN  = "cn=shop,ou=services,dc=corp,dc=test"
+	servicePwd = "service-secret"
+)
+
+// newDirectory starts directory and returns it
+// with file of its certificate.
+func newDirectory(t *testing.T, newServer func() (*ldaptest.Server, error)) (*ldaptest.Server, string) {
+	srv, err := newServer()
+	require.NoError(t, err)
+	t.Cleanup(func() { srv.Close() })
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, srv.CertPEM(), 0o600))
+
+	srv.AddEntry(serviceDN, servicePwd, nil)
+	srv.AddEntry("uid=alice,ou=people,dc=corp,dc=test", "secret", map[string][]string{
+		"uid":              {"alice"},
+		"displayName":      {"Alice Smith"},
+		"departmentNumber": {"Logistics"},
+	})
+	return srv, caFile
+}
+
+func TestLocal(t *testing.T) {
+	h := &hasher.BcryptHasher{Cost: bcrypt.MinCost}
+	hash, err := h.Generate(context.Background(), "secret")
+	require.NoError(t, err)
+
+	a := NewLocal(h)
+	usr := &db.User{Username: "alice", Password: hash}
+
+	profile, err := a.Authenticate(context.Background(), "alice", "secret", usr)
+	require.NoError(t, err)
+	require.Nil(t, profile)
+
+	_, err = a.Authenticate(context.Background(), "alice", "wrong", usr)
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = a.Authenticate(context.Background(), "bob", "secret", nil)
+	require.ErrorIs(t, err, ErrUnknownUser)
+}
+
+func TestLDAP(t *testing.T) {
+	dir, caFile := newDirectory(t, ldaptest.NewServer)
+	tlsDir, tlsCAFile := newDirectory(t, ldaptest.NewTLSServer)
+
+	testCases := []struct {
+		name       string
+		cfg        LDAPConfig
+		username   string
+		password   string
+		expProfile *Profile
+		expErr     error
+		anyErr     bool
+	}{
+		{
+			name:       "OK Anonymous Search",
+			cfg:        LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN},
+			username:   "alice",
+			password:   "secret",
+			expProfile: &Profile{DisplayName: "Alice Smith", Department: "Logistics"},
+		},
+		{
+			name:       "OK LDAPS",
+			cfg:        LDAPConfig{URL: tlsDir.URL(), CAFile: tlsCAFile, BaseDN: baseDN},
+			username:   "alice",
+			password:   "secret",
+			expProfile: &Profile{DisplayName: "Alice Smith", Department: "Logistics"},
+		},
+		{
+			name:       "OK Insecure",
+			cfg:        LDAPConfig{URL: dir.URL(), Insecure: true, BaseDN: baseDN},
+			username:   "alice",
+			password:   "secret",
+			expProfile: &Profile{DisplayName: "Alice Smith", Department: "Logistics"},
+		},
+		{
+			name:       "OK Service Account",
+			cfg:        LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN, BindDN: serviceDN, BindPassword: servicePwd},
+			username:   "alice",
+			password:   "secret",
+			expProfile: &Profile{DisplayName: "Alice Smith", Department: "Logistics"},
+		},
+		{
+			name:       "OK Custom Attributes",
+			cfg:        LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN, DisplayNameAttr: "uid", DepartmentAttr: "ou"},
+			username:   "alice",
+			password:   "secret",
+			expProfile: &Profile{DisplayName: "alice"},
+		},
+		{
+			name:     "Err Wrong Password",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN},
+			username: "alice",
+			password: "wrong",
+			expErr:   ErrInvalidCredentials,
+		},
+		{
+			name:     "Err Empty Password",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN},
+			username: "alice",
+			password: "",
+			expErr:   ErrInvalidCredentials,
+		},
+		{
+			name:     "Err Unknown User",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN},
+			username: "bob",
+			password: "secret",
+			expErr:   ErrInvalidCredentials,
+		},
+		{
+			// filter value is compared literally
+			name:     "Err Wildcard Username",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN},
+			username: "*",
+			password: "secret",
+			expErr:   ErrInvalidCredentials,
+		},
+		{
+			name:     "Err Untrusted Certificate",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, BaseDN: baseDN},
+			username: "alice",
+			password: "secret",
+			anyErr:   true,
+		},
+		{
+			name:     "Err Service Account",
+			cfg:      LDAPConfig{URL: dir.URL(), StartTLS: true, CAFile: caFile, BaseDN: baseDN, BindDN: serviceDN, BindPassword: "wrong"},
+			username: "alice",
+			password: "secret",
+			anyErr:   true,
+		},
+		{
+			name:     "Err Directory Down",
+			cfg:      LDAPConfig{URL: "ldaps://127.0.0.1:1", BaseDN: baseDN},
+			username: "alice",
+			password: "secret",
+			anyErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := NewLDAP(tc.cfg)
+			require.NoError(t, err)
+
+			profile, err := a.Authenticate(context.Background(), tc.username, tc.password, nil)
+			switch {
+			case tc.expErr != nil:
+				require.ErrorIs(t, err, tc.expErr)
+			case tc.anyErr:
+				require.Error(t, err)
+				// misconfiguration must not look like wrong password
+				require.NotErrorIs(t, err, ErrInvalidCredentials)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.expProfile, profile)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	a, err := New(Config{}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &Local{}, a)
+
+	a, err = New(Config{Backend: "ldap", LDAP: LDAPConfig{URL: "ldaps://localhost", BaseDN: baseDN}}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &LDAP{}, a)
+
+	// passwords must not be sent in plaintext by accident
+	_, err = New(Config{Backend: "ldap", LDAP: LDAPConfig{URL: "ldap://localhost", BaseDN: baseDN}}, nil)
+	require.Error(t, err)
+
+	_, err = New(Config{Backend: "ldap", LDAP: LDAPConfig{URL: "ldap://localhost", BaseDN: baseDN, StartTLS: true}}, nil)
+	require.NoError(t, err)
+
+	_, err = New(Config{Backend: "ldap", LDAP: LDAPConfig{URL: "ldap://localhost", BaseDN: baseDN, Insecure: true}}, nil)
+	require.NoError(t, err)
+
+	_, err = New(Config{Backend: "ldap", LDAP: LDAPConfig{URL: "ldaps://localhost", BaseDN: baseDN, CAFile: "missing.pem"}}, nil)
+	require.Error(t, err)
+
+	_, err = New(Config{Backend: "ldap"}, nil)
+	require.Error(t, err)
+
+	_, err = New(Config{Backend: "kerberos"}, nil)
+	require.Error(t, err)
+}
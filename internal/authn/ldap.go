@@ -0,0 +1,153 @@
+package authn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var defaultLDAPConfig = LDAPConfig{
+	UsernameAttr:    "uid",
+	DisplayNameAttr: "displayName",
+	DepartmentAttr:  "departmentNumber",
+	Timeout:         5 * time.Second,
+}
+
+// LDAP finds user in directory and binds as them with password.
+// Users unknown locally are created on first login.
+type LDAP struct {
+	cfg LDAPConfig
+	tls *tls.Config
+}
+
+// NewLDAP checks that passwords won't be sent in plaintext: URL must be
+// ldaps:// or StartTLS enabled, unless cfg.Insecure is set.
+func NewLDAP(cfg LDAPConfig) (Authenticator, error) {
+	if cfg.UsernameAttr == "" {
+		cfg.UsernameAttr = defaultLDAPConfig.UsernameAttr
+	}
+	if cfg.DisplayNameAttr == "" {
+		cfg.DisplayNameAttr = defaultLDAPConfig.DisplayNameAttr
+	}
+	if cfg.DepartmentAttr == "" {
+		cfg.DepartmentAttr = defaultLDAPConfig.DepartmentAttr
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultLDAPConfig.Timeout
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url: %w", err)
+	}
+	switch u.Scheme {
+	case "ldaps":
+	case "ldap":
+		if !cfg.StartTLS && !cfg.Insecure {
+			return nil, errors.New("ldap:// sends passwords in plaintext, use ldaps://, StartTLS or insecure flag")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ldap url scheme %q", u.Scheme)
+	}
+
+	tlsCfg := &tls.Config{ServerName: u.Hostname()}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ldap ca file: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates in ldap ca file")
+		}
+	}
+
+	return &LDAP{cfg: cfg, tls: tlsCfg}, nil
+}
+
+// dial connects to directory, connection is closed
+// when ctx is done.
+func (l *LDAP) dial(ctx context.Context) (*ldap.Conn, func(), error) {
+	dialer := &net.Dialer{}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+
+	conn, err := ldap.DialURL(l.cfg.URL, ldap.DialWithDialer(dialer), ldap.DialWithTLSConfig(l.tls))
+	if err != nil {
+		return nil, nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	closeConn := func() {
+		stop()
+		conn.Close()
+	}
+
+	if l.cfg.StartTLS {
+		if err = conn.StartTLS(l.tls); err != nil {
+			closeConn()
+			return nil, nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	return conn, closeConn, nil
+}
+
+func (l *LDAP) Authenticate(ctx context.Context, username, password string, dbUsr *db.User) (*Profile, error) {
+	// empty password is anonymous bind, which always succeeds
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.cfg.Timeout)
+	defer cancel()
+
+	conn, closeConn, err := l.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	defer closeConn()
+
+	if l.cfg.BindDN != "" {
+		if err = conn.Bind(l.cfg.BindDN, l.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind service account: %w", err)
+		}
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		l.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(%s=%s)", ldap.EscapeFilter(l.cfg.UsernameAttr), ldap.EscapeFilter(username)),
+		[]string{l.cfg.DisplayNameAttr, l.cfg.DepartmentAttr},
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search directory: %w", err)
+	}
+	switch {
+	case len(res.Entries) == 0:
+		return nil, ErrInvalidCredentials
+	case len(res.Entries) > 1:
+		return nil, fmt.Errorf("%d directory entries of %s", len(res.Entries), username)
+	}
+	entry := res.Entries[0]
+
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to bind user: %w", err)
+	}
+
+	return &Profile{
+		DisplayName: entry.GetEqualFoldAttributeValue(l.cfg.DisplayNameAttr),
+		Department:  entry.GetEqualFoldAttributeValue(l.cfg.DepartmentAttr),
+	}, nil
+}
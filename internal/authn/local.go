@@ -0,0 +1,33 @@
+package authn
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/hasher"
+)
+
+// Local compares password with hash stored in Users,
+// unknown users can sign up.
+type Local struct {
+	hasher hasher.Hasher
+}
+
+func NewLocal(h hasher.Hasher) Authenticator {
+	return &Local{h}
+}
+
+func (l *Local) Authenticate(ctx context.Context, username, password string, dbUsr *db.User) (*Profile, error) {
+	if dbUsr == nil {
+		return nil, ErrUnknownUser
+	}
+
+	if err := l.hasher.Compare(ctx, dbUsr.Password, password); err != nil {
+		if errors.Is(err, hasher.ErrDontCompare) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	return nil, nil
+}
@@ -1,6 +1,10 @@
 package backconfig
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	// 4ALL
@@ -9,6 +13,11 @@ type Config struct {
 	Testing      bool   `mapstructure:"TESTING"`
 	JWTSecretKey string `mapstructure:"JWT_SECRET_KEY"`
 
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"` // empty - X-Forwarded-For is ignored
+
+	AdminUsernames   []string `mapstructure:"ADMIN_USERNAMES"`
+	FinanceUsernames []string `mapstructure:"FINANCE_USERNAMES"`
+
 	// POSTGRES
 	PostgresHost   string `mapstructure:"POSTGRES_HOST"`
 	PostgresUser   string `mapstructure:"POSTGRES_USER"`
@@ -18,6 +27,107 @@ type Config struct {
 	// REDIS
 	RedisUser string `mapstructure:"REDIS_USER"`
 	RedisHost string `mapstructure:"REDIS_HOST"`
+
+	// COINS
+	CoinLifetimeMonths int           `mapstructure:"COIN_LIFETIME_MONTHS"` // 0 disables expiry
+	CoinExpiryNotice   time.Duration `mapstructure:"COIN_EXPIRY_NOTICE"`
+	CoinExpiryInterval time.Duration `mapstructure:"COIN_EXPIRY_INTERVAL"`
+
+	// TRANSFER LIMITS (0 means unlimited)
+	TransferLimitPerTransaction int32 `mapstructure:"TRANSFER_LIMIT_PER_TRANSACTION"`
+	TransferLimitDaily          int32 `mapstructure:"TRANSFER_LIMIT_DAILY"`
+	TransferLimitMonthly        int32 `mapstructure:"TRANSFER_LIMIT_MONTHLY"`
+	TransferLimitPerRecipient   int32 `mapstructure:"TRANSFER_LIMIT_PER_RECIPIENT"`
+
+	// FRAUD (actions: none, flag, hold)
+	FraudDetection        bool          `mapstructure:"FRAUD_DETECTION"`
+	FraudWindow           time.Duration `mapstructure:"FRAUD_WINDOW"`
+	FraudCycleMaxDepth    int           `mapstructure:"FRAUD_CYCLE_MAX_DEPTH"`
+	FraudCycleAction      string        `mapstructure:"FRAUD_CYCLE_ACTION"`
+	FraudNewAccountAge    time.Duration `mapstructure:"FRAUD_NEW_ACCOUNT_AGE"`
+	FraudFanInMinSenders  int32         `mapstructure:"FRAUD_FAN_IN_MIN_SENDERS"`
+	FraudFanInAction      string        `mapstructure:"FRAUD_FAN_IN_ACTION"`
+	FraudVelocityWindow   time.Duration `mapstructure:"FRAUD_VELOCITY_WINDOW"`
+	FraudVelocityMaxCount int32         `mapstructure:"FRAUD_VELOCITY_MAX_COUNT"`
+	FraudVelocityAction   string        `mapstructure:"FRAUD_VELOCITY_ACTION"`
+
+	// TRANSFER APPROVALS
+	TransferApprovalThreshold int32         `mapstructure:"TRANSFER_APPROVAL_THRESHOLD"` // 0 disables approvals
+	TransferApprovalTimeout   time.Duration `mapstructure:"TRANSFER_APPROVAL_TIMEOUT"`
+	TransferApprovalInterval  time.Duration `mapstructure:"TRANSFER_APPROVAL_INTERVAL"`
+
+	// MARKETPLACE
+	MarketFeePercent     int32         `mapstructure:"MARKET_FEE_PERCENT"`
+	MarketListingTTL     time.Duration `mapstructure:"MARKET_LISTING_TTL"`
+	MarketExpiryInterval time.Duration `mapstructure:"MARKET_EXPIRY_INTERVAL"`
+
+	// AUCTIONS
+	AuctionCloseInterval time.Duration `mapstructure:"AUCTION_CLOSE_INTERVAL"`
+
+	// ORDERS
+	PickupLocations []string `mapstructure:"PICKUP_LOCATIONS"` // empty disables pickup location selection
+
+	// WISHLISTS
+	WishlistCheckInterval time.Duration `mapstructure:"WISHLIST_CHECK_INTERVAL"`
+
+	// PREORDERS
+	PreorderExpiryInterval time.Duration `mapstructure:"PREORDER_EXPIRY_INTERVAL"`
+
+	// RAFFLES
+	RaffleDrawInterval time.Duration `mapstructure:"RAFFLE_DRAW_INTERVAL"`
+
+	// PASSWORDS
+	PasswordResetTTL      time.Duration `mapstructure:"PASSWORD_RESET_TTL"`
+	PasswordMinLength     int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMinClasses    int           `mapstructure:"PASSWORD_MIN_CLASSES"`    // of lowercase, uppercase, digits and symbols
+	BreachedPasswordsFile string        `mapstructure:"BREACHED_PASSWORDS_FILE"` // empty - only embedded list
+
+	// PASSWORD HASHING
+	PasswordHasher string `mapstructure:"PASSWORD_HASHER"` // argon2id or bcrypt
+	BcryptCost     int    `mapstructure:"BCRYPT_COST"`
+	Argon2Memory   uint32 `mapstructure:"ARGON2_MEMORY"` // KiB
+	Argon2Time     uint32 `mapstructure:"ARGON2_TIME"`
+	Argon2Threads  uint8  `mapstructure:"ARGON2_THREADS"`
+
+	HashMaxConcurrent int           `mapstructure:"HASH_MAX_CONCURRENT"` // upper bound of adaptive limit
+	HashMaxWait       time.Duration `mapstructure:"HASH_MAX_WAIT"`       // 503 after waiting that long
+
+	// LOGIN THROTTLING
+	LoginUserFreeAttempts int64         `mapstructure:"LOGIN_USER_FREE_ATTEMPTS"`
+	LoginIPFreeAttempts   int64         `mapstructure:"LOGIN_IP_FREE_ATTEMPTS"`
+	LoginBackoffBase      time.Duration `mapstructure:"LOGIN_BACKOFF_BASE"`
+	LoginBackoffMax       time.Duration `mapstructure:"LOGIN_BACKOFF_MAX"`
+	LoginFailureWindow    time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"`
+
+	// USERNAMES
+	UsernameMinLength int      `mapstructure:"USERNAME_MIN_LENGTH"`
+	UsernameMaxLength int      `mapstructure:"USERNAME_MAX_LENGTH"`
+	UsernamePattern   string   `mapstructure:"USERNAME_PATTERN"`
+	ReservedUsernames []string `mapstructure:"RESERVED_USERNAMES"`
+
+	// AUTH BACKEND
+	AuthBackend         string        `mapstructure:"AUTH_BACKEND"` // local or ldap
+	LDAPURL             string        `mapstructure:"LDAP_URL"`
+	LDAPStartTLS        bool          `mapstructure:"LDAP_START_TLS"`
+	LDAPInsecure        bool          `mapstructure:"LDAP_INSECURE"` // allows plaintext ldap://
+	LDAPCAFile          string        `mapstructure:"LDAP_CA_FILE"`  // empty - system roots
+	LDAPBindDN          string        `mapstructure:"LDAP_BIND_DN"`  // empty - anonymous search
+	LDAPBindPassword    string        `mapstructure:"LDAP_BIND_PASSWORD"`
+	LDAPBaseDN          string        `mapstructure:"LDAP_BASE_DN"`
+	LDAPUsernameAttr    string        `mapstructure:"LDAP_USERNAME_ATTR"`
+	LDAPDisplayNameAttr string        `mapstructure:"LDAP_DISPLAY_NAME_ATTR"`
+	LDAPDepartmentAttr  string        `mapstructure:"LDAP_DEPARTMENT_ATTR"`
+	LDAPTimeout         time.Duration `mapstructure:"LDAP_TIMEOUT"`
+
+	// OIDC
+	OIDCIssuer       string   `mapstructure:"OIDC_ISSUER"` // empty - disabled
+	OIDCClientID     string   `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string   `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string   `mapstructure:"OIDC_REDIRECT_URL"`
+	OIDCScopes       []string `mapstructure:"OIDC_SCOPES"`
+
+	// API KEYS
+	APIKeyRateLimit int32 `mapstructure:"API_KEY_RATE_LIMIT"` // requests per minute, default of new keys
 }
 
 func LoadConfig() (config Config, err error) {
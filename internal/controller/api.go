@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
 )
 
 type authReq struct {
@@ -20,11 +21,11 @@ type authReq struct {
 func (h *Controller) Authorize(c *gin.Context) {
 	var req authReq
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.JSONError(c, err)
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
 		return
 	}
 
-	token, err := h.srv.AuthorizeUser(c, req.Username, req.Password)
+	token, err := h.srv.AuthorizeUser(c, req.Username, req.Password, c.ClientIP())
 	if err != nil {
 		h.JSONError(c, err)
 		return
@@ -58,6 +59,8 @@ type sendCoinReq struct {
 
 // SendCoins checks providen token with middleware and
 // than transfers money from one user to another.
+//
+// Held transfers are answered with 202 and case id.
 func (h *Controller) SendCoins(c *gin.Context) {
 	username, ok := c.Get("username")
 	if !ok {
@@ -71,12 +74,18 @@ func (h *Controller) SendCoins(c *gin.Context) {
 		return
 	}
 
-	err := h.srv.SendCoin(c, username.(string), req.ToUser, req.Amount)
+	res, err := h.srv.SendCoin(c, username.(string), req.ToUser, req.Amount)
 	if err != nil {
 		h.JSONError(c, err)
 		return
 	}
 
+	// transfer is waiting for review
+	if res != nil && res.Status != models.TransferCompleted {
+		c.JSON(http.StatusAccepted, res)
+		return
+	}
+
 	c.JSON(http.StatusOK, nil)
 }
 
@@ -93,7 +102,7 @@ func (h *Controller) BuyItem(c *gin.Context) {
 		return
 	}
 
-	err := h.srv.BuyItem(c, username.(string), item)
+	err := h.srv.BuyItem(c, username.(string), item, c.Query("variant"), c.Query("promo"))
 	if err != nil {
 		h.JSONError(c, err)
 		return
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+type grantCoinsReq struct {
+	Amount int32  `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+// CreateAPIKey issues API key, key is shown only in this response.
+func (h *Controller) CreateAPIKey(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewAPIKey
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	key, err := h.srv.CreateAPIKey(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeys returns every API key without secrets.
+func (h *Controller) ListAPIKeys(c *gin.Context) {
+	keys, err := h.srv.ListAPIKeys(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey makes API key stop working.
+func (h *Controller) RevokeAPIKey(c *gin.Context) {
+	keyID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid api key id", err))
+		return
+	}
+
+	if err = h.srv.RevokeAPIKey(c, int32(keyID)); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+// GrantCoins credits user with coins, admins and
+// API keys with grants:write only.
+func (h *Controller) GrantCoins(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req grantCoinsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	if err := h.srv.GrantCoins(c, username.(string), c.Param("username"), req.Amount, req.Reason); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/myacey/avito-shop/internal/apperror"
 	"github.com/myacey/avito-shop/internal/mocks"
 	"github.com/myacey/avito-shop/internal/models"
 	"github.com/stretchr/testify/require"
@@ -17,7 +19,7 @@ import (
 
 var (
 	ErrMock  = errors.New("mock error")
-	mockUser = models.User{1, "mockuser", "", 1000, nil, nil}
+	mockUser = models.User{ID: 1, Username: "mockuser", Coins: 1000}
 )
 
 func TestAuthorize(t *testing.T) {
@@ -28,18 +30,19 @@ func TestAuthorize(t *testing.T) {
 	handler := NewController(mockSrv)
 
 	testCases := []struct {
-		name         string
-		req          authReq
-		mockBehavior func(req authReq)
-		expStatus    int
-		expAns       interface{}
+		name          string
+		req           authReq
+		mockBehavior  func(req authReq)
+		expStatus     int
+		expAns        interface{}
+		expRetryAfter string
 	}{
 		{
 			name: "OK",
 			req:  authReq{"mockuser", "mockpassword"},
 			mockBehavior: func(req authReq) {
 				mockSrv.EXPECT().
-					AuthorizeUser(gomock.Any(), req.Username, req.Password).
+					AuthorizeUser(gomock.Any(), req.Username, req.Password, gomock.Any()).
 					Return("valid", nil)
 			},
 			expStatus: http.StatusOK,
@@ -50,12 +53,25 @@ func TestAuthorize(t *testing.T) {
 			req:  authReq{"mockuser", "mockpassword"},
 			mockBehavior: func(req authReq) {
 				mockSrv.EXPECT().
-					AuthorizeUser(gomock.Any(), req.Username, req.Password).
+					AuthorizeUser(gomock.Any(), req.Username, req.Password, gomock.Any()).
 					Return("", ErrMock)
 			},
 			expStatus: http.StatusInternalServerError,
 			expAns:    gin.H{"errors": "internal server error"},
 		},
+		{
+			name: "Err Locked",
+			req:  authReq{"mockuser", "mockpassword"},
+			mockBehavior: func(req authReq) {
+				mockSrv.EXPECT().
+					AuthorizeUser(gomock.Any(), req.Username, req.Password, gomock.Any()).
+					Return("", apperror.NewTooManyRequests("too many failed login attempts", nil).
+						WithRetryAfter(1500*time.Millisecond))
+			},
+			expStatus:     http.StatusTooManyRequests,
+			expAns:        gin.H{"errors": "too many failed login attempts"},
+			expRetryAfter: "2",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -76,6 +92,7 @@ func TestAuthorize(t *testing.T) {
 			handler.Authorize(c)
 
 			require.Equal(t, tc.expStatus, w.Code)
+			require.Equal(t, tc.expRetryAfter, w.Header().Get("Retry-After"))
 			crResp, err := json.Marshal(tc.expAns)
 			require.NoError(t, err)
 			require.Equal(t, crResp, w.Body.Bytes())
@@ -181,11 +198,22 @@ func TestSendCoins(t *testing.T) {
 			mockBehavior: func(username string, req sendCoinReq) {
 				mockSrv.EXPECT().
 					SendCoin(gomock.Any(), username, req.ToUser, req.Amount).
-					Return(nil)
+					Return(&models.TransferResult{Status: models.TransferCompleted}, nil)
 			},
 			expStatus: http.StatusOK,
 			expAns:    nil,
 		},
+		{
+			name:     "OK Held",
+			username: "mockuser",
+			mockBehavior: func(username string, req sendCoinReq) {
+				mockSrv.EXPECT().
+					SendCoin(gomock.Any(), username, req.ToUser, req.Amount).
+					Return(&models.TransferResult{Status: models.TransferHeld, CaseID: 1}, nil)
+			},
+			expStatus: http.StatusAccepted,
+			expAns:    models.TransferResult{Status: models.TransferHeld, CaseID: 1},
+		},
 		{
 			name:         "Err No Username",
 			username:     "mockuser",
@@ -200,7 +228,7 @@ func TestSendCoins(t *testing.T) {
 			mockBehavior: func(username string, req sendCoinReq) {
 				mockSrv.EXPECT().
 					SendCoin(gomock.Any(), username, req.ToUser, req.Amount).
-					Return(ErrMock)
+					Return(nil, ErrMock)
 			},
 			expStatus: http.StatusInternalServerError,
 			expAns:    gin.H{"errors": "internal server error"},
@@ -259,7 +287,7 @@ func TestBuyItem(t *testing.T) {
 			itemName: "mockItem",
 			mockBehavior: func(username string, item string) {
 				mockSrv.EXPECT().
-					BuyItem(gomock.Any(), username, item).
+					BuyItem(gomock.Any(), username, item, "", "").
 					Return(nil)
 			},
 			expStatus: http.StatusOK,
@@ -288,7 +316,7 @@ func TestBuyItem(t *testing.T) {
 			itemName: "mockItem",
 			mockBehavior: func(username string, item string) {
 				mockSrv.EXPECT().
-					BuyItem(gomock.Any(), username, item).
+					BuyItem(gomock.Any(), username, item, "", "").
 					Return(ErrMock)
 			},
 			expStatus: http.StatusInternalServerError,
@@ -324,3 +352,63 @@ func TestBuyItem(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizeClientIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockInterface(ctrl)
+	handler := NewController(mockSrv)
+
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		expIP          string
+	}{
+		{
+			name:       "Spoofed Header Ignored",
+			remoteAddr: "192.0.2.10:4321",
+			expIP:      "192.0.2.10",
+		},
+		{
+			name:           "Trusted Proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.5:4321",
+			expIP:          "203.0.113.7",
+		},
+		{
+			name:           "Untrusted Proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "192.0.2.10:4321",
+			expIP:          "192.0.2.10",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRouter(tc.trustedProxies)
+			require.NoError(t, err)
+			r.POST("/api/auth", handler.Authorize)
+
+			mockSrv.EXPECT().
+				AuthorizeUser(gomock.Any(), "mockuser", "mockpassword", tc.expIP).
+				Return("valid", nil)
+
+			body, err := json.Marshal(authReq{"mockuser", "mockpassword"})
+			require.NoError(t, err)
+			req := httptest.NewRequest("POST", "/api/auth", bytes.NewReader(body))
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestNewRouterInvalidProxy(t *testing.T) {
+	_, err := NewRouter([]string{"not-an-ip"})
+	require.Error(t, err)
+}
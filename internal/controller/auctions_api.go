@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type createAuctionReq struct {
+	Item     string    `json:"item"`
+	Quantity int32     `json:"quantity"`
+	MinBid   int32     `json:"minBid"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+type placeBidReq struct {
+	Amount int32 `json:"amount"`
+}
+
+// CreateAuction starts new auction, admins only.
+func (h *Controller) CreateAuction(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req createAuctionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid auction", err))
+		return
+	}
+
+	a, err := h.srv.CreateAuction(c, username.(string), req.Item, req.Quantity, req.MinBid, req.EndsAt)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, a)
+}
+
+// GetAuctions returns open auctions.
+func (h *Controller) GetAuctions(c *gin.Context) {
+	auctions, err := h.srv.GetAuctions(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, auctions)
+}
+
+// GetAuction returns auction with leading bids.
+func (h *Controller) GetAuction(c *gin.Context) {
+	auctionID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid auction id", err))
+		return
+	}
+
+	a, err := h.srv.GetAuction(c, int32(auctionID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, a)
+}
+
+// PlaceBid places or raises user's bid.
+func (h *Controller) PlaceBid(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	auctionID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid auction id", err))
+		return
+	}
+
+	var req placeBidReq
+	if err = c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	bid, err := h.srv.PlaceBid(c, username.(string), int32(auctionID), req.Amount)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bid)
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+// GetBundles returns bundles on sale.
+func (h *Controller) GetBundles(c *gin.Context) {
+	bundles, err := h.srv.GetBundles(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles)
+}
+
+// BuyBundle buys bundle for user.
+func (h *Controller) BuyBundle(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	name := c.Param("name")
+	if name == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid bundle", nil))
+		return
+	}
+
+	if err := h.srv.BuyBundle(c, username.(string), name); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+// CreateBundle creates new bundle.
+func (h *Controller) CreateBundle(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewBundle
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	b, err := h.srv.CreateBundle(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, b)
+}
+
+// DeactivateBundle stops selling bundle.
+func (h *Controller) DeactivateBundle(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid bundle", nil))
+		return
+	}
+
+	b, err := h.srv.DeactivateBundle(c, name)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, b)
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/myacey/avito-shop/internal/apperror"
@@ -23,6 +25,19 @@ func NewController(srv service.Interface) *Controller {
 	return &Controller{srv, testingStatus}
 }
 
+// NewRouter creates engine which takes client IP from X-Forwarded-For
+// only if request came from one of trustedProxies (IPs or CIDRs),
+// with none every header is ignored and remote address is used.
+func NewRouter(trustedProxies []string) (*gin.Engine, error) {
+	r := gin.New()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	// lets request cancellation reach service
+	r.ContextWithFallback = true
+	return r, nil
+}
+
 func (h *Controller) JSONError(c *gin.Context, err error) {
 	var appErr *apperror.AppError
 	if errors.As(err, &appErr) {
@@ -30,6 +45,15 @@ func (h *Controller) JSONError(c *gin.Context, err error) {
 			log.Printf("Internal error! user message: %s", appErr.Message)
 		}
 		log.Printf("error: %s", fmt.Sprint(appErr.Err))
+		if appErr.RetryAfter > 0 {
+			// header has whole seconds, round up to not retry too early
+			seconds := int64(math.Ceil(appErr.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.FormatInt(seconds, 10))
+		}
+		if appErr.Details != nil {
+			c.JSON(appErr.HTTPCode, gin.H{"errors": appErr.Message, "details": appErr.Details})
+			return
+		}
 		c.JSON(appErr.HTTPCode, gin.H{"errors": appErr.Message})
 		return
 	}
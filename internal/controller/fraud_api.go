@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+// ListFraudCases returns fraud cases by status
+// (?status=open|approved|rejected, open by default).
+func (h *Controller) ListFraudCases(c *gin.Context) {
+	cases, err := h.srv.ListFraudCases(c, c.Query("status"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cases)
+}
+
+// ApproveFraudCase marks case as fine, held transfer is executed.
+func (h *Controller) ApproveFraudCase(c *gin.Context) {
+	h.resolveFraudCase(c, true)
+}
+
+// RejectFraudCase marks case as fraud, held transfer is dropped.
+func (h *Controller) RejectFraudCase(c *gin.Context) {
+	h.resolveFraudCase(c, false)
+}
+
+func (h *Controller) resolveFraudCase(c *gin.Context, approve bool) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	caseID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid case id", err))
+		return
+	}
+
+	fc, err := h.srv.ResolveFraudCase(c, int32(caseID), username.(string), approve)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fc)
+}
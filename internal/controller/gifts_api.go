@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type buyGiftReq struct {
+	ToUser  string `json:"toUser"`
+	Item    string `json:"item"`
+	Message string `json:"message"`
+}
+
+// BuyGift buys an item for another user.
+func (h *Controller) BuyGift(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req buyGiftReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+	if req.ToUser == "" || req.Item == "" {
+		h.JSONError(c, apperror.NewBadReq("toUser and item are required", nil))
+		return
+	}
+
+	err := h.srv.BuyGift(c, username.(string), req.ToUser, req.Item, req.Message)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
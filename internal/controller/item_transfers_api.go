@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type sendItemReq struct {
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant"` // sku of items with variants
+	Quantity int32  `json:"quantity"`
+}
+
+// SendItem gives owned items to another user.
+func (h *Controller) SendItem(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req sendItemReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+	if req.ToUser == "" || req.Item == "" {
+		h.JSONError(c, apperror.NewBadReq("toUser and item are required", nil))
+		return
+	}
+
+	err := h.srv.SendItem(c, username.(string), req.ToUser, req.Item, req.Variant, req.Quantity)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
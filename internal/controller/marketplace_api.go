@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type listItemReq struct {
+	Item     string `json:"item"`
+	Variant  string `json:"variant"` // sku of items with variants
+	Quantity int32  `json:"quantity"`
+	Price    int32  `json:"price"`
+}
+
+// GetListings returns active listings (?item= filters by item).
+func (h *Controller) GetListings(c *gin.Context) {
+	listings, err := h.srv.GetListings(c, c.Query("item"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listings)
+}
+
+// ListItem puts items from user's inventory on sale.
+func (h *Controller) ListItem(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req listItemReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+	if req.Item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid item", nil))
+		return
+	}
+
+	l, err := h.srv.ListItem(c, username.(string), req.Item, req.Variant, req.Quantity, req.Price)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, l)
+}
+
+// BuyListing buys listed items.
+func (h *Controller) BuyListing(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	listingID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid listing id", err))
+		return
+	}
+
+	l, err := h.srv.BuyListing(c, username.(string), int32(listingID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, l)
+}
+
+// CancelListing removes user's listing, items go back to inventory.
+func (h *Controller) CancelListing(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	listingID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid listing id", err))
+		return
+	}
+
+	l, err := h.srv.CancelListing(c, username.(string), int32(listingID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, l)
+}
@@ -7,8 +7,27 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
 )
 
+// apiKeyHeader carries API key of bots and integrations,
+// it's checked instead of Authorization.
+const apiKeyHeader = "X-API-Key"
+
+// apiKeyScopes are routes API keys can call, keyed
+// by method and route, and scope they require.
+var apiKeyScopes = map[string]string{
+	"GET /api/info":                          models.ScopeReadUsers,
+	"POST /api/sendCoin":                     models.ScopeTransfersWrite,
+	"POST /api/admin/users/:username/grants": models.ScopeGrantsWrite,
+}
+
+// adminScopes let API key pass AdminMiddleware by themselves, keys
+// with other scopes pass it only if their owner is admin.
+var adminScopes = map[string]struct{}{
+	models.ScopeGrantsWrite: {},
+}
+
 func (h *Controller) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if c.Request.Method == http.MethodOptions {
@@ -22,6 +41,11 @@ func (h *Controller) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if key := c.GetHeader(apiKeyHeader); key != "" {
+			h.apiKeyAuth(c, key)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 
 		bearerToken := strings.Split(authHeader, " ")
@@ -42,3 +66,59 @@ func (h *Controller) AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// apiKeyAuth lets request in as owner of key if key has
+// scope of route. Routes missing in apiKeyScopes are denied.
+func (h *Controller) apiKeyAuth(c *gin.Context, key string) {
+	scope, ok := apiKeyScopes[c.Request.Method+" "+c.FullPath()]
+	if !ok {
+		h.JSONError(c, apperror.NewForbidden("api key has no access to this request", nil))
+		c.Abort()
+		return
+	}
+
+	usrname, err := h.srv.CheckAPIKey(c, key, scope)
+	if err != nil {
+		h.JSONError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Set("username", usrname)
+	c.Set("apiKey", true)
+	c.Set("apiKeyScope", scope)
+	c.Next()
+}
+
+// AdminMiddleware allows request only for users from admins list.
+// Requests with API key are allowed if scope of route is one of
+// adminScopes, scope itself is checked by AuthMiddleware.
+// Should be used after AuthMiddleware.
+func (h *Controller) AdminMiddleware(admins []string) gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(admins))
+	for _, a := range admins {
+		allowed[a] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := adminScopes[c.GetString("apiKeyScope")]; ok && c.GetBool("apiKey") {
+			c.Next()
+			return
+		}
+
+		username, ok := c.Get("username")
+		if !ok {
+			h.JSONError(c, apperror.NewInternal("no username in token", nil))
+			c.Abort()
+			return
+		}
+
+		if _, ok = allowed[username.(string)]; !ok {
+			h.JSONError(c, apperror.NewForbidden("admin rights required", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockInterface(ctrl)
+	handler := NewController(mockSrv)
+	middleware := handler.AdminMiddleware([]string{"admin"})
+
+	testCases := []struct {
+		name      string
+		username  string
+		scope     string // scope of route for API key requests
+		expStatus int
+		expAns    interface{}
+	}{
+		{
+			name:      "OK",
+			username:  "admin",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "OK API Key Admin Scope",
+			username:  "bot",
+			scope:     models.ScopeGrantsWrite,
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "Err Not Admin",
+			username:  "mockuser",
+			expStatus: http.StatusForbidden,
+			expAns:    gin.H{"errors": "admin rights required"},
+		},
+		{
+			name:      "Err API Key Other Scope",
+			username:  "bot",
+			scope:     models.ScopeReadUsers,
+			expStatus: http.StatusForbidden,
+			expAns:    gin.H{"errors": "admin rights required"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("username", tc.username)
+			if tc.scope != "" {
+				c.Set("apiKey", true)
+				c.Set("apiKeyScope", tc.scope)
+			}
+
+			req, err := http.NewRequest("GET", "/api/admin/limits/mockuser", nil)
+			require.NoError(t, err)
+			c.Request = req
+
+			middleware(c)
+
+			require.Equal(t, tc.expStatus, w.Code)
+			if tc.expAns != nil {
+				crResp, err := json.Marshal(tc.expAns)
+				require.NoError(t, err)
+				require.Equal(t, crResp, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSrv := mocks.NewMockInterface(ctrl)
+	handler := NewController(mockSrv)
+
+	r := gin.New()
+	r.Use(handler.AuthMiddleware())
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"username": c.GetString("username")}) }
+	r.GET("/api/info", ok)
+	r.POST("/api/password", ok)
+	admin := r.Group("/api/admin", handler.AdminMiddleware([]string{"admin"}))
+	admin.POST("/users/:username/grants", ok)
+	admin.GET("/limits/:username", ok)
+
+	testCases := []struct {
+		name         string
+		method, path string
+		mockBehavior func()
+		expStatus    int
+		expAns       interface{}
+	}{
+		{
+			name:   "OK",
+			method: "GET",
+			path:   "/api/info",
+			mockBehavior: func() {
+				mockSrv.EXPECT().
+					CheckAPIKey(gomock.Any(), "shop_key", models.ScopeReadUsers).
+					Return("bot", nil)
+			},
+			expStatus: http.StatusOK,
+			expAns:    gin.H{"username": "bot"},
+		},
+		{
+			name:   "OK Admin Route",
+			method: "POST",
+			path:   "/api/admin/users/mockuser/grants",
+			mockBehavior: func() {
+				mockSrv.EXPECT().
+					CheckAPIKey(gomock.Any(), "shop_key", models.ScopeGrantsWrite).
+					Return("bot", nil)
+			},
+			expStatus: http.StatusOK,
+			expAns:    gin.H{"username": "bot"},
+		},
+		{
+			name:         "Err Route Without Scope",
+			method:       "POST",
+			path:         "/api/password",
+			mockBehavior: func() {},
+			expStatus:    http.StatusForbidden,
+			expAns:       gin.H{"errors": "api key has no access to this request"},
+		},
+		{
+			name:         "Err Admin Route Without Scope",
+			method:       "GET",
+			path:         "/api/admin/limits/mockuser",
+			mockBehavior: func() {},
+			expStatus:    http.StatusForbidden,
+			expAns:       gin.H{"errors": "api key has no access to this request"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			require.NoError(t, err)
+			req.Header.Set(apiKeyHeader, "shop_key")
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expStatus, w.Code)
+			crResp, err := json.Marshal(tc.expAns)
+			require.NoError(t, err)
+			require.Equal(t, crResp, w.Body.Bytes())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartOIDCLogin redirects user to identity provider's login page.
+func (h *Controller) StartOIDCLogin(c *gin.Context) {
+	url, err := h.srv.StartOIDCLogin(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// FinishOIDCLogin is where identity provider sends user back,
+// gives access token like /api/auth.
+func (h *Controller) FinishOIDCLogin(c *gin.Context) {
+	token, err := h.srv.FinishOIDCLogin(c, c.Query("code"), c.Query("state"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type setPickupLocationReq struct {
+	Location string `json:"location"`
+}
+
+type updateOrderStatusReq struct {
+	Status string `json:"status"`
+}
+
+// GetOrders returns user's orders with delivery status.
+func (h *Controller) GetOrders(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	orders, err := h.srv.GetOrders(c, username.(string))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetPickupLocations returns offices where orders can be picked up.
+func (h *Controller) GetPickupLocations(c *gin.Context) {
+	locations, err := h.srv.GetPickupLocations(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
+
+// SetPickupLocation selects office for user's order.
+func (h *Controller) SetPickupLocation(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid order id", err))
+		return
+	}
+
+	var req setPickupLocationReq
+	if err = c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	o, err := h.srv.SetPickupLocation(c, username.(string), int32(orderID), req.Location)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, o)
+}
+
+// CancelOrder cancels user's order and refunds coins.
+func (h *Controller) CancelOrder(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid order id", err))
+		return
+	}
+
+	o, err := h.srv.CancelOrder(c, username.(string), int32(orderID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, o)
+}
+
+// ListOrders returns orders, optionally filtered by status.
+func (h *Controller) ListOrders(c *gin.Context) {
+	orders, err := h.srv.ListOrders(c, c.Query("status"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// UpdateOrderStatus moves order to next status.
+func (h *Controller) UpdateOrderStatus(c *gin.Context) {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid order id", err))
+		return
+	}
+
+	var req updateOrderStatusReq
+	if err = c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	o, err := h.srv.UpdateOrderStatus(c, int32(orderID), req.Status)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, o)
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type changePasswordReq struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+type resetPasswordReq struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+// ChangePassword replaces user's password and gives new access
+// token, other sessions are revoked.
+func (h *Controller) ChangePassword(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req changePasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	token, err := h.srv.ChangePassword(c, username.(string), req.OldPassword, req.NewPassword, c.ClientIP())
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// ResetPassword sets new password by one-time reset token
+// and gives new access token.
+func (h *Controller) ResetPassword(c *gin.Context) {
+	var req resetPasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	token, err := h.srv.ResetPassword(c, req.Token, req.NewPassword)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// CreatePasswordReset issues reset token for user, admins only.
+func (h *Controller) CreatePasswordReset(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	reset, err := h.srv.CreatePasswordReset(c, username.(string), c.Param("username"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reset)
+}
+
+// UnlockUser drops login lockout of user, admins only.
+func (h *Controller) UnlockUser(c *gin.Context) {
+	if err := h.srv.UnlockUser(c, c.Param("username")); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
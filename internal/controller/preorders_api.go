@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+type stockArrivedReq struct {
+	Quantity int32 `json:"quantity"`
+}
+
+// GetPreorderBatches returns batches open for preorders.
+func (h *Controller) GetPreorderBatches(c *gin.Context) {
+	batches, err := h.srv.GetPreorderBatches(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, batches)
+}
+
+// Preorder holds coins for item from batch.
+func (h *Controller) Preorder(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	batchID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid batch id", err))
+		return
+	}
+
+	p, err := h.srv.Preorder(c, username.(string), int32(batchID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// GetPreorders returns user's preorders.
+func (h *Controller) GetPreorders(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	preorders, err := h.srv.GetPreorders(c, username.(string))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preorders)
+}
+
+// CancelPreorder cancels user's preorder and releases held coins.
+func (h *Controller) CancelPreorder(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	preorderID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid preorder id", err))
+		return
+	}
+
+	p, err := h.srv.CancelPreorder(c, username.(string), int32(preorderID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// CreatePreorderBatch opens preorders for upcoming stock, admins only.
+func (h *Controller) CreatePreorderBatch(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewPreorderBatch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid preorder batch", err))
+		return
+	}
+
+	b, err := h.srv.CreatePreorderBatch(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, b)
+}
+
+// MarkPreorderStockArrived allocates arrived stock to preorders.
+func (h *Controller) MarkPreorderStockArrived(c *gin.Context) {
+	batchID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid batch id", err))
+		return
+	}
+
+	var req stockArrivedReq
+	if err = c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	res, err := h.srv.MarkPreorderStockArrived(c, int32(batchID), req.Quantity)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+// GetCatalog returns items with regular and current prices.
+func (h *Controller) GetCatalog(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	items, err := h.srv.GetCatalog(c, username.(string))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// ListPriceSchedules returns price history, optionally filtered by item.
+func (h *Controller) ListPriceSchedules(c *gin.Context) {
+	schedules, err := h.srv.ListPriceSchedules(c, c.Query("item"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// CreatePriceSchedule schedules sale price of item.
+func (h *Controller) CreatePriceSchedule(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewPriceSchedule
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	ps, err := h.srv.CreatePriceSchedule(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ps)
+}
+
+// CancelPriceSchedule cancels sale.
+func (h *Controller) CancelPriceSchedule(c *gin.Context) {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid schedule id", err))
+		return
+	}
+
+	ps, err := h.srv.CancelPriceSchedule(c, int32(scheduleID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ps)
+}
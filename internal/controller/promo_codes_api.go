@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+// ListPromoCodes returns all promo codes.
+func (h *Controller) ListPromoCodes(c *gin.Context) {
+	codes, err := h.srv.ListPromoCodes(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// CreatePromoCode creates new promo code.
+func (h *Controller) CreatePromoCode(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewPromoCode
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	p, err := h.srv.CreatePromoCode(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// DisablePromoCode disables promo code.
+func (h *Controller) DisablePromoCode(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid promo code", nil))
+		return
+	}
+
+	p, err := h.srv.DisablePromoCode(c, code)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+// SetPurchaseLimit caps how many items one user can buy.
+func (h *Controller) SetPurchaseLimit(c *gin.Context) {
+	item := c.Param("item")
+	if item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid item", nil))
+		return
+	}
+
+	var req models.PurchaseLimit
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+	req.Item = item
+
+	limit, err := h.srv.SetPurchaseLimit(c, &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limit)
+}
+
+// DeletePurchaseLimit removes purchase limit of item.
+func (h *Controller) DeletePurchaseLimit(c *gin.Context) {
+	item := c.Param("item")
+	if item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid item", nil))
+		return
+	}
+
+	if err := h.srv.DeletePurchaseLimit(c, item); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
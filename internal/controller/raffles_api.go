@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+type buyTicketsReq struct {
+	Quantity int32 `json:"quantity"`
+}
+
+// GetRaffles returns raffles, latest first.
+func (h *Controller) GetRaffles(c *gin.Context) {
+	raffles, err := h.srv.GetRaffles(c)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, raffles)
+}
+
+// GetRaffle returns raffle with its winners.
+func (h *Controller) GetRaffle(c *gin.Context) {
+	raffleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid raffle id", err))
+		return
+	}
+
+	r, err := h.srv.GetRaffle(c, int32(raffleID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, r)
+}
+
+// GetRaffleTickets returns all tickets of raffle for audit.
+func (h *Controller) GetRaffleTickets(c *gin.Context) {
+	raffleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid raffle id", err))
+		return
+	}
+
+	tickets, err := h.srv.GetRaffleTickets(c, int32(raffleID))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// BuyRaffleTickets buys tickets of raffle for user's coins.
+func (h *Controller) BuyRaffleTickets(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	raffleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid raffle id", err))
+		return
+	}
+
+	var req buyTicketsReq
+	if err = c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	tickets, err := h.srv.BuyRaffleTickets(c, username.(string), int32(raffleID), req.Quantity)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tickets)
+}
+
+// CreateRaffle starts new raffle, admins only.
+func (h *Controller) CreateRaffle(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req models.NewRaffle
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid raffle", err))
+		return
+	}
+
+	r, err := h.srv.CreateRaffle(c, username.(string), &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, r)
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+// ListTransferApprovals returns approvals by status
+// (?status=pending|approved|rejected|expired, pending by default).
+func (h *Controller) ListTransferApprovals(c *gin.Context) {
+	approvals, err := h.srv.ListTransferApprovals(c, c.Query("status"))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, approvals)
+}
+
+// ApproveTransfer completes pending transfer.
+func (h *Controller) ApproveTransfer(c *gin.Context) {
+	h.resolveTransferApproval(c, true)
+}
+
+// RejectTransfer releases held coins back to sender.
+func (h *Controller) RejectTransfer(c *gin.Context) {
+	h.resolveTransferApproval(c, false)
+}
+
+func (h *Controller) resolveTransferApproval(c *gin.Context, approve bool) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	approvalID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid approval id", err))
+		return
+	}
+
+	a, err := h.srv.ResolveTransferApproval(c, int32(approvalID), username.(string), approve)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, a)
+}
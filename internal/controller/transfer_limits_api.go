@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+// GetTransferLimits returns effective transfer limits of user.
+func (h *Controller) GetTransferLimits(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid username", nil))
+		return
+	}
+
+	limits, err := h.srv.GetTransferLimits(c, username)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+// SetTransferLimits overrides transfer limits of user.
+// Omitted fields fall back to default limits.
+func (h *Controller) SetTransferLimits(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid username", nil))
+		return
+	}
+
+	var req models.TransferLimitOverride
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	limits, err := h.srv.SetTransferLimitOverride(c, username, &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, limits)
+}
+
+// DeleteTransferLimits resets transfer limits of user to defaults.
+func (h *Controller) DeleteTransferLimits(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid username", nil))
+		return
+	}
+
+	if err := h.srv.DeleteTransferLimitOverride(c, username); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+type setStockReq struct {
+	Stock int32 `json:"stock"`
+}
+
+// CreateVariant adds variant to item.
+func (h *Controller) CreateVariant(c *gin.Context) {
+	item := c.Param("item")
+	if item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid item", nil))
+		return
+	}
+
+	var req models.NewVariant
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	v, err := h.srv.CreateVariant(c, item, &req)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, v)
+}
+
+// SetVariantStock sets stock of variant.
+func (h *Controller) SetVariantStock(c *gin.Context) {
+	sku := c.Param("sku")
+	if sku == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid sku", nil))
+		return
+	}
+
+	var req setStockReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	v, err := h.srv.SetVariantStock(c, sku, req.Stock)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v)
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+type addToWishlistReq struct {
+	Item string `json:"item"`
+}
+
+// GetWishlist returns items saved by user.
+func (h *Controller) GetWishlist(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	wishlist, err := h.srv.GetWishlist(c, username.(string))
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wishlist)
+}
+
+// AddToWishlist saves item to user's wishlist.
+func (h *Controller) AddToWishlist(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	var req addToWishlistReq
+	if err := c.ShouldBindJSON(&req); err != nil || req.Item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid request", err))
+		return
+	}
+
+	item, err := h.srv.AddToWishlist(c, username.(string), req.Item)
+	if err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// RemoveFromWishlist removes item from user's wishlist.
+func (h *Controller) RemoveFromWishlist(c *gin.Context) {
+	username, ok := c.Get("username")
+	if !ok {
+		h.JSONError(c, apperror.NewInternal("no username in token", nil))
+		return
+	}
+
+	item := c.Param("item")
+	if item == "" {
+		h.JSONError(c, apperror.NewBadReq("invalid item", nil))
+		return
+	}
+
+	if err := h.srv.RemoveFromWishlist(c, username.(string), item); err != nil {
+		h.JSONError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
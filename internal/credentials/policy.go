@@ -0,0 +1,218 @@
+package credentials
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// breached is a short list of the most common leaked passwords,
+// bigger lists can be loaded with Config.BreachedFile.
+//
+//go:embed breached.txt
+var breached string
+
+const (
+	FieldUsername = "username"
+	FieldPassword = "password"
+)
+
+type Config struct {
+	UsernameMinLength int
+	UsernameMaxLength int
+	// UsernamePattern is a regexp whole username must match (anchors are
+	// added), empty allows any charset.
+	UsernamePattern   string
+	ReservedUsernames []string
+
+	PasswordMinLength int
+	// PasswordMinClasses is how many of lowercase, uppercase, digits
+	// and symbols password must contain.
+	PasswordMinClasses int
+	// BreachedFile has one leaked password per line, checked in addition
+	// to the embedded list.
+	BreachedFile string
+}
+
+type Policy struct {
+	cfg      Config
+	pattern  *regexp.Regexp
+	reserved map[string]struct{}
+	breached map[string]struct{}
+}
+
+func NewPolicy(cfg Config) (*Policy, error) {
+	p := &Policy{
+		cfg:      cfg,
+		reserved: make(map[string]struct{}, len(cfg.ReservedUsernames)),
+		breached: make(map[string]struct{}),
+	}
+
+	if cfg.UsernamePattern != "" {
+		// pattern is anchored, so partial matches don't pass
+		pattern, err := regexp.Compile(`^(?:` + cfg.UsernamePattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid username pattern: %w", err)
+		}
+		p.pattern = pattern
+	}
+	for _, name := range cfg.ReservedUsernames {
+		p.reserved[strings.ToLower(strings.TrimSpace(name))] = struct{}{}
+	}
+
+	if err := p.loadBreached(strings.NewReader(breached)); err != nil {
+		return nil, err
+	}
+	if cfg.BreachedFile != "" {
+		f, err := os.Open(cfg.BreachedFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breached passwords: %w", err)
+		}
+		defer f.Close()
+
+		if err = p.loadBreached(f); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Policy) loadBreached(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			p.breached[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read breached passwords: %w", err)
+	}
+	return nil
+}
+
+// FieldErrors maps field to its violated rules.
+type FieldErrors map[string][]string
+
+func (e FieldErrors) add(field, rule string) {
+	e[field] = append(e[field], rule)
+}
+
+// Required checks credentials are present, it's enough for login.
+// Returns nil if both are set.
+func Required(username, password string) FieldErrors {
+	errs := FieldErrors{}
+	if username == "" {
+		errs.add(FieldUsername, "is required")
+	}
+	if password == "" {
+		errs.add(FieldPassword, "is required")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// CheckUsername returns violated rules of new username.
+func (p *Policy) CheckUsername(username string) []string {
+	if username == "" {
+		return []string{"is required"}
+	}
+
+	var rules []string
+	length := len([]rune(username))
+	if p.cfg.UsernameMinLength > 0 && length < p.cfg.UsernameMinLength {
+		rules = append(rules, fmt.Sprintf("must be at least %d characters", p.cfg.UsernameMinLength))
+	}
+	if p.cfg.UsernameMaxLength > 0 && length > p.cfg.UsernameMaxLength {
+		rules = append(rules, fmt.Sprintf("must be at most %d characters", p.cfg.UsernameMaxLength))
+	}
+	if p.pattern != nil && !p.pattern.MatchString(username) {
+		rules = append(rules, "contains forbidden characters")
+	}
+	if _, ok := p.reserved[strings.ToLower(username)]; ok {
+		rules = append(rules, "is reserved")
+	}
+
+	return rules
+}
+
+// FitUsername cuts name, so name with suffix fits max username length.
+func (p *Policy) FitUsername(name, suffix string) string {
+	keep := p.cfg.UsernameMaxLength - len([]rune(suffix))
+	if p.cfg.UsernameMaxLength > 0 && len([]rune(name)) > keep {
+		name = string([]rune(name)[:max(keep, 0)])
+	}
+	return name + suffix
+}
+
+// CheckPassword returns violated rules of new password of user.
+func (p *Policy) CheckPassword(username, password string) []string {
+	if password == "" {
+		return []string{"is required"}
+	}
+
+	var rules []string
+	if p.cfg.PasswordMinLength > 0 && len([]rune(password)) < p.cfg.PasswordMinLength {
+		rules = append(rules, fmt.Sprintf("must be at least %d characters", p.cfg.PasswordMinLength))
+	}
+	if classes := charClasses(password); classes < p.cfg.PasswordMinClasses {
+		rules = append(rules, fmt.Sprintf("must contain at least %d of lowercase, uppercase, digits and symbols", p.cfg.PasswordMinClasses))
+	}
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		rules = append(rules, "must not contain username")
+	}
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		rules = append(rules, "is found in breached passwords")
+	}
+
+	return rules
+}
+
+// Check validates credentials of new user.
+// Returns nil if they are fine.
+func (p *Policy) Check(username, password string) FieldErrors {
+	errs := FieldErrors{}
+	if rules := p.CheckUsername(username); len(rules) > 0 {
+		errs[FieldUsername] = rules
+	}
+	if rules := p.CheckPassword(username, password); len(rules) > 0 {
+		errs[FieldPassword] = rules
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func charClasses(s string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	n := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
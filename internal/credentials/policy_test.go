@@ -0,0 +1,120 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var mockConfig = Config{
+	UsernameMinLength:  3,
+	UsernameMaxLength:  10,
+	UsernamePattern:    `^[a-zA-Z0-9_.-]+$`,
+	ReservedUsernames:  []string{"root", "System"},
+	PasswordMinLength:  8,
+	PasswordMinClasses: 2,
+}
+
+func TestCheck(t *testing.T) {
+	p, err := NewPolicy(mockConfig)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		username string
+		password string
+		expErrs  FieldErrors
+	}{
+		{
+			name:     "OK",
+			username: "alice",
+			password: "correct-Horse",
+		},
+		{
+			name:    "Empty",
+			expErrs: FieldErrors{FieldUsername: {"is required"}, FieldPassword: {"is required"}},
+		},
+		{
+			name:     "Bad Username",
+			username: "al ice!",
+			password: "correct-Horse",
+			expErrs:  FieldErrors{FieldUsername: {"contains forbidden characters"}},
+		},
+		{
+			name:     "Username Length",
+			username: "al",
+			password: "correct-Horse",
+			expErrs:  FieldErrors{FieldUsername: {"must be at least 3 characters"}},
+		},
+		{
+			name:     "Reserved Username",
+			username: "system",
+			password: "correct-Horse",
+			expErrs:  FieldErrors{FieldUsername: {"is reserved"}},
+		},
+		{
+			name:     "Weak Password",
+			username: "alice",
+			password: "short",
+			expErrs: FieldErrors{FieldPassword: {
+				"must be at least 8 characters",
+				"must contain at least 2 of lowercase, uppercase, digits and symbols",
+			}},
+		},
+		{
+			name:     "Password Contains Username",
+			username: "alice",
+			password: "Alice-2025",
+			expErrs:  FieldErrors{FieldPassword: {"must not contain username"}},
+		},
+		{
+			name:     "Breached Password",
+			username: "alice",
+			password: "Password123",
+			expErrs:  FieldErrors{FieldPassword: {"is found in breached passwords"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expErrs, p.Check(tc.username, tc.password))
+		})
+	}
+}
+
+func TestUnanchoredPattern(t *testing.T) {
+	cfg := mockConfig
+	cfg.UsernamePattern = `[a-z]+`
+	p, err := NewPolicy(cfg)
+	require.NoError(t, err)
+
+	require.Nil(t, p.CheckUsername("alice"))
+	require.Equal(t, []string{"contains forbidden characters"}, p.CheckUsername("alice!"))
+}
+
+func TestFitUsername(t *testing.T) {
+	p, err := NewPolicy(mockConfig)
+	require.NoError(t, err)
+
+	require.Equal(t, "alice2", p.FitUsername("alice", "2"))
+	require.Equal(t, "alexandr12", p.FitUsername("alexandra.smith", "12"))
+}
+
+func TestBreachedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Tr0ub4dor&3\n\n"), 0o600))
+
+	cfg := mockConfig
+	cfg.BreachedFile = path
+	p, err := NewPolicy(cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"is found in breached passwords"}, p.CheckPassword("alice", "tr0ub4dor&3"))
+}
+
+func TestRequired(t *testing.T) {
+	require.Nil(t, Required("alice", "x"))
+	require.Equal(t, FieldErrors{FieldPassword: {"is required"}}, Required("alice", ""))
+}
@@ -0,0 +1,106 @@
+package fraud
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+type Action int
+
+const (
+	ActionNone Action = iota
+	// ActionFlag lets transfer through, but creates a case for review.
+	ActionFlag
+	// ActionHold stops transfer until admin approves it.
+	ActionHold
+)
+
+// ParseAction converts config value to Action.
+func ParseAction(s string) Action {
+	switch strings.ToLower(s) {
+	case "hold":
+		return ActionHold
+	case "flag":
+		return ActionFlag
+	default:
+		return ActionNone
+	}
+}
+
+func (a Action) String() string {
+	switch a {
+	case ActionHold:
+		return "held"
+	case ActionFlag:
+		return "flagged"
+	default:
+		return "none"
+	}
+}
+
+// Transfer is a transfer to analyse, it isn't created yet.
+type Transfer struct {
+	From   string
+	To     string
+	Amount int32
+	At     time.Time
+}
+
+// Finding is a single triggered rule.
+type Finding struct {
+	Rule   string
+	Action Action
+	Reason string
+}
+
+type Rule interface {
+	Name() string
+	// Check returns nil finding if transfer looks fine.
+	Check(c context.Context, t Transfer) (*Finding, error)
+}
+
+// Verdict is a result of all rules,
+// Action is the strongest action of findings.
+type Verdict struct {
+	Action   Action
+	Findings []*Finding
+}
+
+// Reasons joins findings into a single text for admins.
+func (v *Verdict) Reasons() string {
+	reasons := make([]string, len(v.Findings))
+	for i, f := range v.Findings {
+		reasons[i] = f.Rule + ": " + f.Reason
+	}
+	return strings.Join(reasons, "; ")
+}
+
+type Detector struct {
+	rules []Rule
+}
+
+func NewDetector(rules ...Rule) *Detector {
+	return &Detector{rules}
+}
+
+// Analyze runs every rule against the transfer.
+func (d *Detector) Analyze(c context.Context, t Transfer) (*Verdict, error) {
+	v := &Verdict{Action: ActionNone}
+	for _, r := range d.rules {
+		f, err := r.Check(c, t)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil || f.Action == ActionNone {
+			continue
+		}
+
+		v.Findings = append(v.Findings, f)
+		if f.Action > v.Action {
+			v.Action = f.Action
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,120 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+// CycleRule finds coins coming back to sender through
+// a chain of recent transfers: from -> to -> ... -> from.
+type CycleRule struct {
+	Transfers repository.TransferRepository
+	Window    time.Duration
+	MaxDepth  int // max transfers in a cycle, including the checked one
+	Action    Action
+}
+
+func (r *CycleRule) Name() string { return "cycle" }
+
+func (r *CycleRule) Check(c context.Context, t Transfer) (*Finding, error) {
+	since := t.At.Add(-r.Window)
+
+	// bfs over recipients, starting from receiver
+	visited := map[string]struct{}{t.To: {}}
+	level := []string{t.To}
+	for depth := 2; depth <= r.MaxDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, u := range level {
+			recipients, err := r.Transfers.GetRecipientsSince(c, u, since)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, rcp := range recipients {
+				if rcp == t.From {
+					return &Finding{
+						Rule:   r.Name(),
+						Action: r.Action,
+						Reason: fmt.Sprintf("coins return to %s in %d transfers", t.From, depth),
+					}, nil
+				}
+				if _, ok := visited[rcp]; ok {
+					continue
+				}
+				visited[rcp] = struct{}{}
+				next = append(next, rcp)
+			}
+		}
+		level = next
+	}
+
+	return nil, nil
+}
+
+// FanInRule finds receivers collecting coins from many
+// freshly registered accounts.
+type FanInRule struct {
+	Users      repository.UserRepository
+	Transfers  repository.TransferRepository
+	Window     time.Duration
+	AccountAge time.Duration // accounts younger than this are new
+	MinSenders int32
+	Action     Action
+}
+
+func (r *FanInRule) Name() string { return "fan-in" }
+
+func (r *FanInRule) Check(c context.Context, t Transfer) (*Finding, error) {
+	sender, err := r.Users.GetUser(c, t.From)
+	if err != nil {
+		return nil, err
+	}
+
+	registeredAfter := t.At.Add(-r.AccountAge)
+	if sender.CreatedAt.Before(registeredAfter) {
+		return nil, nil
+	}
+
+	others, err := r.Transfers.CountNewSendersSince(c, t.To, t.From, t.At.Add(-r.Window), registeredAfter)
+	if err != nil {
+		return nil, err
+	}
+	if others+1 < r.MinSenders {
+		return nil, nil
+	}
+
+	return &Finding{
+		Rule:   r.Name(),
+		Action: r.Action,
+		Reason: fmt.Sprintf("%s received coins from %d new accounts", t.To, others+1),
+	}, nil
+}
+
+// VelocityRule finds senders making too many transfers in a short window.
+type VelocityRule struct {
+	Transfers repository.TransferRepository
+	Window    time.Duration
+	MaxCount  int32
+	Action    Action
+}
+
+func (r *VelocityRule) Name() string { return "velocity" }
+
+func (r *VelocityRule) Check(c context.Context, t Transfer) (*Finding, error) {
+	sent, err := r.Transfers.CountSentSince(c, t.From, t.At.Add(-r.Window))
+	if err != nil {
+		return nil, err
+	}
+	if sent+1 <= r.MaxCount {
+		return nil, nil
+	}
+
+	return &Finding{
+		Rule:   r.Name(),
+		Action: r.Action,
+		Reason: fmt.Sprintf("%s made %d transfers in %s", t.From, sent+1, r.Window),
+	}, nil
+}
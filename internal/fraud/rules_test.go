@@ -0,0 +1,126 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockNow      = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	mockTransfer = Transfer{From: "alice", To: "bob", Amount: 100, At: mockNow}
+)
+
+func TestCycleRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	rule := &CycleRule{Transfers: transferRepo, Window: time.Hour, MaxDepth: 3, Action: ActionHold}
+	since := mockNow.Add(-time.Hour)
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expFinding   *Finding
+	}{
+		{
+			name: "Cycle Of Three",
+			mockBehavior: func() {
+				transferRepo.EXPECT().
+					GetRecipientsSince(gomock.Any(), "bob", since).
+					Return([]string{"carol"}, nil)
+				transferRepo.EXPECT().
+					GetRecipientsSince(gomock.Any(), "carol", since).
+					Return([]string{"alice"}, nil)
+			},
+			expFinding: &Finding{Rule: "cycle", Action: ActionHold, Reason: "coins return to alice in 3 transfers"},
+		},
+		{
+			name: "No Cycle Within Depth",
+			mockBehavior: func() {
+				transferRepo.EXPECT().
+					GetRecipientsSince(gomock.Any(), "bob", since).
+					Return([]string{"carol"}, nil)
+				transferRepo.EXPECT().
+					GetRecipientsSince(gomock.Any(), "carol", since).
+					Return([]string{"dave", "bob"}, nil)
+			},
+			expFinding: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			f, err := rule.Check(context.Background(), mockTransfer)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expFinding, f)
+		})
+	}
+}
+
+func TestFanInRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	rule := &FanInRule{
+		Users:      userRepo,
+		Transfers:  transferRepo,
+		Window:     time.Hour,
+		AccountAge: 24 * time.Hour,
+		MinSenders: 3,
+		Action:     ActionFlag,
+	}
+
+	// old sender is never suspicious
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(&db.User{Username: "alice", CreatedAt: mockNow.Add(-48 * time.Hour)}, nil)
+	f, err := rule.Check(context.Background(), mockTransfer)
+	require.NoError(t, err)
+	require.Nil(t, f)
+
+	// new sender joins two other new senders
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(&db.User{Username: "alice", CreatedAt: mockNow.Add(-time.Hour)}, nil)
+	transferRepo.EXPECT().
+		CountNewSendersSince(gomock.Any(), "bob", "alice", mockNow.Add(-time.Hour), mockNow.Add(-24*time.Hour)).
+		Return(int32(2), nil)
+	f, err = rule.Check(context.Background(), mockTransfer)
+	require.NoError(t, err)
+	require.Equal(t, &Finding{Rule: "fan-in", Action: ActionFlag, Reason: "bob received coins from 3 new accounts"}, f)
+}
+
+type stubRule struct {
+	finding *Finding
+}
+
+func (r *stubRule) Name() string { return "stub" }
+
+func (r *stubRule) Check(_ context.Context, _ Transfer) (*Finding, error) {
+	return r.finding, nil
+}
+
+func TestDetectorAnalyze(t *testing.T) {
+	flag := &Finding{Rule: "a", Action: ActionFlag, Reason: "x"}
+	hold := &Finding{Rule: "b", Action: ActionHold, Reason: "y"}
+
+	d := NewDetector(&stubRule{flag}, &stubRule{nil}, &stubRule{hold})
+	v, err := d.Analyze(context.Background(), mockTransfer)
+
+	require.NoError(t, err)
+	require.Equal(t, ActionHold, v.Action)
+	require.Equal(t, []*Finding{flag, hold}, v.Findings)
+	require.Equal(t, "a: x; b: y", v.Reasons())
+}
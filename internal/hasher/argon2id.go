@@ -0,0 +1,122 @@
+package hasher
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Argon2Params are argon2id cost parameters, zero fields
+// are replaced with defaults.
+type Argon2Params struct {
+	Memory  uint32 // KiB
+	Time    uint32
+	Threads uint8
+}
+
+// defaults are OWASP minimum recommendation
+var defaultArgon2Params = Argon2Params{Memory: 19 * 1024, Time: 2, Threads: 1}
+
+const (
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+func (p Argon2Params) withDefaults() Argon2Params {
+	if p.Memory == 0 {
+		p.Memory = defaultArgon2Params.Memory
+	}
+	if p.Time == 0 {
+		p.Time = defaultArgon2Params.Time
+	}
+	if p.Threads == 0 {
+		p.Threads = defaultArgon2Params.Threads
+	}
+	return p
+}
+
+// Argon2idHasher hashes with argon2id in PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+type Argon2idHasher struct {
+	Params  Argon2Params
+	Limiter *Limiter
+}
+
+func isArgon2id(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+func (a *Argon2idHasher) Generate(ctx context.Context, password string) (string, error) {
+	release, err := a.Limiter.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	p := a.Params.withDefaults()
+	salt := make([]byte, argon2SaltLen)
+	if _, err = rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, argon2KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2idHasher) Compare(ctx context.Context, hash, password string) error {
+	return compare(ctx, a.Limiter, hash, password)
+}
+
+func (a *Argon2idHasher) NeedsRehash(hash string) bool {
+	p, _, key, err := parseArgon2id(hash)
+	return err != nil || p != a.Params.withDefaults() || len(key) != argon2KeyLen
+}
+
+func parseArgon2id(hash string) (p Argon2Params, salt, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrUnknownFormat
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, ErrUnknownFormat
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, ErrUnknownFormat
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, ErrUnknownFormat
+	}
+
+	return p, salt, key, nil
+}
+
+func compareArgon2id(hash, password string) error {
+	p, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrDontCompare
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package hasher
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher hashes with bcrypt, zero Cost means bcrypt.DefaultCost.
+type BcryptHasher struct {
+	Cost    int
+	Limiter *Limiter
+}
+
+func (b *BcryptHasher) cost() int {
+	if b.Cost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return b.Cost
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+func (b *BcryptHasher) Generate(ctx context.Context, password string) (string, error) {
+	release, err := b.Limiter.Acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), b.cost())
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrToLong
+		}
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func (b *BcryptHasher) Compare(ctx context.Context, hash, password string) error {
+	return compare(ctx, b.Limiter, hash, password)
+}
+
+func (b *BcryptHasher) NeedsRehash(hash string) bool {
+	if !isBcrypt(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost()
+}
+
+func compareBcrypt(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrDontCompare
+	}
+	return err
+}
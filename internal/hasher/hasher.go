@@ -1,63 +1,73 @@
 package hasher
 
 import (
+	"context"
 	"errors"
-
-	"golang.org/x/crypto/bcrypt"
+	"fmt"
+	"strings"
 )
 
+// Hasher generates hashes with its own algorithm, but compares hashes
+// of every supported algorithm, so users can be migrated on login.
 type Hasher interface {
-	Generate(password string) (string, error)
-	Compare(hash, password string) error
+	Generate(ctx context.Context, password string) (string, error)
+	Compare(ctx context.Context, hash, password string) error
+	// NeedsRehash reports whether hash was made with another
+	// algorithm or outdated parameters.
+	NeedsRehash(hash string) bool
 }
 
-const (
-	// maxConcurrentBcryptOps represents a max bcrypt operation count.
-	maxConcurrentBcryptOps = 20
-)
-
 var (
-	bcryptSemaphore chan struct{}
-
-	ErrToLong      = errors.New("providen password too long")
-	ErrDontCompare = errors.New("passwords dont match")
+	ErrToLong        = errors.New("providen password too long")
+	ErrDontCompare   = errors.New("passwords dont match")
+	ErrUnknownFormat = errors.New("unknown hash format")
 )
 
-type BcryptHasher struct{}
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
 
-func init() {
-	bcryptSemaphore = make(chan struct{}, maxConcurrentBcryptOps)
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+	Limiter    *Limiter // nil - unlimited
 }
 
-func (b *BcryptHasher) Generate(password string) (string, error) {
-	bcryptSemaphore <- struct{}{}
-	defer func() { <-bcryptSemaphore }()
-
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
-	if err != nil {
-		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
-			return "", ErrToLong
-		}
-		return "", err
+// New returns hasher generating hashes with configured algorithm.
+func New(cfg Config) (Hasher, error) {
+	switch strings.ToLower(cfg.Algorithm) {
+	case AlgorithmBcrypt:
+		return &BcryptHasher{Cost: cfg.BcryptCost, Limiter: cfg.Limiter}, nil
+	case AlgorithmArgon2id, "":
+		return &Argon2idHasher{Params: cfg.Argon2, Limiter: cfg.Limiter}, nil
+	default:
+		return nil, fmt.Errorf("unknown password hashing algorithm %q", cfg.Algorithm)
 	}
-	return string(bytes), err
-
-	// return password, nil
 }
 
-func (b *BcryptHasher) Compare(hash, password string) error {
-	bcryptSemaphore <- struct{}{}
-	defer func() { <-bcryptSemaphore }()
-
-	res := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	if errors.Is(res, bcrypt.ErrMismatchedHashAndPassword) {
+// compare checks password against hash of any supported algorithm.
+// Only hashing takes slot of limiter, its latency adapts the limit.
+func compare(ctx context.Context, l *Limiter, hash, password string) error {
+	var cmp func(hash, password string) error
+	switch {
+	// users without password, e.g. of identity provider
+	case hash == "":
 		return ErrDontCompare
+	case isArgon2id(hash):
+		cmp = compareArgon2id
+	case isBcrypt(hash):
+		cmp = compareBcrypt
+	default:
+		return ErrUnknownFormat
 	}
 
-	return res
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
 
-	// if hash == password {
-	// 	return nil
-	// }
-	// return ErrDontCompare
+	return cmp(hash, password)
 }
@@ -0,0 +1,157 @@
+package hasher
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheap parameters to keep tests fast
+var testArgon2Params = Argon2Params{Memory: 64, Time: 1, Threads: 1}
+
+func TestArgon2idHasher(t *testing.T) {
+	h := &Argon2idHasher{Params: testArgon2Params}
+
+	hash, err := h.Generate(context.Background(), "password")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	require.NoError(t, h.Compare(context.Background(), hash, "password"))
+	require.ErrorIs(t, h.Compare(context.Background(), hash, "wrong"), ErrDontCompare)
+	require.False(t, h.NeedsRehash(hash))
+
+	// stronger parameters make old hash outdated
+	stronger := &Argon2idHasher{Params: Argon2Params{Memory: 128, Time: 1, Threads: 1}}
+	require.True(t, stronger.NeedsRehash(hash))
+	require.NoError(t, stronger.Compare(context.Background(), hash, "password"))
+}
+
+func TestBcryptHasher(t *testing.T) {
+	h := &BcryptHasher{Cost: bcrypt.MinCost}
+
+	hash, err := h.Generate(context.Background(), "password")
+	require.NoError(t, err)
+
+	require.NoError(t, h.Compare(context.Background(), hash, "password"))
+	require.ErrorIs(t, h.Compare(context.Background(), hash, "wrong"), ErrDontCompare)
+	require.False(t, h.NeedsRehash(hash))
+	require.True(t, (&BcryptHasher{Cost: bcrypt.MinCost + 1}).NeedsRehash(hash))
+
+	_, err = h.Generate(context.Background(), strings.Repeat("a", 73))
+	require.ErrorIs(t, err, ErrToLong)
+}
+
+func TestMigration(t *testing.T) {
+	bcryptHash, err := (&BcryptHasher{Cost: bcrypt.MinCost}).Generate(context.Background(), "password")
+	require.NoError(t, err)
+
+	// argon2id hasher verifies legacy bcrypt hashes and asks to rehash them
+	h := &Argon2idHasher{Params: testArgon2Params}
+	require.NoError(t, h.Compare(context.Background(), bcryptHash, "password"))
+	require.True(t, h.NeedsRehash(bcryptHash))
+
+	// users of identity provider have no password
+	require.ErrorIs(t, h.Compare(context.Background(), "", ""), ErrDontCompare)
+
+	require.ErrorIs(t, h.Compare(context.Background(), "plaintext", "plaintext"), ErrUnknownFormat)
+	require.ErrorIs(t, h.Compare(context.Background(), "$argon2id$v=19$broken", "password"), ErrUnknownFormat)
+}
+
+func TestNew(t *testing.T) {
+	h, err := New(Config{Algorithm: "bcrypt", BcryptCost: 12})
+	require.NoError(t, err)
+	require.Equal(t, &BcryptHasher{Cost: 12}, h)
+
+	h, err = New(Config{Algorithm: "argon2id"})
+	require.NoError(t, err)
+	require.Equal(t, &Argon2idHasher{}, h)
+
+	_, err = New(Config{Algorithm: "md5"})
+	require.Error(t, err)
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(1, 10*time.Millisecond)
+
+	release, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// slot is taken - waiting fails after deadline
+	_, err = l.Acquire(context.Background())
+	require.ErrorIs(t, err, ErrBusy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.Acquire(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Equal(t, LimiterStats{Limit: 1, MaxLimit: 1, InFlight: 1, Waiting: 0, Rejected: 2}, l.Stats())
+
+	release()
+	release, err = l.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	require.Equal(t, 0, l.Stats().InFlight)
+
+	// hashers without limiter aren't limited
+	var nilLimiter *Limiter
+	release, err = nilLimiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
+
+func TestLimiterWaits(t *testing.T) {
+	l := NewLimiter(1, time.Second)
+	h := &BcryptHasher{Cost: bcrypt.MinCost, Limiter: l}
+
+	release, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := h.Generate(context.Background(), "password")
+		done <- err
+	}()
+
+	require.Eventually(t, func() bool { return l.Stats().Waiting == 1 }, time.Second, time.Millisecond)
+	release()
+	require.NoError(t, <-done)
+}
+
+func TestLimiterAdapts(t *testing.T) {
+	l := NewLimiter(4, 10*time.Millisecond)
+	take := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := l.Acquire(context.Background())
+			require.NoError(t, err)
+		}
+	}
+
+	// first operation sets baseline
+	take(1)
+	l.finish(time.Now(), time.Millisecond)
+	require.Equal(t, 4, l.Stats().Limit)
+
+	// much slower operation cuts limit
+	take(2)
+	l.finish(time.Now(), 5*time.Millisecond)
+	require.Equal(t, 3, l.Stats().Limit)
+
+	// operation started before cut doesn't cut again
+	l.finish(time.Now().Add(-time.Second), 5*time.Millisecond)
+	require.Equal(t, 3, l.Stats().Limit)
+
+	// fast operation of saturated limiter grows limit
+	take(3)
+	l.finish(time.Now(), time.Millisecond)
+	require.Equal(t, 4, l.Stats().Limit)
+
+	// not saturated - limit stays
+	l.finish(time.Now(), time.Millisecond)
+	l.finish(time.Now(), time.Millisecond)
+	require.Equal(t, LimiterStats{Limit: 4, MaxLimit: 4}, l.Stats())
+}
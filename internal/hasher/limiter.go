@@ -0,0 +1,179 @@
+package hasher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxConcurrent = 20
+	defaultMaxWait       = time.Second
+
+	// latencyTolerance is how many times operation may be slower
+	// than baseline before limit is decreased.
+	latencyTolerance = 2
+	// baselineDecay makes baseline follow slower operations by 1/baselineDecay
+	// of difference, so it recovers if hashing got slower for good.
+	baselineDecay = 100
+)
+
+var ErrBusy = errors.New("too many concurrent hashing operations")
+
+// Limiter bounds concurrent hashing operations and adapts the bound
+// to latency. Hashing is CPU bound, so operation much slower than
+// baseline means too many of them run at once: limit is cut by a quarter.
+// Saturated limiter with fast operations grows limit by one up to
+// maximum. Caller waits for free slot at most MaxWait or until its
+// context is done.
+type Limiter struct {
+	maxLimit int
+	maxWait  time.Duration
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+	waiters  []chan struct{} // FIFO
+	baseline time.Duration   // latency of unloaded operation
+	lastCut  time.Time
+	rejected int64
+}
+
+// LimiterStats is a snapshot of limiter queue.
+type LimiterStats struct {
+	Limit    int   `json:"limit"` // current, adapted
+	MaxLimit int   `json:"maxLimit"`
+	InFlight int   `json:"inFlight"`
+	Waiting  int   `json:"waiting"`
+	Rejected int64 `json:"rejected"` // since start
+}
+
+// NewLimiter returns limiter of at most maxConcurrent operations,
+// zero values are replaced with defaults.
+func NewLimiter(maxConcurrent int, maxWait time.Duration) *Limiter {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrent
+	}
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
+	return &Limiter{
+		maxLimit: maxConcurrent,
+		maxWait:  maxWait,
+		limit:    maxConcurrent,
+	}
+}
+
+// Acquire takes slot, release must be called after operation.
+// Returns ErrBusy if slot wasn't freed in time, or context error.
+// Nil limiter doesn't limit anything.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	// fast path without timer
+	if l.inFlight < l.limit && len(l.waiters) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return l.releaser(), nil
+	}
+	ready := make(chan struct{})
+	l.waiters = append(l.waiters, ready)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+
+	select {
+	case <-ready:
+		return l.releaser(), nil
+	case <-timer.C:
+		err = ErrBusy
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rejected++
+	select {
+	case <-ready:
+		// slot was given meanwhile, pass it on
+		l.inFlight--
+		l.wake()
+	default:
+		l.removeWaiter(ready)
+	}
+	return nil, err
+}
+
+func (l *Limiter) releaser() func() {
+	start := time.Now()
+	return func() { l.finish(start, time.Since(start)) }
+}
+
+// finish frees slot of operation started at start which took d.
+func (l *Limiter) finish(start time.Time, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	saturated := l.inFlight >= l.limit
+	l.inFlight--
+	l.adapt(start, d, saturated)
+	l.wake()
+}
+
+// adapt updates limit by latency of operation. Operations started
+// before last cut ran under old limit, they don't cut it again.
+func (l *Limiter) adapt(start time.Time, d time.Duration, saturated bool) {
+	if l.baseline == 0 || d < l.baseline {
+		l.baseline = d
+	} else {
+		l.baseline += (d - l.baseline) / baselineDecay
+	}
+
+	switch {
+	case d > latencyTolerance*l.baseline:
+		if start.After(l.lastCut) {
+			l.limit = max(1, l.limit*3/4)
+			l.lastCut = time.Now()
+		}
+	case saturated && l.limit < l.maxLimit:
+		l.limit++
+	}
+}
+
+// wake gives free slots to waiters in order.
+func (l *Limiter) wake() {
+	for l.inFlight < l.limit && len(l.waiters) > 0 {
+		l.inFlight++
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+	}
+}
+
+func (l *Limiter) removeWaiter(ready chan struct{}) {
+	for i, w := range l.waiters {
+		if w == ready {
+			l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *Limiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return LimiterStats{
+		Limit:    l.limit,
+		MaxLimit: l.maxLimit,
+		InFlight: l.inFlight,
+		Waiting:  len(l.waiters),
+		Rejected: l.rejected,
+	}
+}
@@ -0,0 +1,332 @@
+// Package ldaptest has in-process LDAP directory for tests.
+package ldaptest
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+type entry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// get returns first value of attribute, attribute
+// names are case-insensitive.
+func (e *entry) get(attr string) string {
+	for name, vals := range e.attrs {
+		if strings.EqualFold(name, attr) && len(vals) > 0 {
+			return vals[0]
+		}
+	}
+	return ""
+}
+
+// Server is in-process directory: it answers simple binds, equality
+// searches over entries in memory and StartTLS.
+type Server struct {
+	ln      net.Listener
+	scheme  string
+	tls     *tls.Config
+	certPEM []byte
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	entries []*entry
+	conns   map[net.Conn]struct{}
+}
+
+// NewServer starts ldap:// server on random local port,
+// clients may upgrade with StartTLS. Caller must Close it.
+func NewServer() (*Server, error) {
+	return newServer(false)
+}
+
+// NewTLSServer starts ldaps:// server on random local port,
+// caller must Close it.
+func NewTLSServer() (*Server, error) {
+	return newServer(true)
+}
+
+func newServer(ldaps bool) (*Server, error) {
+	tlsCfg, certPEM, err := selfSigned()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{ln: ln, scheme: "ldap", tls: tlsCfg, certPEM: certPEM, conns: make(map[net.Conn]struct{})}
+	if ldaps {
+		s.ln = tls.NewListener(ln, tlsCfg)
+		s.scheme = "ldaps"
+	}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// selfSigned makes certificate of 127.0.0.1 and localhost.
+func selfSigned() (*tls.Config, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ldaptest"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, certPEM, nil
+}
+
+// URL of server to dial.
+func (s *Server) URL() string {
+	return s.scheme + "://" + s.ln.Addr().String()
+}
+
+// CertPEM is certificate server presents, clients must trust it.
+func (s *Server) CertPEM() []byte {
+	return s.certPEM
+}
+
+// AddEntry adds object, password is checked on bind as dn.
+func (s *Server) AddEntry(dn, password string, attrs map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &entry{dn: dn, password: password, attrs: make(map[string][]string, len(attrs))}
+	for name, vals := range attrs {
+		e.attrs[name] = vals
+	}
+	s.entries = append(s.entries, e)
+}
+
+func (s *Server) Close() error {
+	err := s.ln.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+			conn.Close()
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	var rw io.ReadWriter = conn
+	r := bufio.NewReader(rw)
+	for {
+		msg, err := ber.ReadPacket(r)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, ok := msg.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		op := msg.Children[1]
+		if op.ClassType != ber.ClassApplication {
+			return
+		}
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			responses = []*ber.Packet{s.bind(op)}
+		case ldap.ApplicationSearchRequest:
+			responses = s.search(op)
+		case ldap.ApplicationExtendedRequest:
+			if len(op.Children) == 0 || op.Children[0].Data.String() != startTLSOID {
+				responses = []*ber.Packet{result(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError, "only StartTLS is supported")}
+				break
+			}
+			if _, err = conn.Write(message(id, result(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess, "")).Bytes()); err != nil {
+				return
+			}
+			// the rest of session is encrypted
+			tlsConn := tls.Server(conn, s.tls)
+			if err = tlsConn.Handshake(); err != nil {
+				return
+			}
+			rw = tlsConn
+			r = bufio.NewReader(rw)
+			continue
+		default:
+			// unbind and anything else ends session
+			return
+		}
+
+		for _, resp := range responses {
+			if _, err = rw.Write(message(id, resp).Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 || op.Children[2].ClassType != ber.ClassContext || op.Children[2].Tag != 0 {
+		return result(ldap.ApplicationBindResponse, ldap.LDAPResultProtocolError, "only simple bind is supported")
+	}
+	dn, password := op.Children[1].Data.String(), op.Children[2].Data.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if strings.EqualFold(e.dn, dn) && password != "" && e.password == password {
+			return result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess, "")
+		}
+	}
+	return result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials, "invalid credentials")
+}
+
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError, "malformed search")}
+	}
+	baseDN, filter := op.Children[0].Data.String(), op.Children[6]
+	if filter.ClassType != ber.ClassContext || filter.Tag != ldap.FilterEqualityMatch || len(filter.Children) != 2 {
+		return []*ber.Packet{result(ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError, "only equality filter is supported")}
+	}
+	attr, value := filter.Children[0].Data.String(), filter.Children[1].Data.String()
+
+	var requested []string
+	for _, a := range op.Children[7].Children {
+		requested = append(requested, a.Data.String())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var responses []*ber.Packet
+	for _, e := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(e.dn), strings.ToLower(baseDN)) || !strings.EqualFold(e.get(attr), value) {
+			continue
+		}
+		responses = append(responses, entryPacket(e, requested))
+	}
+
+	return append(responses, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess, ""))
+}
+
+func message(id int64, op *ber.Packet) *ber.Packet {
+	msg := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	msg.AppendChild(op)
+	return msg
+}
+
+func result(tag ber.Tag, code int64, diagnostic string) *ber.Packet {
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	res.AppendChild(octetString(""))
+	res.AppendChild(octetString(diagnostic))
+	return res
+}
+
+func octetString(s string) *ber.Packet {
+	return ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, s, "")
+}
+
+func entryPacket(e *entry, requested []string) *ber.Packet {
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, vals := range e.attrs {
+		if !isRequested(name, requested) {
+			continue
+		}
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range vals {
+			set.AppendChild(octetString(v))
+		}
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attr.AppendChild(octetString(name))
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	res.AppendChild(octetString(e.dn))
+	res.AppendChild(attrs)
+	return res
+}
+
+// isRequested reports whether attribute is in requested list,
+// empty list means every attribute.
+func isRequested(name string, requested []string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		if strings.EqualFold(r, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/api_key_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockAPIKeyRepository) CreateKey(c context.Context, key *models.NewAPIKey, keyHash, prefix, createdBy string) (*db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", c, key, keyHash, prefix, createdBy)
+	ret0, _ := ret[0].(*db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) CreateKey(c, key, keyHash, prefix, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).CreateKey), c, key, keyHash, prefix, createdBy)
+}
+
+// GetKeyByHash mocks base method.
+func (m *MockAPIKeyRepository) GetKeyByHash(c context.Context, keyHash string) (*db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyByHash", c, keyHash)
+	ret0, _ := ret[0].(*db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyByHash indicates an expected call of GetKeyByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetKeyByHash(c, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetKeyByHash), c, keyHash)
+}
+
+// ListKeys mocks base method.
+func (m *MockAPIKeyRepository) ListKeys(c context.Context) ([]*db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", c)
+	ret0, _ := ret[0].([]*db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockAPIKeyRepositoryMockRecorder) ListKeys(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockAPIKeyRepository)(nil).ListKeys), c)
+}
+
+// RevokeKey mocks base method.
+func (m *MockAPIKeyRepository) RevokeKey(c context.Context, keyID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", c, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) RevokeKey(c, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).RevokeKey), c, keyID)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/auction_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockAuctionRepository is a mock of AuctionRepository interface.
+type MockAuctionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuctionRepositoryMockRecorder
+}
+
+// MockAuctionRepositoryMockRecorder is the mock recorder for MockAuctionRepository.
+type MockAuctionRepositoryMockRecorder struct {
+	mock *MockAuctionRepository
+}
+
+// NewMockAuctionRepository creates a new mock instance.
+func NewMockAuctionRepository(ctrl *gomock.Controller) *MockAuctionRepository {
+	mock := &MockAuctionRepository{ctrl: ctrl}
+	mock.recorder = &MockAuctionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuctionRepository) EXPECT() *MockAuctionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CloseAuction mocks base method.
+func (m *MockAuctionRepository) CloseAuction(c context.Context, auctionID int32, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAuction", c, auctionID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseAuction indicates an expected call of CloseAuction.
+func (mr *MockAuctionRepositoryMockRecorder) CloseAuction(c, auctionID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAuction", reflect.TypeOf((*MockAuctionRepository)(nil).CloseAuction), c, auctionID, status)
+}
+
+// CreateAuction mocks base method.
+func (m *MockAuctionRepository) CreateAuction(c context.Context, itemType string, quantity, minBid int32, createdBy string, endsAt time.Time) (*db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuction", c, itemType, quantity, minBid, createdBy, endsAt)
+	ret0, _ := ret[0].(*db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuction indicates an expected call of CreateAuction.
+func (mr *MockAuctionRepositoryMockRecorder) CreateAuction(c, itemType, quantity, minBid, createdBy, endsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuction", reflect.TypeOf((*MockAuctionRepository)(nil).CreateAuction), c, itemType, quantity, minBid, createdBy, endsAt)
+}
+
+// CreateBid mocks base method.
+func (m *MockAuctionRepository) CreateBid(c context.Context, auctionID int32, username string, amount int32) (*db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBid", c, auctionID, username, amount)
+	ret0, _ := ret[0].(*db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBid indicates an expected call of CreateBid.
+func (mr *MockAuctionRepositoryMockRecorder) CreateBid(c, auctionID, username, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockAuctionRepository)(nil).CreateBid), c, auctionID, username, amount)
+}
+
+// GetActiveBids mocks base method.
+func (m *MockAuctionRepository) GetActiveBids(c context.Context, auctionID int32) ([]*db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBids", c, auctionID)
+	ret0, _ := ret[0].([]*db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBids indicates an expected call of GetActiveBids.
+func (mr *MockAuctionRepositoryMockRecorder) GetActiveBids(c, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBids", reflect.TypeOf((*MockAuctionRepository)(nil).GetActiveBids), c, auctionID)
+}
+
+// GetAuction mocks base method.
+func (m *MockAuctionRepository) GetAuction(c context.Context, auctionID int32) (*db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", c, auctionID)
+	ret0, _ := ret[0].(*db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockAuctionRepositoryMockRecorder) GetAuction(c, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockAuctionRepository)(nil).GetAuction), c, auctionID)
+}
+
+// GetAuctionForUpdate mocks base method.
+func (m *MockAuctionRepository) GetAuctionForUpdate(c context.Context, auctionID int32) (*db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuctionForUpdate", c, auctionID)
+	ret0, _ := ret[0].(*db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuctionForUpdate indicates an expected call of GetAuctionForUpdate.
+func (mr *MockAuctionRepositoryMockRecorder) GetAuctionForUpdate(c, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuctionForUpdate", reflect.TypeOf((*MockAuctionRepository)(nil).GetAuctionForUpdate), c, auctionID)
+}
+
+// GetEndedAuctions mocks base method.
+func (m *MockAuctionRepository) GetEndedAuctions(c context.Context, now time.Time) ([]*db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndedAuctions", c, now)
+	ret0, _ := ret[0].([]*db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndedAuctions indicates an expected call of GetEndedAuctions.
+func (mr *MockAuctionRepositoryMockRecorder) GetEndedAuctions(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndedAuctions", reflect.TypeOf((*MockAuctionRepository)(nil).GetEndedAuctions), c, now)
+}
+
+// ListOpen mocks base method.
+func (m *MockAuctionRepository) ListOpen(c context.Context) ([]*db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpen", c)
+	ret0, _ := ret[0].([]*db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpen indicates an expected call of ListOpen.
+func (mr *MockAuctionRepositoryMockRecorder) ListOpen(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpen", reflect.TypeOf((*MockAuctionRepository)(nil).ListOpen), c)
+}
+
+// SetBidStatus mocks base method.
+func (m *MockAuctionRepository) SetBidStatus(c context.Context, bidID int32, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBidStatus", c, bidID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBidStatus indicates an expected call of SetBidStatus.
+func (mr *MockAuctionRepositoryMockRecorder) SetBidStatus(c, bidID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockAuctionRepository)(nil).SetBidStatus), c, bidID, status)
+}
+
+// UpdateBidAmount mocks base method.
+func (m *MockAuctionRepository) UpdateBidAmount(c context.Context, bidID, amount int32) (*db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBidAmount", c, bidID, amount)
+	ret0, _ := ret[0].(*db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBidAmount indicates an expected call of UpdateBidAmount.
+func (mr *MockAuctionRepositoryMockRecorder) UpdateBidAmount(c, bidID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBidAmount", reflect.TypeOf((*MockAuctionRepository)(nil).UpdateBidAmount), c, bidID, amount)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/bundle_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockBundleRepository is a mock of BundleRepository interface.
+type MockBundleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleRepositoryMockRecorder
+}
+
+// MockBundleRepositoryMockRecorder is the mock recorder for MockBundleRepository.
+type MockBundleRepositoryMockRecorder struct {
+	mock *MockBundleRepository
+}
+
+// NewMockBundleRepository creates a new mock instance.
+func NewMockBundleRepository(ctrl *gomock.Controller) *MockBundleRepository {
+	mock := &MockBundleRepository{ctrl: ctrl}
+	mock.recorder = &MockBundleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundleRepository) EXPECT() *MockBundleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBundleItem mocks base method.
+func (m *MockBundleRepository) AddBundleItem(c context.Context, bundleName, itemType, variant string, quantity int32) (*db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBundleItem", c, bundleName, itemType, variant, quantity)
+	ret0, _ := ret[0].(*db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBundleItem indicates an expected call of AddBundleItem.
+func (mr *MockBundleRepositoryMockRecorder) AddBundleItem(c, bundleName, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBundleItem", reflect.TypeOf((*MockBundleRepository)(nil).AddBundleItem), c, bundleName, itemType, variant, quantity)
+}
+
+// CreateBundle mocks base method.
+func (m *MockBundleRepository) CreateBundle(c context.Context, name string, price int32, createdBy string) (*db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBundle", c, name, price, createdBy)
+	ret0, _ := ret[0].(*db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBundle indicates an expected call of CreateBundle.
+func (mr *MockBundleRepositoryMockRecorder) CreateBundle(c, name, price, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockBundleRepository)(nil).CreateBundle), c, name, price, createdBy)
+}
+
+// DeactivateBundle mocks base method.
+func (m *MockBundleRepository) DeactivateBundle(c context.Context, name string) (*db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateBundle", c, name)
+	ret0, _ := ret[0].(*db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateBundle indicates an expected call of DeactivateBundle.
+func (mr *MockBundleRepositoryMockRecorder) DeactivateBundle(c, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateBundle", reflect.TypeOf((*MockBundleRepository)(nil).DeactivateBundle), c, name)
+}
+
+// GetBundle mocks base method.
+func (m *MockBundleRepository) GetBundle(c context.Context, name string) (*db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundle", c, name)
+	ret0, _ := ret[0].(*db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundle indicates an expected call of GetBundle.
+func (mr *MockBundleRepositoryMockRecorder) GetBundle(c, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundle", reflect.TypeOf((*MockBundleRepository)(nil).GetBundle), c, name)
+}
+
+// GetBundleItems mocks base method.
+func (m *MockBundleRepository) GetBundleItems(c context.Context, name string) ([]*db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleItems", c, name)
+	ret0, _ := ret[0].([]*db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundleItems indicates an expected call of GetBundleItems.
+func (mr *MockBundleRepositoryMockRecorder) GetBundleItems(c, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleItems", reflect.TypeOf((*MockBundleRepository)(nil).GetBundleItems), c, name)
+}
+
+// ListActiveBundleItems mocks base method.
+func (m *MockBundleRepository) ListActiveBundleItems(c context.Context) ([]*db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBundleItems", c)
+	ret0, _ := ret[0].([]*db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBundleItems indicates an expected call of ListActiveBundleItems.
+func (mr *MockBundleRepositoryMockRecorder) ListActiveBundleItems(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBundleItems", reflect.TypeOf((*MockBundleRepository)(nil).ListActiveBundleItems), c)
+}
+
+// ListActiveBundles mocks base method.
+func (m *MockBundleRepository) ListActiveBundles(c context.Context) ([]*db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBundles", c)
+	ret0, _ := ret[0].([]*db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBundles indicates an expected call of ListActiveBundles.
+func (mr *MockBundleRepositoryMockRecorder) ListActiveBundles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBundles", reflect.TypeOf((*MockBundleRepository)(nil).ListActiveBundles), c)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/coin_lot_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockCoinLotRepository is a mock of CoinLotRepository interface.
+type MockCoinLotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoinLotRepositoryMockRecorder
+}
+
+// MockCoinLotRepositoryMockRecorder is the mock recorder for MockCoinLotRepository.
+type MockCoinLotRepositoryMockRecorder struct {
+	mock *MockCoinLotRepository
+}
+
+// NewMockCoinLotRepository creates a new mock instance.
+func NewMockCoinLotRepository(ctrl *gomock.Controller) *MockCoinLotRepository {
+	mock := &MockCoinLotRepository{ctrl: ctrl}
+	mock.recorder = &MockCoinLotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoinLotRepository) EXPECT() *MockCoinLotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeCoins mocks base method.
+func (m *MockCoinLotRepository) ConsumeCoins(c context.Context, userID, amount int32) ([]*models.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeCoins", c, userID, amount)
+	ret0, _ := ret[0].([]*models.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeCoins indicates an expected call of ConsumeCoins.
+func (mr *MockCoinLotRepositoryMockRecorder) ConsumeCoins(c, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeCoins", reflect.TypeOf((*MockCoinLotRepository)(nil).ConsumeCoins), c, userID, amount)
+}
+
+// ExpireLots mocks base method.
+func (m *MockCoinLotRepository) ExpireLots(c context.Context, now time.Time) ([]*db.CoinExpiration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireLots", c, now)
+	ret0, _ := ret[0].([]*db.CoinExpiration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireLots indicates an expected call of ExpireLots.
+func (mr *MockCoinLotRepositoryMockRecorder) ExpireLots(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireLots", reflect.TypeOf((*MockCoinLotRepository)(nil).ExpireLots), c, now)
+}
+
+// GetExpirations mocks base method.
+func (m *MockCoinLotRepository) GetExpirations(c context.Context, username string) ([]*db.CoinExpiration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpirations", c, username)
+	ret0, _ := ret[0].([]*db.CoinExpiration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpirations indicates an expected call of GetExpirations.
+func (mr *MockCoinLotRepositoryMockRecorder) GetExpirations(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpirations", reflect.TypeOf((*MockCoinLotRepository)(nil).GetExpirations), c, username)
+}
+
+// GetExpiringLots mocks base method.
+func (m *MockCoinLotRepository) GetExpiringLots(c context.Context, userID int32, before time.Time) ([]*db.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringLots", c, userID, before)
+	ret0, _ := ret[0].([]*db.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringLots indicates an expected call of GetExpiringLots.
+func (mr *MockCoinLotRepositoryMockRecorder) GetExpiringLots(c, userID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringLots", reflect.TypeOf((*MockCoinLotRepository)(nil).GetExpiringLots), c, userID, before)
+}
+
+// GrantCoins mocks base method.
+func (m *MockCoinLotRepository) GrantCoins(c context.Context, userID, amount int32, expiresAt time.Time) (*db.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCoins", c, userID, amount, expiresAt)
+	ret0, _ := ret[0].(*db.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantCoins indicates an expected call of GrantCoins.
+func (mr *MockCoinLotRepositoryMockRecorder) GrantCoins(c, userID, amount, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCoins", reflect.TypeOf((*MockCoinLotRepository)(nil).GrantCoins), c, userID, amount, expiresAt)
+}
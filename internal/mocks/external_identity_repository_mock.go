@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/external_identity_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockExternalIdentityRepository is a mock of ExternalIdentityRepository interface.
+type MockExternalIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalIdentityRepositoryMockRecorder
+}
+
+// MockExternalIdentityRepositoryMockRecorder is the mock recorder for MockExternalIdentityRepository.
+type MockExternalIdentityRepositoryMockRecorder struct {
+	mock *MockExternalIdentityRepository
+}
+
+// NewMockExternalIdentityRepository creates a new mock instance.
+func NewMockExternalIdentityRepository(ctrl *gomock.Controller) *MockExternalIdentityRepository {
+	mock := &MockExternalIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockExternalIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalIdentityRepository) EXPECT() *MockExternalIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIdentity mocks base method.
+func (m *MockExternalIdentityRepository) CreateIdentity(c context.Context, issuer, subject, username string) (*db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", c, issuer, subject, username)
+	ret0, _ := ret[0].(*db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockExternalIdentityRepositoryMockRecorder) CreateIdentity(c, issuer, subject, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockExternalIdentityRepository)(nil).CreateIdentity), c, issuer, subject, username)
+}
+
+// GetIdentity mocks base method.
+func (m *MockExternalIdentityRepository) GetIdentity(c context.Context, issuer, subject string) (*db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", c, issuer, subject)
+	ret0, _ := ret[0].(*db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockExternalIdentityRepositoryMockRecorder) GetIdentity(c, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockExternalIdentityRepository)(nil).GetIdentity), c, issuer, subject)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/fraud_case_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockFraudCaseRepository is a mock of FraudCaseRepository interface.
+type MockFraudCaseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudCaseRepositoryMockRecorder
+}
+
+// MockFraudCaseRepositoryMockRecorder is the mock recorder for MockFraudCaseRepository.
+type MockFraudCaseRepositoryMockRecorder struct {
+	mock *MockFraudCaseRepository
+}
+
+// NewMockFraudCaseRepository creates a new mock instance.
+func NewMockFraudCaseRepository(ctrl *gomock.Controller) *MockFraudCaseRepository {
+	mock := &MockFraudCaseRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudCaseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudCaseRepository) EXPECT() *MockFraudCaseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCase mocks base method.
+func (m *MockFraudCaseRepository) CreateCase(c context.Context, fromUsername, toUsername string, amount int32, action, reasons string, transferID int32) (*db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCase", c, fromUsername, toUsername, amount, action, reasons, transferID)
+	ret0, _ := ret[0].(*db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCase indicates an expected call of CreateCase.
+func (mr *MockFraudCaseRepositoryMockRecorder) CreateCase(c, fromUsername, toUsername, amount, action, reasons, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCase", reflect.TypeOf((*MockFraudCaseRepository)(nil).CreateCase), c, fromUsername, toUsername, amount, action, reasons, transferID)
+}
+
+// GetCaseForUpdate mocks base method.
+func (m *MockFraudCaseRepository) GetCaseForUpdate(c context.Context, caseID int32) (*db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaseForUpdate", c, caseID)
+	ret0, _ := ret[0].(*db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaseForUpdate indicates an expected call of GetCaseForUpdate.
+func (mr *MockFraudCaseRepositoryMockRecorder) GetCaseForUpdate(c, caseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaseForUpdate", reflect.TypeOf((*MockFraudCaseRepository)(nil).GetCaseForUpdate), c, caseID)
+}
+
+// ListCases mocks base method.
+func (m *MockFraudCaseRepository) ListCases(c context.Context, status string) ([]*db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", c, status)
+	ret0, _ := ret[0].([]*db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockFraudCaseRepositoryMockRecorder) ListCases(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*MockFraudCaseRepository)(nil).ListCases), c, status)
+}
+
+// ResolveCase mocks base method.
+func (m *MockFraudCaseRepository) ResolveCase(c context.Context, caseID int32, status, resolvedBy string, transferID int32) (*db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCase", c, caseID, status, resolvedBy, transferID)
+	ret0, _ := ret[0].(*db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCase indicates an expected call of ResolveCase.
+func (mr *MockFraudCaseRepositoryMockRecorder) ResolveCase(c, caseID, status, resolvedBy, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCase", reflect.TypeOf((*MockFraudCaseRepository)(nil).ResolveCase), c, caseID, status, resolvedBy, transferID)
+}
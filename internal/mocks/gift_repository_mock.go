@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/gift_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockGiftRepository is a mock of GiftRepository interface.
+type MockGiftRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGiftRepositoryMockRecorder
+}
+
+// MockGiftRepositoryMockRecorder is the mock recorder for MockGiftRepository.
+type MockGiftRepositoryMockRecorder struct {
+	mock *MockGiftRepository
+}
+
+// NewMockGiftRepository creates a new mock instance.
+func NewMockGiftRepository(ctrl *gomock.Controller) *MockGiftRepository {
+	mock := &MockGiftRepository{ctrl: ctrl}
+	mock.recorder = &MockGiftRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGiftRepository) EXPECT() *MockGiftRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGift mocks base method.
+func (m *MockGiftRepository) CreateGift(c context.Context, fromUsername, toUsername, itemType, message string) (*db.Gift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGift", c, fromUsername, toUsername, itemType, message)
+	ret0, _ := ret[0].(*db.Gift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGift indicates an expected call of CreateGift.
+func (mr *MockGiftRepositoryMockRecorder) CreateGift(c, fromUsername, toUsername, itemType, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGift", reflect.TypeOf((*MockGiftRepository)(nil).CreateGift), c, fromUsername, toUsername, itemType, message)
+}
+
+// GetGiftsWithUser mocks base method.
+func (m *MockGiftRepository) GetGiftsWithUser(c context.Context, username string) ([]*db.Gift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftsWithUser", c, username)
+	ret0, _ := ret[0].([]*db.Gift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftsWithUser indicates an expected call of GetGiftsWithUser.
+func (mr *MockGiftRepositoryMockRecorder) GetGiftsWithUser(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftsWithUser", reflect.TypeOf((*MockGiftRepository)(nil).GetGiftsWithUser), c, username)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,30 +35,44 @@ func (m *MockHasher) EXPECT() *MockHasherMockRecorder {
 }
 
 // Compare mocks base method.
-func (m *MockHasher) Compare(hash, password string) error {
+func (m *MockHasher) Compare(ctx context.Context, hash, password string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Compare", hash, password)
+	ret := m.ctrl.Call(m, "Compare", ctx, hash, password)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Compare indicates an expected call of Compare.
-func (mr *MockHasherMockRecorder) Compare(hash, password interface{}) *gomock.Call {
+func (mr *MockHasherMockRecorder) Compare(ctx, hash, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockHasher)(nil).Compare), hash, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockHasher)(nil).Compare), ctx, hash, password)
 }
 
 // Generate mocks base method.
-func (m *MockHasher) Generate(password string) (string, error) {
+func (m *MockHasher) Generate(ctx context.Context, password string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Generate", password)
+	ret := m.ctrl.Call(m, "Generate", ctx, password)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Generate indicates an expected call of Generate.
-func (mr *MockHasherMockRecorder) Generate(password interface{}) *gomock.Call {
+func (mr *MockHasherMockRecorder) Generate(ctx, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockHasher)(nil).Generate), password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockHasher)(nil).Generate), ctx, password)
+}
+
+// NeedsRehash mocks base method.
+func (m *MockHasher) NeedsRehash(hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockHasherMockRecorder) NeedsRehash(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockHasher)(nil).NeedsRehash), hash)
 }
@@ -36,17 +36,31 @@ func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder
 }
 
 // AddItemToInventory mocks base method.
-func (m *MockInventoryRepository) AddItemToInventory(c context.Context, userID int32, itemType string) error {
+func (m *MockInventoryRepository) AddItemToInventory(c context.Context, userID int32, itemType, variant string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddItemToInventory", c, userID, itemType)
+	ret := m.ctrl.Call(m, "AddItemToInventory", c, userID, itemType, variant)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddItemToInventory indicates an expected call of AddItemToInventory.
-func (mr *MockInventoryRepositoryMockRecorder) AddItemToInventory(c, userID, itemType interface{}) *gomock.Call {
+func (mr *MockInventoryRepositoryMockRecorder) AddItemToInventory(c, userID, itemType, variant interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToInventory", reflect.TypeOf((*MockInventoryRepository)(nil).AddItemToInventory), c, userID, itemType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemToInventory", reflect.TypeOf((*MockInventoryRepository)(nil).AddItemToInventory), c, userID, itemType, variant)
+}
+
+// AddItems mocks base method.
+func (m *MockInventoryRepository) AddItems(c context.Context, userID int32, itemType, variant string, quantity int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItems", c, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItems indicates an expected call of AddItems.
+func (mr *MockInventoryRepositoryMockRecorder) AddItems(c, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItems", reflect.TypeOf((*MockInventoryRepository)(nil).AddItems), c, userID, itemType, variant, quantity)
 }
 
 // GetInventory mocks base method.
@@ -63,3 +77,17 @@ func (mr *MockInventoryRepositoryMockRecorder) GetInventory(c, userID interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockInventoryRepository)(nil).GetInventory), c, userID)
 }
+
+// RemoveItems mocks base method.
+func (m *MockInventoryRepository) RemoveItems(c context.Context, userID int32, itemType, variant string, quantity int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItems", c, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItems indicates an expected call of RemoveItems.
+func (mr *MockInventoryRepositoryMockRecorder) RemoveItems(c, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItems", reflect.TypeOf((*MockInventoryRepository)(nil).RemoveItems), c, userID, itemType, variant, quantity)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/item_transfer_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockItemTransferRepository is a mock of ItemTransferRepository interface.
+type MockItemTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemTransferRepositoryMockRecorder
+}
+
+// MockItemTransferRepositoryMockRecorder is the mock recorder for MockItemTransferRepository.
+type MockItemTransferRepositoryMockRecorder struct {
+	mock *MockItemTransferRepository
+}
+
+// NewMockItemTransferRepository creates a new mock instance.
+func NewMockItemTransferRepository(ctrl *gomock.Controller) *MockItemTransferRepository {
+	mock := &MockItemTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockItemTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemTransferRepository) EXPECT() *MockItemTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateItemTransfer mocks base method.
+func (m *MockItemTransferRepository) CreateItemTransfer(c context.Context, fromUsername, toUsername, itemType, variant string, quantity int32) (*db.ItemTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemTransfer", c, fromUsername, toUsername, itemType, variant, quantity)
+	ret0, _ := ret[0].(*db.ItemTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItemTransfer indicates an expected call of CreateItemTransfer.
+func (mr *MockItemTransferRepositoryMockRecorder) CreateItemTransfer(c, fromUsername, toUsername, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemTransfer", reflect.TypeOf((*MockItemTransferRepository)(nil).CreateItemTransfer), c, fromUsername, toUsername, itemType, variant, quantity)
+}
+
+// GetItemTransfersWithUser mocks base method.
+func (m *MockItemTransferRepository) GetItemTransfersWithUser(c context.Context, username string) ([]*db.ItemTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemTransfersWithUser", c, username)
+	ret0, _ := ret[0].([]*db.ItemTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemTransfersWithUser indicates an expected call of GetItemTransfersWithUser.
+func (mr *MockItemTransferRepositoryMockRecorder) GetItemTransfersWithUser(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemTransfersWithUser", reflect.TypeOf((*MockItemTransferRepository)(nil).GetItemTransfersWithUser), c, username)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/listing_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockListingRepository is a mock of ListingRepository interface.
+type MockListingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockListingRepositoryMockRecorder
+}
+
+// MockListingRepositoryMockRecorder is the mock recorder for MockListingRepository.
+type MockListingRepositoryMockRecorder struct {
+	mock *MockListingRepository
+}
+
+// NewMockListingRepository creates a new mock instance.
+func NewMockListingRepository(ctrl *gomock.Controller) *MockListingRepository {
+	mock := &MockListingRepository{ctrl: ctrl}
+	mock.recorder = &MockListingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListingRepository) EXPECT() *MockListingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CloseListing mocks base method.
+func (m *MockListingRepository) CloseListing(c context.Context, listingID int32, status, buyerUsername string) (*db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseListing", c, listingID, status, buyerUsername)
+	ret0, _ := ret[0].(*db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseListing indicates an expected call of CloseListing.
+func (mr *MockListingRepositoryMockRecorder) CloseListing(c, listingID, status, buyerUsername interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseListing", reflect.TypeOf((*MockListingRepository)(nil).CloseListing), c, listingID, status, buyerUsername)
+}
+
+// CreateListing mocks base method.
+func (m *MockListingRepository) CreateListing(c context.Context, sellerUsername, itemType, variant string, quantity, price int32, expiresAt time.Time) (*db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListing", c, sellerUsername, itemType, variant, quantity, price, expiresAt)
+	ret0, _ := ret[0].(*db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListing indicates an expected call of CreateListing.
+func (mr *MockListingRepositoryMockRecorder) CreateListing(c, sellerUsername, itemType, variant, quantity, price, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockListingRepository)(nil).CreateListing), c, sellerUsername, itemType, variant, quantity, price, expiresAt)
+}
+
+// ExpireListings mocks base method.
+func (m *MockListingRepository) ExpireListings(c context.Context, now time.Time) ([]*db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireListings", c, now)
+	ret0, _ := ret[0].([]*db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireListings indicates an expected call of ExpireListings.
+func (mr *MockListingRepositoryMockRecorder) ExpireListings(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireListings", reflect.TypeOf((*MockListingRepository)(nil).ExpireListings), c, now)
+}
+
+// GetListing mocks base method.
+func (m *MockListingRepository) GetListing(c context.Context, listingID int32) (*db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListing", c, listingID)
+	ret0, _ := ret[0].(*db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListing indicates an expected call of GetListing.
+func (mr *MockListingRepositoryMockRecorder) GetListing(c, listingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListing", reflect.TypeOf((*MockListingRepository)(nil).GetListing), c, listingID)
+}
+
+// ListActive mocks base method.
+func (m *MockListingRepository) ListActive(c context.Context, itemType string, now time.Time) ([]*db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", c, itemType, now)
+	ret0, _ := ret[0].([]*db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockListingRepositoryMockRecorder) ListActive(c, itemType, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockListingRepository)(nil).ListActive), c, itemType, now)
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/order_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountItemOrders mocks base method.
+func (m *MockOrderRepository) CountItemOrders(c context.Context, username, itemType string, since time.Time) (int32, int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItemOrders", c, username, itemType, since)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(int32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountItemOrders indicates an expected call of CountItemOrders.
+func (mr *MockOrderRepositoryMockRecorder) CountItemOrders(c, username, itemType, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItemOrders", reflect.TypeOf((*MockOrderRepository)(nil).CountItemOrders), c, username, itemType, since)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderRepository) CreateOrder(c context.Context, order *models.NewOrder) (*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", c, order)
+	ret0, _ := ret[0].(*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderRepositoryMockRecorder) CreateOrder(c, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepository)(nil).CreateOrder), c, order)
+}
+
+// GetOrderCoinLots mocks base method.
+func (m *MockOrderRepository) GetOrderCoinLots(c context.Context, orderID int32) ([]*db.OrderCoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderCoinLots", c, orderID)
+	ret0, _ := ret[0].([]*db.OrderCoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderCoinLots indicates an expected call of GetOrderCoinLots.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderCoinLots(c, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderCoinLots", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderCoinLots), c, orderID)
+}
+
+// GetOrderForUpdate mocks base method.
+func (m *MockOrderRepository) GetOrderForUpdate(c context.Context, orderID int32) (*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderForUpdate", c, orderID)
+	ret0, _ := ret[0].(*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderForUpdate indicates an expected call of GetOrderForUpdate.
+func (mr *MockOrderRepositoryMockRecorder) GetOrderForUpdate(c, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderForUpdate", reflect.TypeOf((*MockOrderRepository)(nil).GetOrderForUpdate), c, orderID)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderRepository) ListOrders(c context.Context, status string) ([]*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", c, status)
+	ret0, _ := ret[0].([]*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderRepositoryMockRecorder) ListOrders(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderRepository)(nil).ListOrders), c, status)
+}
+
+// ListUserOrders mocks base method.
+func (m *MockOrderRepository) ListUserOrders(c context.Context, username string) ([]*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOrders", c, username)
+	ret0, _ := ret[0].([]*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOrders indicates an expected call of ListUserOrders.
+func (mr *MockOrderRepositoryMockRecorder) ListUserOrders(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrders", reflect.TypeOf((*MockOrderRepository)(nil).ListUserOrders), c, username)
+}
+
+// SetPickupLocation mocks base method.
+func (m *MockOrderRepository) SetPickupLocation(c context.Context, orderID int32, location string) (*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPickupLocation", c, orderID, location)
+	ret0, _ := ret[0].(*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPickupLocation indicates an expected call of SetPickupLocation.
+func (mr *MockOrderRepositoryMockRecorder) SetPickupLocation(c, orderID, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPickupLocation", reflect.TypeOf((*MockOrderRepository)(nil).SetPickupLocation), c, orderID, location)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockOrderRepository) UpdateStatus(c context.Context, orderID int32, status string) (*db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", c, orderID, status)
+	ret0, _ := ret[0].(*db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockOrderRepositoryMockRecorder) UpdateStatus(c, orderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockOrderRepository)(nil).UpdateStatus), c, orderID, status)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/password_reset_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockPasswordResetRepository is a mock of PasswordResetRepository interface.
+type MockPasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepositoryMockRecorder
+}
+
+// MockPasswordResetRepositoryMockRecorder is the mock recorder for MockPasswordResetRepository.
+type MockPasswordResetRepositoryMockRecorder struct {
+	mock *MockPasswordResetRepository
+}
+
+// NewMockPasswordResetRepository creates a new mock instance.
+func NewMockPasswordResetRepository(ctrl *gomock.Controller) *MockPasswordResetRepository {
+	mock := &MockPasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepository) EXPECT() *MockPasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateReset mocks base method.
+func (m *MockPasswordResetRepository) CreateReset(c context.Context, tokenHash, username, createdBy string, expiresAt time.Time) (*db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReset", c, tokenHash, username, createdBy, expiresAt)
+	ret0, _ := ret[0].(*db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReset indicates an expected call of CreateReset.
+func (mr *MockPasswordResetRepositoryMockRecorder) CreateReset(c, tokenHash, username, createdBy, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReset", reflect.TypeOf((*MockPasswordResetRepository)(nil).CreateReset), c, tokenHash, username, createdBy, expiresAt)
+}
+
+// InvalidateResets mocks base method.
+func (m *MockPasswordResetRepository) InvalidateResets(c context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateResets", c, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateResets indicates an expected call of InvalidateResets.
+func (mr *MockPasswordResetRepositoryMockRecorder) InvalidateResets(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateResets", reflect.TypeOf((*MockPasswordResetRepository)(nil).InvalidateResets), c, username)
+}
+
+// UseReset mocks base method.
+func (m *MockPasswordResetRepository) UseReset(c context.Context, tokenHash string, now time.Time) (*db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseReset", c, tokenHash, now)
+	ret0, _ := ret[0].(*db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseReset indicates an expected call of UseReset.
+func (mr *MockPasswordResetRepositoryMockRecorder) UseReset(c, tokenHash, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseReset", reflect.TypeOf((*MockPasswordResetRepository)(nil).UseReset), c, tokenHash, now)
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/preorder_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockPreorderRepository is a mock of PreorderRepository interface.
+type MockPreorderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreorderRepositoryMockRecorder
+}
+
+// MockPreorderRepositoryMockRecorder is the mock recorder for MockPreorderRepository.
+type MockPreorderRepositoryMockRecorder struct {
+	mock *MockPreorderRepository
+}
+
+// NewMockPreorderRepository creates a new mock instance.
+func NewMockPreorderRepository(ctrl *gomock.Controller) *MockPreorderRepository {
+	mock := &MockPreorderRepository{ctrl: ctrl}
+	mock.recorder = &MockPreorderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreorderRepository) EXPECT() *MockPreorderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CloseBatch mocks base method.
+func (m *MockPreorderRepository) CloseBatch(c context.Context, batchID int32, status string) (*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseBatch", c, batchID, status)
+	ret0, _ := ret[0].(*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseBatch indicates an expected call of CloseBatch.
+func (mr *MockPreorderRepositoryMockRecorder) CloseBatch(c, batchID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseBatch", reflect.TypeOf((*MockPreorderRepository)(nil).CloseBatch), c, batchID, status)
+}
+
+// CountPending mocks base method.
+func (m *MockPreorderRepository) CountPending(c context.Context, batchID int32) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPending", c, batchID)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPending indicates an expected call of CountPending.
+func (mr *MockPreorderRepositoryMockRecorder) CountPending(c, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPending", reflect.TypeOf((*MockPreorderRepository)(nil).CountPending), c, batchID)
+}
+
+// CreateBatch mocks base method.
+func (m *MockPreorderRepository) CreateBatch(c context.Context, itemType, variant string, price int32, createdBy string, expiresAt time.Time) (*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", c, itemType, variant, price, createdBy, expiresAt)
+	ret0, _ := ret[0].(*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockPreorderRepositoryMockRecorder) CreateBatch(c, itemType, variant, price, createdBy, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockPreorderRepository)(nil).CreateBatch), c, itemType, variant, price, createdBy, expiresAt)
+}
+
+// CreatePreorder mocks base method.
+func (m *MockPreorderRepository) CreatePreorder(c context.Context, batchID int32, username string) (*db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreorder", c, batchID, username)
+	ret0, _ := ret[0].(*db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreorder indicates an expected call of CreatePreorder.
+func (mr *MockPreorderRepositoryMockRecorder) CreatePreorder(c, batchID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreorder", reflect.TypeOf((*MockPreorderRepository)(nil).CreatePreorder), c, batchID, username)
+}
+
+// GetBatch mocks base method.
+func (m *MockPreorderRepository) GetBatch(c context.Context, batchID int32) (*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", c, batchID)
+	ret0, _ := ret[0].(*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockPreorderRepositoryMockRecorder) GetBatch(c, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockPreorderRepository)(nil).GetBatch), c, batchID)
+}
+
+// GetBatchForUpdate mocks base method.
+func (m *MockPreorderRepository) GetBatchForUpdate(c context.Context, batchID int32) (*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchForUpdate", c, batchID)
+	ret0, _ := ret[0].(*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchForUpdate indicates an expected call of GetBatchForUpdate.
+func (mr *MockPreorderRepositoryMockRecorder) GetBatchForUpdate(c, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchForUpdate", reflect.TypeOf((*MockPreorderRepository)(nil).GetBatchForUpdate), c, batchID)
+}
+
+// GetExpiredBatches mocks base method.
+func (m *MockPreorderRepository) GetExpiredBatches(c context.Context, now time.Time) ([]*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredBatches", c, now)
+	ret0, _ := ret[0].([]*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredBatches indicates an expected call of GetExpiredBatches.
+func (mr *MockPreorderRepositoryMockRecorder) GetExpiredBatches(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredBatches", reflect.TypeOf((*MockPreorderRepository)(nil).GetExpiredBatches), c, now)
+}
+
+// GetPendingPreorders mocks base method.
+func (m *MockPreorderRepository) GetPendingPreorders(c context.Context, batchID int32) ([]*db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPreorders", c, batchID)
+	ret0, _ := ret[0].([]*db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPreorders indicates an expected call of GetPendingPreorders.
+func (mr *MockPreorderRepositoryMockRecorder) GetPendingPreorders(c, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPreorders", reflect.TypeOf((*MockPreorderRepository)(nil).GetPendingPreorders), c, batchID)
+}
+
+// GetPreorderForUpdate mocks base method.
+func (m *MockPreorderRepository) GetPreorderForUpdate(c context.Context, preorderID int32) (*db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorderForUpdate", c, preorderID)
+	ret0, _ := ret[0].(*db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorderForUpdate indicates an expected call of GetPreorderForUpdate.
+func (mr *MockPreorderRepositoryMockRecorder) GetPreorderForUpdate(c, preorderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorderForUpdate", reflect.TypeOf((*MockPreorderRepository)(nil).GetPreorderForUpdate), c, preorderID)
+}
+
+// ListOpenBatches mocks base method.
+func (m *MockPreorderRepository) ListOpenBatches(c context.Context) ([]*db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenBatches", c)
+	ret0, _ := ret[0].([]*db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenBatches indicates an expected call of ListOpenBatches.
+func (mr *MockPreorderRepositoryMockRecorder) ListOpenBatches(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenBatches", reflect.TypeOf((*MockPreorderRepository)(nil).ListOpenBatches), c)
+}
+
+// ListUserPreorders mocks base method.
+func (m *MockPreorderRepository) ListUserPreorders(c context.Context, username string) ([]*db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPreorders", c, username)
+	ret0, _ := ret[0].([]*db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPreorders indicates an expected call of ListUserPreorders.
+func (mr *MockPreorderRepositoryMockRecorder) ListUserPreorders(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPreorders", reflect.TypeOf((*MockPreorderRepository)(nil).ListUserPreorders), c, username)
+}
+
+// SetPreorderStatus mocks base method.
+func (m *MockPreorderRepository) SetPreorderStatus(c context.Context, preorderID int32, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreorderStatus", c, preorderID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreorderStatus indicates an expected call of SetPreorderStatus.
+func (mr *MockPreorderRepositoryMockRecorder) SetPreorderStatus(c, preorderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreorderStatus", reflect.TypeOf((*MockPreorderRepository)(nil).SetPreorderStatus), c, preorderID, status)
+}
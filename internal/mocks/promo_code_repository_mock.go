@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/promo_code_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockPromoCodeRepository is a mock of PromoCodeRepository interface.
+type MockPromoCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoCodeRepositoryMockRecorder
+}
+
+// MockPromoCodeRepositoryMockRecorder is the mock recorder for MockPromoCodeRepository.
+type MockPromoCodeRepositoryMockRecorder struct {
+	mock *MockPromoCodeRepository
+}
+
+// NewMockPromoCodeRepository creates a new mock instance.
+func NewMockPromoCodeRepository(ctrl *gomock.Controller) *MockPromoCodeRepository {
+	mock := &MockPromoCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockPromoCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoCodeRepository) EXPECT() *MockPromoCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimUse mocks base method.
+func (m *MockPromoCodeRepository) ClaimUse(c context.Context, code, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUse", c, code, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimUse indicates an expected call of ClaimUse.
+func (mr *MockPromoCodeRepositoryMockRecorder) ClaimUse(c, code, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUse", reflect.TypeOf((*MockPromoCodeRepository)(nil).ClaimUse), c, code, username)
+}
+
+// CreatePromoCode mocks base method.
+func (m *MockPromoCodeRepository) CreatePromoCode(c context.Context, createdBy string, promo *models.NewPromoCode) (*db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromoCode", c, createdBy, promo)
+	ret0, _ := ret[0].(*db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockPromoCodeRepositoryMockRecorder) CreatePromoCode(c, createdBy, promo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockPromoCodeRepository)(nil).CreatePromoCode), c, createdBy, promo)
+}
+
+// DisablePromoCode mocks base method.
+func (m *MockPromoCodeRepository) DisablePromoCode(c context.Context, code string) (*db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisablePromoCode", c, code)
+	ret0, _ := ret[0].(*db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisablePromoCode indicates an expected call of DisablePromoCode.
+func (mr *MockPromoCodeRepositoryMockRecorder) DisablePromoCode(c, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisablePromoCode", reflect.TypeOf((*MockPromoCodeRepository)(nil).DisablePromoCode), c, code)
+}
+
+// GetPromoCode mocks base method.
+func (m *MockPromoCodeRepository) GetPromoCode(c context.Context, code string) (*db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCode", c, code)
+	ret0, _ := ret[0].(*db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCode indicates an expected call of GetPromoCode.
+func (mr *MockPromoCodeRepositoryMockRecorder) GetPromoCode(c, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCode", reflect.TypeOf((*MockPromoCodeRepository)(nil).GetPromoCode), c, code)
+}
+
+// ListPromoCodes mocks base method.
+func (m *MockPromoCodeRepository) ListPromoCodes(c context.Context) ([]*db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromoCodes", c)
+	ret0, _ := ret[0].([]*db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromoCodes indicates an expected call of ListPromoCodes.
+func (mr *MockPromoCodeRepositoryMockRecorder) ListPromoCodes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromoCodes", reflect.TypeOf((*MockPromoCodeRepository)(nil).ListPromoCodes), c)
+}
+
+// ReleaseUse mocks base method.
+func (m *MockPromoCodeRepository) ReleaseUse(c context.Context, code, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseUse", c, code, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseUse indicates an expected call of ReleaseUse.
+func (mr *MockPromoCodeRepositoryMockRecorder) ReleaseUse(c, code, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseUse", reflect.TypeOf((*MockPromoCodeRepository)(nil).ReleaseUse), c, code, username)
+}
+
+// UsePromoCode mocks base method.
+func (m *MockPromoCodeRepository) UsePromoCode(c context.Context, code, itemType string, now time.Time) (*db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePromoCode", c, code, itemType, now)
+	ret0, _ := ret[0].(*db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePromoCode indicates an expected call of UsePromoCode.
+func (mr *MockPromoCodeRepositoryMockRecorder) UsePromoCode(c, code, itemType, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePromoCode", reflect.TypeOf((*MockPromoCodeRepository)(nil).UsePromoCode), c, code, itemType, now)
+}
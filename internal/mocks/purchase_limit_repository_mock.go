@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/purchase_limit_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockPurchaseLimitRepository is a mock of PurchaseLimitRepository interface.
+type MockPurchaseLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseLimitRepositoryMockRecorder
+}
+
+// MockPurchaseLimitRepositoryMockRecorder is the mock recorder for MockPurchaseLimitRepository.
+type MockPurchaseLimitRepositoryMockRecorder struct {
+	mock *MockPurchaseLimitRepository
+}
+
+// NewMockPurchaseLimitRepository creates a new mock instance.
+func NewMockPurchaseLimitRepository(ctrl *gomock.Controller) *MockPurchaseLimitRepository {
+	mock := &MockPurchaseLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockPurchaseLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseLimitRepository) EXPECT() *MockPurchaseLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePurchaseLimit mocks base method.
+func (m *MockPurchaseLimitRepository) DeletePurchaseLimit(c context.Context, itemType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseLimit", c, itemType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePurchaseLimit indicates an expected call of DeletePurchaseLimit.
+func (mr *MockPurchaseLimitRepositoryMockRecorder) DeletePurchaseLimit(c, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseLimit", reflect.TypeOf((*MockPurchaseLimitRepository)(nil).DeletePurchaseLimit), c, itemType)
+}
+
+// GetPurchaseLimit mocks base method.
+func (m *MockPurchaseLimitRepository) GetPurchaseLimit(c context.Context, itemType string) (*db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseLimit", c, itemType)
+	ret0, _ := ret[0].(*db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseLimit indicates an expected call of GetPurchaseLimit.
+func (mr *MockPurchaseLimitRepositoryMockRecorder) GetPurchaseLimit(c, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseLimit", reflect.TypeOf((*MockPurchaseLimitRepository)(nil).GetPurchaseLimit), c, itemType)
+}
+
+// ListPurchaseLimits mocks base method.
+func (m *MockPurchaseLimitRepository) ListPurchaseLimits(c context.Context) ([]*db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseLimits", c)
+	ret0, _ := ret[0].([]*db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseLimits indicates an expected call of ListPurchaseLimits.
+func (mr *MockPurchaseLimitRepositoryMockRecorder) ListPurchaseLimits(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseLimits", reflect.TypeOf((*MockPurchaseLimitRepository)(nil).ListPurchaseLimits), c)
+}
+
+// SetPurchaseLimit mocks base method.
+func (m *MockPurchaseLimitRepository) SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPurchaseLimit", c, limit)
+	ret0, _ := ret[0].(*db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPurchaseLimit indicates an expected call of SetPurchaseLimit.
+func (mr *MockPurchaseLimitRepositoryMockRecorder) SetPurchaseLimit(c, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPurchaseLimit", reflect.TypeOf((*MockPurchaseLimitRepository)(nil).SetPurchaseLimit), c, limit)
+}
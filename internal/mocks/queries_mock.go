@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/myacey/avito-shop/db/sqlc"
@@ -35,138 +36,2047 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// AddBundleItem mocks base method.
+func (m *MockQuerier) AddBundleItem(ctx context.Context, arg db.AddBundleItemParams) (db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBundleItem", ctx, arg)
+	ret0, _ := ret[0].(db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBundleItem indicates an expected call of AddBundleItem.
+func (mr *MockQuerierMockRecorder) AddBundleItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBundleItem", reflect.TypeOf((*MockQuerier)(nil).AddBundleItem), ctx, arg)
+}
+
+// AddItemVariantStock mocks base method.
+func (m *MockQuerier) AddItemVariantStock(ctx context.Context, arg db.AddItemVariantStockParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemVariantStock", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItemVariantStock indicates an expected call of AddItemVariantStock.
+func (mr *MockQuerierMockRecorder) AddItemVariantStock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemVariantStock", reflect.TypeOf((*MockQuerier)(nil).AddItemVariantStock), ctx, arg)
+}
+
+// AddItemsToInventory mocks base method.
+func (m *MockQuerier) AddItemsToInventory(ctx context.Context, arg db.AddItemsToInventoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItemsToInventory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItemsToInventory indicates an expected call of AddItemsToInventory.
+func (mr *MockQuerierMockRecorder) AddItemsToInventory(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToInventory", reflect.TypeOf((*MockQuerier)(nil).AddItemsToInventory), ctx, arg)
+}
+
+// AddUserCoins mocks base method.
+func (m *MockQuerier) AddUserCoins(ctx context.Context, arg db.AddUserCoinsParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserCoins", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserCoins indicates an expected call of AddUserCoins.
+func (mr *MockQuerierMockRecorder) AddUserCoins(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserCoins", reflect.TypeOf((*MockQuerier)(nil).AddUserCoins), ctx, arg)
+}
+
+// AddWishlistItem mocks base method.
+func (m *MockQuerier) AddWishlistItem(ctx context.Context, arg db.AddWishlistItemParams) (db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWishlistItem", ctx, arg)
+	ret0, _ := ret[0].(db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWishlistItem indicates an expected call of AddWishlistItem.
+func (mr *MockQuerierMockRecorder) AddWishlistItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWishlistItem", reflect.TypeOf((*MockQuerier)(nil).AddWishlistItem), ctx, arg)
+}
+
 // BuyItem mocks base method.
 func (m *MockQuerier) BuyItem(ctx context.Context, arg db.BuyItemParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyItem", ctx, arg)
+	ret := m.ctrl.Call(m, "BuyItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuyItem indicates an expected call of BuyItem.
+func (mr *MockQuerierMockRecorder) BuyItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyItem", reflect.TypeOf((*MockQuerier)(nil).BuyItem), ctx, arg)
+}
+
+// CancelPriceSchedule mocks base method.
+func (m *MockQuerier) CancelPriceSchedule(ctx context.Context, scheduleID int32) (db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPriceSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPriceSchedule indicates an expected call of CancelPriceSchedule.
+func (mr *MockQuerierMockRecorder) CancelPriceSchedule(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPriceSchedule", reflect.TypeOf((*MockQuerier)(nil).CancelPriceSchedule), ctx, scheduleID)
+}
+
+// ClaimPromoCodeUse mocks base method.
+func (m *MockQuerier) ClaimPromoCodeUse(ctx context.Context, arg db.ClaimPromoCodeUseParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPromoCodeUse", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPromoCodeUse indicates an expected call of ClaimPromoCodeUse.
+func (mr *MockQuerierMockRecorder) ClaimPromoCodeUse(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPromoCodeUse", reflect.TypeOf((*MockQuerier)(nil).ClaimPromoCodeUse), ctx, arg)
+}
+
+// CloseAuction mocks base method.
+func (m *MockQuerier) CloseAuction(ctx context.Context, arg db.CloseAuctionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAuction", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseAuction indicates an expected call of CloseAuction.
+func (mr *MockQuerierMockRecorder) CloseAuction(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAuction", reflect.TypeOf((*MockQuerier)(nil).CloseAuction), ctx, arg)
+}
+
+// CloseListing mocks base method.
+func (m *MockQuerier) CloseListing(ctx context.Context, arg db.CloseListingParams) (db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseListing", ctx, arg)
+	ret0, _ := ret[0].(db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseListing indicates an expected call of CloseListing.
+func (mr *MockQuerierMockRecorder) CloseListing(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseListing", reflect.TypeOf((*MockQuerier)(nil).CloseListing), ctx, arg)
+}
+
+// ClosePreorderBatch mocks base method.
+func (m *MockQuerier) ClosePreorderBatch(ctx context.Context, arg db.ClosePreorderBatchParams) (db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePreorderBatch", ctx, arg)
+	ret0, _ := ret[0].(db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePreorderBatch indicates an expected call of ClosePreorderBatch.
+func (mr *MockQuerierMockRecorder) ClosePreorderBatch(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePreorderBatch", reflect.TypeOf((*MockQuerier)(nil).ClosePreorderBatch), ctx, arg)
+}
+
+// CountNewSendersSince mocks base method.
+func (m *MockQuerier) CountNewSendersSince(ctx context.Context, arg db.CountNewSendersSinceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNewSendersSince", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNewSendersSince indicates an expected call of CountNewSendersSince.
+func (mr *MockQuerierMockRecorder) CountNewSendersSince(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNewSendersSince", reflect.TypeOf((*MockQuerier)(nil).CountNewSendersSince), ctx, arg)
+}
+
+// CountPendingPreorders mocks base method.
+func (m *MockQuerier) CountPendingPreorders(ctx context.Context, batchID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingPreorders", ctx, batchID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingPreorders indicates an expected call of CountPendingPreorders.
+func (mr *MockQuerierMockRecorder) CountPendingPreorders(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingPreorders", reflect.TypeOf((*MockQuerier)(nil).CountPendingPreorders), ctx, batchID)
+}
+
+// CountRaffleTickets mocks base method.
+func (m *MockQuerier) CountRaffleTickets(ctx context.Context, raffleID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRaffleTickets", ctx, raffleID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRaffleTickets indicates an expected call of CountRaffleTickets.
+func (mr *MockQuerierMockRecorder) CountRaffleTickets(ctx, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRaffleTickets", reflect.TypeOf((*MockQuerier)(nil).CountRaffleTickets), ctx, raffleID)
+}
+
+// CountSentSince mocks base method.
+func (m *MockQuerier) CountSentSince(ctx context.Context, arg db.CountSentSinceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSentSince", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSentSince indicates an expected call of CountSentSince.
+func (mr *MockQuerierMockRecorder) CountSentSince(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentSince", reflect.TypeOf((*MockQuerier)(nil).CountSentSince), ctx, arg)
+}
+
+// CountUserItemOrders mocks base method.
+func (m *MockQuerier) CountUserItemOrders(ctx context.Context, arg db.CountUserItemOrdersParams) (db.CountUserItemOrdersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserItemOrders", ctx, arg)
+	ret0, _ := ret[0].(db.CountUserItemOrdersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserItemOrders indicates an expected call of CountUserItemOrders.
+func (mr *MockQuerierMockRecorder) CountUserItemOrders(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserItemOrders", reflect.TypeOf((*MockQuerier)(nil).CountUserItemOrders), ctx, arg)
+}
+
+// CountUserRaffleTickets mocks base method.
+func (m *MockQuerier) CountUserRaffleTickets(ctx context.Context, arg db.CountUserRaffleTicketsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserRaffleTickets", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserRaffleTickets indicates an expected call of CountUserRaffleTickets.
+func (mr *MockQuerierMockRecorder) CountUserRaffleTickets(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRaffleTickets", reflect.TypeOf((*MockQuerier)(nil).CountUserRaffleTickets), ctx, arg)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockQuerier) CreateApiKey(ctx context.Context, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", ctx, arg)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockQuerierMockRecorder) CreateApiKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockQuerier)(nil).CreateApiKey), ctx, arg)
+}
+
+// CreateAuction mocks base method.
+func (m *MockQuerier) CreateAuction(ctx context.Context, arg db.CreateAuctionParams) (db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuction", ctx, arg)
+	ret0, _ := ret[0].(db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuction indicates an expected call of CreateAuction.
+func (mr *MockQuerierMockRecorder) CreateAuction(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuction", reflect.TypeOf((*MockQuerier)(nil).CreateAuction), ctx, arg)
+}
+
+// CreateBid mocks base method.
+func (m *MockQuerier) CreateBid(ctx context.Context, arg db.CreateBidParams) (db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBid", ctx, arg)
+	ret0, _ := ret[0].(db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBid indicates an expected call of CreateBid.
+func (mr *MockQuerierMockRecorder) CreateBid(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockQuerier)(nil).CreateBid), ctx, arg)
+}
+
+// CreateBundle mocks base method.
+func (m *MockQuerier) CreateBundle(ctx context.Context, arg db.CreateBundleParams) (db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBundle", ctx, arg)
+	ret0, _ := ret[0].(db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBundle indicates an expected call of CreateBundle.
+func (mr *MockQuerierMockRecorder) CreateBundle(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockQuerier)(nil).CreateBundle), ctx, arg)
+}
+
+// CreateCoinLot mocks base method.
+func (m *MockQuerier) CreateCoinLot(ctx context.Context, arg db.CreateCoinLotParams) (db.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoinLot", ctx, arg)
+	ret0, _ := ret[0].(db.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCoinLot indicates an expected call of CreateCoinLot.
+func (mr *MockQuerierMockRecorder) CreateCoinLot(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoinLot", reflect.TypeOf((*MockQuerier)(nil).CreateCoinLot), ctx, arg)
+}
+
+// CreateExternalIdentity mocks base method.
+func (m *MockQuerier) CreateExternalIdentity(ctx context.Context, arg db.CreateExternalIdentityParams) (db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalIdentity", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalIdentity indicates an expected call of CreateExternalIdentity.
+func (mr *MockQuerierMockRecorder) CreateExternalIdentity(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalIdentity", reflect.TypeOf((*MockQuerier)(nil).CreateExternalIdentity), ctx, arg)
+}
+
+// CreateFraudCase mocks base method.
+func (m *MockQuerier) CreateFraudCase(ctx context.Context, arg db.CreateFraudCaseParams) (db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudCase", ctx, arg)
+	ret0, _ := ret[0].(db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudCase indicates an expected call of CreateFraudCase.
+func (mr *MockQuerierMockRecorder) CreateFraudCase(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudCase", reflect.TypeOf((*MockQuerier)(nil).CreateFraudCase), ctx, arg)
+}
+
+// CreateGift mocks base method.
+func (m *MockQuerier) CreateGift(ctx context.Context, arg db.CreateGiftParams) (db.Gift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGift", ctx, arg)
+	ret0, _ := ret[0].(db.Gift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGift indicates an expected call of CreateGift.
+func (mr *MockQuerierMockRecorder) CreateGift(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGift", reflect.TypeOf((*MockQuerier)(nil).CreateGift), ctx, arg)
+}
+
+// CreateItemTransfer mocks base method.
+func (m *MockQuerier) CreateItemTransfer(ctx context.Context, arg db.CreateItemTransferParams) (db.ItemTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ItemTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItemTransfer indicates an expected call of CreateItemTransfer.
+func (mr *MockQuerierMockRecorder) CreateItemTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemTransfer", reflect.TypeOf((*MockQuerier)(nil).CreateItemTransfer), ctx, arg)
+}
+
+// CreateItemVariant mocks base method.
+func (m *MockQuerier) CreateItemVariant(ctx context.Context, arg db.CreateItemVariantParams) (db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemVariant", ctx, arg)
+	ret0, _ := ret[0].(db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItemVariant indicates an expected call of CreateItemVariant.
+func (mr *MockQuerierMockRecorder) CreateItemVariant(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemVariant", reflect.TypeOf((*MockQuerier)(nil).CreateItemVariant), ctx, arg)
+}
+
+// CreateListing mocks base method.
+func (m *MockQuerier) CreateListing(ctx context.Context, arg db.CreateListingParams) (db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListing", ctx, arg)
+	ret0, _ := ret[0].(db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListing indicates an expected call of CreateListing.
+func (mr *MockQuerierMockRecorder) CreateListing(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockQuerier)(nil).CreateListing), ctx, arg)
+}
+
+// CreateMoneyTransfer mocks base method.
+func (m *MockQuerier) CreateMoneyTransfer(ctx context.Context, arg db.CreateMoneyTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMoneyTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMoneyTransfer indicates an expected call of CreateMoneyTransfer.
+func (mr *MockQuerierMockRecorder) CreateMoneyTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMoneyTransfer", reflect.TypeOf((*MockQuerier)(nil).CreateMoneyTransfer), ctx, arg)
+}
+
+// CreateNotification mocks base method.
+func (m *MockQuerier) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, arg)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockQuerierMockRecorder) CreateNotification(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockQuerier)(nil).CreateNotification), ctx, arg)
+}
+
+// CreateOrder mocks base method.
+func (m *MockQuerier) CreateOrder(ctx context.Context, arg db.CreateOrderParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockQuerierMockRecorder) CreateOrder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockQuerier)(nil).CreateOrder), ctx, arg)
+}
+
+// CreateOrderCoinLot mocks base method.
+func (m *MockQuerier) CreateOrderCoinLot(ctx context.Context, arg db.CreateOrderCoinLotParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderCoinLot", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrderCoinLot indicates an expected call of CreateOrderCoinLot.
+func (mr *MockQuerierMockRecorder) CreateOrderCoinLot(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderCoinLot", reflect.TypeOf((*MockQuerier)(nil).CreateOrderCoinLot), ctx, arg)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockQuerier) CreatePasswordReset(ctx context.Context, arg db.CreatePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockQuerierMockRecorder) CreatePasswordReset(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockQuerier)(nil).CreatePasswordReset), ctx, arg)
+}
+
+// CreatePreorder mocks base method.
+func (m *MockQuerier) CreatePreorder(ctx context.Context, arg db.CreatePreorderParams) (db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreorder", ctx, arg)
+	ret0, _ := ret[0].(db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreorder indicates an expected call of CreatePreorder.
+func (mr *MockQuerierMockRecorder) CreatePreorder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreorder", reflect.TypeOf((*MockQuerier)(nil).CreatePreorder), ctx, arg)
+}
+
+// CreatePreorderBatch mocks base method.
+func (m *MockQuerier) CreatePreorderBatch(ctx context.Context, arg db.CreatePreorderBatchParams) (db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreorderBatch", ctx, arg)
+	ret0, _ := ret[0].(db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreorderBatch indicates an expected call of CreatePreorderBatch.
+func (mr *MockQuerierMockRecorder) CreatePreorderBatch(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreorderBatch", reflect.TypeOf((*MockQuerier)(nil).CreatePreorderBatch), ctx, arg)
+}
+
+// CreatePriceSchedule mocks base method.
+func (m *MockQuerier) CreatePriceSchedule(ctx context.Context, arg db.CreatePriceScheduleParams) (db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceSchedule", ctx, arg)
+	ret0, _ := ret[0].(db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceSchedule indicates an expected call of CreatePriceSchedule.
+func (mr *MockQuerierMockRecorder) CreatePriceSchedule(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceSchedule", reflect.TypeOf((*MockQuerier)(nil).CreatePriceSchedule), ctx, arg)
+}
+
+// CreatePromoCode mocks base method.
+func (m *MockQuerier) CreatePromoCode(ctx context.Context, arg db.CreatePromoCodeParams) (db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromoCode", ctx, arg)
+	ret0, _ := ret[0].(db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockQuerierMockRecorder) CreatePromoCode(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockQuerier)(nil).CreatePromoCode), ctx, arg)
+}
+
+// CreateRaffle mocks base method.
+func (m *MockQuerier) CreateRaffle(ctx context.Context, arg db.CreateRaffleParams) (db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRaffle", ctx, arg)
+	ret0, _ := ret[0].(db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRaffle indicates an expected call of CreateRaffle.
+func (mr *MockQuerierMockRecorder) CreateRaffle(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRaffle", reflect.TypeOf((*MockQuerier)(nil).CreateRaffle), ctx, arg)
+}
+
+// CreateRaffleTicket mocks base method.
+func (m *MockQuerier) CreateRaffleTicket(ctx context.Context, arg db.CreateRaffleTicketParams) (db.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRaffleTicket", ctx, arg)
+	ret0, _ := ret[0].(db.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRaffleTicket indicates an expected call of CreateRaffleTicket.
+func (mr *MockQuerierMockRecorder) CreateRaffleTicket(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRaffleTicket", reflect.TypeOf((*MockQuerier)(nil).CreateRaffleTicket), ctx, arg)
+}
+
+// CreateTransferApproval mocks base method.
+func (m *MockQuerier) CreateTransferApproval(ctx context.Context, arg db.CreateTransferApprovalParams) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferApproval", ctx, arg)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferApproval indicates an expected call of CreateTransferApproval.
+func (mr *MockQuerierMockRecorder) CreateTransferApproval(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferApproval", reflect.TypeOf((*MockQuerier)(nil).CreateTransferApproval), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockQuerier) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockQuerierMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockQuerier)(nil).CreateUser), ctx, arg)
+}
+
+// DeactivateBundle mocks base method.
+func (m *MockQuerier) DeactivateBundle(ctx context.Context, name string) (db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateBundle", ctx, name)
+	ret0, _ := ret[0].(db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateBundle indicates an expected call of DeactivateBundle.
+func (mr *MockQuerierMockRecorder) DeactivateBundle(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateBundle", reflect.TypeOf((*MockQuerier)(nil).DeactivateBundle), ctx, name)
+}
+
+// DeleteCoinLot mocks base method.
+func (m *MockQuerier) DeleteCoinLot(ctx context.Context, lotID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoinLot", ctx, lotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoinLot indicates an expected call of DeleteCoinLot.
+func (mr *MockQuerierMockRecorder) DeleteCoinLot(ctx, lotID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoinLot", reflect.TypeOf((*MockQuerier)(nil).DeleteCoinLot), ctx, lotID)
+}
+
+// DeleteEmptyInventoryItem mocks base method.
+func (m *MockQuerier) DeleteEmptyInventoryItem(ctx context.Context, inventoryID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmptyInventoryItem", ctx, inventoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmptyInventoryItem indicates an expected call of DeleteEmptyInventoryItem.
+func (mr *MockQuerierMockRecorder) DeleteEmptyInventoryItem(ctx, inventoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmptyInventoryItem", reflect.TypeOf((*MockQuerier)(nil).DeleteEmptyInventoryItem), ctx, inventoryID)
+}
+
+// DeletePurchaseLimit mocks base method.
+func (m *MockQuerier) DeletePurchaseLimit(ctx context.Context, itemType string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseLimit", ctx, itemType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePurchaseLimit indicates an expected call of DeletePurchaseLimit.
+func (mr *MockQuerierMockRecorder) DeletePurchaseLimit(ctx, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseLimit", reflect.TypeOf((*MockQuerier)(nil).DeletePurchaseLimit), ctx, itemType)
+}
+
+// DeleteTransferLimitOverride mocks base method.
+func (m *MockQuerier) DeleteTransferLimitOverride(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransferLimitOverride", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransferLimitOverride indicates an expected call of DeleteTransferLimitOverride.
+func (mr *MockQuerierMockRecorder) DeleteTransferLimitOverride(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransferLimitOverride", reflect.TypeOf((*MockQuerier)(nil).DeleteTransferLimitOverride), ctx, username)
+}
+
+// DisablePromoCode mocks base method.
+func (m *MockQuerier) DisablePromoCode(ctx context.Context, code string) (db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisablePromoCode", ctx, code)
+	ret0, _ := ret[0].(db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisablePromoCode indicates an expected call of DisablePromoCode.
+func (mr *MockQuerierMockRecorder) DisablePromoCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisablePromoCode", reflect.TypeOf((*MockQuerier)(nil).DisablePromoCode), ctx, code)
+}
+
+// ExpireCoinLots mocks base method.
+func (m *MockQuerier) ExpireCoinLots(ctx context.Context, now time.Time) ([]db.CoinExpiration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireCoinLots", ctx, now)
+	ret0, _ := ret[0].([]db.CoinExpiration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireCoinLots indicates an expected call of ExpireCoinLots.
+func (mr *MockQuerierMockRecorder) ExpireCoinLots(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireCoinLots", reflect.TypeOf((*MockQuerier)(nil).ExpireCoinLots), ctx, now)
+}
+
+// ExpireListings mocks base method.
+func (m *MockQuerier) ExpireListings(ctx context.Context, expiresAt time.Time) ([]db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireListings", ctx, expiresAt)
+	ret0, _ := ret[0].([]db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireListings indicates an expected call of ExpireListings.
+func (mr *MockQuerierMockRecorder) ExpireListings(ctx, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireListings", reflect.TypeOf((*MockQuerier)(nil).ExpireListings), ctx, expiresAt)
+}
+
+// GetActiveBids mocks base method.
+func (m *MockQuerier) GetActiveBids(ctx context.Context, auctionID int32) ([]db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBids", ctx, auctionID)
+	ret0, _ := ret[0].([]db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBids indicates an expected call of GetActiveBids.
+func (mr *MockQuerierMockRecorder) GetActiveBids(ctx, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBids", reflect.TypeOf((*MockQuerier)(nil).GetActiveBids), ctx, auctionID)
+}
+
+// GetActivePriceSchedule mocks base method.
+func (m *MockQuerier) GetActivePriceSchedule(ctx context.Context, arg db.GetActivePriceScheduleParams) (db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePriceSchedule", ctx, arg)
+	ret0, _ := ret[0].(db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePriceSchedule indicates an expected call of GetActivePriceSchedule.
+func (mr *MockQuerierMockRecorder) GetActivePriceSchedule(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePriceSchedule", reflect.TypeOf((*MockQuerier)(nil).GetActivePriceSchedule), ctx, arg)
+}
+
+// GetApiKeyByHash mocks base method.
+func (m *MockQuerier) GetApiKeyByHash(ctx context.Context, keyHash string) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByHash indicates an expected call of GetApiKeyByHash.
+func (mr *MockQuerierMockRecorder) GetApiKeyByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByHash", reflect.TypeOf((*MockQuerier)(nil).GetApiKeyByHash), ctx, keyHash)
+}
+
+// GetAuction mocks base method.
+func (m *MockQuerier) GetAuction(ctx context.Context, auctionID int32) (db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", ctx, auctionID)
+	ret0, _ := ret[0].(db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockQuerierMockRecorder) GetAuction(ctx, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockQuerier)(nil).GetAuction), ctx, auctionID)
+}
+
+// GetAuctionForUpdate mocks base method.
+func (m *MockQuerier) GetAuctionForUpdate(ctx context.Context, auctionID int32) (db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuctionForUpdate", ctx, auctionID)
+	ret0, _ := ret[0].(db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuctionForUpdate indicates an expected call of GetAuctionForUpdate.
+func (mr *MockQuerierMockRecorder) GetAuctionForUpdate(ctx, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuctionForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetAuctionForUpdate), ctx, auctionID)
+}
+
+// GetBundle mocks base method.
+func (m *MockQuerier) GetBundle(ctx context.Context, name string) (db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundle", ctx, name)
+	ret0, _ := ret[0].(db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundle indicates an expected call of GetBundle.
+func (mr *MockQuerierMockRecorder) GetBundle(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundle", reflect.TypeOf((*MockQuerier)(nil).GetBundle), ctx, name)
+}
+
+// GetBundleItems mocks base method.
+func (m *MockQuerier) GetBundleItems(ctx context.Context, bundleName string) ([]db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleItems", ctx, bundleName)
+	ret0, _ := ret[0].([]db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundleItems indicates an expected call of GetBundleItems.
+func (mr *MockQuerierMockRecorder) GetBundleItems(ctx, bundleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleItems", reflect.TypeOf((*MockQuerier)(nil).GetBundleItems), ctx, bundleName)
+}
+
+// GetCoinExpirations mocks base method.
+func (m *MockQuerier) GetCoinExpirations(ctx context.Context, username string) ([]db.CoinExpiration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinExpirations", ctx, username)
+	ret0, _ := ret[0].([]db.CoinExpiration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinExpirations indicates an expected call of GetCoinExpirations.
+func (mr *MockQuerierMockRecorder) GetCoinExpirations(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinExpirations", reflect.TypeOf((*MockQuerier)(nil).GetCoinExpirations), ctx, username)
+}
+
+// GetCoinLotsForUpdate mocks base method.
+func (m *MockQuerier) GetCoinLotsForUpdate(ctx context.Context, userID int32) ([]db.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinLotsForUpdate", ctx, userID)
+	ret0, _ := ret[0].([]db.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinLotsForUpdate indicates an expected call of GetCoinLotsForUpdate.
+func (mr *MockQuerierMockRecorder) GetCoinLotsForUpdate(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinLotsForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetCoinLotsForUpdate), ctx, userID)
+}
+
+// GetDueRaffles mocks base method.
+func (m *MockQuerier) GetDueRaffles(ctx context.Context, drawsAt time.Time) ([]db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRaffles", ctx, drawsAt)
+	ret0, _ := ret[0].([]db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRaffles indicates an expected call of GetDueRaffles.
+func (mr *MockQuerierMockRecorder) GetDueRaffles(ctx, drawsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRaffles", reflect.TypeOf((*MockQuerier)(nil).GetDueRaffles), ctx, drawsAt)
+}
+
+// GetEndedAuctions mocks base method.
+func (m *MockQuerier) GetEndedAuctions(ctx context.Context, endsAt time.Time) ([]db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndedAuctions", ctx, endsAt)
+	ret0, _ := ret[0].([]db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndedAuctions indicates an expected call of GetEndedAuctions.
+func (mr *MockQuerierMockRecorder) GetEndedAuctions(ctx, endsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndedAuctions", reflect.TypeOf((*MockQuerier)(nil).GetEndedAuctions), ctx, endsAt)
+}
+
+// GetExpiredPreorderBatches mocks base method.
+func (m *MockQuerier) GetExpiredPreorderBatches(ctx context.Context, expiresAt time.Time) ([]db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredPreorderBatches", ctx, expiresAt)
+	ret0, _ := ret[0].([]db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredPreorderBatches indicates an expected call of GetExpiredPreorderBatches.
+func (mr *MockQuerierMockRecorder) GetExpiredPreorderBatches(ctx, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredPreorderBatches", reflect.TypeOf((*MockQuerier)(nil).GetExpiredPreorderBatches), ctx, expiresAt)
+}
+
+// GetExpiredTransferApprovals mocks base method.
+func (m *MockQuerier) GetExpiredTransferApprovals(ctx context.Context, expiresAt time.Time) ([]db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredTransferApprovals", ctx, expiresAt)
+	ret0, _ := ret[0].([]db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredTransferApprovals indicates an expected call of GetExpiredTransferApprovals.
+func (mr *MockQuerierMockRecorder) GetExpiredTransferApprovals(ctx, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredTransferApprovals", reflect.TypeOf((*MockQuerier)(nil).GetExpiredTransferApprovals), ctx, expiresAt)
+}
+
+// GetExpiringCoinLots mocks base method.
+func (m *MockQuerier) GetExpiringCoinLots(ctx context.Context, arg db.GetExpiringCoinLotsParams) ([]db.CoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiringCoinLots", ctx, arg)
+	ret0, _ := ret[0].([]db.CoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiringCoinLots indicates an expected call of GetExpiringCoinLots.
+func (mr *MockQuerierMockRecorder) GetExpiringCoinLots(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiringCoinLots", reflect.TypeOf((*MockQuerier)(nil).GetExpiringCoinLots), ctx, arg)
+}
+
+// GetExternalIdentity mocks base method.
+func (m *MockQuerier) GetExternalIdentity(ctx context.Context, arg db.GetExternalIdentityParams) (db.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalIdentity", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalIdentity indicates an expected call of GetExternalIdentity.
+func (mr *MockQuerierMockRecorder) GetExternalIdentity(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalIdentity", reflect.TypeOf((*MockQuerier)(nil).GetExternalIdentity), ctx, arg)
+}
+
+// GetFraudCaseForUpdate mocks base method.
+func (m *MockQuerier) GetFraudCaseForUpdate(ctx context.Context, caseID int32) (db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudCaseForUpdate", ctx, caseID)
+	ret0, _ := ret[0].(db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudCaseForUpdate indicates an expected call of GetFraudCaseForUpdate.
+func (mr *MockQuerierMockRecorder) GetFraudCaseForUpdate(ctx, caseID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudCaseForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetFraudCaseForUpdate), ctx, caseID)
+}
+
+// GetGiftsWithUser mocks base method.
+func (m *MockQuerier) GetGiftsWithUser(ctx context.Context, username string) ([]db.Gift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftsWithUser", ctx, username)
+	ret0, _ := ret[0].([]db.Gift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftsWithUser indicates an expected call of GetGiftsWithUser.
+func (mr *MockQuerierMockRecorder) GetGiftsWithUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftsWithUser", reflect.TypeOf((*MockQuerier)(nil).GetGiftsWithUser), ctx, username)
+}
+
+// GetInventory mocks base method.
+func (m *MockQuerier) GetInventory(ctx context.Context, userID int32) ([]db.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", ctx, userID)
+	ret0, _ := ret[0].([]db.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockQuerierMockRecorder) GetInventory(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockQuerier)(nil).GetInventory), ctx, userID)
+}
+
+// GetInventoryItem mocks base method.
+func (m *MockQuerier) GetInventoryItem(ctx context.Context, arg db.GetInventoryItemParams) (db.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryItem", ctx, arg)
+	ret0, _ := ret[0].(db.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryItem indicates an expected call of GetInventoryItem.
+func (mr *MockQuerierMockRecorder) GetInventoryItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItem", reflect.TypeOf((*MockQuerier)(nil).GetInventoryItem), ctx, arg)
+}
+
+// GetItemFromStore mocks base method.
+func (m *MockQuerier) GetItemFromStore(ctx context.Context, itemType string) (db.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemFromStore", ctx, itemType)
+	ret0, _ := ret[0].(db.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemFromStore indicates an expected call of GetItemFromStore.
+func (mr *MockQuerierMockRecorder) GetItemFromStore(ctx, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemFromStore", reflect.TypeOf((*MockQuerier)(nil).GetItemFromStore), ctx, itemType)
+}
+
+// GetItemTransfersWithUser mocks base method.
+func (m *MockQuerier) GetItemTransfersWithUser(ctx context.Context, username string) ([]db.ItemTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemTransfersWithUser", ctx, username)
+	ret0, _ := ret[0].([]db.ItemTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemTransfersWithUser indicates an expected call of GetItemTransfersWithUser.
+func (mr *MockQuerierMockRecorder) GetItemTransfersWithUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemTransfersWithUser", reflect.TypeOf((*MockQuerier)(nil).GetItemTransfersWithUser), ctx, username)
+}
+
+// GetListing mocks base method.
+func (m *MockQuerier) GetListing(ctx context.Context, listingID int32) (db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListing", ctx, listingID)
+	ret0, _ := ret[0].(db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListing indicates an expected call of GetListing.
+func (mr *MockQuerierMockRecorder) GetListing(ctx, listingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListing", reflect.TypeOf((*MockQuerier)(nil).GetListing), ctx, listingID)
+}
+
+// GetOrderCoinLots mocks base method.
+func (m *MockQuerier) GetOrderCoinLots(ctx context.Context, orderID int32) ([]db.OrderCoinLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderCoinLots", ctx, orderID)
+	ret0, _ := ret[0].([]db.OrderCoinLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderCoinLots indicates an expected call of GetOrderCoinLots.
+func (mr *MockQuerierMockRecorder) GetOrderCoinLots(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderCoinLots", reflect.TypeOf((*MockQuerier)(nil).GetOrderCoinLots), ctx, orderID)
+}
+
+// GetOrderForUpdate mocks base method.
+func (m *MockQuerier) GetOrderForUpdate(ctx context.Context, orderID int32) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderForUpdate", ctx, orderID)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderForUpdate indicates an expected call of GetOrderForUpdate.
+func (mr *MockQuerierMockRecorder) GetOrderForUpdate(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetOrderForUpdate), ctx, orderID)
+}
+
+// GetPendingPreorders mocks base method.
+func (m *MockQuerier) GetPendingPreorders(ctx context.Context, batchID int32) ([]db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPreorders", ctx, batchID)
+	ret0, _ := ret[0].([]db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPreorders indicates an expected call of GetPendingPreorders.
+func (mr *MockQuerierMockRecorder) GetPendingPreorders(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPreorders", reflect.TypeOf((*MockQuerier)(nil).GetPendingPreorders), ctx, batchID)
+}
+
+// GetPreorderBatch mocks base method.
+func (m *MockQuerier) GetPreorderBatch(ctx context.Context, batchID int32) (db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorderBatch", ctx, batchID)
+	ret0, _ := ret[0].(db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorderBatch indicates an expected call of GetPreorderBatch.
+func (mr *MockQuerierMockRecorder) GetPreorderBatch(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorderBatch", reflect.TypeOf((*MockQuerier)(nil).GetPreorderBatch), ctx, batchID)
+}
+
+// GetPreorderBatchForUpdate mocks base method.
+func (m *MockQuerier) GetPreorderBatchForUpdate(ctx context.Context, batchID int32) (db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorderBatchForUpdate", ctx, batchID)
+	ret0, _ := ret[0].(db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorderBatchForUpdate indicates an expected call of GetPreorderBatchForUpdate.
+func (mr *MockQuerierMockRecorder) GetPreorderBatchForUpdate(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorderBatchForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetPreorderBatchForUpdate), ctx, batchID)
+}
+
+// GetPreorderForUpdate mocks base method.
+func (m *MockQuerier) GetPreorderForUpdate(ctx context.Context, preorderID int32) (db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorderForUpdate", ctx, preorderID)
+	ret0, _ := ret[0].(db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorderForUpdate indicates an expected call of GetPreorderForUpdate.
+func (mr *MockQuerierMockRecorder) GetPreorderForUpdate(ctx, preorderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorderForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetPreorderForUpdate), ctx, preorderID)
+}
+
+// GetPromoCode mocks base method.
+func (m *MockQuerier) GetPromoCode(ctx context.Context, code string) (db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCode", ctx, code)
+	ret0, _ := ret[0].(db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCode indicates an expected call of GetPromoCode.
+func (mr *MockQuerierMockRecorder) GetPromoCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCode", reflect.TypeOf((*MockQuerier)(nil).GetPromoCode), ctx, code)
+}
+
+// GetPurchaseLimit mocks base method.
+func (m *MockQuerier) GetPurchaseLimit(ctx context.Context, itemType string) (db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseLimit", ctx, itemType)
+	ret0, _ := ret[0].(db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseLimit indicates an expected call of GetPurchaseLimit.
+func (mr *MockQuerierMockRecorder) GetPurchaseLimit(ctx, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseLimit", reflect.TypeOf((*MockQuerier)(nil).GetPurchaseLimit), ctx, itemType)
+}
+
+// GetRaffle mocks base method.
+func (m *MockQuerier) GetRaffle(ctx context.Context, raffleID int32) (db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffle", ctx, raffleID)
+	ret0, _ := ret[0].(db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffle indicates an expected call of GetRaffle.
+func (mr *MockQuerierMockRecorder) GetRaffle(ctx, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffle", reflect.TypeOf((*MockQuerier)(nil).GetRaffle), ctx, raffleID)
+}
+
+// GetRaffleForUpdate mocks base method.
+func (m *MockQuerier) GetRaffleForUpdate(ctx context.Context, raffleID int32) (db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffleForUpdate", ctx, raffleID)
+	ret0, _ := ret[0].(db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffleForUpdate indicates an expected call of GetRaffleForUpdate.
+func (mr *MockQuerierMockRecorder) GetRaffleForUpdate(ctx, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffleForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetRaffleForUpdate), ctx, raffleID)
+}
+
+// GetRecipientsSince mocks base method.
+func (m *MockQuerier) GetRecipientsSince(ctx context.Context, arg db.GetRecipientsSinceParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipientsSince", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipientsSince indicates an expected call of GetRecipientsSince.
+func (mr *MockQuerierMockRecorder) GetRecipientsSince(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipientsSince", reflect.TypeOf((*MockQuerier)(nil).GetRecipientsSince), ctx, arg)
+}
+
+// GetReservedTransferAmount mocks base method.
+func (m *MockQuerier) GetReservedTransferAmount(ctx context.Context, fromUsername string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedTransferAmount", ctx, fromUsername)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedTransferAmount indicates an expected call of GetReservedTransferAmount.
+func (mr *MockQuerierMockRecorder) GetReservedTransferAmount(ctx, fromUsername interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedTransferAmount", reflect.TypeOf((*MockQuerier)(nil).GetReservedTransferAmount), ctx, fromUsername)
+}
+
+// GetReservedTransferToUserAmount mocks base method.
+func (m *MockQuerier) GetReservedTransferToUserAmount(ctx context.Context, arg db.GetReservedTransferToUserAmountParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedTransferToUserAmount", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedTransferToUserAmount indicates an expected call of GetReservedTransferToUserAmount.
+func (mr *MockQuerierMockRecorder) GetReservedTransferToUserAmount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedTransferToUserAmount", reflect.TypeOf((*MockQuerier)(nil).GetReservedTransferToUserAmount), ctx, arg)
+}
+
+// GetSentAmountSince mocks base method.
+func (m *MockQuerier) GetSentAmountSince(ctx context.Context, arg db.GetSentAmountSinceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentAmountSince", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentAmountSince indicates an expected call of GetSentAmountSince.
+func (mr *MockQuerierMockRecorder) GetSentAmountSince(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentAmountSince", reflect.TypeOf((*MockQuerier)(nil).GetSentAmountSince), ctx, arg)
+}
+
+// GetSentToUserAmountSince mocks base method.
+func (m *MockQuerier) GetSentToUserAmountSince(ctx context.Context, arg db.GetSentToUserAmountSinceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentToUserAmountSince", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentToUserAmountSince indicates an expected call of GetSentToUserAmountSince.
+func (mr *MockQuerierMockRecorder) GetSentToUserAmountSince(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentToUserAmountSince", reflect.TypeOf((*MockQuerier)(nil).GetSentToUserAmountSince), ctx, arg)
+}
+
+// GetTransferApprovalForUpdate mocks base method.
+func (m *MockQuerier) GetTransferApprovalForUpdate(ctx context.Context, approvalID int32) (db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferApprovalForUpdate", ctx, approvalID)
+	ret0, _ := ret[0].(db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferApprovalForUpdate indicates an expected call of GetTransferApprovalForUpdate.
+func (mr *MockQuerierMockRecorder) GetTransferApprovalForUpdate(ctx, approvalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferApprovalForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetTransferApprovalForUpdate), ctx, approvalID)
+}
+
+// GetTransferLimitOverride mocks base method.
+func (m *MockQuerier) GetTransferLimitOverride(ctx context.Context, username string) (db.TransferLimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferLimitOverride", ctx, username)
+	ret0, _ := ret[0].(db.TransferLimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferLimitOverride indicates an expected call of GetTransferLimitOverride.
+func (mr *MockQuerierMockRecorder) GetTransferLimitOverride(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferLimitOverride", reflect.TypeOf((*MockQuerier)(nil).GetTransferLimitOverride), ctx, username)
+}
+
+// GetTransfersWithUser mocks base method.
+func (m *MockQuerier) GetTransfersWithUser(ctx context.Context, username string) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfersWithUser", ctx, username)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersWithUser indicates an expected call of GetTransfersWithUser.
+func (mr *MockQuerierMockRecorder) GetTransfersWithUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersWithUser", reflect.TypeOf((*MockQuerier)(nil).GetTransfersWithUser), ctx, username)
+}
+
+// GetUser mocks base method.
+func (m *MockQuerier) GetUser(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockQuerierMockRecorder) GetUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockQuerier)(nil).GetUser), ctx, username)
+}
+
+// GetUserForUpdate mocks base method.
+func (m *MockQuerier) GetUserForUpdate(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserForUpdate", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserForUpdate indicates an expected call of GetUserForUpdate.
+func (mr *MockQuerierMockRecorder) GetUserForUpdate(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetUserForUpdate), ctx, username)
+}
+
+// GetUserViaID mocks base method.
+func (m *MockQuerier) GetUserViaID(ctx context.Context, userID int32) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserViaID", ctx, userID)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserViaID indicates an expected call of GetUserViaID.
+func (mr *MockQuerierMockRecorder) GetUserViaID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserViaID", reflect.TypeOf((*MockQuerier)(nil).GetUserViaID), ctx, userID)
+}
+
+// HoldUserCoins mocks base method.
+func (m *MockQuerier) HoldUserCoins(ctx context.Context, arg db.HoldUserCoinsParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldUserCoins", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldUserCoins indicates an expected call of HoldUserCoins.
+func (mr *MockQuerierMockRecorder) HoldUserCoins(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldUserCoins", reflect.TypeOf((*MockQuerier)(nil).HoldUserCoins), ctx, arg)
+}
+
+// InvalidatePasswordResets mocks base method.
+func (m *MockQuerier) InvalidatePasswordResets(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResets", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResets indicates an expected call of InvalidatePasswordResets.
+func (mr *MockQuerierMockRecorder) InvalidatePasswordResets(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResets", reflect.TypeOf((*MockQuerier)(nil).InvalidatePasswordResets), ctx, username)
+}
+
+// ListActiveBundleItems mocks base method.
+func (m *MockQuerier) ListActiveBundleItems(ctx context.Context) ([]db.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBundleItems", ctx)
+	ret0, _ := ret[0].([]db.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBundleItems indicates an expected call of ListActiveBundleItems.
+func (mr *MockQuerierMockRecorder) ListActiveBundleItems(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBundleItems", reflect.TypeOf((*MockQuerier)(nil).ListActiveBundleItems), ctx)
+}
+
+// ListActiveBundles mocks base method.
+func (m *MockQuerier) ListActiveBundles(ctx context.Context) ([]db.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBundles", ctx)
+	ret0, _ := ret[0].([]db.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBundles indicates an expected call of ListActiveBundles.
+func (mr *MockQuerierMockRecorder) ListActiveBundles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBundles", reflect.TypeOf((*MockQuerier)(nil).ListActiveBundles), ctx)
+}
+
+// ListActiveListings mocks base method.
+func (m *MockQuerier) ListActiveListings(ctx context.Context, arg db.ListActiveListingsParams) ([]db.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveListings", ctx, arg)
+	ret0, _ := ret[0].([]db.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveListings indicates an expected call of ListActiveListings.
+func (mr *MockQuerierMockRecorder) ListActiveListings(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveListings", reflect.TypeOf((*MockQuerier)(nil).ListActiveListings), ctx, arg)
+}
+
+// ListActivePriceSchedules mocks base method.
+func (m *MockQuerier) ListActivePriceSchedules(ctx context.Context, now time.Time) ([]db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePriceSchedules", ctx, now)
+	ret0, _ := ret[0].([]db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePriceSchedules indicates an expected call of ListActivePriceSchedules.
+func (mr *MockQuerierMockRecorder) ListActivePriceSchedules(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePriceSchedules", reflect.TypeOf((*MockQuerier)(nil).ListActivePriceSchedules), ctx, now)
+}
+
+// ListAllItemVariants mocks base method.
+func (m *MockQuerier) ListAllItemVariants(ctx context.Context) ([]db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllItemVariants", ctx)
+	ret0, _ := ret[0].([]db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllItemVariants indicates an expected call of ListAllItemVariants.
+func (mr *MockQuerierMockRecorder) ListAllItemVariants(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllItemVariants", reflect.TypeOf((*MockQuerier)(nil).ListAllItemVariants), ctx)
+}
+
+// ListApiKeys mocks base method.
+func (m *MockQuerier) ListApiKeys(ctx context.Context) ([]db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApiKeys", ctx)
+	ret0, _ := ret[0].([]db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApiKeys indicates an expected call of ListApiKeys.
+func (mr *MockQuerierMockRecorder) ListApiKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeys", reflect.TypeOf((*MockQuerier)(nil).ListApiKeys), ctx)
+}
+
+// ListFraudCases mocks base method.
+func (m *MockQuerier) ListFraudCases(ctx context.Context, status string) ([]db.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFraudCases", ctx, status)
+	ret0, _ := ret[0].([]db.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudCases indicates an expected call of ListFraudCases.
+func (mr *MockQuerierMockRecorder) ListFraudCases(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudCases", reflect.TypeOf((*MockQuerier)(nil).ListFraudCases), ctx, status)
+}
+
+// ListItemVariants mocks base method.
+func (m *MockQuerier) ListItemVariants(ctx context.Context, itemType string) ([]db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemVariants", ctx, itemType)
+	ret0, _ := ret[0].([]db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemVariants indicates an expected call of ListItemVariants.
+func (mr *MockQuerierMockRecorder) ListItemVariants(ctx, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemVariants", reflect.TypeOf((*MockQuerier)(nil).ListItemVariants), ctx, itemType)
+}
+
+// ListItems mocks base method.
+func (m *MockQuerier) ListItems(ctx context.Context) ([]db.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx)
+	ret0, _ := ret[0].([]db.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockQuerierMockRecorder) ListItems(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockQuerier)(nil).ListItems), ctx)
+}
+
+// ListNotifications mocks base method.
+func (m *MockQuerier) ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, arg)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockQuerierMockRecorder) ListNotifications(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockQuerier)(nil).ListNotifications), ctx, arg)
+}
+
+// ListOpenAuctions mocks base method.
+func (m *MockQuerier) ListOpenAuctions(ctx context.Context) ([]db.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenAuctions", ctx)
+	ret0, _ := ret[0].([]db.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenAuctions indicates an expected call of ListOpenAuctions.
+func (mr *MockQuerierMockRecorder) ListOpenAuctions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenAuctions", reflect.TypeOf((*MockQuerier)(nil).ListOpenAuctions), ctx)
+}
+
+// ListOpenPreorderBatches mocks base method.
+func (m *MockQuerier) ListOpenPreorderBatches(ctx context.Context) ([]db.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenPreorderBatches", ctx)
+	ret0, _ := ret[0].([]db.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenPreorderBatches indicates an expected call of ListOpenPreorderBatches.
+func (mr *MockQuerierMockRecorder) ListOpenPreorderBatches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenPreorderBatches", reflect.TypeOf((*MockQuerier)(nil).ListOpenPreorderBatches), ctx)
+}
+
+// ListOrders mocks base method.
+func (m *MockQuerier) ListOrders(ctx context.Context, status string) ([]db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, status)
+	ret0, _ := ret[0].([]db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockQuerierMockRecorder) ListOrders(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockQuerier)(nil).ListOrders), ctx, status)
+}
+
+// ListPriceSchedules mocks base method.
+func (m *MockQuerier) ListPriceSchedules(ctx context.Context, itemType string) ([]db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceSchedules", ctx, itemType)
+	ret0, _ := ret[0].([]db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceSchedules indicates an expected call of ListPriceSchedules.
+func (mr *MockQuerierMockRecorder) ListPriceSchedules(ctx, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceSchedules", reflect.TypeOf((*MockQuerier)(nil).ListPriceSchedules), ctx, itemType)
+}
+
+// ListPromoCodes mocks base method.
+func (m *MockQuerier) ListPromoCodes(ctx context.Context) ([]db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromoCodes", ctx)
+	ret0, _ := ret[0].([]db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromoCodes indicates an expected call of ListPromoCodes.
+func (mr *MockQuerierMockRecorder) ListPromoCodes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromoCodes", reflect.TypeOf((*MockQuerier)(nil).ListPromoCodes), ctx)
+}
+
+// ListPurchaseLimits mocks base method.
+func (m *MockQuerier) ListPurchaseLimits(ctx context.Context) ([]db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseLimits", ctx)
+	ret0, _ := ret[0].([]db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseLimits indicates an expected call of ListPurchaseLimits.
+func (mr *MockQuerierMockRecorder) ListPurchaseLimits(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseLimits", reflect.TypeOf((*MockQuerier)(nil).ListPurchaseLimits), ctx)
+}
+
+// ListRaffleTickets mocks base method.
+func (m *MockQuerier) ListRaffleTickets(ctx context.Context, raffleID int32) ([]db.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRaffleTickets", ctx, raffleID)
+	ret0, _ := ret[0].([]db.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRaffleTickets indicates an expected call of ListRaffleTickets.
+func (mr *MockQuerierMockRecorder) ListRaffleTickets(ctx, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRaffleTickets", reflect.TypeOf((*MockQuerier)(nil).ListRaffleTickets), ctx, raffleID)
+}
+
+// ListRaffles mocks base method.
+func (m *MockQuerier) ListRaffles(ctx context.Context) ([]db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRaffles", ctx)
+	ret0, _ := ret[0].([]db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRaffles indicates an expected call of ListRaffles.
+func (mr *MockQuerierMockRecorder) ListRaffles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRaffles", reflect.TypeOf((*MockQuerier)(nil).ListRaffles), ctx)
+}
+
+// ListTransferApprovals mocks base method.
+func (m *MockQuerier) ListTransferApprovals(ctx context.Context, status string) ([]db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferApprovals", ctx, status)
+	ret0, _ := ret[0].([]db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferApprovals indicates an expected call of ListTransferApprovals.
+func (mr *MockQuerierMockRecorder) ListTransferApprovals(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferApprovals", reflect.TypeOf((*MockQuerier)(nil).ListTransferApprovals), ctx, status)
+}
+
+// ListUserOrders mocks base method.
+func (m *MockQuerier) ListUserOrders(ctx context.Context, username string) ([]db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOrders", ctx, username)
+	ret0, _ := ret[0].([]db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOrders indicates an expected call of ListUserOrders.
+func (mr *MockQuerierMockRecorder) ListUserOrders(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrders", reflect.TypeOf((*MockQuerier)(nil).ListUserOrders), ctx, username)
+}
+
+// ListUserPreorders mocks base method.
+func (m *MockQuerier) ListUserPreorders(ctx context.Context, username string) ([]db.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPreorders", ctx, username)
+	ret0, _ := ret[0].([]db.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPreorders indicates an expected call of ListUserPreorders.
+func (mr *MockQuerierMockRecorder) ListUserPreorders(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPreorders", reflect.TypeOf((*MockQuerier)(nil).ListUserPreorders), ctx, username)
+}
+
+// ListWishlist mocks base method.
+func (m *MockQuerier) ListWishlist(ctx context.Context, username string) ([]db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWishlist", ctx, username)
+	ret0, _ := ret[0].([]db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWishlist indicates an expected call of ListWishlist.
+func (mr *MockQuerierMockRecorder) ListWishlist(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWishlist", reflect.TypeOf((*MockQuerier)(nil).ListWishlist), ctx, username)
+}
+
+// ListWishlistsWithCoins mocks base method.
+func (m *MockQuerier) ListWishlistsWithCoins(ctx context.Context) ([]db.ListWishlistsWithCoinsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWishlistsWithCoins", ctx)
+	ret0, _ := ret[0].([]db.ListWishlistsWithCoinsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWishlistsWithCoins indicates an expected call of ListWishlistsWithCoins.
+func (mr *MockQuerierMockRecorder) ListWishlistsWithCoins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWishlistsWithCoins", reflect.TypeOf((*MockQuerier)(nil).ListWishlistsWithCoins), ctx)
+}
+
+// LockTwoUsers mocks base method.
+func (m *MockQuerier) LockTwoUsers(ctx context.Context, arg db.LockTwoUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTwoUsers", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTwoUsers indicates an expected call of LockTwoUsers.
+func (mr *MockQuerierMockRecorder) LockTwoUsers(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTwoUsers", reflect.TypeOf((*MockQuerier)(nil).LockTwoUsers), ctx, arg)
+}
+
+// LockUsersWithExpiredLots mocks base method.
+func (m *MockQuerier) LockUsersWithExpiredLots(ctx context.Context, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUsersWithExpiredLots", ctx, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BuyItem indicates an expected call of BuyItem.
-func (mr *MockQuerierMockRecorder) BuyItem(ctx, arg interface{}) *gomock.Call {
+// LockUsersWithExpiredLots indicates an expected call of LockUsersWithExpiredLots.
+func (mr *MockQuerierMockRecorder) LockUsersWithExpiredLots(ctx, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyItem", reflect.TypeOf((*MockQuerier)(nil).BuyItem), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUsersWithExpiredLots", reflect.TypeOf((*MockQuerier)(nil).LockUsersWithExpiredLots), ctx, expiresAt)
 }
 
-// CreateMoneyTransfer mocks base method.
-func (m *MockQuerier) CreateMoneyTransfer(ctx context.Context, arg db.CreateMoneyTransferParams) (db.Transfer, error) {
+// MarkNotificationsRead mocks base method.
+func (m *MockQuerier) MarkNotificationsRead(ctx context.Context, username string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMoneyTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, username)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateMoneyTransfer indicates an expected call of CreateMoneyTransfer.
-func (mr *MockQuerierMockRecorder) CreateMoneyTransfer(ctx, arg interface{}) *gomock.Call {
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockQuerierMockRecorder) MarkNotificationsRead(ctx, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMoneyTransfer", reflect.TypeOf((*MockQuerier)(nil).CreateMoneyTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockQuerier)(nil).MarkNotificationsRead), ctx, username)
 }
 
-// CreateUser mocks base method.
-func (m *MockQuerier) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+// ReleasePromoCodeUse mocks base method.
+func (m *MockQuerier) ReleasePromoCodeUse(ctx context.Context, arg db.ReleasePromoCodeUseParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret := m.ctrl.Call(m, "ReleasePromoCodeUse", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleasePromoCodeUse indicates an expected call of ReleasePromoCodeUse.
+func (mr *MockQuerierMockRecorder) ReleasePromoCodeUse(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePromoCodeUse", reflect.TypeOf((*MockQuerier)(nil).ReleasePromoCodeUse), ctx, arg)
+}
+
+// ReleaseUserCoins mocks base method.
+func (m *MockQuerier) ReleaseUserCoins(ctx context.Context, arg db.ReleaseUserCoinsParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseUserCoins", ctx, arg)
 	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockQuerierMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+// ReleaseUserCoins indicates an expected call of ReleaseUserCoins.
+func (mr *MockQuerierMockRecorder) ReleaseUserCoins(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockQuerier)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseUserCoins", reflect.TypeOf((*MockQuerier)(nil).ReleaseUserCoins), ctx, arg)
 }
 
-// GetInventory mocks base method.
-func (m *MockQuerier) GetInventory(ctx context.Context, userID int32) ([]db.Inventory, error) {
+// RemoveWishlistItem mocks base method.
+func (m *MockQuerier) RemoveWishlistItem(ctx context.Context, arg db.RemoveWishlistItemParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInventory", ctx, userID)
-	ret0, _ := ret[0].([]db.Inventory)
+	ret := m.ctrl.Call(m, "RemoveWishlistItem", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInventory indicates an expected call of GetInventory.
-func (mr *MockQuerierMockRecorder) GetInventory(ctx, userID interface{}) *gomock.Call {
+// RemoveWishlistItem indicates an expected call of RemoveWishlistItem.
+func (mr *MockQuerierMockRecorder) RemoveWishlistItem(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockQuerier)(nil).GetInventory), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWishlistItem", reflect.TypeOf((*MockQuerier)(nil).RemoveWishlistItem), ctx, arg)
 }
 
-// GetItemFromStore mocks base method.
-func (m *MockQuerier) GetItemFromStore(ctx context.Context, itemType string) (db.Item, error) {
+// ResolveFraudCase mocks base method.
+func (m *MockQuerier) ResolveFraudCase(ctx context.Context, arg db.ResolveFraudCaseParams) (db.FraudCase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemFromStore", ctx, itemType)
-	ret0, _ := ret[0].(db.Item)
+	ret := m.ctrl.Call(m, "ResolveFraudCase", ctx, arg)
+	ret0, _ := ret[0].(db.FraudCase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItemFromStore indicates an expected call of GetItemFromStore.
-func (mr *MockQuerierMockRecorder) GetItemFromStore(ctx, itemType interface{}) *gomock.Call {
+// ResolveFraudCase indicates an expected call of ResolveFraudCase.
+func (mr *MockQuerierMockRecorder) ResolveFraudCase(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemFromStore", reflect.TypeOf((*MockQuerier)(nil).GetItemFromStore), ctx, itemType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveFraudCase", reflect.TypeOf((*MockQuerier)(nil).ResolveFraudCase), ctx, arg)
 }
 
-// GetTransfersWithUser mocks base method.
-func (m *MockQuerier) GetTransfersWithUser(ctx context.Context, username string) ([]db.Transfer, error) {
+// ResolveTransferApproval mocks base method.
+func (m *MockQuerier) ResolveTransferApproval(ctx context.Context, arg db.ResolveTransferApprovalParams) (db.TransferApproval, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfersWithUser", ctx, username)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "ResolveTransferApproval", ctx, arg)
+	ret0, _ := ret[0].(db.TransferApproval)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfersWithUser indicates an expected call of GetTransfersWithUser.
-func (mr *MockQuerierMockRecorder) GetTransfersWithUser(ctx, username interface{}) *gomock.Call {
+// ResolveTransferApproval indicates an expected call of ResolveTransferApproval.
+func (mr *MockQuerierMockRecorder) ResolveTransferApproval(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersWithUser", reflect.TypeOf((*MockQuerier)(nil).GetTransfersWithUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTransferApproval", reflect.TypeOf((*MockQuerier)(nil).ResolveTransferApproval), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockQuerier) GetUser(ctx context.Context, username string) (db.User, error) {
+// RevokeApiKey mocks base method.
+func (m *MockQuerier) RevokeApiKey(ctx context.Context, keyID int32) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, username)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RevokeApiKey", ctx, keyID)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockQuerierMockRecorder) GetUser(ctx, username interface{}) *gomock.Call {
+// RevokeApiKey indicates an expected call of RevokeApiKey.
+func (mr *MockQuerierMockRecorder) RevokeApiKey(ctx, keyID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockQuerier)(nil).GetUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeApiKey", reflect.TypeOf((*MockQuerier)(nil).RevokeApiKey), ctx, keyID)
 }
 
-// GetUserForUpdate mocks base method.
-func (m *MockQuerier) GetUserForUpdate(ctx context.Context, username string) (db.User, error) {
+// SetBidStatus mocks base method.
+func (m *MockQuerier) SetBidStatus(ctx context.Context, arg db.SetBidStatusParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserForUpdate", ctx, username)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SetBidStatus", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBidStatus indicates an expected call of SetBidStatus.
+func (mr *MockQuerierMockRecorder) SetBidStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockQuerier)(nil).SetBidStatus), ctx, arg)
+}
+
+// SetOrderPickupLocation mocks base method.
+func (m *MockQuerier) SetOrderPickupLocation(ctx context.Context, arg db.SetOrderPickupLocationParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderPickupLocation", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserForUpdate indicates an expected call of GetUserForUpdate.
-func (mr *MockQuerierMockRecorder) GetUserForUpdate(ctx, username interface{}) *gomock.Call {
+// SetOrderPickupLocation indicates an expected call of SetOrderPickupLocation.
+func (mr *MockQuerierMockRecorder) SetOrderPickupLocation(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockQuerier)(nil).GetUserForUpdate), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderPickupLocation", reflect.TypeOf((*MockQuerier)(nil).SetOrderPickupLocation), ctx, arg)
 }
 
-// GetUserViaID mocks base method.
-func (m *MockQuerier) GetUserViaID(ctx context.Context, userID int32) (db.User, error) {
+// SetPreorderStatus mocks base method.
+func (m *MockQuerier) SetPreorderStatus(ctx context.Context, arg db.SetPreorderStatusParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserViaID", ctx, userID)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SetPreorderStatus", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserViaID indicates an expected call of GetUserViaID.
-func (mr *MockQuerierMockRecorder) GetUserViaID(ctx, userID interface{}) *gomock.Call {
+// SetPreorderStatus indicates an expected call of SetPreorderStatus.
+func (mr *MockQuerierMockRecorder) SetPreorderStatus(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserViaID", reflect.TypeOf((*MockQuerier)(nil).GetUserViaID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreorderStatus", reflect.TypeOf((*MockQuerier)(nil).SetPreorderStatus), ctx, arg)
+}
+
+// SetRaffleDrawn mocks base method.
+func (m *MockQuerier) SetRaffleDrawn(ctx context.Context, raffleID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRaffleDrawn", ctx, raffleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRaffleDrawn indicates an expected call of SetRaffleDrawn.
+func (mr *MockQuerierMockRecorder) SetRaffleDrawn(ctx, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRaffleDrawn", reflect.TypeOf((*MockQuerier)(nil).SetRaffleDrawn), ctx, raffleID)
+}
+
+// SetRaffleTicketWon mocks base method.
+func (m *MockQuerier) SetRaffleTicketWon(ctx context.Context, ticketID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRaffleTicketWon", ctx, ticketID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRaffleTicketWon indicates an expected call of SetRaffleTicketWon.
+func (mr *MockQuerierMockRecorder) SetRaffleTicketWon(ctx, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRaffleTicketWon", reflect.TypeOf((*MockQuerier)(nil).SetRaffleTicketWon), ctx, ticketID)
+}
+
+// TakeInventoryItems mocks base method.
+func (m *MockQuerier) TakeInventoryItems(ctx context.Context, arg db.TakeInventoryItemsParams) (db.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeInventoryItems", ctx, arg)
+	ret0, _ := ret[0].(db.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeInventoryItems indicates an expected call of TakeInventoryItems.
+func (mr *MockQuerierMockRecorder) TakeInventoryItems(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeInventoryItems", reflect.TypeOf((*MockQuerier)(nil).TakeInventoryItems), ctx, arg)
+}
+
+// TakeItemVariantStock mocks base method.
+func (m *MockQuerier) TakeItemVariantStock(ctx context.Context, arg db.TakeItemVariantStockParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeItemVariantStock", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeItemVariantStock indicates an expected call of TakeItemVariantStock.
+func (mr *MockQuerierMockRecorder) TakeItemVariantStock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeItemVariantStock", reflect.TypeOf((*MockQuerier)(nil).TakeItemVariantStock), ctx, arg)
+}
+
+// UpdateBidAmount mocks base method.
+func (m *MockQuerier) UpdateBidAmount(ctx context.Context, arg db.UpdateBidAmountParams) (db.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBidAmount", ctx, arg)
+	ret0, _ := ret[0].(db.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBidAmount indicates an expected call of UpdateBidAmount.
+func (mr *MockQuerierMockRecorder) UpdateBidAmount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBidAmount", reflect.TypeOf((*MockQuerier)(nil).UpdateBidAmount), ctx, arg)
+}
+
+// UpdateCoinLotAmount mocks base method.
+func (m *MockQuerier) UpdateCoinLotAmount(ctx context.Context, arg db.UpdateCoinLotAmountParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoinLotAmount", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCoinLotAmount indicates an expected call of UpdateCoinLotAmount.
+func (mr *MockQuerierMockRecorder) UpdateCoinLotAmount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoinLotAmount", reflect.TypeOf((*MockQuerier)(nil).UpdateCoinLotAmount), ctx, arg)
+}
+
+// UpdateItemVariantStock mocks base method.
+func (m *MockQuerier) UpdateItemVariantStock(ctx context.Context, arg db.UpdateItemVariantStockParams) (db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemVariantStock", ctx, arg)
+	ret0, _ := ret[0].(db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItemVariantStock indicates an expected call of UpdateItemVariantStock.
+func (mr *MockQuerierMockRecorder) UpdateItemVariantStock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemVariantStock", reflect.TypeOf((*MockQuerier)(nil).UpdateItemVariantStock), ctx, arg)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockQuerier) UpdateOrderStatus(ctx context.Context, arg db.UpdateOrderStatusParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, arg)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockQuerierMockRecorder) UpdateOrderStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockQuerier)(nil).UpdateOrderStatus), ctx, arg)
 }
 
 // UpdateTwoUsersBalance mocks base method.
@@ -198,3 +2108,105 @@ func (mr *MockQuerierMockRecorder) UpdateUserBalance(ctx, arg interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBalance", reflect.TypeOf((*MockQuerier)(nil).UpdateUserBalance), ctx, arg)
 }
+
+// UpdateUserPassword mocks base method.
+func (m *MockQuerier) UpdateUserPassword(ctx context.Context, arg db.UpdateUserPasswordParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockQuerierMockRecorder) UpdateUserPassword(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockQuerier)(nil).UpdateUserPassword), ctx, arg)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockQuerier) UpdateUserProfile(ctx context.Context, arg db.UpdateUserProfileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockQuerierMockRecorder) UpdateUserProfile(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockQuerier)(nil).UpdateUserProfile), ctx, arg)
+}
+
+// UpdateWishlistState mocks base method.
+func (m *MockQuerier) UpdateWishlistState(ctx context.Context, arg db.UpdateWishlistStateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWishlistState", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWishlistState indicates an expected call of UpdateWishlistState.
+func (mr *MockQuerierMockRecorder) UpdateWishlistState(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWishlistState", reflect.TypeOf((*MockQuerier)(nil).UpdateWishlistState), ctx, arg)
+}
+
+// UpsertPurchaseLimit mocks base method.
+func (m *MockQuerier) UpsertPurchaseLimit(ctx context.Context, arg db.UpsertPurchaseLimitParams) (db.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPurchaseLimit", ctx, arg)
+	ret0, _ := ret[0].(db.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPurchaseLimit indicates an expected call of UpsertPurchaseLimit.
+func (mr *MockQuerierMockRecorder) UpsertPurchaseLimit(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPurchaseLimit", reflect.TypeOf((*MockQuerier)(nil).UpsertPurchaseLimit), ctx, arg)
+}
+
+// UpsertTransferLimitOverride mocks base method.
+func (m *MockQuerier) UpsertTransferLimitOverride(ctx context.Context, arg db.UpsertTransferLimitOverrideParams) (db.TransferLimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTransferLimitOverride", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTransferLimitOverride indicates an expected call of UpsertTransferLimitOverride.
+func (mr *MockQuerierMockRecorder) UpsertTransferLimitOverride(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTransferLimitOverride", reflect.TypeOf((*MockQuerier)(nil).UpsertTransferLimitOverride), ctx, arg)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockQuerier) UsePasswordReset(ctx context.Context, arg db.UsePasswordResetParams) (db.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(db.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockQuerierMockRecorder) UsePasswordReset(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockQuerier)(nil).UsePasswordReset), ctx, arg)
+}
+
+// UsePromoCode mocks base method.
+func (m *MockQuerier) UsePromoCode(ctx context.Context, arg db.UsePromoCodeParams) (db.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePromoCode", ctx, arg)
+	ret0, _ := ret[0].(db.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePromoCode indicates an expected call of UsePromoCode.
+func (mr *MockQuerierMockRecorder) UsePromoCode(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePromoCode", reflect.TypeOf((*MockQuerier)(nil).UsePromoCode), ctx, arg)
+}
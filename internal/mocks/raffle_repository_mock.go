@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/raffle_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockRaffleRepository is a mock of RaffleRepository interface.
+type MockRaffleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRaffleRepositoryMockRecorder
+}
+
+// MockRaffleRepositoryMockRecorder is the mock recorder for MockRaffleRepository.
+type MockRaffleRepositoryMockRecorder struct {
+	mock *MockRaffleRepository
+}
+
+// NewMockRaffleRepository creates a new mock instance.
+func NewMockRaffleRepository(ctrl *gomock.Controller) *MockRaffleRepository {
+	mock := &MockRaffleRepository{ctrl: ctrl}
+	mock.recorder = &MockRaffleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRaffleRepository) EXPECT() *MockRaffleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountTickets mocks base method.
+func (m *MockRaffleRepository) CountTickets(c context.Context, raffleID int32) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTickets", c, raffleID)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTickets indicates an expected call of CountTickets.
+func (mr *MockRaffleRepositoryMockRecorder) CountTickets(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTickets", reflect.TypeOf((*MockRaffleRepository)(nil).CountTickets), c, raffleID)
+}
+
+// CountUserTickets mocks base method.
+func (m *MockRaffleRepository) CountUserTickets(c context.Context, raffleID int32, username string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserTickets", c, raffleID, username)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserTickets indicates an expected call of CountUserTickets.
+func (mr *MockRaffleRepositoryMockRecorder) CountUserTickets(c, raffleID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserTickets", reflect.TypeOf((*MockRaffleRepository)(nil).CountUserTickets), c, raffleID, username)
+}
+
+// CreateRaffle mocks base method.
+func (m *MockRaffleRepository) CreateRaffle(c context.Context, raffle *models.NewRaffle, seed, seedHash, createdBy string) (*db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRaffle", c, raffle, seed, seedHash, createdBy)
+	ret0, _ := ret[0].(*db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRaffle indicates an expected call of CreateRaffle.
+func (mr *MockRaffleRepositoryMockRecorder) CreateRaffle(c, raffle, seed, seedHash, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRaffle", reflect.TypeOf((*MockRaffleRepository)(nil).CreateRaffle), c, raffle, seed, seedHash, createdBy)
+}
+
+// CreateTicket mocks base method.
+func (m *MockRaffleRepository) CreateTicket(c context.Context, raffleID int32, username string, price int32) (*db.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicket", c, raffleID, username, price)
+	ret0, _ := ret[0].(*db.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTicket indicates an expected call of CreateTicket.
+func (mr *MockRaffleRepositoryMockRecorder) CreateTicket(c, raffleID, username, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockRaffleRepository)(nil).CreateTicket), c, raffleID, username, price)
+}
+
+// GetDueRaffles mocks base method.
+func (m *MockRaffleRepository) GetDueRaffles(c context.Context, now time.Time) ([]*db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRaffles", c, now)
+	ret0, _ := ret[0].([]*db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRaffles indicates an expected call of GetDueRaffles.
+func (mr *MockRaffleRepositoryMockRecorder) GetDueRaffles(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRaffles", reflect.TypeOf((*MockRaffleRepository)(nil).GetDueRaffles), c, now)
+}
+
+// GetRaffle mocks base method.
+func (m *MockRaffleRepository) GetRaffle(c context.Context, raffleID int32) (*db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffle", c, raffleID)
+	ret0, _ := ret[0].(*db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffle indicates an expected call of GetRaffle.
+func (mr *MockRaffleRepositoryMockRecorder) GetRaffle(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffle", reflect.TypeOf((*MockRaffleRepository)(nil).GetRaffle), c, raffleID)
+}
+
+// GetRaffleForUpdate mocks base method.
+func (m *MockRaffleRepository) GetRaffleForUpdate(c context.Context, raffleID int32) (*db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffleForUpdate", c, raffleID)
+	ret0, _ := ret[0].(*db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffleForUpdate indicates an expected call of GetRaffleForUpdate.
+func (mr *MockRaffleRepositoryMockRecorder) GetRaffleForUpdate(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffleForUpdate", reflect.TypeOf((*MockRaffleRepository)(nil).GetRaffleForUpdate), c, raffleID)
+}
+
+// ListRaffles mocks base method.
+func (m *MockRaffleRepository) ListRaffles(c context.Context) ([]*db.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRaffles", c)
+	ret0, _ := ret[0].([]*db.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRaffles indicates an expected call of ListRaffles.
+func (mr *MockRaffleRepositoryMockRecorder) ListRaffles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRaffles", reflect.TypeOf((*MockRaffleRepository)(nil).ListRaffles), c)
+}
+
+// ListTickets mocks base method.
+func (m *MockRaffleRepository) ListTickets(c context.Context, raffleID int32) ([]*db.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTickets", c, raffleID)
+	ret0, _ := ret[0].([]*db.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTickets indicates an expected call of ListTickets.
+func (mr *MockRaffleRepositoryMockRecorder) ListTickets(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTickets", reflect.TypeOf((*MockRaffleRepository)(nil).ListTickets), c, raffleID)
+}
+
+// SetDrawn mocks base method.
+func (m *MockRaffleRepository) SetDrawn(c context.Context, raffleID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDrawn", c, raffleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDrawn indicates an expected call of SetDrawn.
+func (mr *MockRaffleRepositoryMockRecorder) SetDrawn(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDrawn", reflect.TypeOf((*MockRaffleRepository)(nil).SetDrawn), c, raffleID)
+}
+
+// SetTicketWon mocks base method.
+func (m *MockRaffleRepository) SetTicketWon(c context.Context, ticketID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTicketWon", c, ticketID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTicketWon indicates an expected call of SetTicketWon.
+func (mr *MockRaffleRepositoryMockRecorder) SetTicketWon(c, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTicketWon", reflect.TypeOf((*MockRaffleRepository)(nil).SetTicketWon), c, ticketID)
+}
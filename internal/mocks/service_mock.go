@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/myacey/avito-shop/internal/models"
@@ -35,33 +36,196 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddToWishlist mocks base method.
+func (m *MockInterface) AddToWishlist(c context.Context, username, itemName string) (*models.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWishlist", c, username, itemName)
+	ret0, _ := ret[0].(*models.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWishlist indicates an expected call of AddToWishlist.
+func (mr *MockInterfaceMockRecorder) AddToWishlist(c, username, itemName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWishlist", reflect.TypeOf((*MockInterface)(nil).AddToWishlist), c, username, itemName)
+}
+
 // AuthorizeUser mocks base method.
-func (m *MockInterface) AuthorizeUser(c context.Context, username, password string) (string, error) {
+func (m *MockInterface) AuthorizeUser(c context.Context, username, password, ip string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AuthorizeUser", c, username, password)
+	ret := m.ctrl.Call(m, "AuthorizeUser", c, username, password, ip)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AuthorizeUser indicates an expected call of AuthorizeUser.
-func (mr *MockInterfaceMockRecorder) AuthorizeUser(c, username, password interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) AuthorizeUser(c, username, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeUser", reflect.TypeOf((*MockInterface)(nil).AuthorizeUser), c, username, password, ip)
+}
+
+// BuyBundle mocks base method.
+func (m *MockInterface) BuyBundle(c context.Context, username, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyBundle", c, username, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuyBundle indicates an expected call of BuyBundle.
+func (mr *MockInterfaceMockRecorder) BuyBundle(c, username, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeUser", reflect.TypeOf((*MockInterface)(nil).AuthorizeUser), c, username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyBundle", reflect.TypeOf((*MockInterface)(nil).BuyBundle), c, username, name)
+}
+
+// BuyGift mocks base method.
+func (m *MockInterface) BuyGift(c context.Context, fromUsername, toUsername, itemName, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyGift", c, fromUsername, toUsername, itemName, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuyGift indicates an expected call of BuyGift.
+func (mr *MockInterfaceMockRecorder) BuyGift(c, fromUsername, toUsername, itemName, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyGift", reflect.TypeOf((*MockInterface)(nil).BuyGift), c, fromUsername, toUsername, itemName, message)
 }
 
 // BuyItem mocks base method.
-func (m *MockInterface) BuyItem(c context.Context, username, itemName string) error {
+func (m *MockInterface) BuyItem(c context.Context, username, itemName, sku, promoCode string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyItem", c, username, itemName)
+	ret := m.ctrl.Call(m, "BuyItem", c, username, itemName, sku, promoCode)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BuyItem indicates an expected call of BuyItem.
-func (mr *MockInterfaceMockRecorder) BuyItem(c, username, itemName interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) BuyItem(c, username, itemName, sku, promoCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyItem", reflect.TypeOf((*MockInterface)(nil).BuyItem), c, username, itemName, sku, promoCode)
+}
+
+// BuyListing mocks base method.
+func (m *MockInterface) BuyListing(c context.Context, buyerUsername string, listingID int32) (*models.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyListing", c, buyerUsername, listingID)
+	ret0, _ := ret[0].(*models.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyListing indicates an expected call of BuyListing.
+func (mr *MockInterfaceMockRecorder) BuyListing(c, buyerUsername, listingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyListing", reflect.TypeOf((*MockInterface)(nil).BuyListing), c, buyerUsername, listingID)
+}
+
+// BuyRaffleTickets mocks base method.
+func (m *MockInterface) BuyRaffleTickets(c context.Context, username string, raffleID, quantity int32) ([]*models.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyRaffleTickets", c, username, raffleID, quantity)
+	ret0, _ := ret[0].([]*models.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyRaffleTickets indicates an expected call of BuyRaffleTickets.
+func (mr *MockInterfaceMockRecorder) BuyRaffleTickets(c, username, raffleID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyRaffleTickets", reflect.TypeOf((*MockInterface)(nil).BuyRaffleTickets), c, username, raffleID, quantity)
+}
+
+// CancelListing mocks base method.
+func (m *MockInterface) CancelListing(c context.Context, sellerUsername string, listingID int32) (*models.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelListing", c, sellerUsername, listingID)
+	ret0, _ := ret[0].(*models.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelListing indicates an expected call of CancelListing.
+func (mr *MockInterfaceMockRecorder) CancelListing(c, sellerUsername, listingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelListing", reflect.TypeOf((*MockInterface)(nil).CancelListing), c, sellerUsername, listingID)
+}
+
+// CancelOrder mocks base method.
+func (m *MockInterface) CancelOrder(c context.Context, username string, orderID int32) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", c, username, orderID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockInterfaceMockRecorder) CancelOrder(c, username, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockInterface)(nil).CancelOrder), c, username, orderID)
+}
+
+// CancelPreorder mocks base method.
+func (m *MockInterface) CancelPreorder(c context.Context, username string, preorderID int32) (*models.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPreorder", c, username, preorderID)
+	ret0, _ := ret[0].(*models.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPreorder indicates an expected call of CancelPreorder.
+func (mr *MockInterfaceMockRecorder) CancelPreorder(c, username, preorderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyItem", reflect.TypeOf((*MockInterface)(nil).BuyItem), c, username, itemName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPreorder", reflect.TypeOf((*MockInterface)(nil).CancelPreorder), c, username, preorderID)
+}
+
+// CancelPriceSchedule mocks base method.
+func (m *MockInterface) CancelPriceSchedule(c context.Context, scheduleID int32) (*models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPriceSchedule", c, scheduleID)
+	ret0, _ := ret[0].(*models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPriceSchedule indicates an expected call of CancelPriceSchedule.
+func (mr *MockInterfaceMockRecorder) CancelPriceSchedule(c, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPriceSchedule", reflect.TypeOf((*MockInterface)(nil).CancelPriceSchedule), c, scheduleID)
+}
+
+// ChangePassword mocks base method.
+func (m *MockInterface) ChangePassword(c context.Context, username, oldPassword, newPassword, ip string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", c, username, oldPassword, newPassword, ip)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockInterfaceMockRecorder) ChangePassword(c, username, oldPassword, newPassword, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockInterface)(nil).ChangePassword), c, username, oldPassword, newPassword, ip)
+}
+
+// CheckAPIKey mocks base method.
+func (m *MockInterface) CheckAPIKey(c context.Context, key, scope string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAPIKey", c, key, scope)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAPIKey indicates an expected call of CheckAPIKey.
+func (mr *MockInterfaceMockRecorder) CheckAPIKey(c, key, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAPIKey", reflect.TypeOf((*MockInterface)(nil).CheckAPIKey), c, key, scope)
 }
 
 // CheckAuthToken mocks base method.
@@ -79,31 +243,932 @@ func (mr *MockInterfaceMockRecorder) CheckAuthToken(c, token interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAuthToken", reflect.TypeOf((*MockInterface)(nil).CheckAuthToken), c, token)
 }
 
-// GetFullUserInfo mocks base method.
-func (m *MockInterface) GetFullUserInfo(c context.Context, username string) (*models.User, error) {
+// CloseAuctions mocks base method.
+func (m *MockInterface) CloseAuctions(c context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFullUserInfo", c, username)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "CloseAuctions", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseAuctions indicates an expected call of CloseAuctions.
+func (mr *MockInterfaceMockRecorder) CloseAuctions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAuctions", reflect.TypeOf((*MockInterface)(nil).CloseAuctions), c)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockInterface) CreateAPIKey(c context.Context, adminUsername string, key *models.NewAPIKey) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", c, adminUsername, key)
+	ret0, _ := ret[0].(*models.APIKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFullUserInfo indicates an expected call of GetFullUserInfo.
-func (mr *MockInterfaceMockRecorder) GetFullUserInfo(c, username interface{}) *gomock.Call {
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockInterfaceMockRecorder) CreateAPIKey(c, adminUsername, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullUserInfo", reflect.TypeOf((*MockInterface)(nil).GetFullUserInfo), c, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockInterface)(nil).CreateAPIKey), c, adminUsername, key)
 }
 
-// SendCoin mocks base method.
-func (m *MockInterface) SendCoin(c context.Context, fromUsername, toUsername string, amount int32) error {
+// CreateAuction mocks base method.
+func (m *MockInterface) CreateAuction(c context.Context, adminUsername, itemName string, quantity, minBid int32, endsAt time.Time) (*models.Auction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendCoin", c, fromUsername, toUsername, amount)
+	ret := m.ctrl.Call(m, "CreateAuction", c, adminUsername, itemName, quantity, minBid, endsAt)
+	ret0, _ := ret[0].(*models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuction indicates an expected call of CreateAuction.
+func (mr *MockInterfaceMockRecorder) CreateAuction(c, adminUsername, itemName, quantity, minBid, endsAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuction", reflect.TypeOf((*MockInterface)(nil).CreateAuction), c, adminUsername, itemName, quantity, minBid, endsAt)
+}
+
+// CreateBundle mocks base method.
+func (m *MockInterface) CreateBundle(c context.Context, adminUsername string, bundle *models.NewBundle) (*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBundle", c, adminUsername, bundle)
+	ret0, _ := ret[0].(*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBundle indicates an expected call of CreateBundle.
+func (mr *MockInterfaceMockRecorder) CreateBundle(c, adminUsername, bundle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockInterface)(nil).CreateBundle), c, adminUsername, bundle)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockInterface) CreatePasswordReset(c context.Context, adminUsername, username string) (*models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", c, adminUsername, username)
+	ret0, _ := ret[0].(*models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockInterfaceMockRecorder) CreatePasswordReset(c, adminUsername, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockInterface)(nil).CreatePasswordReset), c, adminUsername, username)
+}
+
+// CreatePreorderBatch mocks base method.
+func (m *MockInterface) CreatePreorderBatch(c context.Context, adminUsername string, batch *models.NewPreorderBatch) (*models.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreorderBatch", c, adminUsername, batch)
+	ret0, _ := ret[0].(*models.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreorderBatch indicates an expected call of CreatePreorderBatch.
+func (mr *MockInterfaceMockRecorder) CreatePreorderBatch(c, adminUsername, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreorderBatch", reflect.TypeOf((*MockInterface)(nil).CreatePreorderBatch), c, adminUsername, batch)
+}
+
+// CreatePriceSchedule mocks base method.
+func (m *MockInterface) CreatePriceSchedule(c context.Context, adminUsername string, schedule *models.NewPriceSchedule) (*models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceSchedule", c, adminUsername, schedule)
+	ret0, _ := ret[0].(*models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceSchedule indicates an expected call of CreatePriceSchedule.
+func (mr *MockInterfaceMockRecorder) CreatePriceSchedule(c, adminUsername, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceSchedule", reflect.TypeOf((*MockInterface)(nil).CreatePriceSchedule), c, adminUsername, schedule)
+}
+
+// CreatePromoCode mocks base method.
+func (m *MockInterface) CreatePromoCode(c context.Context, adminUsername string, promo *models.NewPromoCode) (*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromoCode", c, adminUsername, promo)
+	ret0, _ := ret[0].(*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockInterfaceMockRecorder) CreatePromoCode(c, adminUsername, promo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockInterface)(nil).CreatePromoCode), c, adminUsername, promo)
+}
+
+// CreateRaffle mocks base method.
+func (m *MockInterface) CreateRaffle(c context.Context, adminUsername string, raffle *models.NewRaffle) (*models.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRaffle", c, adminUsername, raffle)
+	ret0, _ := ret[0].(*models.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRaffle indicates an expected call of CreateRaffle.
+func (mr *MockInterfaceMockRecorder) CreateRaffle(c, adminUsername, raffle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRaffle", reflect.TypeOf((*MockInterface)(nil).CreateRaffle), c, adminUsername, raffle)
+}
+
+// CreateVariant mocks base method.
+func (m *MockInterface) CreateVariant(c context.Context, itemName string, variant *models.NewVariant) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", c, itemName, variant)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockInterfaceMockRecorder) CreateVariant(c, itemName, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockInterface)(nil).CreateVariant), c, itemName, variant)
+}
+
+// DeactivateBundle mocks base method.
+func (m *MockInterface) DeactivateBundle(c context.Context, name string) (*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateBundle", c, name)
+	ret0, _ := ret[0].(*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateBundle indicates an expected call of DeactivateBundle.
+func (mr *MockInterfaceMockRecorder) DeactivateBundle(c, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateBundle", reflect.TypeOf((*MockInterface)(nil).DeactivateBundle), c, name)
+}
+
+// DeletePurchaseLimit mocks base method.
+func (m *MockInterface) DeletePurchaseLimit(c context.Context, itemName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseLimit", c, itemName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendCoin indicates an expected call of SendCoin.
-func (mr *MockInterfaceMockRecorder) SendCoin(c, fromUsername, toUsername, amount interface{}) *gomock.Call {
+// DeletePurchaseLimit indicates an expected call of DeletePurchaseLimit.
+func (mr *MockInterfaceMockRecorder) DeletePurchaseLimit(c, itemName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*MockInterface)(nil).SendCoin), c, fromUsername, toUsername, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseLimit", reflect.TypeOf((*MockInterface)(nil).DeletePurchaseLimit), c, itemName)
+}
+
+// DeleteTransferLimitOverride mocks base method.
+func (m *MockInterface) DeleteTransferLimitOverride(c context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransferLimitOverride", c, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransferLimitOverride indicates an expected call of DeleteTransferLimitOverride.
+func (mr *MockInterfaceMockRecorder) DeleteTransferLimitOverride(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransferLimitOverride", reflect.TypeOf((*MockInterface)(nil).DeleteTransferLimitOverride), c, username)
+}
+
+// DisablePromoCode mocks base method.
+func (m *MockInterface) DisablePromoCode(c context.Context, code string) (*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisablePromoCode", c, code)
+	ret0, _ := ret[0].(*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisablePromoCode indicates an expected call of DisablePromoCode.
+func (mr *MockInterfaceMockRecorder) DisablePromoCode(c, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisablePromoCode", reflect.TypeOf((*MockInterface)(nil).DisablePromoCode), c, code)
+}
+
+// DrawRaffles mocks base method.
+func (m *MockInterface) DrawRaffles(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrawRaffles", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrawRaffles indicates an expected call of DrawRaffles.
+func (mr *MockInterfaceMockRecorder) DrawRaffles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrawRaffles", reflect.TypeOf((*MockInterface)(nil).DrawRaffles), c)
+}
+
+// ExpireCoins mocks base method.
+func (m *MockInterface) ExpireCoins(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireCoins", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireCoins indicates an expected call of ExpireCoins.
+func (mr *MockInterfaceMockRecorder) ExpireCoins(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireCoins", reflect.TypeOf((*MockInterface)(nil).ExpireCoins), c)
+}
+
+// ExpireListings mocks base method.
+func (m *MockInterface) ExpireListings(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireListings", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireListings indicates an expected call of ExpireListings.
+func (mr *MockInterfaceMockRecorder) ExpireListings(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireListings", reflect.TypeOf((*MockInterface)(nil).ExpireListings), c)
+}
+
+// ExpirePreorders mocks base method.
+func (m *MockInterface) ExpirePreorders(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePreorders", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpirePreorders indicates an expected call of ExpirePreorders.
+func (mr *MockInterfaceMockRecorder) ExpirePreorders(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePreorders", reflect.TypeOf((*MockInterface)(nil).ExpirePreorders), c)
+}
+
+// FinishOIDCLogin mocks base method.
+func (m *MockInterface) FinishOIDCLogin(c context.Context, code, state string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishOIDCLogin", c, code, state)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishOIDCLogin indicates an expected call of FinishOIDCLogin.
+func (mr *MockInterfaceMockRecorder) FinishOIDCLogin(c, code, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOIDCLogin", reflect.TypeOf((*MockInterface)(nil).FinishOIDCLogin), c, code, state)
+}
+
+// GetAuction mocks base method.
+func (m *MockInterface) GetAuction(c context.Context, auctionID int32) (*models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", c, auctionID)
+	ret0, _ := ret[0].(*models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockInterfaceMockRecorder) GetAuction(c, auctionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockInterface)(nil).GetAuction), c, auctionID)
+}
+
+// GetAuctions mocks base method.
+func (m *MockInterface) GetAuctions(c context.Context) ([]*models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuctions", c)
+	ret0, _ := ret[0].([]*models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuctions indicates an expected call of GetAuctions.
+func (mr *MockInterfaceMockRecorder) GetAuctions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuctions", reflect.TypeOf((*MockInterface)(nil).GetAuctions), c)
+}
+
+// GetBundles mocks base method.
+func (m *MockInterface) GetBundles(c context.Context) ([]*models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundles", c)
+	ret0, _ := ret[0].([]*models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundles indicates an expected call of GetBundles.
+func (mr *MockInterfaceMockRecorder) GetBundles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundles", reflect.TypeOf((*MockInterface)(nil).GetBundles), c)
+}
+
+// GetCatalog mocks base method.
+func (m *MockInterface) GetCatalog(c context.Context, username string) ([]*models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalog", c, username)
+	ret0, _ := ret[0].([]*models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalog indicates an expected call of GetCatalog.
+func (mr *MockInterfaceMockRecorder) GetCatalog(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalog", reflect.TypeOf((*MockInterface)(nil).GetCatalog), c, username)
+}
+
+// GetFullUserInfo mocks base method.
+func (m *MockInterface) GetFullUserInfo(c context.Context, username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFullUserInfo", c, username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFullUserInfo indicates an expected call of GetFullUserInfo.
+func (mr *MockInterfaceMockRecorder) GetFullUserInfo(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullUserInfo", reflect.TypeOf((*MockInterface)(nil).GetFullUserInfo), c, username)
+}
+
+// GetListings mocks base method.
+func (m *MockInterface) GetListings(c context.Context, itemName string) ([]*models.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListings", c, itemName)
+	ret0, _ := ret[0].([]*models.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListings indicates an expected call of GetListings.
+func (mr *MockInterfaceMockRecorder) GetListings(c, itemName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListings", reflect.TypeOf((*MockInterface)(nil).GetListings), c, itemName)
+}
+
+// GetNotifications mocks base method.
+func (m *MockInterface) GetNotifications(c context.Context, username string) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", c, username)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockInterfaceMockRecorder) GetNotifications(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockInterface)(nil).GetNotifications), c, username)
+}
+
+// GetOrders mocks base method.
+func (m *MockInterface) GetOrders(c context.Context, username string) ([]*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", c, username)
+	ret0, _ := ret[0].([]*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockInterfaceMockRecorder) GetOrders(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockInterface)(nil).GetOrders), c, username)
+}
+
+// GetPickupLocations mocks base method.
+func (m *MockInterface) GetPickupLocations(c context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickupLocations", c)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickupLocations indicates an expected call of GetPickupLocations.
+func (mr *MockInterfaceMockRecorder) GetPickupLocations(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickupLocations", reflect.TypeOf((*MockInterface)(nil).GetPickupLocations), c)
+}
+
+// GetPreorderBatches mocks base method.
+func (m *MockInterface) GetPreorderBatches(c context.Context) ([]*models.PreorderBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorderBatches", c)
+	ret0, _ := ret[0].([]*models.PreorderBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorderBatches indicates an expected call of GetPreorderBatches.
+func (mr *MockInterfaceMockRecorder) GetPreorderBatches(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorderBatches", reflect.TypeOf((*MockInterface)(nil).GetPreorderBatches), c)
+}
+
+// GetPreorders mocks base method.
+func (m *MockInterface) GetPreorders(c context.Context, username string) ([]*models.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreorders", c, username)
+	ret0, _ := ret[0].([]*models.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreorders indicates an expected call of GetPreorders.
+func (mr *MockInterfaceMockRecorder) GetPreorders(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreorders", reflect.TypeOf((*MockInterface)(nil).GetPreorders), c, username)
+}
+
+// GetRaffle mocks base method.
+func (m *MockInterface) GetRaffle(c context.Context, raffleID int32) (*models.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffle", c, raffleID)
+	ret0, _ := ret[0].(*models.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffle indicates an expected call of GetRaffle.
+func (mr *MockInterfaceMockRecorder) GetRaffle(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffle", reflect.TypeOf((*MockInterface)(nil).GetRaffle), c, raffleID)
+}
+
+// GetRaffleTickets mocks base method.
+func (m *MockInterface) GetRaffleTickets(c context.Context, raffleID int32) ([]*models.RaffleTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffleTickets", c, raffleID)
+	ret0, _ := ret[0].([]*models.RaffleTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffleTickets indicates an expected call of GetRaffleTickets.
+func (mr *MockInterfaceMockRecorder) GetRaffleTickets(c, raffleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffleTickets", reflect.TypeOf((*MockInterface)(nil).GetRaffleTickets), c, raffleID)
+}
+
+// GetRaffles mocks base method.
+func (m *MockInterface) GetRaffles(c context.Context) ([]*models.Raffle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaffles", c)
+	ret0, _ := ret[0].([]*models.Raffle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaffles indicates an expected call of GetRaffles.
+func (mr *MockInterfaceMockRecorder) GetRaffles(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaffles", reflect.TypeOf((*MockInterface)(nil).GetRaffles), c)
+}
+
+// GetTransferLimits mocks base method.
+func (m *MockInterface) GetTransferLimits(c context.Context, username string) (*models.TransferLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferLimits", c, username)
+	ret0, _ := ret[0].(*models.TransferLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferLimits indicates an expected call of GetTransferLimits.
+func (mr *MockInterfaceMockRecorder) GetTransferLimits(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferLimits", reflect.TypeOf((*MockInterface)(nil).GetTransferLimits), c, username)
+}
+
+// GetWishlist mocks base method.
+func (m *MockInterface) GetWishlist(c context.Context, username string) ([]*models.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", c, username)
+	ret0, _ := ret[0].([]*models.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockInterfaceMockRecorder) GetWishlist(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockInterface)(nil).GetWishlist), c, username)
+}
+
+// GrantCoins mocks base method.
+func (m *MockInterface) GrantCoins(c context.Context, grantedBy, username string, amount int32, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCoins", c, grantedBy, username, amount, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantCoins indicates an expected call of GrantCoins.
+func (mr *MockInterfaceMockRecorder) GrantCoins(c, grantedBy, username, amount, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCoins", reflect.TypeOf((*MockInterface)(nil).GrantCoins), c, grantedBy, username, amount, reason)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockInterface) ListAPIKeys(c context.Context) ([]*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", c)
+	ret0, _ := ret[0].([]*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockInterfaceMockRecorder) ListAPIKeys(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockInterface)(nil).ListAPIKeys), c)
+}
+
+// ListFraudCases mocks base method.
+func (m *MockInterface) ListFraudCases(c context.Context, status string) ([]*models.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFraudCases", c, status)
+	ret0, _ := ret[0].([]*models.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudCases indicates an expected call of ListFraudCases.
+func (mr *MockInterfaceMockRecorder) ListFraudCases(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudCases", reflect.TypeOf((*MockInterface)(nil).ListFraudCases), c, status)
+}
+
+// ListItem mocks base method.
+func (m *MockInterface) ListItem(c context.Context, sellerUsername, itemName, variant string, quantity, price int32) (*models.Listing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItem", c, sellerUsername, itemName, variant, quantity, price)
+	ret0, _ := ret[0].(*models.Listing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItem indicates an expected call of ListItem.
+func (mr *MockInterfaceMockRecorder) ListItem(c, sellerUsername, itemName, variant, quantity, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItem", reflect.TypeOf((*MockInterface)(nil).ListItem), c, sellerUsername, itemName, variant, quantity, price)
+}
+
+// ListOrders mocks base method.
+func (m *MockInterface) ListOrders(c context.Context, status string) ([]*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", c, status)
+	ret0, _ := ret[0].([]*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockInterfaceMockRecorder) ListOrders(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockInterface)(nil).ListOrders), c, status)
+}
+
+// ListPriceSchedules mocks base method.
+func (m *MockInterface) ListPriceSchedules(c context.Context, itemName string) ([]*models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceSchedules", c, itemName)
+	ret0, _ := ret[0].([]*models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceSchedules indicates an expected call of ListPriceSchedules.
+func (mr *MockInterfaceMockRecorder) ListPriceSchedules(c, itemName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceSchedules", reflect.TypeOf((*MockInterface)(nil).ListPriceSchedules), c, itemName)
+}
+
+// ListPromoCodes mocks base method.
+func (m *MockInterface) ListPromoCodes(c context.Context) ([]*models.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromoCodes", c)
+	ret0, _ := ret[0].([]*models.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromoCodes indicates an expected call of ListPromoCodes.
+func (mr *MockInterfaceMockRecorder) ListPromoCodes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromoCodes", reflect.TypeOf((*MockInterface)(nil).ListPromoCodes), c)
+}
+
+// ListTransferApprovals mocks base method.
+func (m *MockInterface) ListTransferApprovals(c context.Context, status string) ([]*models.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferApprovals", c, status)
+	ret0, _ := ret[0].([]*models.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferApprovals indicates an expected call of ListTransferApprovals.
+func (mr *MockInterfaceMockRecorder) ListTransferApprovals(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferApprovals", reflect.TypeOf((*MockInterface)(nil).ListTransferApprovals), c, status)
+}
+
+// MarkPreorderStockArrived mocks base method.
+func (m *MockInterface) MarkPreorderStockArrived(c context.Context, batchID, quantity int32) (*models.PreorderAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPreorderStockArrived", c, batchID, quantity)
+	ret0, _ := ret[0].(*models.PreorderAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPreorderStockArrived indicates an expected call of MarkPreorderStockArrived.
+func (mr *MockInterfaceMockRecorder) MarkPreorderStockArrived(c, batchID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPreorderStockArrived", reflect.TypeOf((*MockInterface)(nil).MarkPreorderStockArrived), c, batchID, quantity)
+}
+
+// NotifyWishlists mocks base method.
+func (m *MockInterface) NotifyWishlists(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyWishlists", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyWishlists indicates an expected call of NotifyWishlists.
+func (mr *MockInterfaceMockRecorder) NotifyWishlists(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWishlists", reflect.TypeOf((*MockInterface)(nil).NotifyWishlists), c)
+}
+
+// PlaceBid mocks base method.
+func (m *MockInterface) PlaceBid(c context.Context, username string, auctionID, amount int32) (*models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceBid", c, username, auctionID, amount)
+	ret0, _ := ret[0].(*models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceBid indicates an expected call of PlaceBid.
+func (mr *MockInterfaceMockRecorder) PlaceBid(c, username, auctionID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceBid", reflect.TypeOf((*MockInterface)(nil).PlaceBid), c, username, auctionID, amount)
+}
+
+// Preorder mocks base method.
+func (m *MockInterface) Preorder(c context.Context, username string, batchID int32) (*models.Preorder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preorder", c, username, batchID)
+	ret0, _ := ret[0].(*models.Preorder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preorder indicates an expected call of Preorder.
+func (mr *MockInterfaceMockRecorder) Preorder(c, username, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preorder", reflect.TypeOf((*MockInterface)(nil).Preorder), c, username, batchID)
+}
+
+// ReadNotifications mocks base method.
+func (m *MockInterface) ReadNotifications(c context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadNotifications", c, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadNotifications indicates an expected call of ReadNotifications.
+func (mr *MockInterfaceMockRecorder) ReadNotifications(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNotifications", reflect.TypeOf((*MockInterface)(nil).ReadNotifications), c, username)
+}
+
+// ReleaseExpiredHolds mocks base method.
+func (m *MockInterface) ReleaseExpiredHolds(c context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredHolds", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseExpiredHolds indicates an expected call of ReleaseExpiredHolds.
+func (mr *MockInterfaceMockRecorder) ReleaseExpiredHolds(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredHolds", reflect.TypeOf((*MockInterface)(nil).ReleaseExpiredHolds), c)
+}
+
+// RemoveFromWishlist mocks base method.
+func (m *MockInterface) RemoveFromWishlist(c context.Context, username, itemName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWishlist", c, username, itemName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWishlist indicates an expected call of RemoveFromWishlist.
+func (mr *MockInterfaceMockRecorder) RemoveFromWishlist(c, username, itemName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWishlist", reflect.TypeOf((*MockInterface)(nil).RemoveFromWishlist), c, username, itemName)
+}
+
+// ResetPassword mocks base method.
+func (m *MockInterface) ResetPassword(c context.Context, token, newPassword string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", c, token, newPassword)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockInterfaceMockRecorder) ResetPassword(c, token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockInterface)(nil).ResetPassword), c, token, newPassword)
+}
+
+// ResolveFraudCase mocks base method.
+func (m *MockInterface) ResolveFraudCase(c context.Context, caseID int32, adminUsername string, approve bool) (*models.FraudCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveFraudCase", c, caseID, adminUsername, approve)
+	ret0, _ := ret[0].(*models.FraudCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveFraudCase indicates an expected call of ResolveFraudCase.
+func (mr *MockInterfaceMockRecorder) ResolveFraudCase(c, caseID, adminUsername, approve interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveFraudCase", reflect.TypeOf((*MockInterface)(nil).ResolveFraudCase), c, caseID, adminUsername, approve)
+}
+
+// ResolveTransferApproval mocks base method.
+func (m *MockInterface) ResolveTransferApproval(c context.Context, approvalID int32, adminUsername string, approve bool) (*models.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTransferApproval", c, approvalID, adminUsername, approve)
+	ret0, _ := ret[0].(*models.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveTransferApproval indicates an expected call of ResolveTransferApproval.
+func (mr *MockInterfaceMockRecorder) ResolveTransferApproval(c, approvalID, adminUsername, approve interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTransferApproval", reflect.TypeOf((*MockInterface)(nil).ResolveTransferApproval), c, approvalID, adminUsername, approve)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockInterface) RevokeAPIKey(c context.Context, keyID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", c, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockInterfaceMockRecorder) RevokeAPIKey(c, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockInterface)(nil).RevokeAPIKey), c, keyID)
+}
+
+// SendCoin mocks base method.
+func (m *MockInterface) SendCoin(c context.Context, fromUsername, toUsername string, amount int32) (*models.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCoin", c, fromUsername, toUsername, amount)
+	ret0, _ := ret[0].(*models.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCoin indicates an expected call of SendCoin.
+func (mr *MockInterfaceMockRecorder) SendCoin(c, fromUsername, toUsername, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*MockInterface)(nil).SendCoin), c, fromUsername, toUsername, amount)
+}
+
+// SendItem mocks base method.
+func (m *MockInterface) SendItem(c context.Context, fromUsername, toUsername, itemName, variant string, quantity int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendItem", c, fromUsername, toUsername, itemName, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendItem indicates an expected call of SendItem.
+func (mr *MockInterfaceMockRecorder) SendItem(c, fromUsername, toUsername, itemName, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendItem", reflect.TypeOf((*MockInterface)(nil).SendItem), c, fromUsername, toUsername, itemName, variant, quantity)
+}
+
+// SetPickupLocation mocks base method.
+func (m *MockInterface) SetPickupLocation(c context.Context, username string, orderID int32, location string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPickupLocation", c, username, orderID, location)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPickupLocation indicates an expected call of SetPickupLocation.
+func (mr *MockInterfaceMockRecorder) SetPickupLocation(c, username, orderID, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPickupLocation", reflect.TypeOf((*MockInterface)(nil).SetPickupLocation), c, username, orderID, location)
+}
+
+// SetPurchaseLimit mocks base method.
+func (m *MockInterface) SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*models.PurchaseLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPurchaseLimit", c, limit)
+	ret0, _ := ret[0].(*models.PurchaseLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPurchaseLimit indicates an expected call of SetPurchaseLimit.
+func (mr *MockInterfaceMockRecorder) SetPurchaseLimit(c, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPurchaseLimit", reflect.TypeOf((*MockInterface)(nil).SetPurchaseLimit), c, limit)
+}
+
+// SetTransferLimitOverride mocks base method.
+func (m *MockInterface) SetTransferLimitOverride(c context.Context, username string, override *models.TransferLimitOverride) (*models.TransferLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransferLimitOverride", c, username, override)
+	ret0, _ := ret[0].(*models.TransferLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTransferLimitOverride indicates an expected call of SetTransferLimitOverride.
+func (mr *MockInterfaceMockRecorder) SetTransferLimitOverride(c, username, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransferLimitOverride", reflect.TypeOf((*MockInterface)(nil).SetTransferLimitOverride), c, username, override)
+}
+
+// SetVariantStock mocks base method.
+func (m *MockInterface) SetVariantStock(c context.Context, sku string, stock int32) (*models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariantStock", c, sku, stock)
+	ret0, _ := ret[0].(*models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVariantStock indicates an expected call of SetVariantStock.
+func (mr *MockInterfaceMockRecorder) SetVariantStock(c, sku, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariantStock", reflect.TypeOf((*MockInterface)(nil).SetVariantStock), c, sku, stock)
+}
+
+// StartOIDCLogin mocks base method.
+func (m *MockInterface) StartOIDCLogin(c context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOIDCLogin", c)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOIDCLogin indicates an expected call of StartOIDCLogin.
+func (mr *MockInterfaceMockRecorder) StartOIDCLogin(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOIDCLogin", reflect.TypeOf((*MockInterface)(nil).StartOIDCLogin), c)
+}
+
+// UnlockUser mocks base method.
+func (m *MockInterface) UnlockUser(c context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", c, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockInterfaceMockRecorder) UnlockUser(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockInterface)(nil).UnlockUser), c, username)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockInterface) UpdateOrderStatus(c context.Context, orderID int32, status string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", c, orderID, status)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockInterfaceMockRecorder) UpdateOrderStatus(c, orderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockInterface)(nil).UpdateOrderStatus), c, orderID, status)
 }
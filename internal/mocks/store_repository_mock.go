@@ -7,9 +7,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
 )
 
 // MockStoreRepository is a mock of StoreRepository interface.
@@ -35,17 +37,165 @@ func (m *MockStoreRepository) EXPECT() *MockStoreRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddVariantStock mocks base method.
+func (m *MockStoreRepository) AddVariantStock(c context.Context, sku string, quantity int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVariantStock", c, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVariantStock indicates an expected call of AddVariantStock.
+func (mr *MockStoreRepositoryMockRecorder) AddVariantStock(c, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVariantStock", reflect.TypeOf((*MockStoreRepository)(nil).AddVariantStock), c, sku, quantity)
+}
+
+// CancelPriceSchedule mocks base method.
+func (m *MockStoreRepository) CancelPriceSchedule(c context.Context, scheduleID int32) (*db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPriceSchedule", c, scheduleID)
+	ret0, _ := ret[0].(*db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPriceSchedule indicates an expected call of CancelPriceSchedule.
+func (mr *MockStoreRepositoryMockRecorder) CancelPriceSchedule(c, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPriceSchedule", reflect.TypeOf((*MockStoreRepository)(nil).CancelPriceSchedule), c, scheduleID)
+}
+
+// CreatePriceSchedule mocks base method.
+func (m *MockStoreRepository) CreatePriceSchedule(c context.Context, createdBy string, schedule *models.NewPriceSchedule) (*db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceSchedule", c, createdBy, schedule)
+	ret0, _ := ret[0].(*db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceSchedule indicates an expected call of CreatePriceSchedule.
+func (mr *MockStoreRepositoryMockRecorder) CreatePriceSchedule(c, createdBy, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceSchedule", reflect.TypeOf((*MockStoreRepository)(nil).CreatePriceSchedule), c, createdBy, schedule)
+}
+
+// CreateVariant mocks base method.
+func (m *MockStoreRepository) CreateVariant(c context.Context, itemType string, variant *models.NewVariant) (*db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", c, itemType, variant)
+	ret0, _ := ret[0].(*db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockStoreRepositoryMockRecorder) CreateVariant(c, itemType, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockStoreRepository)(nil).CreateVariant), c, itemType, variant)
+}
+
 // GetItemInfo mocks base method.
-func (m *MockStoreRepository) GetItemInfo(c context.Context, itemName string) (*db.Item, error) {
+func (m *MockStoreRepository) GetItemInfo(c context.Context, itemName string, now time.Time) (*models.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemInfo", c, itemName)
-	ret0, _ := ret[0].(*db.Item)
+	ret := m.ctrl.Call(m, "GetItemInfo", c, itemName, now)
+	ret0, _ := ret[0].(*models.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItemInfo indicates an expected call of GetItemInfo.
-func (mr *MockStoreRepositoryMockRecorder) GetItemInfo(c, itemName interface{}) *gomock.Call {
+func (mr *MockStoreRepositoryMockRecorder) GetItemInfo(c, itemName, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemInfo", reflect.TypeOf((*MockStoreRepository)(nil).GetItemInfo), c, itemName, now)
+}
+
+// ListAllItemVariants mocks base method.
+func (m *MockStoreRepository) ListAllItemVariants(c context.Context) ([]*db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllItemVariants", c)
+	ret0, _ := ret[0].([]*db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllItemVariants indicates an expected call of ListAllItemVariants.
+func (mr *MockStoreRepositoryMockRecorder) ListAllItemVariants(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllItemVariants", reflect.TypeOf((*MockStoreRepository)(nil).ListAllItemVariants), c)
+}
+
+// ListItemVariants mocks base method.
+func (m *MockStoreRepository) ListItemVariants(c context.Context, itemType string) ([]*db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemVariants", c, itemType)
+	ret0, _ := ret[0].([]*db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItemVariants indicates an expected call of ListItemVariants.
+func (mr *MockStoreRepositoryMockRecorder) ListItemVariants(c, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemVariants", reflect.TypeOf((*MockStoreRepository)(nil).ListItemVariants), c, itemType)
+}
+
+// ListItems mocks base method.
+func (m *MockStoreRepository) ListItems(c context.Context, now time.Time) ([]*models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", c, now)
+	ret0, _ := ret[0].([]*models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockStoreRepositoryMockRecorder) ListItems(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockStoreRepository)(nil).ListItems), c, now)
+}
+
+// ListPriceSchedules mocks base method.
+func (m *MockStoreRepository) ListPriceSchedules(c context.Context, itemType string) ([]*db.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceSchedules", c, itemType)
+	ret0, _ := ret[0].([]*db.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceSchedules indicates an expected call of ListPriceSchedules.
+func (mr *MockStoreRepositoryMockRecorder) ListPriceSchedules(c, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceSchedules", reflect.TypeOf((*MockStoreRepository)(nil).ListPriceSchedules), c, itemType)
+}
+
+// SetVariantStock mocks base method.
+func (m *MockStoreRepository) SetVariantStock(c context.Context, sku string, stock int32) (*db.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVariantStock", c, sku, stock)
+	ret0, _ := ret[0].(*db.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetVariantStock indicates an expected call of SetVariantStock.
+func (mr *MockStoreRepositoryMockRecorder) SetVariantStock(c, sku, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVariantStock", reflect.TypeOf((*MockStoreRepository)(nil).SetVariantStock), c, sku, stock)
+}
+
+// TakeVariantStock mocks base method.
+func (m *MockStoreRepository) TakeVariantStock(c context.Context, sku string, quantity int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeVariantStock", c, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeVariantStock indicates an expected call of TakeVariantStock.
+func (mr *MockStoreRepositoryMockRecorder) TakeVariantStock(c, sku, quantity interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemInfo", reflect.TypeOf((*MockStoreRepository)(nil).GetItemInfo), c, itemName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeVariantStock", reflect.TypeOf((*MockStoreRepository)(nil).TakeVariantStock), c, sku, quantity)
 }
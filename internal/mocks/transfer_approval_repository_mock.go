@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/transfer_approval_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+// MockTransferApprovalRepository is a mock of TransferApprovalRepository interface.
+type MockTransferApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferApprovalRepositoryMockRecorder
+}
+
+// MockTransferApprovalRepositoryMockRecorder is the mock recorder for MockTransferApprovalRepository.
+type MockTransferApprovalRepositoryMockRecorder struct {
+	mock *MockTransferApprovalRepository
+}
+
+// NewMockTransferApprovalRepository creates a new mock instance.
+func NewMockTransferApprovalRepository(ctrl *gomock.Controller) *MockTransferApprovalRepository {
+	mock := &MockTransferApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockTransferApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferApprovalRepository) EXPECT() *MockTransferApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateApproval mocks base method.
+func (m *MockTransferApprovalRepository) CreateApproval(c context.Context, fromUsername, toUsername string, amount int32, expiresAt time.Time) (*db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApproval", c, fromUsername, toUsername, amount, expiresAt)
+	ret0, _ := ret[0].(*db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApproval indicates an expected call of CreateApproval.
+func (mr *MockTransferApprovalRepositoryMockRecorder) CreateApproval(c, fromUsername, toUsername, amount, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApproval", reflect.TypeOf((*MockTransferApprovalRepository)(nil).CreateApproval), c, fromUsername, toUsername, amount, expiresAt)
+}
+
+// GetApprovalForUpdate mocks base method.
+func (m *MockTransferApprovalRepository) GetApprovalForUpdate(c context.Context, approvalID int32) (*db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalForUpdate", c, approvalID)
+	ret0, _ := ret[0].(*db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalForUpdate indicates an expected call of GetApprovalForUpdate.
+func (mr *MockTransferApprovalRepositoryMockRecorder) GetApprovalForUpdate(c, approvalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalForUpdate", reflect.TypeOf((*MockTransferApprovalRepository)(nil).GetApprovalForUpdate), c, approvalID)
+}
+
+// GetExpiredApprovals mocks base method.
+func (m *MockTransferApprovalRepository) GetExpiredApprovals(c context.Context, now time.Time) ([]*db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredApprovals", c, now)
+	ret0, _ := ret[0].([]*db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredApprovals indicates an expected call of GetExpiredApprovals.
+func (mr *MockTransferApprovalRepositoryMockRecorder) GetExpiredApprovals(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredApprovals", reflect.TypeOf((*MockTransferApprovalRepository)(nil).GetExpiredApprovals), c, now)
+}
+
+// ListApprovals mocks base method.
+func (m *MockTransferApprovalRepository) ListApprovals(c context.Context, status string) ([]*db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovals", c, status)
+	ret0, _ := ret[0].([]*db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovals indicates an expected call of ListApprovals.
+func (mr *MockTransferApprovalRepositoryMockRecorder) ListApprovals(c, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovals", reflect.TypeOf((*MockTransferApprovalRepository)(nil).ListApprovals), c, status)
+}
+
+// ResolveApproval mocks base method.
+func (m *MockTransferApprovalRepository) ResolveApproval(c context.Context, approvalID int32, status, resolvedBy string, transferID int32) (*db.TransferApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveApproval", c, approvalID, status, resolvedBy, transferID)
+	ret0, _ := ret[0].(*db.TransferApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveApproval indicates an expected call of ResolveApproval.
+func (mr *MockTransferApprovalRepositoryMockRecorder) ResolveApproval(c, approvalID, status, resolvedBy, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveApproval", reflect.TypeOf((*MockTransferApprovalRepository)(nil).ResolveApproval), c, approvalID, status, resolvedBy, transferID)
+}
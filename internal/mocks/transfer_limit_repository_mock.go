@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/transfer_limit_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockTransferLimitRepository is a mock of TransferLimitRepository interface.
+type MockTransferLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferLimitRepositoryMockRecorder
+}
+
+// MockTransferLimitRepositoryMockRecorder is the mock recorder for MockTransferLimitRepository.
+type MockTransferLimitRepositoryMockRecorder struct {
+	mock *MockTransferLimitRepository
+}
+
+// NewMockTransferLimitRepository creates a new mock instance.
+func NewMockTransferLimitRepository(ctrl *gomock.Controller) *MockTransferLimitRepository {
+	mock := &MockTransferLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockTransferLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferLimitRepository) EXPECT() *MockTransferLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteOverride mocks base method.
+func (m *MockTransferLimitRepository) DeleteOverride(c context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOverride", c, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOverride indicates an expected call of DeleteOverride.
+func (mr *MockTransferLimitRepositoryMockRecorder) DeleteOverride(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOverride", reflect.TypeOf((*MockTransferLimitRepository)(nil).DeleteOverride), c, username)
+}
+
+// GetOverride mocks base method.
+func (m *MockTransferLimitRepository) GetOverride(c context.Context, username string) (*db.TransferLimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverride", c, username)
+	ret0, _ := ret[0].(*db.TransferLimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverride indicates an expected call of GetOverride.
+func (mr *MockTransferLimitRepositoryMockRecorder) GetOverride(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverride", reflect.TypeOf((*MockTransferLimitRepository)(nil).GetOverride), c, username)
+}
+
+// SetOverride mocks base method.
+func (m *MockTransferLimitRepository) SetOverride(c context.Context, username string, override *models.TransferLimitOverride) (*db.TransferLimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOverride", c, username, override)
+	ret0, _ := ret[0].(*db.TransferLimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOverride indicates an expected call of SetOverride.
+func (mr *MockTransferLimitRepositoryMockRecorder) SetOverride(c, username, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOverride", reflect.TypeOf((*MockTransferLimitRepository)(nil).SetOverride), c, username, override)
+}
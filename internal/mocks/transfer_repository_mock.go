@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/repository/transfer_repository.go
+// Source: ./internal/repository/transfer_repository.go
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/myacey/avito-shop/db/sqlc"
@@ -35,6 +36,36 @@ func (m *MockTransferRepository) EXPECT() *MockTransferRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountNewSendersSince mocks base method.
+func (m *MockTransferRepository) CountNewSendersSince(c context.Context, toUsername, excludeUsername string, since, registeredAfter time.Time) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountNewSendersSince", c, toUsername, excludeUsername, since, registeredAfter)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountNewSendersSince indicates an expected call of CountNewSendersSince.
+func (mr *MockTransferRepositoryMockRecorder) CountNewSendersSince(c, toUsername, excludeUsername, since, registeredAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountNewSendersSince", reflect.TypeOf((*MockTransferRepository)(nil).CountNewSendersSince), c, toUsername, excludeUsername, since, registeredAfter)
+}
+
+// CountSentSince mocks base method.
+func (m *MockTransferRepository) CountSentSince(c context.Context, fromUsername string, since time.Time) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSentSince", c, fromUsername, since)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSentSince indicates an expected call of CountSentSince.
+func (mr *MockTransferRepositoryMockRecorder) CountSentSince(c, fromUsername, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentSince", reflect.TypeOf((*MockTransferRepository)(nil).CountSentSince), c, fromUsername, since)
+}
+
 // CreateMoneyTransfer mocks base method.
 func (m *MockTransferRepository) CreateMoneyTransfer(c context.Context, fromUsername, toUsername string, amount int32) (*db.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +81,66 @@ func (mr *MockTransferRepositoryMockRecorder) CreateMoneyTransfer(c, fromUsernam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMoneyTransfer", reflect.TypeOf((*MockTransferRepository)(nil).CreateMoneyTransfer), c, fromUsername, toUsername, amount)
 }
 
+// GetRecipientsSince mocks base method.
+func (m *MockTransferRepository) GetRecipientsSince(c context.Context, fromUsername string, since time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipientsSince", c, fromUsername, since)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipientsSince indicates an expected call of GetRecipientsSince.
+func (mr *MockTransferRepositoryMockRecorder) GetRecipientsSince(c, fromUsername, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipientsSince", reflect.TypeOf((*MockTransferRepository)(nil).GetRecipientsSince), c, fromUsername, since)
+}
+
+// GetReservedAmount mocks base method.
+func (m *MockTransferRepository) GetReservedAmount(c context.Context, fromUsername, toUsername string) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedAmount", c, fromUsername, toUsername)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedAmount indicates an expected call of GetReservedAmount.
+func (mr *MockTransferRepositoryMockRecorder) GetReservedAmount(c, fromUsername, toUsername interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedAmount", reflect.TypeOf((*MockTransferRepository)(nil).GetReservedAmount), c, fromUsername, toUsername)
+}
+
+// GetSentAmountSince mocks base method.
+func (m *MockTransferRepository) GetSentAmountSince(c context.Context, fromUsername string, since time.Time) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentAmountSince", c, fromUsername, since)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentAmountSince indicates an expected call of GetSentAmountSince.
+func (mr *MockTransferRepositoryMockRecorder) GetSentAmountSince(c, fromUsername, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentAmountSince", reflect.TypeOf((*MockTransferRepository)(nil).GetSentAmountSince), c, fromUsername, since)
+}
+
+// GetSentToUserAmountSince mocks base method.
+func (m *MockTransferRepository) GetSentToUserAmountSince(c context.Context, fromUsername, toUsername string, since time.Time) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentToUserAmountSince", c, fromUsername, toUsername, since)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentToUserAmountSince indicates an expected call of GetSentToUserAmountSince.
+func (mr *MockTransferRepositoryMockRecorder) GetSentToUserAmountSince(c, fromUsername, toUsername, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentToUserAmountSince", reflect.TypeOf((*MockTransferRepository)(nil).GetSentToUserAmountSince), c, fromUsername, toUsername, since)
+}
+
 // GetTransfersWithUser mocks base method.
 func (m *MockTransferRepository) GetTransfersWithUser(c context.Context, username string) ([]*db.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddCoins mocks base method.
+func (m *MockUserRepository) AddCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCoins", c, username, amount)
+	ret0, _ := ret[0].(*db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCoins indicates an expected call of AddCoins.
+func (mr *MockUserRepositoryMockRecorder) AddCoins(c, username, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCoins", reflect.TypeOf((*MockUserRepository)(nil).AddCoins), c, username, amount)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserRepository) CreateUser(c context.Context, username, password string) (*db.User, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +95,51 @@ func (mr *MockUserRepositoryMockRecorder) GetUserForUpdate(c, username interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockUserRepository)(nil).GetUserForUpdate), c, username)
 }
 
+// HoldCoins mocks base method.
+func (m *MockUserRepository) HoldCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldCoins", c, username, amount)
+	ret0, _ := ret[0].(*db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldCoins indicates an expected call of HoldCoins.
+func (mr *MockUserRepositoryMockRecorder) HoldCoins(c, username, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldCoins", reflect.TypeOf((*MockUserRepository)(nil).HoldCoins), c, username, amount)
+}
+
+// LockTwoUsers mocks base method.
+func (m *MockUserRepository) LockTwoUsers(c context.Context, firstUsername, secondUsername string) ([]*db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTwoUsers", c, firstUsername, secondUsername)
+	ret0, _ := ret[0].([]*db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTwoUsers indicates an expected call of LockTwoUsers.
+func (mr *MockUserRepositoryMockRecorder) LockTwoUsers(c, firstUsername, secondUsername interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTwoUsers", reflect.TypeOf((*MockUserRepository)(nil).LockTwoUsers), c, firstUsername, secondUsername)
+}
+
+// ReleaseCoins mocks base method.
+func (m *MockUserRepository) ReleaseCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCoins", c, username, amount)
+	ret0, _ := ret[0].(*db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseCoins indicates an expected call of ReleaseCoins.
+func (mr *MockUserRepositoryMockRecorder) ReleaseCoins(c, username, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCoins", reflect.TypeOf((*MockUserRepository)(nil).ReleaseCoins), c, username, amount)
+}
+
 // UpdateBalance mocks base method.
 func (m *MockUserRepository) UpdateBalance(c context.Context, userID, newCointCount int32) (*db.User, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +155,34 @@ func (mr *MockUserRepositoryMockRecorder) UpdateBalance(c, userID, newCointCount
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockUserRepository)(nil).UpdateBalance), c, userID, newCointCount)
 }
 
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(c context.Context, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", c, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(c, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), c, username, password)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserRepository) UpdateProfile(c context.Context, username, displayName, department string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", c, username, displayName, department)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserRepositoryMockRecorder) UpdateProfile(c, username, displayName, department interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfile), c, username, displayName, department)
+}
+
 // UpdateTwoUsersBalance mocks base method.
 func (m *MockUserRepository) UpdateTwoUsersBalance(c context.Context, fromUsername, toUsername string, coinsAmount int32) ([]*db.User, error) {
 	m.ctrl.T.Helper()
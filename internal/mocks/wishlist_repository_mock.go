@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/wishlist_repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	models "github.com/myacey/avito-shop/internal/models"
+)
+
+// MockWishlistRepository is a mock of WishlistRepository interface.
+type MockWishlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistRepositoryMockRecorder
+}
+
+// MockWishlistRepositoryMockRecorder is the mock recorder for MockWishlistRepository.
+type MockWishlistRepositoryMockRecorder struct {
+	mock *MockWishlistRepository
+}
+
+// NewMockWishlistRepository creates a new mock instance.
+func NewMockWishlistRepository(ctrl *gomock.Controller) *MockWishlistRepository {
+	mock := &MockWishlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWishlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistRepository) EXPECT() *MockWishlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockWishlistRepository) AddItem(c context.Context, state *models.WishlistState) (*db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", c, state)
+	ret0, _ := ret[0].(*db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockWishlistRepositoryMockRecorder) AddItem(c, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockWishlistRepository)(nil).AddItem), c, state)
+}
+
+// ListWishlist mocks base method.
+func (m *MockWishlistRepository) ListWishlist(c context.Context, username string) ([]*db.Wishlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWishlist", c, username)
+	ret0, _ := ret[0].([]*db.Wishlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWishlist indicates an expected call of ListWishlist.
+func (mr *MockWishlistRepositoryMockRecorder) ListWishlist(c, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWishlist", reflect.TypeOf((*MockWishlistRepository)(nil).ListWishlist), c, username)
+}
+
+// ListWithCoins mocks base method.
+func (m *MockWishlistRepository) ListWithCoins(c context.Context) ([]*db.ListWishlistsWithCoinsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWithCoins", c)
+	ret0, _ := ret[0].([]*db.ListWishlistsWithCoinsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWithCoins indicates an expected call of ListWithCoins.
+func (mr *MockWishlistRepositoryMockRecorder) ListWithCoins(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithCoins", reflect.TypeOf((*MockWishlistRepository)(nil).ListWithCoins), c)
+}
+
+// RemoveItem mocks base method.
+func (m *MockWishlistRepository) RemoveItem(c context.Context, username, itemType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", c, username, itemType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockWishlistRepositoryMockRecorder) RemoveItem(c, username, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*MockWishlistRepository)(nil).RemoveItem), c, username, itemType)
+}
+
+// UpdateState mocks base method.
+func (m *MockWishlistRepository) UpdateState(c context.Context, state *models.WishlistState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", c, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockWishlistRepositoryMockRecorder) UpdateState(c, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockWishlistRepository)(nil).UpdateState), c, state)
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// API key scopes
+const (
+	ScopeReadUsers      = "read:users"
+	ScopeTransfersWrite = "transfers:write"
+	ScopeGrantsWrite    = "grants:write"
+)
+
+// Scopes are every scope API key can have.
+var Scopes = []string{ScopeReadUsers, ScopeTransfersWrite, ScopeGrantsWrite}
+
+const NotificationGrant = "grant"
+
+// APIKey lets bots and integrations call API as Username
+// within Scopes. Key itself is shown only on creation.
+type APIKey struct {
+	ID        int32      `json:"id"`
+	Name      string     `json:"name"`
+	Username  string     `json:"username"`
+	Prefix    string     `json:"prefix"`
+	Key       string     `json:"key,omitempty"`
+	Scopes    []string   `json:"scopes"`
+	RateLimit int32      `json:"rateLimit"` // requests per minute
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// NewAPIKey is admin request for API key.
+type NewAPIKey struct {
+	Name      string   `json:"name"`
+	Username  string   `json:"username"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int32    `json:"rateLimit"` // 0 - default
+}
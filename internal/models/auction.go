@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	AuctionOpen   = "open"
+	AuctionClosed = "closed"
+	AuctionFailed = "failed" // settlement failed, bids are released
+
+	BidActive   = "active"
+	BidOutbid   = "outbid"
+	BidWon      = "won"
+	BidRejected = "rejected" // winner reached purchase limit
+	BidReleased = "released" // auction failed, coins returned
+)
+
+type Auction struct {
+	ID       int32     `json:"id"`
+	Item     string    `json:"item"`
+	Quantity int32     `json:"quantity"`
+	MinBid   int32     `json:"minBid"`
+	Status   string    `json:"status"`
+	EndsAt   time.Time `json:"endsAt"`
+	Bids     []*Bid    `json:"bids,omitempty"`
+}
+
+type Bid struct {
+	ID       int32  `json:"id"`
+	Username string `json:"username"`
+	Amount   int32  `json:"amount"`
+	Status   string `json:"status"`
+}
@@ -0,0 +1,22 @@
+package models
+
+type BundleItem struct {
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"` // SKU for items with variants
+	Quantity int32  `json:"quantity"`
+}
+
+// Bundle is product of several items sold for one price.
+type Bundle struct {
+	Name   string        `json:"name"`
+	Price  int32         `json:"price"`
+	Active bool          `json:"active"`
+	Items  []*BundleItem `json:"items"`
+}
+
+// NewBundle is admin request for bundle.
+type NewBundle struct {
+	Name  string        `json:"name"`
+	Price int32         `json:"price"`
+	Items []*BundleItem `json:"items"`
+}
@@ -0,0 +1,8 @@
+package models
+
+import "time"
+
+type CoinLot struct {
+	Amount    int32     `json:"amount"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -3,4 +3,5 @@ package models
 type InventoryItem struct {
 	Type     string `json:"type"`
 	Quantity int32  `json:"quantity"`
+	Variant  string `json:"variant,omitempty"`
 }
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Item is catalog item with price at the moment of request.
+type Item struct {
+	Type         string     `json:"type"`
+	Price        int32      `json:"price"`        // regular price
+	CurrentPrice int32      `json:"currentPrice"` // sale price during sale
+	SaleEndsAt   *time.Time `json:"saleEndsAt,omitempty"`
+	ScheduleID   int32      `json:"-"` // 0 if no sale is active
+	Variants     []*Variant `json:"variants,omitempty"`
+
+	PurchaseLimit *PurchaseLimit `json:"purchaseLimit,omitempty"`
+	Remaining     *int32         `json:"remaining,omitempty"` // purchases left for current user
+}
+
+// Variant is SKU of item such as size or color with its own stock.
+type Variant struct {
+	SKU        string `json:"sku"`
+	Size       string `json:"size,omitempty"`
+	Color      string `json:"color,omitempty"`
+	PriceDelta int32  `json:"priceDelta"`
+	Price      int32  `json:"price"` // current price of item with delta
+	Stock      int32  `json:"stock"`
+}
+
+// NewVariant is admin request for item variant.
+type NewVariant struct {
+	SKU        string `json:"sku"`
+	Size       string `json:"size"`
+	Color      string `json:"color"`
+	PriceDelta int32  `json:"priceDelta"`
+	Stock      int32  `json:"stock"`
+}
+
+// PurchaseLimit caps how many items one user can buy,
+// 0 means no cap.
+type PurchaseLimit struct {
+	Item       string `json:"item"`
+	Lifetime   int32  `json:"lifetime"`
+	PerPeriod  int32  `json:"perPeriod"`
+	PeriodDays int32  `json:"periodDays"`
+}
+
+// NewPriceSchedule is admin request for sale price.
+type NewPriceSchedule struct {
+	Item      string    `json:"item"`
+	SalePrice int32     `json:"salePrice"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+}
+
+type PriceSchedule struct {
+	ID          int32      `json:"id"`
+	Item        string     `json:"item"`
+	SalePrice   int32      `json:"salePrice"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      time.Time  `json:"endsAt"`
+	CreatedBy   string     `json:"createdBy"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+}
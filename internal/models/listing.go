@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	ListingActive    = "active"
+	ListingSold      = "sold"
+	ListingCancelled = "cancelled"
+	ListingExpired   = "expired"
+)
+
+type Listing struct {
+	ID        int32     `json:"id"`
+	Seller    string    `json:"seller"`
+	Item      string    `json:"item"`
+	Variant   string    `json:"variant,omitempty"`
+	Quantity  int32     `json:"quantity"`
+	Price     int32     `json:"price"`
+	Status    string    `json:"status"`
+	Buyer     string    `json:"buyer,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
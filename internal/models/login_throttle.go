@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// LoginThrottle configures brute-force protection of login. Once free
+// attempts are spent, every failure locks username or IP for BaseDelay
+// doubled per extra failure, up to MaxDelay.
+type LoginThrottle struct {
+	UserFreeAttempts int64
+	IPFreeAttempts   int64
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	// Window after last failure when failures are forgotten.
+	Window time.Duration
+}
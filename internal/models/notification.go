@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+const (
+	NotificationGift    = "gift"
+	NotificationSale    = "sale"
+	NotificationAuction = "auction"
+)
+
+type Notification struct {
+	ID        int32     `json:"id"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"createdAt"`
+	Read      bool      `json:"read"`
+}
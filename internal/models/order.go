@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// order statuses
+const (
+	OrderPlaced    = "placed"
+	OrderPacked    = "packed"
+	OrderReady     = "ready" // ready for pickup
+	OrderDelivered = "delivered"
+	OrderCancelled = "cancelled"
+)
+
+const NotificationOrder = "order"
+
+type Order struct {
+	ID             int32     `json:"id"`
+	Username       string    `json:"username"`
+	Recipient      string    `json:"recipient,omitempty"` // set for gifts
+	Item           string    `json:"item,omitempty"`
+	Bundle         string    `json:"bundle,omitempty"`
+	Variant        string    `json:"variant,omitempty"`
+	Price          int32     `json:"price"` // charged price
+	Discount       int32     `json:"discount"`
+	PromoCode      string    `json:"promoCode,omitempty"`
+	Status         string    `json:"status"`
+	PickupLocation string    `json:"pickupLocation,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// NewOrder is purchase to be saved.
+type NewOrder struct {
+	Username   string
+	Item       string // empty for bundles
+	Bundle     string // empty for single items
+	Variant    string // empty for items without variants
+	Price      int32  // charged price
+	Discount   int32
+	PromoCode  string     // empty if no code was applied
+	Recipient  string     // user who received the gift, empty if order isn't a gift
+	ScheduleID int32      // 0 if item was sold at regular price
+	CoinLots   []*CoinLot // lots price was paid from, empty if coin lots are disabled
+}
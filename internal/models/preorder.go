@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+const (
+	PreorderBatchOpen    = "open"
+	PreorderBatchArrived = "arrived"
+	PreorderBatchExpired = "expired"
+
+	PreorderPending   = "pending"
+	PreorderFulfilled = "fulfilled"
+	PreorderReleased  = "released" // stock didn't arrive or wasn't enough
+	PreorderCancelled = "cancelled"
+)
+
+const NotificationPreorder = "preorder"
+
+// PreorderBatch is upcoming stock of item users can preorder.
+type PreorderBatch struct {
+	ID        int32     `json:"id"`
+	Item      string    `json:"item"`
+	Variant   string    `json:"variant,omitempty"`
+	Price     int32     `json:"price"`
+	Status    string    `json:"status"`
+	Demand    int32     `json:"demand"` // pending preorders
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// NewPreorderBatch is admin request for preorders.
+type NewPreorderBatch struct {
+	Item      string    `json:"item"`
+	Variant   string    `json:"variant"`
+	Price     int32     `json:"price"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type Preorder struct {
+	ID        int32     `json:"id"`
+	BatchID   int32     `json:"batchId"`
+	Username  string    `json:"username"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// PreorderAllocation is result of stock arrival.
+type PreorderAllocation struct {
+	BatchID   int32 `json:"batchId"`
+	Fulfilled int32 `json:"fulfilled"`
+	Released  int32 `json:"released"`
+	Restocked int32 `json:"restocked"` // units left after preorders, added to variant stock
+}
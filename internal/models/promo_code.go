@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	PromoPercent = "percent" // value is percent off price
+	PromoFixed   = "fixed"   // value is coins off price
+)
+
+// NewPromoCode is admin request for promo code.
+type NewPromoCode struct {
+	Code     string     `json:"code"`
+	Kind     string     `json:"kind"`
+	Value    int32      `json:"value"`
+	Item     string     `json:"item"`    // empty for whole catalog
+	MaxUses  int32      `json:"maxUses"` // 0 for unlimited
+	StartsAt *time.Time `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+}
+
+type PromoCode struct {
+	Code      string     `json:"code"`
+	Kind      string     `json:"kind"`
+	Value     int32      `json:"value"`
+	Item      string     `json:"item,omitempty"`
+	MaxUses   int32      `json:"maxUses"`
+	UsedCount int32      `json:"usedCount"`
+	StartsAt  time.Time  `json:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	Disabled  bool       `json:"disabled"`
+	CreatedBy string     `json:"createdBy"`
+}
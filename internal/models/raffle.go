@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	RaffleOpen  = "open"
+	RaffleDrawn = "drawn"
+)
+
+const NotificationRaffle = "raffle"
+
+// Raffle sells tickets for prize item, winners are drawn with
+// seeded RNG. SeedHash is published on creation, Seed is revealed
+// after draw.
+type Raffle struct {
+	ID          int32           `json:"id"`
+	Item        string          `json:"item"`
+	Prizes      int32           `json:"prizes"`
+	TicketPrice int32           `json:"ticketPrice"`
+	MaxTickets  int32           `json:"maxTickets"` // per user, 0 - unlimited
+	Tickets     int32           `json:"tickets"`
+	SeedHash    string          `json:"seedHash"`
+	Seed        string          `json:"seed,omitempty"`
+	Status      string          `json:"status"`
+	DrawsAt     time.Time       `json:"drawsAt"`
+	Winners     []*RaffleTicket `json:"winners,omitempty"`
+}
+
+// NewRaffle is admin request for raffle.
+type NewRaffle struct {
+	Item        string    `json:"item"`
+	Prizes      int32     `json:"prizes"`
+	TicketPrice int32     `json:"ticketPrice"`
+	MaxTickets  int32     `json:"maxTickets"`
+	DrawsAt     time.Time `json:"drawsAt"`
+}
+
+type RaffleTicket struct {
+	ID        int32     `json:"id"`
+	RaffleID  int32     `json:"raffleId"`
+	Username  string    `json:"username"`
+	Price     int32     `json:"price"`
+	Won       bool      `json:"won"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	TransferCompleted = "completed"
+	TransferHeld      = "held"
+	TransferPending   = "pending"
+)
+
+type TransferResult struct {
+	Status     string `json:"status"`
+	CaseID     int32  `json:"caseId,omitempty"`
+	ApprovalID int32  `json:"approvalId,omitempty"`
+}
+
+const (
+	FraudCaseOpen     = "open"
+	FraudCaseApproved = "approved"
+	FraudCaseRejected = "rejected"
+)
+
+type FraudCase struct {
+	ID         int32      `json:"id"`
+	FromUser   string     `json:"fromUser"`
+	ToUser     string     `json:"toUser"`
+	Amount     int32      `json:"amount"`
+	Action     string     `json:"action"`
+	Reasons    string     `json:"reasons"`
+	Status     string     `json:"status"`
+	TransferID *int32     `json:"transferId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ResolvedBy string     `json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+const (
+	ApprovalPending  = "pending"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+	ApprovalExpired  = "expired"
+)
+
+type TransferApproval struct {
+	ID         int32      `json:"id"`
+	FromUser   string     `json:"fromUser"`
+	ToUser     string     `json:"toUser"`
+	Amount     int32      `json:"amount"`
+	Status     string     `json:"status"`
+	TransferID *int32     `json:"transferId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	ResolvedBy string     `json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
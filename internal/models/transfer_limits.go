@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// TransferLimits are effective limits of user, 0 means unlimited.
+type TransferLimits struct {
+	PerTransaction int32 `json:"perTransaction"`
+	Daily          int32 `json:"daily"`
+	Monthly        int32 `json:"monthly"`
+	PerRecipient   int32 `json:"perRecipient"` // per recipient per day
+}
+
+// TransferLimitOverride replaces default limits for one user,
+// nil fields fall back to defaults.
+type TransferLimitOverride struct {
+	PerTransaction *int32 `json:"perTransaction"`
+	Daily          *int32 `json:"daily"`
+	Monthly        *int32 `json:"monthly"`
+	PerRecipient   *int32 `json:"perRecipient"`
+}
+
+// LimitExceeded is returned to user with 403/429 errors.
+type LimitExceeded struct {
+	Limit     string     `json:"limit"`
+	Max       int32      `json:"max"`
+	Remaining int32      `json:"remaining"`
+	ResetAt   *time.Time `json:"resetAt,omitempty"`
+}
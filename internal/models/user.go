@@ -1,10 +1,24 @@
 package models
 
+import "time"
+
 type User struct {
 	ID           int32            `json:"-"`
 	Username     string           `json:"-"`
 	Password     string           `json:"-"`
+	DisplayName  string           `json:"displayName,omitempty"` // synced from directory
+	Department   string           `json:"department,omitempty"`
 	Coins        int32            `json:"coins"`
+	HeldCoins    int32            `json:"heldCoins"`
 	Inventory    []*InventoryItem `json:"inventory"`
 	EntryHistory interface{}      `json:"coinHistory"`
+	ExpiringSoon []*CoinLot       `json:"expiringSoon,omitempty"`
+}
+
+// PasswordReset is one-time token for setting new password,
+// token itself is shown only once.
+type PasswordReset struct {
+	Username  string    `json:"username"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	NotificationAffordable = "affordable"
+	NotificationPriceDrop  = "price_drop"
+	NotificationRestock    = "restock"
+)
+
+// WishlistItem is item saved by user with its price at the moment of request.
+type WishlistItem struct {
+	Item         string     `json:"item"`
+	Price        int32      `json:"price"`
+	CurrentPrice int32      `json:"currentPrice"`
+	SaleEndsAt   *time.Time `json:"saleEndsAt,omitempty"`
+	InStock      bool       `json:"inStock"`
+	AddedAt      time.Time  `json:"addedAt"`
+}
+
+// WishlistState is what user was already notified about.
+type WishlistState struct {
+	Username       string
+	Item           string
+	Affordable     bool
+	InStock        bool
+	SaleScheduleID int32 // 0 if user wasn't notified about any sale
+}
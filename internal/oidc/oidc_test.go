@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/myacey/avito-shop/internal/oidc/oidctest"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://shop.test/api/oidc/callback"
+
+func newTestProvider(t *testing.T, idp *oidctest.Provider, secret string) *Provider {
+	p, err := NewProvider(context.Background(), Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: secret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"profile", "email"},
+	}, idp.Client())
+	require.NoError(t, err)
+	return p
+}
+
+// login goes through provider's login page like browser
+// and returns code from redirect back.
+func login(t *testing.T, p *Provider, state, nonce string) string {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Get(p.AuthCodeURL(state, nonce))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	back, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, redirectURL, back.Scheme+"://"+back.Host+back.Path)
+	require.Equal(t, state, back.Query().Get("state"))
+
+	return back.Query().Get("code")
+}
+
+func TestExchange(t *testing.T) {
+	idp := oidctest.NewProvider("shop", "secret")
+	defer idp.Close()
+	idp.SetUser(oidctest.User{Subject: "emp-1", PreferredUsername: "alice", Email: "alice@corp.test"})
+
+	p := newTestProvider(t, idp, "secret")
+
+	authURL, err := url.Parse(p.AuthCodeURL("state", "nonce"))
+	require.NoError(t, err)
+	require.Equal(t, "openid profile email", authURL.Query().Get("scope"))
+
+	code := login(t, p, "state", "nonce")
+	identity, err := p.Exchange(context.Background(), code, "nonce")
+	require.NoError(t, err)
+	require.Equal(t, &Identity{
+		Issuer:            idp.Issuer(),
+		Subject:           "emp-1",
+		PreferredUsername: "alice",
+		Email:             "alice@corp.test",
+	}, identity)
+
+	// codes are single use
+	_, err = p.Exchange(context.Background(), code, "nonce")
+	require.ErrorIs(t, err, ErrExchange)
+
+	// nonce of another login
+	code = login(t, p, "state", "nonce")
+	_, err = p.Exchange(context.Background(), code, "other")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestExchangeInvalidClient(t *testing.T) {
+	idp := oidctest.NewProvider("shop", "secret")
+	defer idp.Close()
+	idp.SetUser(oidctest.User{Subject: "emp-1"})
+
+	p := newTestProvider(t, idp, "wrong")
+	_, err := p.Exchange(context.Background(), login(t, p, "state", "nonce"), "nonce")
+	require.ErrorIs(t, err, ErrExchange)
+}
+
+func TestVerify(t *testing.T) {
+	idp := oidctest.NewProvider("shop", "secret")
+	defer idp.Close()
+
+	p := newTestProvider(t, idp, "secret")
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   idp.Issuer(),
+			"sub":   "emp-1",
+			"aud":   "shop",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "nonce",
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		modify func(jwt.MapClaims)
+		expErr bool
+	}{
+		{name: "OK", modify: func(jwt.MapClaims) {}},
+		{name: "Err Audience", modify: func(c jwt.MapClaims) { c["aud"] = "other" }, expErr: true},
+		{name: "Err Issuer", modify: func(c jwt.MapClaims) { c["iss"] = "http://evil.test" }, expErr: true},
+		{name: "Err Expired", modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }, expErr: true},
+		{name: "Err No Expiry", modify: func(c jwt.MapClaims) { delete(c, "exp") }, expErr: true},
+		{name: "Err No Subject", modify: func(c jwt.MapClaims) { delete(c, "sub") }, expErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := valid()
+			tc.modify(claims)
+			token, err := idp.Sign(claims)
+			require.NoError(t, err)
+
+			_, err = p.verify(context.Background(), token, "nonce")
+			if tc.expErr {
+				require.ErrorIs(t, err, ErrInvalidToken)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// HS256 token signed with public key must not pass
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+	forged.Header["kid"] = "test-key"
+	token, err := forged.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = p.verify(context.Background(), token, "nonce")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewProviderIssuerMismatch(t *testing.T) {
+	idp := oidctest.NewProvider("shop", "secret")
+	defer idp.Close()
+
+	_, err := NewProvider(context.Background(), Config{Issuer: idp.Issuer() + "/"}, idp.Client())
+	require.Error(t, err)
+}
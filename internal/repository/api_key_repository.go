@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type APIKeyRepository interface {
+	CreateKey(c context.Context, key *models.NewAPIKey, keyHash, prefix, createdBy string) (*db.ApiKey, error)
+	GetKeyByHash(c context.Context, keyHash string) (*db.ApiKey, error)
+	ListKeys(c context.Context) ([]*db.ApiKey, error)
+	// RevokeKey returns ErrAPIKeyNotFound if there is no active key.
+	RevokeKey(c context.Context, keyID int32) error
+}
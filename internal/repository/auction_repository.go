@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var ErrAuctionNotFound = errors.New("auction not found")
+
+type AuctionRepository interface {
+	CreateAuction(c context.Context, itemType string, quantity, minBid int32, createdBy string, endsAt time.Time) (*db.Auction, error)
+	GetAuction(c context.Context, auctionID int32) (*db.Auction, error)
+	// Should be called only in transactions.
+	GetAuctionForUpdate(c context.Context, auctionID int32) (*db.Auction, error)
+	ListOpen(c context.Context) ([]*db.Auction, error)
+	// GetEndedAuctions returns open auctions ended by now.
+	GetEndedAuctions(c context.Context, now time.Time) ([]*db.Auction, error)
+	// CloseAuction sets final status of auction if it's still open.
+	CloseAuction(c context.Context, auctionID int32, status string) error
+
+	CreateBid(c context.Context, auctionID int32, username string, amount int32) (*db.Bid, error)
+	// GetActiveBids returns auction's active bids, highest first.
+	GetActiveBids(c context.Context, auctionID int32) ([]*db.Bid, error)
+	UpdateBidAmount(c context.Context, bidID, amount int32) (*db.Bid, error)
+	SetBidStatus(c context.Context, bidID int32, status string) error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var (
+	ErrBundleNotFound = errors.New("bundle not found")
+	ErrBundleExists   = errors.New("bundle already exists")
+)
+
+type BundleRepository interface {
+	CreateBundle(c context.Context, name string, price int32, createdBy string) (*db.Bundle, error)
+	// AddBundleItem adds component to bundle, variant is empty
+	// for items without variants.
+	AddBundleItem(c context.Context, bundleName, itemType, variant string, quantity int32) (*db.BundleItem, error)
+	GetBundle(c context.Context, name string) (*db.Bundle, error)
+	GetBundleItems(c context.Context, name string) ([]*db.BundleItem, error)
+	ListActiveBundles(c context.Context) ([]*db.Bundle, error)
+	// ListActiveBundleItems returns components of every active bundle.
+	ListActiveBundleItems(c context.Context) ([]*db.BundleItem, error)
+	DeactivateBundle(c context.Context, name string) (*db.Bundle, error)
+}
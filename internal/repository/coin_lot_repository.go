@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrNotEnoughCoins = errors.New("not enough coins in lots")
+
+type CoinLotRepository interface {
+	GrantCoins(c context.Context, userID int32, amount int32, expiresAt time.Time) (*db.CoinLot, error)
+	// ConsumeCoins takes amount from user's lots, oldest expiry first,
+	// and returns taken parts of lots.
+	// Should be called only in transactions.
+	ConsumeCoins(c context.Context, userID int32, amount int32) ([]*models.CoinLot, error)
+	GetExpiringLots(c context.Context, userID int32, before time.Time) ([]*db.CoinLot, error)
+
+	// ExpireLots burns lots expired at now and returns burned amounts per user.
+	// Coins on hold are never burned, their part of lots is kept.
+	// Should be called only in transactions.
+	ExpireLots(c context.Context, now time.Time) ([]*db.CoinExpiration, error)
+	GetExpirations(c context.Context, username string) ([]*db.CoinExpiration, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var (
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+	ErrExternalIdentityExists   = errors.New("external identity already linked")
+)
+
+// ExternalIdentityRepository links identity provider subjects to local users.
+type ExternalIdentityRepository interface {
+	GetIdentity(c context.Context, issuer, subject string) (*db.ExternalIdentity, error)
+	CreateIdentity(c context.Context, issuer, subject, username string) (*db.ExternalIdentity, error)
+}
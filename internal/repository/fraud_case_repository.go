@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var ErrFraudCaseNotFound = errors.New("fraud case not found")
+
+type FraudCaseRepository interface {
+	// CreateCase saves suspicious transfer, transferID is 0 for held transfers.
+	CreateCase(c context.Context, fromUsername, toUsername string, amount int32, action, reasons string, transferID int32) (*db.FraudCase, error)
+	// Should be called only in transactions.
+	GetCaseForUpdate(c context.Context, caseID int32) (*db.FraudCase, error)
+	ListCases(c context.Context, status string) ([]*db.FraudCase, error)
+	ResolveCase(c context.Context, caseID int32, status, resolvedBy string, transferID int32) (*db.FraudCase, error)
+}
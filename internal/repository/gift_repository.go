@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+type GiftRepository interface {
+	CreateGift(c context.Context, fromUsername, toUsername, itemType, message string) (*db.Gift, error)
+	// GetGiftsWithUser returns gifts sent or received by user.
+	GetGiftsWithUser(c context.Context, username string) ([]*db.Gift, error)
+}
@@ -7,9 +7,23 @@ import (
 	db "github.com/myacey/avito-shop/db/sqlc"
 )
 
-var ErrNoInventoryItems = errors.New("empty inventory")
+var (
+	ErrNoInventoryItems = errors.New("empty inventory")
+	ErrItemNotOwned     = errors.New("item not in inventory")
+	ErrNotEnoughItems   = errors.New("not enough items in inventory")
+)
 
 type InventoryRepository interface {
-	AddItemToInventory(c context.Context, userID int32, itemType string) error
+	// AddItemToInventory adds one item, variant is SKU or empty
+	// for items without variants.
+	AddItemToInventory(c context.Context, userID int32, itemType, variant string) error
 	GetInventory(c context.Context, userID int32) ([]*db.Inventory, error)
+
+	// AddItems adds quantity items, variant is SKU or empty
+	// for items without variants.
+	AddItems(c context.Context, userID int32, itemType, variant string, quantity int32) error
+	// RemoveItems decrements item's quantity in one conditional update,
+	// row is deleted at zero. Returns ErrItemNotOwned or ErrNotEnoughItems
+	// if user doesn't have quantity items.
+	RemoveItems(c context.Context, userID int32, itemType, variant string, quantity int32) error
 }
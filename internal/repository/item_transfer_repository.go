@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+type ItemTransferRepository interface {
+	// CreateItemTransfer saves sent items, variant is empty for items without variants.
+	CreateItemTransfer(c context.Context, fromUsername, toUsername, itemType, variant string, quantity int32) (*db.ItemTransfer, error)
+	// GetItemTransfersWithUser returns items sent or received by user.
+	GetItemTransfersWithUser(c context.Context, username string) ([]*db.ItemTransfer, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var (
+	ErrListingNotFound  = errors.New("listing not found")
+	ErrListingNotActive = errors.New("listing is not active")
+)
+
+type ListingRepository interface {
+	// CreateListing saves listing, variant is empty for items without variants.
+	CreateListing(c context.Context, sellerUsername, itemType, variant string, quantity, price int32, expiresAt time.Time) (*db.Listing, error)
+	GetListing(c context.Context, listingID int32) (*db.Listing, error)
+	// ListActive returns listings not expired by now, cheapest first.
+	// Empty itemType returns listings of every item.
+	ListActive(c context.Context, itemType string, now time.Time) ([]*db.Listing, error)
+	// ExpireListings closes active listings expired by now and returns them.
+	ExpireListings(c context.Context, now time.Time) ([]*db.Listing, error)
+	// CloseListing sets final status of active listing, buyer is empty
+	// if listing wasn't sold. Returns ErrListingNotActive if listing
+	// was closed already.
+	CloseListing(c context.Context, listingID int32, status, buyerUsername string) (*db.Listing, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptRepository counts failed logins by key (username or IP)
+// and keeps temporary locks.
+type LoginAttemptRepository interface {
+	// AddFailure counts failed login of key and returns failures so far,
+	// counter is dropped after window without failures.
+	AddFailure(c context.Context, key string, window time.Duration) (int64, error)
+	ResetFailures(c context.Context, key string) error
+
+	// Lock blocks logins of key for ttl.
+	Lock(c context.Context, key string, ttl time.Duration) error
+	// LockTTL returns remaining lock time of key, 0 if key isn't locked.
+	LockTTL(c context.Context, key string) (time.Duration, error)
+	Unlock(c context.Context, key string) error
+}
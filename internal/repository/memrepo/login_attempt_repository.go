@@ -0,0 +1,88 @@
+// Package memrepo has in-memory repositories for single instance
+// setups and tests.
+package memrepo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type failures struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type MemoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	failures map[string]failures
+	locks    map[string]time.Time
+}
+
+func NewMemoryLoginAttemptRepo(now func() time.Time) repository.LoginAttemptRepository {
+	return &MemoryLoginAttemptRepository{
+		now:      now,
+		failures: make(map[string]failures),
+		locks:    make(map[string]time.Time),
+	}
+}
+
+func (r *MemoryLoginAttemptRepository) AddFailure(c context.Context, key string, window time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	f := r.failures[key]
+	if !now.Before(f.expiresAt) {
+		f = failures{}
+	}
+	f.count++
+	f.expiresAt = now.Add(window)
+	r.failures[key] = f
+
+	return f.count, nil
+}
+
+func (r *MemoryLoginAttemptRepository) ResetFailures(c context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, key)
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) Lock(c context.Context, key string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.locks[key] = r.now().Add(ttl)
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) LockTTL(c context.Context, key string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until, ok := r.locks[key]
+	if !ok {
+		return 0, nil
+	}
+	ttl := until.Sub(r.now())
+	if ttl <= 0 {
+		delete(r.locks, key)
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (r *MemoryLoginAttemptRepository) Unlock(c context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.locks, key)
+	return nil
+}
@@ -0,0 +1,48 @@
+package memrepo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type oidcState struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+type MemoryOIDCStateRepository struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	states map[string]oidcState
+}
+
+func NewMemoryOIDCStateRepo(now func() time.Time) repository.OIDCStateRepository {
+	return &MemoryOIDCStateRepository{
+		now:    now,
+		states: make(map[string]oidcState),
+	}
+}
+
+func (r *MemoryOIDCStateRepository) SaveState(c context.Context, state, nonce string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state] = oidcState{nonce: nonce, expiresAt: r.now().Add(ttl)}
+	return nil
+}
+
+func (r *MemoryOIDCStateRepository) ConsumeState(c context.Context, state string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.states[state]
+	delete(r.states, state)
+	if !ok || !r.now().Before(s.expiresAt) {
+		return "", repository.ErrOIDCStateNotFound
+	}
+
+	return s.nonce, nil
+}
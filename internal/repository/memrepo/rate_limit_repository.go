@@ -0,0 +1,37 @@
+package memrepo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type MemoryRateLimitRepository struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	windows map[string]failures
+}
+
+func NewMemoryRateLimitRepo(now func() time.Time) repository.RateLimitRepository {
+	return &MemoryRateLimitRepository{
+		now:     now,
+		windows: make(map[string]failures),
+	}
+}
+
+func (r *MemoryRateLimitRepository) Hit(c context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	w := r.windows[key]
+	if !now.Before(w.expiresAt) {
+		w = failures{expiresAt: now.Add(window)}
+	}
+	w.count++
+	r.windows[key] = w
+
+	return w.count, w.expiresAt.Sub(now), nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+type NotificationRepository interface {
+	CreateNotification(c context.Context, username, kind, message string) (*db.Notification, error)
+	// ListNotifications returns latest notifications first.
+	ListNotifications(c context.Context, username string, limit int32) ([]*db.Notification, error)
+	MarkAllRead(c context.Context, username string) error
+}
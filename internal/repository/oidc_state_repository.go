@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrOIDCStateNotFound = errors.New("oidc state not found")
+
+// OIDCStateRepository keeps nonce of login started at identity
+// provider until user comes back with state.
+type OIDCStateRepository interface {
+	SaveState(c context.Context, state, nonce string, ttl time.Duration) error
+	// ConsumeState returns nonce of state and deletes it, so
+	// every state can be used once.
+	ConsumeState(c context.Context, state string) (string, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+type OrderRepository interface {
+	// CreateOrder saves order and coin lots it was paid from.
+	CreateOrder(c context.Context, order *models.NewOrder) (*db.Order, error)
+	GetOrderCoinLots(c context.Context, orderID int32) ([]*db.OrderCoinLot, error)
+	// CountItemOrders returns how many items user has bought
+	// in total and since given time.
+	CountItemOrders(c context.Context, username, itemType string, since time.Time) (total, sinceCount int32, err error)
+
+	// Should be called only in transactions.
+	GetOrderForUpdate(c context.Context, orderID int32) (*db.Order, error)
+	// ListUserOrders returns latest orders first.
+	ListUserOrders(c context.Context, username string) ([]*db.Order, error)
+	// ListOrders returns orders with status, empty status returns every order.
+	ListOrders(c context.Context, status string) ([]*db.Order, error)
+	UpdateStatus(c context.Context, orderID int32, status string) (*db.Order, error)
+	SetPickupLocation(c context.Context, orderID int32, location string) (*db.Order, error)
+}
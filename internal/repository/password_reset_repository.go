@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var ErrPasswordResetNotFound = errors.New("password reset not found")
+
+type PasswordResetRepository interface {
+	CreateReset(c context.Context, tokenHash, username, createdBy string, expiresAt time.Time) (*db.PasswordReset, error)
+	// UseReset marks reset as used if it's unused and not expired
+	// by now, so token works only once. Returns ErrPasswordResetNotFound
+	// if token can't be used.
+	UseReset(c context.Context, tokenHash string, now time.Time) (*db.PasswordReset, error)
+	// InvalidateResets marks every unused reset of user as used.
+	InvalidateResets(c context.Context, username string) error
+}
@@ -0,0 +1,77 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresAPIKeyRepo struct {
+	store db.Querier
+}
+
+func NewPostgresAPIKeyRepo(store db.Querier) repository.APIKeyRepository {
+	return &PostgresAPIKeyRepo{store}
+}
+
+func (r *PostgresAPIKeyRepo) CreateKey(c context.Context, key *models.NewAPIKey, keyHash, prefix, createdBy string) (*db.ApiKey, error) {
+	res, err := querier(c, r.store).CreateApiKey(c, db.CreateApiKeyParams{
+		KeyHash:   keyHash,
+		Prefix:    prefix,
+		Name:      key.Name,
+		Username:  key.Username,
+		Scopes:    strings.Join(key.Scopes, " "),
+		RateLimit: key.RateLimit,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresAPIKeyRepo) GetKeyByHash(c context.Context, keyHash string) (*db.ApiKey, error) {
+	res, err := querier(c, r.store).GetApiKeyByHash(c, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresAPIKeyRepo) ListKeys(c context.Context) ([]*db.ApiKey, error) {
+	keys, err := querier(c, r.store).ListApiKeys(c)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*db.ApiKey, len(keys))
+	for i := range keys {
+		res[i] = &keys[i]
+	}
+	return res, nil
+}
+
+func (r *PostgresAPIKeyRepo) RevokeKey(c context.Context, keyID int32) error {
+	n, err := querier(c, r.store).RevokeApiKey(c, keyID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresAuctionRepo struct {
+	store db.Querier
+}
+
+func NewPostgresAuctionRepo(store db.Querier) repository.AuctionRepository {
+	return &PostgresAuctionRepo{store}
+}
+
+func (r *PostgresAuctionRepo) CreateAuction(c context.Context, itemType string, quantity, minBid int32, createdBy string, endsAt time.Time) (*db.Auction, error) {
+	a, err := querier(c, r.store).CreateAuction(c, db.CreateAuctionParams{
+		ItemType:  itemType,
+		Quantity:  quantity,
+		MinBid:    minBid,
+		CreatedBy: createdBy,
+		EndsAt:    endsAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *PostgresAuctionRepo) GetAuction(c context.Context, auctionID int32) (*db.Auction, error) {
+	a, err := querier(c, r.store).GetAuction(c, auctionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAuctionNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresAuctionRepo) GetAuctionForUpdate(c context.Context, auctionID int32) (*db.Auction, error) {
+	a, err := querier(c, r.store).GetAuctionForUpdate(c, auctionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAuctionNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *PostgresAuctionRepo) ListOpen(c context.Context) ([]*db.Auction, error) {
+	auctions, err := querier(c, r.store).ListOpenAuctions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuctionPtrs(auctions), nil
+}
+
+func (r *PostgresAuctionRepo) GetEndedAuctions(c context.Context, now time.Time) ([]*db.Auction, error) {
+	auctions, err := querier(c, r.store).GetEndedAuctions(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuctionPtrs(auctions), nil
+}
+
+func (r *PostgresAuctionRepo) CloseAuction(c context.Context, auctionID int32, status string) error {
+	return querier(c, r.store).CloseAuction(c, db.CloseAuctionParams{
+		AuctionID: auctionID,
+		Status:    status,
+	})
+}
+
+func (r *PostgresAuctionRepo) CreateBid(c context.Context, auctionID int32, username string, amount int32) (*db.Bid, error) {
+	b, err := querier(c, r.store).CreateBid(c, db.CreateBidParams{
+		AuctionID: auctionID,
+		Username:  username,
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresAuctionRepo) GetActiveBids(c context.Context, auctionID int32) ([]*db.Bid, error) {
+	bids, err := querier(c, r.store).GetActiveBids(c, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.Bid, len(bids))
+	for i := range bids {
+		ans[i] = &bids[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresAuctionRepo) UpdateBidAmount(c context.Context, bidID, amount int32) (*db.Bid, error) {
+	b, err := querier(c, r.store).UpdateBidAmount(c, db.UpdateBidAmountParams{
+		BidID:  bidID,
+		Amount: amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresAuctionRepo) SetBidStatus(c context.Context, bidID int32, status string) error {
+	return querier(c, r.store).SetBidStatus(c, db.SetBidStatusParams{
+		BidID:  bidID,
+		Status: status,
+	})
+}
+
+func toAuctionPtrs(auctions []db.Auction) []*db.Auction {
+	ans := make([]*db.Auction, len(auctions))
+	for i := range auctions {
+		ans[i] = &auctions[i]
+	}
+	return ans
+}
@@ -0,0 +1,61 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockAuction = db.Auction{AuctionID: 1, ItemType: "hoody", Quantity: 3, MinBid: 100, Status: "open", CreatedBy: "admin"}
+
+func TestGetAuctionForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	auctionRepo := NewPostgresAuctionRepo(mockStore)
+
+	mockStore.EXPECT().
+		GetAuctionForUpdate(gomock.Any(), int32(1)).
+		Return(mockAuction, nil)
+	a, err := auctionRepo.GetAuctionForUpdate(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, &mockAuction, a)
+
+	mockStore.EXPECT().
+		GetAuctionForUpdate(gomock.Any(), int32(2)).
+		Return(db.Auction{}, sql.ErrNoRows)
+	_, err = auctionRepo.GetAuctionForUpdate(context.Background(), 2)
+	require.Equal(t, repository.ErrAuctionNotFound, err)
+}
+
+func TestGetActiveBids(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	auctionRepo := NewPostgresAuctionRepo(mockStore)
+
+	bids := []db.Bid{
+		{BidID: 2, AuctionID: 1, Username: mockUser2.Username, Amount: 200, Status: "active"},
+		{BidID: 1, AuctionID: 1, Username: mockUser1.Username, Amount: 150, Status: "active"},
+	}
+	mockStore.EXPECT().
+		GetActiveBids(gomock.Any(), int32(1)).
+		Return(bids, nil)
+	ans, err := auctionRepo.GetActiveBids(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []*db.Bid{&bids[0], &bids[1]}, ans)
+
+	mockStore.EXPECT().
+		GetActiveBids(gomock.Any(), int32(1)).
+		Return(nil, ErrMock)
+	_, err = auctionRepo.GetActiveBids(context.Background(), 1)
+	require.Equal(t, ErrMock, err)
+}
@@ -0,0 +1,112 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresBundleRepo struct {
+	store db.Querier
+}
+
+func NewPostgresBundleRepo(store db.Querier) repository.BundleRepository {
+	return &PostgresBundleRepo{store}
+}
+
+func (r *PostgresBundleRepo) CreateBundle(c context.Context, name string, price int32, createdBy string) (*db.Bundle, error) {
+	b, err := querier(c, r.store).CreateBundle(c, db.CreateBundleParams{
+		Name:      name,
+		Price:     price,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrBundleExists
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresBundleRepo) AddBundleItem(c context.Context, bundleName, itemType, variant string, quantity int32) (*db.BundleItem, error) {
+	bi, err := querier(c, r.store).AddBundleItem(c, db.AddBundleItemParams{
+		BundleName: bundleName,
+		ItemType:   itemType,
+		Variant:    sql.NullString{String: variant, Valid: variant != ""},
+		Quantity:   quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bi, nil
+}
+
+func (r *PostgresBundleRepo) GetBundle(c context.Context, name string) (*db.Bundle, error) {
+	b, err := querier(c, r.store).GetBundle(c, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBundleNotFound
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresBundleRepo) GetBundleItems(c context.Context, name string) ([]*db.BundleItem, error) {
+	items, err := querier(c, r.store).GetBundleItems(c, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBundleItemPtrs(items), nil
+}
+
+func (r *PostgresBundleRepo) ListActiveBundles(c context.Context) ([]*db.Bundle, error) {
+	bundles, err := querier(c, r.store).ListActiveBundles(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.Bundle, len(bundles))
+	for i := range bundles {
+		ans[i] = &bundles[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresBundleRepo) ListActiveBundleItems(c context.Context) ([]*db.BundleItem, error) {
+	items, err := querier(c, r.store).ListActiveBundleItems(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBundleItemPtrs(items), nil
+}
+
+func (r *PostgresBundleRepo) DeactivateBundle(c context.Context, name string) (*db.Bundle, error) {
+	b, err := querier(c, r.store).DeactivateBundle(c, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBundleNotFound
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func toBundleItemPtrs(items []db.BundleItem) []*db.BundleItem {
+	ans := make([]*db.BundleItem, len(items))
+	for i := range items {
+		ans[i] = &items[i]
+	}
+	return ans
+}
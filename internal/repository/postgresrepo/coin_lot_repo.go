@@ -0,0 +1,128 @@
+package postgresrepo
+
+import (
+	"context"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresCoinLotRepo struct {
+	store db.Querier
+}
+
+func NewPostgresCoinLotRepo(store db.Querier) repository.CoinLotRepository {
+	return &PostgresCoinLotRepo{store}
+}
+
+func (r *PostgresCoinLotRepo) GrantCoins(c context.Context, userID int32, amount int32, expiresAt time.Time) (*db.CoinLot, error) {
+	lot, err := querier(c, r.store).CreateCoinLot(c, db.CreateCoinLotParams{
+		UserID:    userID,
+		Amount:    amount,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+// ConsumeCoins locks user's lots and drains them in FIFO order,
+// deleting every lot that reaches zero.
+func (r *PostgresCoinLotRepo) ConsumeCoins(c context.Context, userID int32, amount int32) ([]*models.CoinLot, error) {
+	lots, err := querier(c, r.store).GetCoinLotsForUpdate(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int32
+	for _, lot := range lots {
+		total += lot.Amount
+	}
+	if total < amount {
+		return nil, repository.ErrNotEnoughCoins
+	}
+
+	var taken []*models.CoinLot
+	for _, lot := range lots {
+		if amount == 0 {
+			break
+		}
+
+		if lot.Amount <= amount {
+			if err = querier(c, r.store).DeleteCoinLot(c, lot.LotID); err != nil {
+				return nil, err
+			}
+			taken = append(taken, &models.CoinLot{Amount: lot.Amount, ExpiresAt: lot.ExpiresAt})
+			amount -= lot.Amount
+			continue
+		}
+
+		err = querier(c, r.store).UpdateCoinLotAmount(c, db.UpdateCoinLotAmountParams{
+			LotID:  lot.LotID,
+			Amount: lot.Amount - amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		taken = append(taken, &models.CoinLot{Amount: amount, ExpiresAt: lot.ExpiresAt})
+		amount = 0
+	}
+
+	return taken, nil
+}
+
+func (r *PostgresCoinLotRepo) GetExpiringLots(c context.Context, userID int32, before time.Time) ([]*db.CoinLot, error) {
+	lots, err := querier(c, r.store).GetExpiringCoinLots(c, db.GetExpiringCoinLotsParams{
+		UserID:    userID,
+		ExpiresAt: before,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.CoinLot, len(lots))
+	for i := range lots {
+		ans[i] = &lots[i]
+	}
+
+	return ans, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresCoinLotRepo) ExpireLots(c context.Context, now time.Time) ([]*db.CoinExpiration, error) {
+	// balances are read after users are locked, so
+	// concurrent payments can't make them negative
+	if err := querier(c, r.store).LockUsersWithExpiredLots(c, now); err != nil {
+		return nil, err
+	}
+
+	expired, err := querier(c, r.store).ExpireCoinLots(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.CoinExpiration, len(expired))
+	for i := range expired {
+		ans[i] = &expired[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresCoinLotRepo) GetExpirations(c context.Context, username string) ([]*db.CoinExpiration, error) {
+	expired, err := querier(c, r.store).GetCoinExpirations(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.CoinExpiration, len(expired))
+	for i := range expired {
+		ans[i] = &expired[i]
+	}
+
+	return ans, nil
+}
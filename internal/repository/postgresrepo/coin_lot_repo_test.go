@@ -0,0 +1,167 @@
+package postgresrepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockLot1 = db.CoinLot{LotID: 1, UserID: mockUser1.UserID, Amount: 100, ExpiresAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mockLot2 = db.CoinLot{LotID: 2, UserID: mockUser1.UserID, Amount: 50, ExpiresAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+)
+
+func TestConsumeCoins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	lotRepo := NewPostgresCoinLotRepo(mockStore)
+
+	testCases := []struct {
+		name         string
+		amount       int32
+		mockBehavior func(amount int32)
+		expTaken     []*models.CoinLot
+		expErr       error
+	}{
+		{
+			name:   "OK Part Of First Lot",
+			amount: 30,
+			mockBehavior: func(amount int32) {
+				mockStore.EXPECT().
+					GetCoinLotsForUpdate(gomock.Any(), mockUser1.UserID).
+					Return([]db.CoinLot{mockLot1, mockLot2}, nil)
+				mockStore.EXPECT().
+					UpdateCoinLotAmount(gomock.Any(), db.UpdateCoinLotAmountParams{LotID: mockLot1.LotID, Amount: 70}).
+					Return(nil)
+			},
+			expTaken: []*models.CoinLot{{Amount: 30, ExpiresAt: mockLot1.ExpiresAt}},
+			expErr:   nil,
+		},
+		{
+			name:   "OK Oldest Lot First",
+			amount: 120,
+			mockBehavior: func(amount int32) {
+				mockStore.EXPECT().
+					GetCoinLotsForUpdate(gomock.Any(), mockUser1.UserID).
+					Return([]db.CoinLot{mockLot1, mockLot2}, nil)
+				mockStore.EXPECT().
+					DeleteCoinLot(gomock.Any(), mockLot1.LotID).
+					Return(nil)
+				mockStore.EXPECT().
+					UpdateCoinLotAmount(gomock.Any(), db.UpdateCoinLotAmountParams{LotID: mockLot2.LotID, Amount: 30}).
+					Return(nil)
+			},
+			expTaken: []*models.CoinLot{
+				{Amount: 100, ExpiresAt: mockLot1.ExpiresAt},
+				{Amount: 20, ExpiresAt: mockLot2.ExpiresAt},
+			},
+			expErr: nil,
+		},
+		{
+			name:   "Err Not Enough Coins",
+			amount: 151,
+			mockBehavior: func(amount int32) {
+				mockStore.EXPECT().
+					GetCoinLotsForUpdate(gomock.Any(), mockUser1.UserID).
+					Return([]db.CoinLot{mockLot1, mockLot2}, nil)
+			},
+			expErr: repository.ErrNotEnoughCoins,
+		},
+		{
+			name:   "Unknown Error",
+			amount: 10,
+			mockBehavior: func(amount int32) {
+				mockStore.EXPECT().
+					GetCoinLotsForUpdate(gomock.Any(), mockUser1.UserID).
+					Return(nil, ErrMock)
+			},
+			expErr: ErrMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.amount)
+
+			taken, err := lotRepo.ConsumeCoins(context.Background(), mockUser1.UserID, tc.amount)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expTaken, taken)
+		})
+	}
+}
+
+func TestExpireLots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	lotRepo := NewPostgresCoinLotRepo(mockStore)
+
+	now := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	expiration := db.CoinExpiration{ExpirationID: 1, Username: mockUser1.Username, Amount: 100, ExpiredAt: now}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expAns       []*db.CoinExpiration
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					LockUsersWithExpiredLots(gomock.Any(), now).
+					Return(nil)
+				mockStore.EXPECT().
+					ExpireCoinLots(gomock.Any(), now).
+					Return([]db.CoinExpiration{expiration}, nil)
+			},
+			expAns: []*db.CoinExpiration{&expiration},
+			expErr: nil,
+		},
+		{
+			name: "Unknown Error",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					LockUsersWithExpiredLots(gomock.Any(), now).
+					Return(nil)
+				mockStore.EXPECT().
+					ExpireCoinLots(gomock.Any(), now).
+					Return(nil, ErrMock)
+			},
+			expAns: nil,
+			expErr: ErrMock,
+		},
+		{
+			name: "Lock Error",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					LockUsersWithExpiredLots(gomock.Any(), now).
+					Return(ErrMock)
+			},
+			expAns: nil,
+			expErr: ErrMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			expired, err := lotRepo.ExpireLots(context.Background(), now)
+
+			require.Equal(t, tc.expAns, expired)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresExternalIdentityRepo struct {
+	store db.Querier
+}
+
+func NewPostgresExternalIdentityRepo(store db.Querier) repository.ExternalIdentityRepository {
+	return &PostgresExternalIdentityRepo{store}
+}
+
+func (r *PostgresExternalIdentityRepo) GetIdentity(c context.Context, issuer, subject string) (*db.ExternalIdentity, error) {
+	res, err := querier(c, r.store).GetExternalIdentity(c, db.GetExternalIdentityParams{
+		Issuer:  issuer,
+		Subject: subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrExternalIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresExternalIdentityRepo) CreateIdentity(c context.Context, issuer, subject, username string) (*db.ExternalIdentity, error) {
+	res, err := querier(c, r.store).CreateExternalIdentity(c, db.CreateExternalIdentityParams{
+		Issuer:   issuer,
+		Subject:  subject,
+		Username: username,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrExternalIdentityExists
+		}
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
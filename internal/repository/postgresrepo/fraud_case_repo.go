@@ -0,0 +1,78 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresFraudCaseRepo struct {
+	store db.Querier
+}
+
+func NewPostgresFraudCaseRepo(store db.Querier) repository.FraudCaseRepository {
+	return &PostgresFraudCaseRepo{store}
+}
+
+func (r *PostgresFraudCaseRepo) CreateCase(c context.Context, fromUsername, toUsername string, amount int32, action, reasons string, transferID int32) (*db.FraudCase, error) {
+	fc, err := querier(c, r.store).CreateFraudCase(c, db.CreateFraudCaseParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		Amount:       amount,
+		Action:       action,
+		Reasons:      reasons,
+		TransferID:   sql.NullInt32{Int32: transferID, Valid: transferID != 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &fc, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresFraudCaseRepo) GetCaseForUpdate(c context.Context, caseID int32) (*db.FraudCase, error) {
+	fc, err := querier(c, r.store).GetFraudCaseForUpdate(c, caseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrFraudCaseNotFound
+		}
+		return nil, err
+	}
+
+	return &fc, nil
+}
+
+func (r *PostgresFraudCaseRepo) ListCases(c context.Context, status string) ([]*db.FraudCase, error) {
+	cases, err := querier(c, r.store).ListFraudCases(c, status)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.FraudCase, len(cases))
+	for i := range cases {
+		ans[i] = &cases[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresFraudCaseRepo) ResolveCase(c context.Context, caseID int32, status, resolvedBy string, transferID int32) (*db.FraudCase, error) {
+	fc, err := querier(c, r.store).ResolveFraudCase(c, db.ResolveFraudCaseParams{
+		CaseID:     caseID,
+		Status:     status,
+		ResolvedBy: sql.NullString{String: resolvedBy, Valid: true},
+		TransferID: sql.NullInt32{Int32: transferID, Valid: transferID != 0},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrFraudCaseNotFound
+		}
+		return nil, err
+	}
+
+	return &fc, nil
+}
@@ -0,0 +1,100 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockFraudCase = db.FraudCase{CaseID: 1, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, Amount: 10, Action: "held", Status: "open"}
+
+func TestCreateCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	caseRepo := NewPostgresFraudCaseRepo(mockStore)
+
+	testCases := []struct {
+		name         string
+		transferID   int32
+		mockBehavior func(transferID int32)
+		expAns       *db.FraudCase
+		expErr       error
+	}{
+		{
+			name:       "OK Held",
+			transferID: 0,
+			mockBehavior: func(transferID int32) {
+				mockStore.EXPECT().
+					CreateFraudCase(gomock.Any(), db.CreateFraudCaseParams{
+						FromUsername: mockUser1.Username,
+						ToUsername:   mockUser2.Username,
+						Amount:       10,
+						Action:       "held",
+						Reasons:      "cycle",
+						TransferID:   sql.NullInt32{},
+					}).
+					Return(mockFraudCase, nil)
+			},
+			expAns: &mockFraudCase,
+			expErr: nil,
+		},
+		{
+			name:       "Unknown Error",
+			transferID: 5,
+			mockBehavior: func(transferID int32) {
+				mockStore.EXPECT().
+					CreateFraudCase(gomock.Any(), db.CreateFraudCaseParams{
+						FromUsername: mockUser1.Username,
+						ToUsername:   mockUser2.Username,
+						Amount:       10,
+						Action:       "held",
+						Reasons:      "cycle",
+						TransferID:   sql.NullInt32{Int32: 5, Valid: true},
+					}).
+					Return(db.FraudCase{}, ErrMock)
+			},
+			expAns: nil,
+			expErr: ErrMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.transferID)
+
+			fc, err := caseRepo.CreateCase(context.Background(), mockUser1.Username, mockUser2.Username, 10, "held", "cycle", tc.transferID)
+
+			require.Equal(t, tc.expAns, fc)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGetCaseForUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	caseRepo := NewPostgresFraudCaseRepo(mockStore)
+
+	mockStore.EXPECT().
+		GetFraudCaseForUpdate(gomock.Any(), int32(1)).
+		Return(mockFraudCase, nil)
+	fc, err := caseRepo.GetCaseForUpdate(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, &mockFraudCase, fc)
+
+	mockStore.EXPECT().
+		GetFraudCaseForUpdate(gomock.Any(), int32(2)).
+		Return(db.FraudCase{}, sql.ErrNoRows)
+	_, err = caseRepo.GetCaseForUpdate(context.Background(), 2)
+	require.Equal(t, repository.ErrFraudCaseNotFound, err)
+}
@@ -0,0 +1,44 @@
+package postgresrepo
+
+import (
+	"context"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresGiftRepo struct {
+	store db.Querier
+}
+
+func NewPostgresGiftRepo(store db.Querier) repository.GiftRepository {
+	return &PostgresGiftRepo{store}
+}
+
+func (r *PostgresGiftRepo) CreateGift(c context.Context, fromUsername, toUsername, itemType, message string) (*db.Gift, error) {
+	g, err := querier(c, r.store).CreateGift(c, db.CreateGiftParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		ItemType:     itemType,
+		Message:      message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (r *PostgresGiftRepo) GetGiftsWithUser(c context.Context, username string) ([]*db.Gift, error) {
+	gifts, err := querier(c, r.store).GetGiftsWithUser(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.Gift, len(gifts))
+	for i := range gifts {
+		ans[i] = &gifts[i]
+	}
+
+	return ans, nil
+}
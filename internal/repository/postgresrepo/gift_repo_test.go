@@ -0,0 +1,63 @@
+package postgresrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGiftsWithUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	giftRepo := NewPostgresGiftRepo(mockStore)
+
+	gifts := []db.Gift{
+		{GiftID: 1, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, ItemType: "cup"},
+		{GiftID: 2, FromUsername: mockUser2.Username, ToUsername: mockUser1.Username, ItemType: "pen"},
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expAns       []*db.Gift
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					GetGiftsWithUser(gomock.Any(), mockUser1.Username).
+					Return(gifts, nil)
+			},
+			expAns: []*db.Gift{&gifts[0], &gifts[1]},
+			expErr: nil,
+		},
+		{
+			name: "Unknown Error",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					GetGiftsWithUser(gomock.Any(), mockUser1.Username).
+					Return(nil, ErrMock)
+			},
+			expAns: nil,
+			expErr: ErrMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			ans, err := giftRepo.GetGiftsWithUser(context.Background(), mockUser1.Username)
+
+			require.Equal(t, tc.expAns, ans)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
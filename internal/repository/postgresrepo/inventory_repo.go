@@ -17,12 +17,13 @@ func NewPostgresInventoryRepo(store db.Querier) repository.InventoryRepository {
 	return &PostgresInventoryRepo{store}
 }
 
-func (r *PostgresInventoryRepo) AddItemToInventory(c context.Context, userID int32, itemType string) error {
+func (r *PostgresInventoryRepo) AddItemToInventory(c context.Context, userID int32, itemType, variant string) error {
 	arg := db.BuyItemParams{
 		UserID:   userID,
 		ItemType: itemType,
+		Variant:  variant,
 	}
-	err := r.store.BuyItem(c, arg)
+	err := querier(c, r.store).BuyItem(c, arg)
 	if err != nil {
 		return err
 	}
@@ -31,7 +32,7 @@ func (r *PostgresInventoryRepo) AddItemToInventory(c context.Context, userID int
 }
 
 func (r *PostgresInventoryRepo) GetInventory(c context.Context, userID int32) ([]*db.Inventory, error) {
-	inv, err := r.store.GetInventory(c, userID)
+	inv, err := querier(c, r.store).GetInventory(c, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrNoInventoryItems
@@ -46,3 +47,48 @@ func (r *PostgresInventoryRepo) GetInventory(c context.Context, userID int32) ([
 
 	return ans, nil
 }
+
+func (r *PostgresInventoryRepo) AddItems(c context.Context, userID int32, itemType, variant string, quantity int32) error {
+	return querier(c, r.store).AddItemsToInventory(c, db.AddItemsToInventoryParams{
+		UserID:   userID,
+		ItemType: itemType,
+		Variant:  variant,
+		Quantity: quantity,
+	})
+}
+
+func (r *PostgresInventoryRepo) RemoveItems(c context.Context, userID int32, itemType, variant string, quantity int32) error {
+	item, err := querier(c, r.store).TakeInventoryItems(c, db.TakeInventoryItemsParams{
+		UserID:   userID,
+		ItemType: itemType,
+		Variant:  variant,
+		Quantity: quantity,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.removeItemsErr(c, userID, itemType, variant)
+		}
+		return err
+	}
+
+	if item.Quantity == 0 {
+		return querier(c, r.store).DeleteEmptyInventoryItem(c, item.InventoryID)
+	}
+	return nil
+}
+
+// removeItemsErr tells why nothing was removed.
+func (r *PostgresInventoryRepo) removeItemsErr(c context.Context, userID int32, itemType, variant string) error {
+	_, err := querier(c, r.store).GetInventoryItem(c, db.GetInventoryItemParams{
+		UserID:   userID,
+		ItemType: itemType,
+		Variant:  variant,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.ErrItemNotOwned
+		}
+		return err
+	}
+	return repository.ErrNotEnoughItems
+}
@@ -65,7 +65,7 @@ func TestAddItemToInventory(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.userID, tc.itemType)
 
-			res := inventoryRepo.AddItemToInventory(context.Background(), tc.userID, tc.itemType)
+			res := inventoryRepo.AddItemToInventory(context.Background(), tc.userID, tc.itemType, "")
 
 			require.Equal(t, res, tc.expRes)
 		})
@@ -132,3 +132,101 @@ func TestGetInventory(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	inventoryRepo := NewPostgresInventoryRepo(mockStore)
+
+	takeArg := func(quantity int32) db.TakeInventoryItemsParams {
+		return db.TakeInventoryItemsParams{
+			UserID:   mockUser1.UserID,
+			ItemType: mockInventory1.ItemType,
+			Quantity: quantity,
+		}
+	}
+	getArg := db.GetInventoryItemParams{UserID: mockUser1.UserID, ItemType: mockInventory1.ItemType}
+
+	testCases := []struct {
+		name         string
+		quantity     int32
+		mockBehavior func(quantity int32)
+		expErr       error
+	}{
+		{
+			name:     "OK Decrement",
+			quantity: 3,
+			mockBehavior: func(quantity int32) {
+				left := mockInventory1
+				left.Quantity -= quantity
+				mockStore.EXPECT().
+					TakeInventoryItems(gomock.Any(), takeArg(quantity)).
+					Return(left, nil)
+			},
+			expErr: nil,
+		},
+		{
+			name:     "OK Delete At Zero",
+			quantity: mockInventory1.Quantity,
+			mockBehavior: func(quantity int32) {
+				left := mockInventory1
+				left.Quantity = 0
+				mockStore.EXPECT().
+					TakeInventoryItems(gomock.Any(), takeArg(quantity)).
+					Return(left, nil)
+				mockStore.EXPECT().
+					DeleteEmptyInventoryItem(gomock.Any(), mockInventory1.InventoryID).
+					Return(nil)
+			},
+			expErr: nil,
+		},
+		{
+			name:     "Not Enough Items",
+			quantity: mockInventory1.Quantity + 1,
+			mockBehavior: func(quantity int32) {
+				mockStore.EXPECT().
+					TakeInventoryItems(gomock.Any(), takeArg(quantity)).
+					Return(db.Inventory{}, sql.ErrNoRows)
+				mockStore.EXPECT().
+					GetInventoryItem(gomock.Any(), getArg).
+					Return(mockInventory1, nil)
+			},
+			expErr: repository.ErrNotEnoughItems,
+		},
+		{
+			name:     "Not Owned",
+			quantity: 1,
+			mockBehavior: func(quantity int32) {
+				mockStore.EXPECT().
+					TakeInventoryItems(gomock.Any(), takeArg(quantity)).
+					Return(db.Inventory{}, sql.ErrNoRows)
+				mockStore.EXPECT().
+					GetInventoryItem(gomock.Any(), getArg).
+					Return(db.Inventory{}, sql.ErrNoRows)
+			},
+			expErr: repository.ErrItemNotOwned,
+		},
+		{
+			name:     "Unexpected Error",
+			quantity: 1,
+			mockBehavior: func(quantity int32) {
+				mockStore.EXPECT().
+					TakeInventoryItems(gomock.Any(), takeArg(quantity)).
+					Return(db.Inventory{}, ErrMock)
+			},
+			expErr: ErrMock,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.quantity)
+
+			err := inventoryRepo.RemoveItems(context.Background(), mockUser1.UserID, mockInventory1.ItemType, "", tc.quantity)
+
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresItemTransferRepo struct {
+	store db.Querier
+}
+
+func NewPostgresItemTransferRepo(store db.Querier) repository.ItemTransferRepository {
+	return &PostgresItemTransferRepo{store}
+}
+
+func (r *PostgresItemTransferRepo) CreateItemTransfer(c context.Context, fromUsername, toUsername, itemType, variant string, quantity int32) (*db.ItemTransfer, error) {
+	t, err := querier(c, r.store).CreateItemTransfer(c, db.CreateItemTransferParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		ItemType:     itemType,
+		Quantity:     quantity,
+		Variant:      sql.NullString{String: variant, Valid: variant != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *PostgresItemTransferRepo) GetItemTransfersWithUser(c context.Context, username string) ([]*db.ItemTransfer, error) {
+	transfers, err := querier(c, r.store).GetItemTransfersWithUser(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.ItemTransfer, len(transfers))
+	for i := range transfers {
+		ans[i] = &transfers[i]
+	}
+
+	return ans, nil
+}
@@ -0,0 +1,92 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresListingRepo struct {
+	store db.Querier
+}
+
+func NewPostgresListingRepo(store db.Querier) repository.ListingRepository {
+	return &PostgresListingRepo{store}
+}
+
+func (r *PostgresListingRepo) CreateListing(c context.Context, sellerUsername, itemType, variant string, quantity, price int32, expiresAt time.Time) (*db.Listing, error) {
+	l, err := querier(c, r.store).CreateListing(c, db.CreateListingParams{
+		SellerUsername: sellerUsername,
+		ItemType:       itemType,
+		Quantity:       quantity,
+		Price:          price,
+		ExpiresAt:      expiresAt,
+		Variant:        sql.NullString{String: variant, Valid: variant != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (r *PostgresListingRepo) GetListing(c context.Context, listingID int32) (*db.Listing, error) {
+	l, err := querier(c, r.store).GetListing(c, listingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrListingNotFound
+		}
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (r *PostgresListingRepo) ListActive(c context.Context, itemType string, now time.Time) ([]*db.Listing, error) {
+	listings, err := querier(c, r.store).ListActiveListings(c, db.ListActiveListingsParams{
+		Now:      now,
+		ItemType: itemType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toListingPtrs(listings), nil
+}
+
+func (r *PostgresListingRepo) ExpireListings(c context.Context, now time.Time) ([]*db.Listing, error) {
+	listings, err := querier(c, r.store).ExpireListings(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return toListingPtrs(listings), nil
+}
+
+func (r *PostgresListingRepo) CloseListing(c context.Context, listingID int32, status, buyerUsername string) (*db.Listing, error) {
+	l, err := querier(c, r.store).CloseListing(c, db.CloseListingParams{
+		ListingID:     listingID,
+		Status:        status,
+		BuyerUsername: sql.NullString{String: buyerUsername, Valid: buyerUsername != ""},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrListingNotActive
+		}
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func toListingPtrs(listings []db.Listing) []*db.Listing {
+	ans := make([]*db.Listing, len(listings))
+	for i := range listings {
+		ans[i] = &listings[i]
+	}
+	return ans
+}
@@ -0,0 +1,95 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockListing = db.Listing{ListingID: 1, SellerUsername: mockUser1.Username, ItemType: "socks", Quantity: 2, Price: 15, Status: "active"}
+
+func TestListActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	listingRepo := NewPostgresListingRepo(mockStore)
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStore.EXPECT().
+		ListActiveListings(gomock.Any(), db.ListActiveListingsParams{Now: now, ItemType: "socks"}).
+		Return([]db.Listing{mockListing}, nil)
+	listings, err := listingRepo.ListActive(context.Background(), "socks", now)
+	require.NoError(t, err)
+	require.Equal(t, []*db.Listing{&mockListing}, listings)
+
+	mockStore.EXPECT().
+		ListActiveListings(gomock.Any(), db.ListActiveListingsParams{Now: now}).
+		Return(nil, ErrMock)
+	_, err = listingRepo.ListActive(context.Background(), "", now)
+	require.Equal(t, ErrMock, err)
+}
+
+func TestCloseListing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	listingRepo := NewPostgresListingRepo(mockStore)
+
+	testCases := []struct {
+		name         string
+		buyer        string
+		mockBehavior func(buyer string)
+		expAns       *db.Listing
+		expErr       error
+	}{
+		{
+			name:  "OK Sold",
+			buyer: mockUser2.Username,
+			mockBehavior: func(buyer string) {
+				mockStore.EXPECT().
+					CloseListing(gomock.Any(), db.CloseListingParams{
+						ListingID:     1,
+						Status:        "sold",
+						BuyerUsername: sql.NullString{String: buyer, Valid: true},
+					}).
+					Return(mockListing, nil)
+			},
+			expAns: &mockListing,
+			expErr: nil,
+		},
+		{
+			name:  "Not Active",
+			buyer: "",
+			mockBehavior: func(buyer string) {
+				mockStore.EXPECT().
+					CloseListing(gomock.Any(), db.CloseListingParams{
+						ListingID: 1,
+						Status:    "sold",
+					}).
+					Return(db.Listing{}, sql.ErrNoRows)
+			},
+			expAns: nil,
+			expErr: repository.ErrListingNotActive,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.buyer)
+
+			l, err := listingRepo.CloseListing(context.Background(), 1, "sold", tc.buyer)
+
+			require.Equal(t, tc.expAns, l)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package postgresrepo
+
+import (
+	"context"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresNotificationRepo struct {
+	store db.Querier
+}
+
+func NewPostgresNotificationRepo(store db.Querier) repository.NotificationRepository {
+	return &PostgresNotificationRepo{store}
+}
+
+func (r *PostgresNotificationRepo) CreateNotification(c context.Context, username, kind, message string) (*db.Notification, error) {
+	n, err := querier(c, r.store).CreateNotification(c, db.CreateNotificationParams{
+		Username: username,
+		Kind:     kind,
+		Message:  message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func (r *PostgresNotificationRepo) ListNotifications(c context.Context, username string, limit int32) ([]*db.Notification, error) {
+	notifications, err := querier(c, r.store).ListNotifications(c, db.ListNotificationsParams{
+		Username: username,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.Notification, len(notifications))
+	for i := range notifications {
+		ans[i] = &notifications[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresNotificationRepo) MarkAllRead(c context.Context, username string) error {
+	_, err := querier(c, r.store).MarkNotificationsRead(c, username)
+	return err
+}
@@ -0,0 +1,145 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresOrderRepo struct {
+	store db.Querier
+}
+
+func NewPostgresOrderRepo(store db.Querier) repository.OrderRepository {
+	return &PostgresOrderRepo{store}
+}
+
+func (r *PostgresOrderRepo) CreateOrder(c context.Context, order *models.NewOrder) (*db.Order, error) {
+	o, err := querier(c, r.store).CreateOrder(c, db.CreateOrderParams{
+		Username:          order.Username,
+		ItemType:          sql.NullString{String: order.Item, Valid: order.Item != ""},
+		Price:             order.Price,
+		Discount:          order.Discount,
+		PromoCode:         sql.NullString{String: order.PromoCode, Valid: order.PromoCode != ""},
+		PriceScheduleID:   sql.NullInt32{Int32: order.ScheduleID, Valid: order.ScheduleID != 0},
+		Variant:           sql.NullString{String: order.Variant, Valid: order.Variant != ""},
+		Bundle:            sql.NullString{String: order.Bundle, Valid: order.Bundle != ""},
+		RecipientUsername: sql.NullString{String: order.Recipient, Valid: order.Recipient != ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lot := range order.CoinLots {
+		err = querier(c, r.store).CreateOrderCoinLot(c, db.CreateOrderCoinLotParams{
+			OrderID:   o.OrderID,
+			Amount:    lot.Amount,
+			ExpiresAt: lot.ExpiresAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &o, nil
+}
+
+func (r *PostgresOrderRepo) GetOrderCoinLots(c context.Context, orderID int32) ([]*db.OrderCoinLot, error) {
+	lots, err := querier(c, r.store).GetOrderCoinLots(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*db.OrderCoinLot, len(lots))
+	for i := range lots {
+		res[i] = &lots[i]
+	}
+	return res, nil
+}
+
+func (r *PostgresOrderRepo) CountItemOrders(c context.Context, username, itemType string, since time.Time) (int32, int32, error) {
+	res, err := querier(c, r.store).CountUserItemOrders(c, db.CountUserItemOrdersParams{
+		Since:    since,
+		Username: username,
+		ItemType: sql.NullString{String: itemType, Valid: true},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int32(res.Total), int32(res.SinceCount), nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresOrderRepo) GetOrderForUpdate(c context.Context, orderID int32) (*db.Order, error) {
+	o, err := querier(c, r.store).GetOrderForUpdate(c, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (r *PostgresOrderRepo) ListUserOrders(c context.Context, username string) ([]*db.Order, error) {
+	orders, err := querier(c, r.store).ListUserOrders(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderPtrs(orders), nil
+}
+
+func (r *PostgresOrderRepo) ListOrders(c context.Context, status string) ([]*db.Order, error) {
+	orders, err := querier(c, r.store).ListOrders(c, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderPtrs(orders), nil
+}
+
+func (r *PostgresOrderRepo) UpdateStatus(c context.Context, orderID int32, status string) (*db.Order, error) {
+	o, err := querier(c, r.store).UpdateOrderStatus(c, db.UpdateOrderStatusParams{
+		OrderID: orderID,
+		Status:  status,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (r *PostgresOrderRepo) SetPickupLocation(c context.Context, orderID int32, location string) (*db.Order, error) {
+	o, err := querier(c, r.store).SetOrderPickupLocation(c, db.SetOrderPickupLocationParams{
+		OrderID:        orderID,
+		PickupLocation: location,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func toOrderPtrs(orders []db.Order) []*db.Order {
+	res := make([]*db.Order, len(orders))
+	for i := range orders {
+		res[i] = &orders[i]
+	}
+	return res
+}
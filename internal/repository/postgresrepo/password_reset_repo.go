@@ -0,0 +1,55 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresPasswordResetRepo struct {
+	store db.Querier
+}
+
+func NewPostgresPasswordResetRepo(store db.Querier) repository.PasswordResetRepository {
+	return &PostgresPasswordResetRepo{store}
+}
+
+func (r *PostgresPasswordResetRepo) CreateReset(c context.Context, tokenHash, username, createdBy string, expiresAt time.Time) (*db.PasswordReset, error) {
+	res, err := querier(c, r.store).CreatePasswordReset(c, db.CreatePasswordResetParams{
+		TokenHash: tokenHash,
+		Username:  username,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresPasswordResetRepo) UseReset(c context.Context, tokenHash string, now time.Time) (*db.PasswordReset, error) {
+	res, err := querier(c, r.store).UsePasswordReset(c, db.UsePasswordResetParams{
+		TokenHash: tokenHash,
+		Now:       now,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPasswordResetNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresPasswordResetRepo) InvalidateResets(c context.Context, username string) error {
+	return querier(c, r.store).InvalidatePasswordResets(c, username)
+}
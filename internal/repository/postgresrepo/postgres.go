@@ -1,12 +1,14 @@
 package postgresrepo
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/lib/pq"
 	db "github.com/myacey/avito-shop/db/sqlc"
 	"github.com/myacey/avito-shop/internal/backconfig"
+	"github.com/myacey/avito-shop/internal/repository"
 )
 
 func ConfiurePostgres(config backconfig.Config) (*db.Queries, *sql.DB, error) {
@@ -29,6 +31,19 @@ func ConfiurePostgres(config backconfig.Config) (*db.Queries, *sql.DB, error) {
 	return queries, conn, nil
 }
 
+// querier returns store bound to tx of context if there is one,
+// so repository calls of service transaction run in it.
+func querier(c context.Context, store db.Querier) db.Querier {
+	tx, ok := repository.TxFromContext(c)
+	if !ok {
+		return store
+	}
+	if q, ok := store.(*db.Queries); ok {
+		return q.WithTx(tx)
+	}
+	return store
+}
+
 // isUniqueViolation checks if err is about
 // not unique val.
 func isUniqueViolation(err error) bool {
@@ -37,3 +52,21 @@ func isUniqueViolation(err error) bool {
 	}
 	return false
 }
+
+// isExclusionViolation checks if err is about row
+// conflicting with existing one by exclusion constraint.
+func isExclusionViolation(err error) bool {
+	if pqErr, ok := err.(*pq.Error); ok {
+		return pqErr.Code == "23P01"
+	}
+	return false
+}
+
+// isForeignKeyViolation checks if err is about
+// reference to unknown row.
+func isForeignKeyViolation(err error) bool {
+	if pqErr, ok := err.(*pq.Error); ok {
+		return pqErr.Code == "23503"
+	}
+	return false
+}
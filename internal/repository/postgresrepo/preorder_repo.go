@@ -0,0 +1,180 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresPreorderRepo struct {
+	store db.Querier
+}
+
+func NewPostgresPreorderRepo(store db.Querier) repository.PreorderRepository {
+	return &PostgresPreorderRepo{store}
+}
+
+func (r *PostgresPreorderRepo) CreateBatch(c context.Context, itemType, variant string, price int32, createdBy string, expiresAt time.Time) (*db.PreorderBatch, error) {
+	b, err := querier(c, r.store).CreatePreorderBatch(c, db.CreatePreorderBatchParams{
+		ItemType:  itemType,
+		Variant:   sql.NullString{String: variant, Valid: variant != ""},
+		Price:     price,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresPreorderRepo) GetBatch(c context.Context, batchID int32) (*db.PreorderBatch, error) {
+	b, err := querier(c, r.store).GetPreorderBatch(c, batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPreorderBatchNotFound
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresPreorderRepo) GetBatchForUpdate(c context.Context, batchID int32) (*db.PreorderBatch, error) {
+	b, err := querier(c, r.store).GetPreorderBatchForUpdate(c, batchID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPreorderBatchNotFound
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresPreorderRepo) ListOpenBatches(c context.Context) ([]*db.PreorderBatch, error) {
+	batches, err := querier(c, r.store).ListOpenPreorderBatches(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPreorderBatchPtrs(batches), nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresPreorderRepo) GetExpiredBatches(c context.Context, now time.Time) ([]*db.PreorderBatch, error) {
+	batches, err := querier(c, r.store).GetExpiredPreorderBatches(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPreorderBatchPtrs(batches), nil
+}
+
+func (r *PostgresPreorderRepo) CloseBatch(c context.Context, batchID int32, status string) (*db.PreorderBatch, error) {
+	b, err := querier(c, r.store).ClosePreorderBatch(c, db.ClosePreorderBatchParams{
+		BatchID: batchID,
+		Status:  status,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPreorderBatchNotOpen
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r *PostgresPreorderRepo) CreatePreorder(c context.Context, batchID int32, username string) (*db.Preorder, error) {
+	p, err := querier(c, r.store).CreatePreorder(c, db.CreatePreorderParams{
+		BatchID:  batchID,
+		Username: username,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrPreorderExists
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresPreorderRepo) GetPreorderForUpdate(c context.Context, preorderID int32) (*db.Preorder, error) {
+	p, err := querier(c, r.store).GetPreorderForUpdate(c, preorderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPreorderNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresPreorderRepo) GetPendingPreorders(c context.Context, batchID int32) ([]*db.Preorder, error) {
+	preorders, err := querier(c, r.store).GetPendingPreorders(c, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPreorderPtrs(preorders), nil
+}
+
+func (r *PostgresPreorderRepo) CountPending(c context.Context, batchID int32) (int32, error) {
+	n, err := querier(c, r.store).CountPendingPreorders(c, batchID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(n), nil
+}
+
+func (r *PostgresPreorderRepo) ListUserPreorders(c context.Context, username string) ([]*db.Preorder, error) {
+	preorders, err := querier(c, r.store).ListUserPreorders(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPreorderPtrs(preorders), nil
+}
+
+func (r *PostgresPreorderRepo) SetPreorderStatus(c context.Context, preorderID int32, status string) error {
+	n, err := querier(c, r.store).SetPreorderStatus(c, db.SetPreorderStatusParams{
+		PreorderID: preorderID,
+		Status:     status,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrPreorderNotPending
+	}
+
+	return nil
+}
+
+func toPreorderBatchPtrs(batches []db.PreorderBatch) []*db.PreorderBatch {
+	ans := make([]*db.PreorderBatch, len(batches))
+	for i := range batches {
+		ans[i] = &batches[i]
+	}
+	return ans
+}
+
+func toPreorderPtrs(preorders []db.Preorder) []*db.Preorder {
+	ans := make([]*db.Preorder, len(preorders))
+	for i := range preorders {
+		ans[i] = &preorders[i]
+	}
+	return ans
+}
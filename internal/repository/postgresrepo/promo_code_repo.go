@@ -0,0 +1,126 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresPromoCodeRepo struct {
+	store db.Querier
+}
+
+func NewPostgresPromoCodeRepo(store db.Querier) repository.PromoCodeRepository {
+	return &PostgresPromoCodeRepo{store}
+}
+
+func (r *PostgresPromoCodeRepo) CreatePromoCode(c context.Context, createdBy string, promo *models.NewPromoCode) (*db.PromoCode, error) {
+	arg := db.CreatePromoCodeParams{
+		Code:      promo.Code,
+		Kind:      promo.Kind,
+		Value:     promo.Value,
+		ItemType:  sql.NullString{String: promo.Item, Valid: promo.Item != ""},
+		MaxUses:   promo.MaxUses,
+		StartsAt:  *promo.StartsAt,
+		CreatedBy: createdBy,
+	}
+	if promo.EndsAt != nil {
+		arg.EndsAt = sql.NullTime{Time: *promo.EndsAt, Valid: true}
+	}
+
+	p, err := querier(c, r.store).CreatePromoCode(c, arg)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrPromoCodeExists
+		}
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrInvalidItemName
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresPromoCodeRepo) GetPromoCode(c context.Context, code string) (*db.PromoCode, error) {
+	p, err := querier(c, r.store).GetPromoCode(c, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPromoCodeNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *PostgresPromoCodeRepo) ListPromoCodes(c context.Context) ([]*db.PromoCode, error) {
+	codes, err := querier(c, r.store).ListPromoCodes(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.PromoCode, len(codes))
+	for i := range codes {
+		ans[i] = &codes[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresPromoCodeRepo) UsePromoCode(c context.Context, code, itemType string, now time.Time) (*db.PromoCode, error) {
+	p, err := querier(c, r.store).UsePromoCode(c, db.UsePromoCodeParams{
+		Code:     code,
+		Now:      now,
+		ItemType: itemType,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPromoCodeUnavailable
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r *PostgresPromoCodeRepo) ClaimUse(c context.Context, code, username string) error {
+	n, err := querier(c, r.store).ClaimPromoCodeUse(c, db.ClaimPromoCodeUseParams{
+		Code:     code,
+		Username: username,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrPromoCodeUseClaimed
+	}
+
+	return nil
+}
+
+func (r *PostgresPromoCodeRepo) ReleaseUse(c context.Context, code, username string) error {
+	_, err := querier(c, r.store).ReleasePromoCodeUse(c, db.ReleasePromoCodeUseParams{
+		Code:     code,
+		Username: username,
+	})
+	return err
+}
+
+func (r *PostgresPromoCodeRepo) DisablePromoCode(c context.Context, code string) (*db.PromoCode, error) {
+	p, err := querier(c, r.store).DisablePromoCode(c, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPromoCodeNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
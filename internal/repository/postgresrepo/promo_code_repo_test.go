@@ -0,0 +1,115 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	promoCodeRepo := NewPostgresPromoCodeRepo(mockStore)
+
+	startsAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(7 * 24 * time.Hour)
+	promo := &models.NewPromoCode{Code: "HOODY30", Kind: "percent", Value: 30, Item: "hoody", StartsAt: &startsAt, EndsAt: &endsAt}
+	arg := db.CreatePromoCodeParams{
+		Code:      "HOODY30",
+		Kind:      "percent",
+		Value:     30,
+		ItemType:  sql.NullString{String: "hoody", Valid: true},
+		StartsAt:  startsAt,
+		EndsAt:    sql.NullTime{Time: endsAt, Valid: true},
+		CreatedBy: "admin",
+	}
+
+	mockStore.EXPECT().
+		CreatePromoCode(gomock.Any(), arg).
+		Return(db.PromoCode{Code: "HOODY30"}, nil)
+	p, err := promoCodeRepo.CreatePromoCode(context.Background(), "admin", promo)
+	require.NoError(t, err)
+	require.Equal(t, &db.PromoCode{Code: "HOODY30"}, p)
+
+	mockStore.EXPECT().
+		CreatePromoCode(gomock.Any(), arg).
+		Return(db.PromoCode{}, &pq.Error{Code: "23505"})
+	_, err = promoCodeRepo.CreatePromoCode(context.Background(), "admin", promo)
+	require.Equal(t, repository.ErrPromoCodeExists, err)
+}
+
+func TestGetPromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	promoCodeRepo := NewPostgresPromoCodeRepo(mockStore)
+
+	mockStore.EXPECT().
+		GetPromoCode(gomock.Any(), "NOPE").
+		Return(db.PromoCode{}, sql.ErrNoRows)
+	_, err := promoCodeRepo.GetPromoCode(context.Background(), "NOPE")
+	require.Equal(t, repository.ErrPromoCodeNotFound, err)
+
+	mockStore.EXPECT().
+		GetPromoCode(gomock.Any(), "SALE").
+		Return(db.PromoCode{}, ErrMock)
+	_, err = promoCodeRepo.GetPromoCode(context.Background(), "SALE")
+	require.Equal(t, ErrMock, err)
+}
+
+func TestUsePromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	promoCodeRepo := NewPostgresPromoCodeRepo(mockStore)
+
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	arg := db.UsePromoCodeParams{Code: "SALE", Now: now, ItemType: "hoody"}
+
+	mockStore.EXPECT().
+		UsePromoCode(gomock.Any(), arg).
+		Return(db.PromoCode{Code: "SALE", UsedCount: 1}, nil)
+	p, err := promoCodeRepo.UsePromoCode(context.Background(), "SALE", "hoody", now)
+	require.NoError(t, err)
+	require.Equal(t, &db.PromoCode{Code: "SALE", UsedCount: 1}, p)
+
+	// inactive, other item or exhausted
+	mockStore.EXPECT().
+		UsePromoCode(gomock.Any(), arg).
+		Return(db.PromoCode{}, sql.ErrNoRows)
+	_, err = promoCodeRepo.UsePromoCode(context.Background(), "SALE", "hoody", now)
+	require.Equal(t, repository.ErrPromoCodeUnavailable, err)
+}
+
+func TestClaimPromoCodeUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	promoCodeRepo := NewPostgresPromoCodeRepo(mockStore)
+
+	arg := db.ClaimPromoCodeUseParams{Code: "SALE", Username: "alice"}
+
+	mockStore.EXPECT().
+		ClaimPromoCodeUse(gomock.Any(), arg).
+		Return(int64(1), nil)
+	require.NoError(t, promoCodeRepo.ClaimUse(context.Background(), "SALE", "alice"))
+
+	mockStore.EXPECT().
+		ClaimPromoCodeUse(gomock.Any(), arg).
+		Return(int64(0), nil)
+	require.Equal(t, repository.ErrPromoCodeUseClaimed, promoCodeRepo.ClaimUse(context.Background(), "SALE", "alice"))
+}
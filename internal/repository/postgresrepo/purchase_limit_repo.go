@@ -0,0 +1,74 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresPurchaseLimitRepo struct {
+	store db.Querier
+}
+
+func NewPostgresPurchaseLimitRepo(store db.Querier) repository.PurchaseLimitRepository {
+	return &PostgresPurchaseLimitRepo{store}
+}
+
+func (r *PostgresPurchaseLimitRepo) GetPurchaseLimit(c context.Context, itemType string) (*db.PurchaseLimit, error) {
+	limit, err := querier(c, r.store).GetPurchaseLimit(c, itemType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoPurchaseLimit
+		}
+		return nil, err
+	}
+
+	return &limit, nil
+}
+
+func (r *PostgresPurchaseLimitRepo) ListPurchaseLimits(c context.Context) ([]*db.PurchaseLimit, error) {
+	limits, err := querier(c, r.store).ListPurchaseLimits(c)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*db.PurchaseLimit, len(limits))
+	for i := range limits {
+		res[i] = &limits[i]
+	}
+
+	return res, nil
+}
+
+func (r *PostgresPurchaseLimitRepo) SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*db.PurchaseLimit, error) {
+	res, err := querier(c, r.store).UpsertPurchaseLimit(c, db.UpsertPurchaseLimitParams{
+		ItemType:   limit.Item,
+		Lifetime:   limit.Lifetime,
+		PerPeriod:  limit.PerPeriod,
+		PeriodDays: limit.PeriodDays,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrInvalidItemName
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresPurchaseLimitRepo) DeletePurchaseLimit(c context.Context, itemType string) error {
+	n, err := querier(c, r.store).DeletePurchaseLimit(c, itemType)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrNoPurchaseLimit
+	}
+
+	return nil
+}
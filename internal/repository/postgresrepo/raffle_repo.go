@@ -0,0 +1,146 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresRaffleRepo struct {
+	store db.Querier
+}
+
+func NewPostgresRaffleRepo(store db.Querier) repository.RaffleRepository {
+	return &PostgresRaffleRepo{store}
+}
+
+func (r *PostgresRaffleRepo) CreateRaffle(c context.Context, raffle *models.NewRaffle, seed, seedHash, createdBy string) (*db.Raffle, error) {
+	res, err := querier(c, r.store).CreateRaffle(c, db.CreateRaffleParams{
+		ItemType:    raffle.Item,
+		Prizes:      raffle.Prizes,
+		TicketPrice: raffle.TicketPrice,
+		MaxTickets:  raffle.MaxTickets,
+		Seed:        seed,
+		SeedHash:    seedHash,
+		CreatedBy:   createdBy,
+		DrawsAt:     raffle.DrawsAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresRaffleRepo) GetRaffle(c context.Context, raffleID int32) (*db.Raffle, error) {
+	res, err := querier(c, r.store).GetRaffle(c, raffleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRaffleNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresRaffleRepo) GetRaffleForUpdate(c context.Context, raffleID int32) (*db.Raffle, error) {
+	res, err := querier(c, r.store).GetRaffleForUpdate(c, raffleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRaffleNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresRaffleRepo) ListRaffles(c context.Context) ([]*db.Raffle, error) {
+	raffles, err := querier(c, r.store).ListRaffles(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRafflePtrs(raffles), nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresRaffleRepo) GetDueRaffles(c context.Context, now time.Time) ([]*db.Raffle, error) {
+	raffles, err := querier(c, r.store).GetDueRaffles(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRafflePtrs(raffles), nil
+}
+
+func (r *PostgresRaffleRepo) SetDrawn(c context.Context, raffleID int32) error {
+	return querier(c, r.store).SetRaffleDrawn(c, raffleID)
+}
+
+func (r *PostgresRaffleRepo) CreateTicket(c context.Context, raffleID int32, username string, price int32) (*db.RaffleTicket, error) {
+	t, err := querier(c, r.store).CreateRaffleTicket(c, db.CreateRaffleTicketParams{
+		RaffleID: raffleID,
+		Username: username,
+		Price:    price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *PostgresRaffleRepo) CountTickets(c context.Context, raffleID int32) (int32, error) {
+	n, err := querier(c, r.store).CountRaffleTickets(c, raffleID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(n), nil
+}
+
+func (r *PostgresRaffleRepo) CountUserTickets(c context.Context, raffleID int32, username string) (int32, error) {
+	n, err := querier(c, r.store).CountUserRaffleTickets(c, db.CountUserRaffleTicketsParams{
+		RaffleID: raffleID,
+		Username: username,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(n), nil
+}
+
+func (r *PostgresRaffleRepo) ListTickets(c context.Context, raffleID int32) ([]*db.RaffleTicket, error) {
+	tickets, err := querier(c, r.store).ListRaffleTickets(c, raffleID)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.RaffleTicket, len(tickets))
+	for i := range tickets {
+		ans[i] = &tickets[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresRaffleRepo) SetTicketWon(c context.Context, ticketID int32) error {
+	return querier(c, r.store).SetRaffleTicketWon(c, ticketID)
+}
+
+func toRafflePtrs(raffles []db.Raffle) []*db.Raffle {
+	ans := make([]*db.Raffle, len(raffles))
+	for i := range raffles {
+		ans[i] = &raffles[i]
+	}
+	return ans
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
 	"github.com/myacey/avito-shop/internal/repository"
 )
 
@@ -17,8 +19,8 @@ func NewPostgresStoreRepo(store db.Querier) repository.StoreRepository {
 	return &PostgresStoreRepo{store}
 }
 
-func (r *PostgresStoreRepo) GetItemInfo(c context.Context, itemName string) (*db.Item, error) {
-	item, err := r.store.GetItemFromStore(c, itemName)
+func (r *PostgresStoreRepo) GetItemInfo(c context.Context, itemName string, now time.Time) (*models.Item, error) {
+	item, err := querier(c, r.store).GetItemFromStore(c, itemName)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrInvalidItemName
@@ -26,5 +28,196 @@ func (r *PostgresStoreRepo) GetItemInfo(c context.Context, itemName string) (*db
 		return nil, err
 	}
 
-	return &item, nil
+	res := toItemModel(&item)
+	sale, err := querier(c, r.store).GetActivePriceSchedule(c, db.GetActivePriceScheduleParams{
+		ItemType: itemName,
+		Now:      now,
+	})
+	if err == nil {
+		applySale(res, &sale)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *PostgresStoreRepo) ListItems(c context.Context, now time.Time) ([]*models.Item, error) {
+	items, err := querier(c, r.store).ListItems(c)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := querier(c, r.store).ListActivePriceSchedules(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	saleByItem := make(map[string]*db.PriceSchedule, len(sales))
+	for i := range sales {
+		saleByItem[sales[i].ItemType] = &sales[i]
+	}
+
+	ans := make([]*models.Item, len(items))
+	for i := range items {
+		ans[i] = toItemModel(&items[i])
+		if sale, ok := saleByItem[items[i].ItemType]; ok {
+			applySale(ans[i], sale)
+		}
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresStoreRepo) CreatePriceSchedule(c context.Context, createdBy string, schedule *models.NewPriceSchedule) (*db.PriceSchedule, error) {
+	ps, err := querier(c, r.store).CreatePriceSchedule(c, db.CreatePriceScheduleParams{
+		ItemType:  schedule.Item,
+		SalePrice: schedule.SalePrice,
+		StartsAt:  schedule.StartsAt,
+		EndsAt:    schedule.EndsAt,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrInvalidItemName
+		}
+		if isExclusionViolation(err) {
+			return nil, repository.ErrScheduleOverlap
+		}
+		return nil, err
+	}
+
+	return &ps, nil
+}
+
+func (r *PostgresStoreRepo) ListPriceSchedules(c context.Context, itemType string) ([]*db.PriceSchedule, error) {
+	schedules, err := querier(c, r.store).ListPriceSchedules(c, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	ans := make([]*db.PriceSchedule, len(schedules))
+	for i := range schedules {
+		ans[i] = &schedules[i]
+	}
+
+	return ans, nil
+}
+
+func (r *PostgresStoreRepo) CancelPriceSchedule(c context.Context, scheduleID int32) (*db.PriceSchedule, error) {
+	ps, err := querier(c, r.store).CancelPriceSchedule(c, scheduleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrPriceScheduleNotFound
+		}
+		return nil, err
+	}
+
+	return &ps, nil
+}
+
+func (r *PostgresStoreRepo) ListItemVariants(c context.Context, itemType string) ([]*db.ItemVariant, error) {
+	variants, err := querier(c, r.store).ListItemVariants(c, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVariantPtrs(variants), nil
+}
+
+func (r *PostgresStoreRepo) ListAllItemVariants(c context.Context) ([]*db.ItemVariant, error) {
+	variants, err := querier(c, r.store).ListAllItemVariants(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return toVariantPtrs(variants), nil
+}
+
+func (r *PostgresStoreRepo) CreateVariant(c context.Context, itemType string, variant *models.NewVariant) (*db.ItemVariant, error) {
+	v, err := querier(c, r.store).CreateItemVariant(c, db.CreateItemVariantParams{
+		Sku:        variant.SKU,
+		ItemType:   itemType,
+		Size:       variant.Size,
+		Color:      variant.Color,
+		PriceDelta: variant.PriceDelta,
+		Stock:      variant.Stock,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrVariantExists
+		}
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrInvalidItemName
+		}
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func (r *PostgresStoreRepo) SetVariantStock(c context.Context, sku string, stock int32) (*db.ItemVariant, error) {
+	v, err := querier(c, r.store).UpdateItemVariantStock(c, db.UpdateItemVariantStockParams{
+		Sku:   sku,
+		Stock: stock,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrVariantNotFound
+		}
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func (r *PostgresStoreRepo) TakeVariantStock(c context.Context, sku string, quantity int32) error {
+	n, err := querier(c, r.store).TakeItemVariantStock(c, db.TakeItemVariantStockParams{
+		Sku:   sku,
+		Stock: quantity,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrVariantOutOfStock
+	}
+
+	return nil
+}
+
+func (r *PostgresStoreRepo) AddVariantStock(c context.Context, sku string, quantity int32) error {
+	n, err := querier(c, r.store).AddItemVariantStock(c, db.AddItemVariantStockParams{
+		Sku:   sku,
+		Stock: quantity,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func toItemModel(item *db.Item) *models.Item {
+	return &models.Item{
+		Type:         item.ItemType,
+		Price:        int32(item.ItemPrice),
+		CurrentPrice: int32(item.ItemPrice),
+	}
+}
+
+func applySale(item *models.Item, sale *db.PriceSchedule) {
+	item.CurrentPrice = sale.SalePrice
+	item.SaleEndsAt = &sale.EndsAt
+	item.ScheduleID = sale.ScheduleID
+}
+
+func toVariantPtrs(variants []db.ItemVariant) []*db.ItemVariant {
+	ans := make([]*db.ItemVariant, len(variants))
+	for i := range variants {
+		ans[i] = &variants[i]
+	}
+	return ans
 }
@@ -4,15 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	db "github.com/myacey/avito-shop/db/sqlc"
 	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
 	"github.com/myacey/avito-shop/internal/repository"
 	"github.com/stretchr/testify/require"
 )
 
-var mockItem = db.Item{ItemID: 1, ItemType: "mockType", ItemPrice: 10}
+var (
+	mockItem = db.Item{ItemID: 1, ItemType: "mockType", ItemPrice: 10}
+	mockNow  = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+)
 
 func TestGetItemInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -25,7 +31,7 @@ func TestGetItemInfo(t *testing.T) {
 		name         string
 		itemName     string
 		mockBehavior func(itemName string)
-		expAns       *db.Item
+		expAns       *models.Item
 		expErr       error
 	}{
 		{
@@ -35,8 +41,31 @@ func TestGetItemInfo(t *testing.T) {
 				mockStore.EXPECT().
 					GetItemFromStore(gomock.Any(), itemName).
 					Return(mockItem, nil)
+				mockStore.EXPECT().
+					GetActivePriceSchedule(gomock.Any(), db.GetActivePriceScheduleParams{ItemType: itemName, Now: mockNow}).
+					Return(db.PriceSchedule{}, sql.ErrNoRows)
+			},
+			expAns: &models.Item{Type: mockItem.ItemType, Price: 10, CurrentPrice: 10},
+			expErr: nil,
+		},
+		{
+			name:     "OK Sale",
+			itemName: mockItem.ItemType,
+			mockBehavior: func(itemName string) {
+				mockStore.EXPECT().
+					GetItemFromStore(gomock.Any(), itemName).
+					Return(mockItem, nil)
+				mockStore.EXPECT().
+					GetActivePriceSchedule(gomock.Any(), db.GetActivePriceScheduleParams{ItemType: itemName, Now: mockNow}).
+					Return(db.PriceSchedule{ScheduleID: 3, ItemType: itemName, SalePrice: 7, EndsAt: mockNow.Add(time.Hour)}, nil)
+			},
+			expAns: &models.Item{
+				Type:         mockItem.ItemType,
+				Price:        10,
+				CurrentPrice: 7,
+				SaleEndsAt:   ptr(mockNow.Add(time.Hour)),
+				ScheduleID:   3,
 			},
-			expAns: &mockItem,
 			expErr: nil,
 		},
 		{
@@ -67,10 +96,73 @@ func TestGetItemInfo(t *testing.T) {
 		t.Run(ts.name, func(t *testing.T) {
 			ts.mockBehavior(ts.itemName)
 
-			item, err := storeRepo.GetItemInfo(context.Background(), ts.itemName)
+			item, err := storeRepo.GetItemInfo(context.Background(), ts.itemName, mockNow)
 
 			require.Equal(t, item, ts.expAns)
 			require.Equal(t, err, ts.expErr)
 		})
 	}
 }
+
+func TestListItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	storeRepo := NewPostgresStoreRepo(mockStore)
+
+	mockStore.EXPECT().
+		ListItems(gomock.Any()).
+		Return([]db.Item{{ItemType: "cup", ItemPrice: 20}, {ItemType: "hoody", ItemPrice: 300}}, nil)
+	mockStore.EXPECT().
+		ListActivePriceSchedules(gomock.Any(), mockNow).
+		Return([]db.PriceSchedule{{ScheduleID: 1, ItemType: "hoody", SalePrice: 210, EndsAt: mockNow.Add(time.Hour)}}, nil)
+
+	items, err := storeRepo.ListItems(context.Background(), mockNow)
+	require.NoError(t, err)
+	require.Equal(t, []*models.Item{
+		{Type: "cup", Price: 20, CurrentPrice: 20},
+		{
+			Type:         "hoody",
+			Price:        300,
+			CurrentPrice: 210,
+			SaleEndsAt:   ptr(mockNow.Add(time.Hour)),
+			ScheduleID:   1,
+		},
+	}, items)
+}
+
+func TestCreatePriceSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	storeRepo := NewPostgresStoreRepo(mockStore)
+
+	schedule := &models.NewPriceSchedule{Item: "hoody", SalePrice: 210, StartsAt: mockNow, EndsAt: mockNow.Add(time.Hour)}
+	arg := db.CreatePriceScheduleParams{
+		ItemType:  "hoody",
+		SalePrice: 210,
+		StartsAt:  mockNow,
+		EndsAt:    mockNow.Add(time.Hour),
+		CreatedBy: "admin",
+	}
+
+	mockStore.EXPECT().
+		CreatePriceSchedule(gomock.Any(), arg).
+		Return(db.PriceSchedule{ScheduleID: 1}, nil)
+	ps, err := storeRepo.CreatePriceSchedule(context.Background(), "admin", schedule)
+	require.NoError(t, err)
+	require.Equal(t, &db.PriceSchedule{ScheduleID: 1}, ps)
+
+	// concurrent schedule was inserted first
+	mockStore.EXPECT().
+		CreatePriceSchedule(gomock.Any(), arg).
+		Return(db.PriceSchedule{}, &pq.Error{Code: "23P01"})
+	_, err = storeRepo.CreatePriceSchedule(context.Background(), "admin", schedule)
+	require.Equal(t, repository.ErrScheduleOverlap, err)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
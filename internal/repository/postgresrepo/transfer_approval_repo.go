@@ -0,0 +1,93 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresTransferApprovalRepo struct {
+	store db.Querier
+}
+
+func NewPostgresTransferApprovalRepo(store db.Querier) repository.TransferApprovalRepository {
+	return &PostgresTransferApprovalRepo{store}
+}
+
+func (r *PostgresTransferApprovalRepo) CreateApproval(c context.Context, fromUsername, toUsername string, amount int32, expiresAt time.Time) (*db.TransferApproval, error) {
+	a, err := querier(c, r.store).CreateTransferApproval(c, db.CreateTransferApprovalParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		Amount:       amount,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresTransferApprovalRepo) GetApprovalForUpdate(c context.Context, approvalID int32) (*db.TransferApproval, error) {
+	a, err := querier(c, r.store).GetTransferApprovalForUpdate(c, approvalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrApprovalNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *PostgresTransferApprovalRepo) ListApprovals(c context.Context, status string) ([]*db.TransferApproval, error) {
+	approvals, err := querier(c, r.store).ListTransferApprovals(c, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return toApprovalPtrs(approvals), nil
+}
+
+// Should be called only in transactions.
+func (r *PostgresTransferApprovalRepo) GetExpiredApprovals(c context.Context, now time.Time) ([]*db.TransferApproval, error) {
+	approvals, err := querier(c, r.store).GetExpiredTransferApprovals(c, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return toApprovalPtrs(approvals), nil
+}
+
+func (r *PostgresTransferApprovalRepo) ResolveApproval(c context.Context, approvalID int32, status, resolvedBy string, transferID int32) (*db.TransferApproval, error) {
+	a, err := querier(c, r.store).ResolveTransferApproval(c, db.ResolveTransferApprovalParams{
+		ApprovalID: approvalID,
+		Status:     status,
+		ResolvedBy: sql.NullString{String: resolvedBy, Valid: resolvedBy != ""},
+		TransferID: sql.NullInt32{Int32: transferID, Valid: transferID != 0},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrApprovalNotFound
+		}
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func toApprovalPtrs(approvals []db.TransferApproval) []*db.TransferApproval {
+	ans := make([]*db.TransferApproval, len(approvals))
+	for i := range approvals {
+		ans[i] = &approvals[i]
+	}
+	return ans
+}
@@ -0,0 +1,113 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockApproval = db.TransferApproval{ApprovalID: 1, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, Amount: 700, Status: "pending"}
+
+func TestResolveApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	approvalRepo := NewPostgresTransferApprovalRepo(mockStore)
+
+	testCases := []struct {
+		name         string
+		resolvedBy   string
+		transferID   int32
+		mockBehavior func(resolvedBy string, transferID int32)
+		expAns       *db.TransferApproval
+		expErr       error
+	}{
+		{
+			name:       "OK Approved",
+			resolvedBy: "finance",
+			transferID: 9,
+			mockBehavior: func(resolvedBy string, transferID int32) {
+				mockStore.EXPECT().
+					ResolveTransferApproval(gomock.Any(), db.ResolveTransferApprovalParams{
+						ApprovalID: 1,
+						Status:     "approved",
+						ResolvedBy: sql.NullString{String: resolvedBy, Valid: true},
+						TransferID: sql.NullInt32{Int32: transferID, Valid: true},
+					}).
+					Return(mockApproval, nil)
+			},
+			expAns: &mockApproval,
+			expErr: nil,
+		},
+		{
+			name:       "OK Expired",
+			resolvedBy: "",
+			transferID: 0,
+			mockBehavior: func(resolvedBy string, transferID int32) {
+				mockStore.EXPECT().
+					ResolveTransferApproval(gomock.Any(), db.ResolveTransferApprovalParams{
+						ApprovalID: 1,
+						Status:     "approved",
+						ResolvedBy: sql.NullString{},
+						TransferID: sql.NullInt32{},
+					}).
+					Return(mockApproval, nil)
+			},
+			expAns: &mockApproval,
+			expErr: nil,
+		},
+		{
+			name:       "Not Found",
+			resolvedBy: "finance",
+			transferID: 0,
+			mockBehavior: func(resolvedBy string, transferID int32) {
+				mockStore.EXPECT().
+					ResolveTransferApproval(gomock.Any(), gomock.Any()).
+					Return(db.TransferApproval{}, sql.ErrNoRows)
+			},
+			expAns: nil,
+			expErr: repository.ErrApprovalNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.resolvedBy, tc.transferID)
+
+			a, err := approvalRepo.ResolveApproval(context.Background(), 1, "approved", tc.resolvedBy, tc.transferID)
+
+			require.Equal(t, tc.expAns, a)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGetExpiredApprovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	approvalRepo := NewPostgresTransferApprovalRepo(mockStore)
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mockStore.EXPECT().
+		GetExpiredTransferApprovals(gomock.Any(), now).
+		Return([]db.TransferApproval{mockApproval}, nil)
+	approvals, err := approvalRepo.GetExpiredApprovals(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, []*db.TransferApproval{&mockApproval}, approvals)
+
+	mockStore.EXPECT().
+		GetExpiredTransferApprovals(gomock.Any(), now).
+		Return(nil, ErrMock)
+	_, err = approvalRepo.GetExpiredApprovals(context.Background(), now)
+	require.Equal(t, ErrMock, err)
+}
@@ -0,0 +1,68 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresTransferLimitRepo struct {
+	store db.Querier
+}
+
+func NewPostgresTransferLimitRepo(store db.Querier) repository.TransferLimitRepository {
+	return &PostgresTransferLimitRepo{store}
+}
+
+func (r *PostgresTransferLimitRepo) GetOverride(c context.Context, username string) (*db.TransferLimitOverride, error) {
+	override, err := querier(c, r.store).GetTransferLimitOverride(c, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoLimitOverride
+		}
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *PostgresTransferLimitRepo) SetOverride(c context.Context, username string, override *models.TransferLimitOverride) (*db.TransferLimitOverride, error) {
+	res, err := querier(c, r.store).UpsertTransferLimitOverride(c, db.UpsertTransferLimitOverrideParams{
+		Username:       username,
+		PerTransaction: toNullInt32(override.PerTransaction),
+		Daily:          toNullInt32(override.Daily),
+		Monthly:        toNullInt32(override.Monthly),
+		PerRecipient:   toNullInt32(override.PerRecipient),
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *PostgresTransferLimitRepo) DeleteOverride(c context.Context, username string) error {
+	n, err := querier(c, r.store).DeleteTransferLimitOverride(c, username)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrNoLimitOverride
+	}
+
+	return nil
+}
+
+func toNullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
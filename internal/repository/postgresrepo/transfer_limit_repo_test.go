@@ -0,0 +1,88 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	limitRepo := NewPostgresTransferLimitRepo(mockStore)
+
+	daily := int32(100)
+	override := &models.TransferLimitOverride{Daily: &daily}
+	params := db.UpsertTransferLimitOverrideParams{
+		Username: mockUser1.Username,
+		Daily:    sql.NullInt32{Int32: daily, Valid: true},
+	}
+	dbOverride := db.TransferLimitOverride{Username: mockUser1.Username, Daily: params.Daily}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expAns       *db.TransferLimitOverride
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					UpsertTransferLimitOverride(gomock.Any(), params).
+					Return(dbOverride, nil)
+			},
+			expAns: &dbOverride,
+			expErr: nil,
+		},
+		{
+			name: "Unknown User",
+			mockBehavior: func() {
+				mockStore.EXPECT().
+					UpsertTransferLimitOverride(gomock.Any(), params).
+					Return(db.TransferLimitOverride{}, &pq.Error{Code: "23503"})
+			},
+			expAns: nil,
+			expErr: repository.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			res, err := limitRepo.SetOverride(context.Background(), mockUser1.Username, override)
+
+			require.Equal(t, tc.expAns, res)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDeleteOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockQuerier(ctrl)
+	limitRepo := NewPostgresTransferLimitRepo(mockStore)
+
+	mockStore.EXPECT().
+		DeleteTransferLimitOverride(gomock.Any(), mockUser1.Username).
+		Return(int64(1), nil)
+	require.NoError(t, limitRepo.DeleteOverride(context.Background(), mockUser1.Username))
+
+	mockStore.EXPECT().
+		DeleteTransferLimitOverride(gomock.Any(), mockUser1.Username).
+		Return(int64(0), nil)
+	require.Equal(t, repository.ErrNoLimitOverride, limitRepo.DeleteOverride(context.Background(), mockUser1.Username))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	db "github.com/myacey/avito-shop/db/sqlc"
 	"github.com/myacey/avito-shop/internal/repository"
@@ -24,7 +25,7 @@ func (r *PostgresTransferRepo) CreateMoneyTransfer(c context.Context, fromUserna
 		Amount:       amount,
 	}
 
-	transfer, err := r.store.CreateMoneyTransfer(c, arg)
+	transfer, err := querier(c, r.store).CreateMoneyTransfer(c, arg)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func (r *PostgresTransferRepo) CreateMoneyTransfer(c context.Context, fromUserna
 }
 
 func (r *PostgresTransferRepo) GetTransfersWithUser(c context.Context, username string) ([]*db.Transfer, error) {
-	transfers, err := r.store.GetTransfersWithUser(c, username)
+	transfers, err := querier(c, r.store).GetTransfersWithUser(c, username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrNoTransfers
@@ -48,3 +49,51 @@ func (r *PostgresTransferRepo) GetTransfersWithUser(c context.Context, username
 
 	return ans, nil
 }
+
+func (r *PostgresTransferRepo) GetSentAmountSince(c context.Context, fromUsername string, since time.Time) (int32, error) {
+	return querier(c, r.store).GetSentAmountSince(c, db.GetSentAmountSinceParams{
+		FromUsername: fromUsername,
+		CreatedAt:    since,
+	})
+}
+
+func (r *PostgresTransferRepo) GetSentToUserAmountSince(c context.Context, fromUsername, toUsername string, since time.Time) (int32, error) {
+	return querier(c, r.store).GetSentToUserAmountSince(c, db.GetSentToUserAmountSinceParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+		CreatedAt:    since,
+	})
+}
+
+func (r *PostgresTransferRepo) GetReservedAmount(c context.Context, fromUsername, toUsername string) (int32, error) {
+	if toUsername == "" {
+		return querier(c, r.store).GetReservedTransferAmount(c, fromUsername)
+	}
+	return querier(c, r.store).GetReservedTransferToUserAmount(c, db.GetReservedTransferToUserAmountParams{
+		FromUsername: fromUsername,
+		ToUsername:   toUsername,
+	})
+}
+
+func (r *PostgresTransferRepo) GetRecipientsSince(c context.Context, fromUsername string, since time.Time) ([]string, error) {
+	return querier(c, r.store).GetRecipientsSince(c, db.GetRecipientsSinceParams{
+		FromUsername: fromUsername,
+		CreatedAt:    since,
+	})
+}
+
+func (r *PostgresTransferRepo) CountSentSince(c context.Context, fromUsername string, since time.Time) (int32, error) {
+	return querier(c, r.store).CountSentSince(c, db.CountSentSinceParams{
+		FromUsername: fromUsername,
+		CreatedAt:    since,
+	})
+}
+
+func (r *PostgresTransferRepo) CountNewSendersSince(c context.Context, toUsername, excludeUsername string, since, registeredAfter time.Time) (int32, error) {
+	return querier(c, r.store).CountNewSendersSince(c, db.CountNewSendersSinceParams{
+		ToUsername:      toUsername,
+		ExcludeUsername: excludeUsername,
+		Since:           since,
+		RegisteredAfter: registeredAfter,
+	})
+}
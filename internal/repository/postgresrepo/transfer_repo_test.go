@@ -95,31 +95,31 @@ func TestGetTransfersWithUser(t *testing.T) {
 					GetTransfersWithUser(gomock.Any(), username).
 					Return([]db.Transfer{
 						{
-							mockTransfer1.TransferID,
-							mockTransfer1.FromUsername,
-							mockTransfer1.ToUsername,
-							mockTransfer1.Amount,
+							TransferID:   mockTransfer1.TransferID,
+							FromUsername: mockTransfer1.FromUsername,
+							ToUsername:   mockTransfer1.ToUsername,
+							Amount:       mockTransfer1.Amount,
 						},
 						{
-							mockTransfer2.TransferID,
-							mockTransfer2.FromUsername,
-							mockTransfer2.ToUsername,
-							mockTransfer2.Amount,
+							TransferID:   mockTransfer2.TransferID,
+							FromUsername: mockTransfer2.FromUsername,
+							ToUsername:   mockTransfer2.ToUsername,
+							Amount:       mockTransfer2.Amount,
 						},
 					}, nil)
 			},
 			expAns: []*db.Transfer{
 				{
-					mockTransfer1.TransferID,
-					mockTransfer1.FromUsername,
-					mockTransfer1.ToUsername,
-					mockTransfer1.Amount,
+					TransferID:   mockTransfer1.TransferID,
+					FromUsername: mockTransfer1.FromUsername,
+					ToUsername:   mockTransfer1.ToUsername,
+					Amount:       mockTransfer1.Amount,
 				},
 				{
-					mockTransfer2.TransferID,
-					mockTransfer2.FromUsername,
-					mockTransfer2.ToUsername,
-					mockTransfer2.Amount,
+					TransferID:   mockTransfer2.TransferID,
+					FromUsername: mockTransfer2.FromUsername,
+					ToUsername:   mockTransfer2.ToUsername,
+					Amount:       mockTransfer2.Amount,
 				},
 			},
 			expErr: nil,
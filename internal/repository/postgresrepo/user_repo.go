@@ -24,7 +24,7 @@ func (r *PostgresUserRepo) CreateUser(c context.Context, username, password stri
 		Username: username,
 		Password: password,
 	}
-	usr, err := r.store.CreateUser(c, arg)
+	usr, err := querier(c, r.store).CreateUser(c, arg)
 	if err != nil {
 		if isUniqueViolation(err) {
 			return nil, repository.ErrUserAlreadyExists
@@ -36,7 +36,7 @@ func (r *PostgresUserRepo) CreateUser(c context.Context, username, password stri
 }
 
 func (r *PostgresUserRepo) GetUser(c context.Context, username string) (*db.User, error) {
-	usr, err := r.store.GetUser(c, username)
+	usr, err := querier(c, r.store).GetUser(c, username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrUserNotFound
@@ -49,7 +49,7 @@ func (r *PostgresUserRepo) GetUser(c context.Context, username string) (*db.User
 
 // Should be called only in transactions.
 func (r *PostgresUserRepo) GetUserForUpdate(c context.Context, username string) (*db.User, error) {
-	usr, err := r.store.GetUserForUpdate(c, username)
+	usr, err := querier(c, r.store).GetUserForUpdate(c, username)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrUserNotFound
@@ -60,13 +60,33 @@ func (r *PostgresUserRepo) GetUserForUpdate(c context.Context, username string)
 	return &usr, nil
 }
 
+func (r *PostgresUserRepo) LockTwoUsers(c context.Context, firstUsername, secondUsername string) ([]*db.User, error) {
+	usrs, err := querier(c, r.store).LockTwoUsers(c, db.LockTwoUsersParams{
+		FirstUsername:  firstUsername,
+		SecondUsername: secondUsername,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(usrs) < 2 && firstUsername != secondUsername || len(usrs) == 0 {
+		return nil, repository.ErrUserNotFound
+	}
+
+	ans := make([]*db.User, len(usrs))
+	for i := range usrs {
+		ans[i] = &usrs[i]
+	}
+
+	return ans, nil
+}
+
 func (r *PostgresUserRepo) UpdateBalance(c context.Context, userID int32, newCointCount int32) (*db.User, error) {
 	arg := db.UpdateUserBalanceParams{
 		UserID: userID,
 		Coins:  newCointCount,
 	}
 
-	usr, err := r.store.UpdateUserBalance(c, arg)
+	usr, err := querier(c, r.store).UpdateUserBalance(c, arg)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrUserNotFound
@@ -78,7 +98,7 @@ func (r *PostgresUserRepo) UpdateBalance(c context.Context, userID int32, newCoi
 }
 
 func (r *PostgresUserRepo) UpdateTwoUsersBalance(c context.Context, fromUsername, toUsername string, coinsAmount int32) ([]*db.User, error) {
-	usrs, err := r.store.UpdateTwoUsersBalance(c, db.UpdateTwoUsersBalanceParams{
+	usrs, err := querier(c, r.store).UpdateTwoUsersBalance(c, db.UpdateTwoUsersBalanceParams{
 		FromUsername: fromUsername,
 		ToUsername:   toUsername,
 		Coins:        coinsAmount,
@@ -97,3 +117,63 @@ func (r *PostgresUserRepo) UpdateTwoUsersBalance(c context.Context, fromUsername
 
 	return ans, nil
 }
+
+func (r *PostgresUserRepo) AddCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	usr, err := querier(c, r.store).AddUserCoins(c, db.AddUserCoinsParams{
+		Amount:   amount,
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &usr, nil
+}
+
+func (r *PostgresUserRepo) HoldCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	usr, err := querier(c, r.store).HoldUserCoins(c, db.HoldUserCoinsParams{
+		Amount:   amount,
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &usr, nil
+}
+
+func (r *PostgresUserRepo) ReleaseCoins(c context.Context, username string, amount int32) (*db.User, error) {
+	usr, err := querier(c, r.store).ReleaseUserCoins(c, db.ReleaseUserCoinsParams{
+		Amount:   amount,
+		Username: username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &usr, nil
+}
+
+func (r *PostgresUserRepo) UpdatePassword(c context.Context, username, password string) error {
+	return querier(c, r.store).UpdateUserPassword(c, db.UpdateUserPasswordParams{
+		Username: username,
+		Password: password,
+	})
+}
+
+func (r *PostgresUserRepo) UpdateProfile(c context.Context, username, displayName, department string) error {
+	return querier(c, r.store).UpdateUserProfile(c, db.UpdateUserProfileParams{
+		Username:    username,
+		DisplayName: displayName,
+		Department:  department,
+	})
+}
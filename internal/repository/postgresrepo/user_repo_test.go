@@ -222,9 +222,9 @@ func TestUpdateBalance(t *testing.T) {
 			mockBehavior: func(userID, newCoinsCount int32) {
 				mockStore.EXPECT().
 					UpdateUserBalance(gomock.Any(), gomock.Eq(db.UpdateUserBalanceParams{userID, newCoinsCount})).
-					Return(db.User{mockUser1.UserID, mockUser1.Username, mockUser1.Password, newCoinsCount}, nil)
+					Return(db.User{UserID: mockUser1.UserID, Username: mockUser1.Username, Password: mockUser1.Password, Coins: newCoinsCount}, nil)
 			},
-			expectedUser:  &db.User{mockUser1.UserID, mockUser1.Username, mockUser1.Password, 1100},
+			expectedUser:  &db.User{UserID: mockUser1.UserID, Username: mockUser1.Username, Password: mockUser1.Password, Coins: 1100},
 			expectedError: nil,
 		},
 		{
@@ -288,13 +288,13 @@ func TestUpdateTwoUsersBalance(t *testing.T) {
 				mockStore.EXPECT().
 					UpdateTwoUsersBalance(gomock.Any(), gomock.Eq(db.UpdateTwoUsersBalanceParams{Coins: coins, FromUsername: fromUsername, ToUsername: toUsername})).
 					Return([]db.User{
-						{mockUser1.UserID, mockUser1.Username, mockUser1.Password, mockUser1.Coins - coins},
-						{mockUser2.UserID, mockUser2.Username, mockUser2.Password, mockUser2.Coins + coins},
+						{UserID: mockUser1.UserID, Username: mockUser1.Username, Password: mockUser1.Password, Coins: mockUser1.Coins - coins},
+						{UserID: mockUser2.UserID, Username: mockUser2.Username, Password: mockUser2.Password, Coins: mockUser2.Coins + coins},
 					}, nil)
 			},
 			expectedUsers: []*db.User{
-				{mockUser1.UserID, mockUser1.Username, mockUser1.Password, mockUser1.Coins - 100},
-				{mockUser2.UserID, mockUser2.Username, mockUser2.Password, mockUser2.Coins + 100},
+				{UserID: mockUser1.UserID, Username: mockUser1.Username, Password: mockUser1.Password, Coins: mockUser1.Coins - 100},
+				{UserID: mockUser2.UserID, Username: mockUser2.Username, Password: mockUser2.Password, Coins: mockUser2.Coins + 100},
 			},
 			expectedError: nil,
 		},
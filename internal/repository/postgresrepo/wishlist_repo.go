@@ -0,0 +1,92 @@
+package postgresrepo
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type PostgresWishlistRepo struct {
+	store db.Querier
+}
+
+func NewPostgresWishlistRepo(store db.Querier) repository.WishlistRepository {
+	return &PostgresWishlistRepo{store}
+}
+
+func (r *PostgresWishlistRepo) AddItem(c context.Context, state *models.WishlistState) (*db.Wishlist, error) {
+	w, err := querier(c, r.store).AddWishlistItem(c, db.AddWishlistItemParams{
+		Username:       state.Username,
+		ItemType:       state.Item,
+		Affordable:     state.Affordable,
+		InStock:        state.InStock,
+		SaleScheduleID: sql.NullInt32{Int32: state.SaleScheduleID, Valid: state.SaleScheduleID != 0},
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repository.ErrWishlistItemExists
+		}
+		if isForeignKeyViolation(err) {
+			return nil, repository.ErrInvalidItemName
+		}
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (r *PostgresWishlistRepo) RemoveItem(c context.Context, username, itemType string) error {
+	n, err := querier(c, r.store).RemoveWishlistItem(c, db.RemoveWishlistItemParams{
+		Username: username,
+		ItemType: itemType,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrWishlistItemNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresWishlistRepo) ListWishlist(c context.Context, username string) ([]*db.Wishlist, error) {
+	wishlist, err := querier(c, r.store).ListWishlist(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*db.Wishlist, len(wishlist))
+	for i := range wishlist {
+		res[i] = &wishlist[i]
+	}
+
+	return res, nil
+}
+
+func (r *PostgresWishlistRepo) ListWithCoins(c context.Context) ([]*db.ListWishlistsWithCoinsRow, error) {
+	rows, err := querier(c, r.store).ListWishlistsWithCoins(c)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*db.ListWishlistsWithCoinsRow, len(rows))
+	for i := range rows {
+		res[i] = &rows[i]
+	}
+
+	return res, nil
+}
+
+func (r *PostgresWishlistRepo) UpdateState(c context.Context, state *models.WishlistState) error {
+	return querier(c, r.store).UpdateWishlistState(c, db.UpdateWishlistStateParams{
+		Username:       state.Username,
+		ItemType:       state.Item,
+		Affordable:     state.Affordable,
+		InStock:        state.InStock,
+		SaleScheduleID: sql.NullInt32{Int32: state.SaleScheduleID, Valid: state.SaleScheduleID != 0},
+	})
+}
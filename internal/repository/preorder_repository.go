@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var (
+	ErrPreorderBatchNotFound = errors.New("preorder batch not found")
+	ErrPreorderNotFound      = errors.New("preorder not found")
+	ErrPreorderExists        = errors.New("preorder already exists")
+	ErrPreorderBatchNotOpen  = errors.New("preorder batch is not open")
+	ErrPreorderNotPending    = errors.New("preorder is not pending")
+)
+
+type PreorderRepository interface {
+	CreateBatch(c context.Context, itemType, variant string, price int32, createdBy string, expiresAt time.Time) (*db.PreorderBatch, error)
+	GetBatch(c context.Context, batchID int32) (*db.PreorderBatch, error)
+	// Should be called only in transactions.
+	GetBatchForUpdate(c context.Context, batchID int32) (*db.PreorderBatch, error)
+	ListOpenBatches(c context.Context) ([]*db.PreorderBatch, error)
+	// GetExpiredBatches locks open batches expired by now.
+	// Should be called only in transactions.
+	GetExpiredBatches(c context.Context, now time.Time) ([]*db.PreorderBatch, error)
+	// CloseBatch sets final status of open batch.
+	// Returns ErrPreorderBatchNotOpen if batch was closed already.
+	CloseBatch(c context.Context, batchID int32, status string) (*db.PreorderBatch, error)
+
+	CreatePreorder(c context.Context, batchID int32, username string) (*db.Preorder, error)
+	// Should be called only in transactions.
+	GetPreorderForUpdate(c context.Context, preorderID int32) (*db.Preorder, error)
+	// GetPendingPreorders locks pending preorders of batch, oldest first.
+	// Should be called only in transactions.
+	GetPendingPreorders(c context.Context, batchID int32) ([]*db.Preorder, error)
+	CountPending(c context.Context, batchID int32) (int32, error)
+	// ListUserPreorders returns latest preorders first.
+	ListUserPreorders(c context.Context, username string) ([]*db.Preorder, error)
+	// SetPreorderStatus moves pending preorder to status.
+	// Returns ErrPreorderNotPending if it's not pending anymore.
+	SetPreorderStatus(c context.Context, preorderID int32, status string) error
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var (
+	ErrPromoCodeNotFound    = errors.New("promo code not found")
+	ErrPromoCodeExists      = errors.New("promo code already exists")
+	ErrPromoCodeUnavailable = errors.New("promo code can't be used")
+	ErrPromoCodeUseClaimed  = errors.New("promo code already used by user")
+)
+
+type PromoCodeRepository interface {
+	// CreatePromoCode expects promo.StartsAt to be set.
+	CreatePromoCode(c context.Context, createdBy string, promo *models.NewPromoCode) (*db.PromoCode, error)
+	GetPromoCode(c context.Context, code string) (*db.PromoCode, error)
+	ListPromoCodes(c context.Context) ([]*db.PromoCode, error)
+	// UsePromoCode counts a use of code if it is active at now, applicable
+	// to item and not exhausted, returns ErrPromoCodeUnavailable otherwise.
+	UsePromoCode(c context.Context, code, itemType string, now time.Time) (*db.PromoCode, error)
+	// ClaimUse saves that user has used code, returns ErrPromoCodeUseClaimed
+	// if user has already used it.
+	ClaimUse(c context.Context, code, username string) error
+	// ReleaseUse deletes user's use of code and uncounts it. Does nothing
+	// if user hasn't used code.
+	ReleaseUse(c context.Context, code, username string) error
+	DisablePromoCode(c context.Context, code string) (*db.PromoCode, error)
+}
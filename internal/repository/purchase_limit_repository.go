@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrNoPurchaseLimit = errors.New("no purchase limit")
+
+type PurchaseLimitRepository interface {
+	GetPurchaseLimit(c context.Context, itemType string) (*db.PurchaseLimit, error)
+	ListPurchaseLimits(c context.Context) ([]*db.PurchaseLimit, error)
+	SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*db.PurchaseLimit, error)
+	DeletePurchaseLimit(c context.Context, itemType string) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrRaffleNotFound = errors.New("raffle not found")
+
+type RaffleRepository interface {
+	CreateRaffle(c context.Context, raffle *models.NewRaffle, seed, seedHash, createdBy string) (*db.Raffle, error)
+	GetRaffle(c context.Context, raffleID int32) (*db.Raffle, error)
+	// Should be called only in transactions.
+	GetRaffleForUpdate(c context.Context, raffleID int32) (*db.Raffle, error)
+	// ListRaffles returns latest raffles first.
+	ListRaffles(c context.Context) ([]*db.Raffle, error)
+	// GetDueRaffles locks open raffles which draw time has come.
+	// Should be called only in transactions.
+	GetDueRaffles(c context.Context, now time.Time) ([]*db.Raffle, error)
+	SetDrawn(c context.Context, raffleID int32) error
+
+	CreateTicket(c context.Context, raffleID int32, username string, price int32) (*db.RaffleTicket, error)
+	CountTickets(c context.Context, raffleID int32) (int32, error)
+	CountUserTickets(c context.Context, raffleID int32, username string) (int32, error)
+	// ListTickets returns raffle's tickets in purchase order.
+	ListTickets(c context.Context, raffleID int32) ([]*db.RaffleTicket, error)
+	SetTicketWon(c context.Context, ticketID int32) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitRepository counts requests in fixed windows.
+type RateLimitRepository interface {
+	// Hit counts request of key and returns requests in current
+	// window so far and time until window resets.
+	Hit(c context.Context, key string, window time.Duration) (int64, time.Duration, error)
+}
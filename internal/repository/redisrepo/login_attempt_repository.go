@@ -0,0 +1,61 @@
+package redisrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresPrefix = "login:failures:"
+	loginLockPrefix     = "login:lock:"
+)
+
+type RedisLoginAttemptRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisLoginAttemptRepo(rdb *redis.Client) repository.LoginAttemptRepository {
+	return &RedisLoginAttemptRepository{rdb}
+}
+
+func (r *RedisLoginAttemptRepository) AddFailure(c context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.rdb.TxPipelined(c, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(c, loginFailuresPrefix+key)
+		pipe.Expire(c, loginFailuresPrefix+key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *RedisLoginAttemptRepository) ResetFailures(c context.Context, key string) error {
+	return r.rdb.Del(c, loginFailuresPrefix+key).Err()
+}
+
+func (r *RedisLoginAttemptRepository) Lock(c context.Context, key string, ttl time.Duration) error {
+	return r.rdb.Set(c, loginLockPrefix+key, 1, ttl).Err()
+}
+
+func (r *RedisLoginAttemptRepository) LockTTL(c context.Context, key string) (time.Duration, error) {
+	ttl, err := r.rdb.PTTL(c, loginLockPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// negative ttl means there is no lock
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (r *RedisLoginAttemptRepository) Unlock(c context.Context, key string) error {
+	return r.rdb.Del(c, loginLockPrefix+key).Err()
+}
@@ -0,0 +1,36 @@
+package redisrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+const oidcStatePrefix = "oidc:state:"
+
+type RedisOIDCStateRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisOIDCStateRepo(rdb *redis.Client) repository.OIDCStateRepository {
+	return &RedisOIDCStateRepository{rdb}
+}
+
+func (r *RedisOIDCStateRepository) SaveState(c context.Context, state, nonce string, ttl time.Duration) error {
+	return r.rdb.Set(c, oidcStatePrefix+state, nonce, ttl).Err()
+}
+
+func (r *RedisOIDCStateRepository) ConsumeState(c context.Context, state string) (string, error) {
+	nonce, err := r.rdb.GetDel(c, oidcStatePrefix+state).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", repository.ErrOIDCStateNotFound
+		}
+		return "", err
+	}
+
+	return nonce, nil
+}
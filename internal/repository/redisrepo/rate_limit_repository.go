@@ -0,0 +1,41 @@
+package redisrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitPrefix = "ratelimit:"
+
+type RedisRateLimitRepository struct {
+	rdb *redis.Client
+}
+
+func NewRedisRateLimitRepo(rdb *redis.Client) repository.RateLimitRepository {
+	return &RedisRateLimitRepository{rdb}
+}
+
+func (r *RedisRateLimitRepository) Hit(c context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	var (
+		incr *redis.IntCmd
+		ttl  *redis.DurationCmd
+	)
+	_, err := r.rdb.TxPipelined(c, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(c, rateLimitPrefix+key)
+		// first hit of window starts it, NX keeps running window
+		pipe.Do(c, "pexpire", rateLimitPrefix+key, window.Milliseconds(), "nx")
+		ttl = pipe.PTTL(c, rateLimitPrefix+key)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if ttl.Val() < 0 {
+		return incr.Val(), window, nil
+	}
+	return incr.Val(), ttl.Val(), nil
+}
@@ -3,12 +3,43 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
 )
 
-var ErrInvalidItemName = errors.New("no item with this name")
+var (
+	ErrInvalidItemName       = errors.New("no item with this name")
+	ErrPriceScheduleNotFound = errors.New("price schedule not found")
+	ErrVariantNotFound       = errors.New("variant not found")
+	ErrVariantExists         = errors.New("variant already exists")
+	ErrVariantOutOfStock     = errors.New("variant out of stock")
+	ErrScheduleOverlap       = errors.New("price schedule overlaps existing one")
+)
 
 type StoreRepository interface {
-	GetItemInfo(c context.Context, itemName string) (*db.Item, error)
+	// GetItemInfo returns item with sale price active by now, without variants.
+	GetItemInfo(c context.Context, itemName string, now time.Time) (*models.Item, error)
+	// ListItems returns catalog with sale prices active by now, without variants.
+	ListItems(c context.Context, now time.Time) ([]*models.Item, error)
+
+	// CreatePriceSchedule returns ErrScheduleOverlap if item has
+	// not cancelled schedule intersecting with the new one.
+	CreatePriceSchedule(c context.Context, createdBy string, schedule *models.NewPriceSchedule) (*db.PriceSchedule, error)
+	// ListPriceSchedules returns schedules including past and cancelled ones.
+	// Empty itemType returns schedules of every item.
+	ListPriceSchedules(c context.Context, itemType string) ([]*db.PriceSchedule, error)
+	CancelPriceSchedule(c context.Context, scheduleID int32) (*db.PriceSchedule, error)
+
+	ListItemVariants(c context.Context, itemType string) ([]*db.ItemVariant, error)
+	// ListAllItemVariants returns variants of every item.
+	ListAllItemVariants(c context.Context) ([]*db.ItemVariant, error)
+	CreateVariant(c context.Context, itemType string, variant *models.NewVariant) (*db.ItemVariant, error)
+	SetVariantStock(c context.Context, sku string, stock int32) (*db.ItemVariant, error)
+	// TakeVariantStock takes quantity units of variant in one update,
+	// returns ErrVariantOutOfStock if there are not enough of them.
+	TakeVariantStock(c context.Context, sku string, quantity int32) error
+	// AddVariantStock puts quantity units of variant back to stock.
+	AddVariantStock(c context.Context, sku string, quantity int32) error
 }
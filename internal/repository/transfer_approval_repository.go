@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+)
+
+var ErrApprovalNotFound = errors.New("transfer approval not found")
+
+type TransferApprovalRepository interface {
+	CreateApproval(c context.Context, fromUsername, toUsername string, amount int32, expiresAt time.Time) (*db.TransferApproval, error)
+	// Should be called only in transactions.
+	GetApprovalForUpdate(c context.Context, approvalID int32) (*db.TransferApproval, error)
+	ListApprovals(c context.Context, status string) ([]*db.TransferApproval, error)
+	// GetExpiredApprovals locks pending approvals expired by now.
+	// Should be called only in transactions.
+	GetExpiredApprovals(c context.Context, now time.Time) ([]*db.TransferApproval, error)
+	// ResolveApproval closes approval, resolvedBy is empty for expired ones,
+	// transferID is 0 if no transfer was made.
+	ResolveApproval(c context.Context, approvalID int32, status, resolvedBy string, transferID int32) (*db.TransferApproval, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var ErrNoLimitOverride = errors.New("no transfer limit override")
+
+type TransferLimitRepository interface {
+	GetOverride(c context.Context, username string) (*db.TransferLimitOverride, error)
+	SetOverride(c context.Context, username string, override *models.TransferLimitOverride) (*db.TransferLimitOverride, error)
+	DeleteOverride(c context.Context, username string) error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	db "github.com/myacey/avito-shop/db/sqlc"
 )
@@ -12,4 +13,15 @@ var ErrNoTransfers = errors.New("no transafers found")
 type TransferRepository interface {
 	CreateMoneyTransfer(c context.Context, fromUsername, toUsername string, amount int32) (*db.Transfer, error)
 	GetTransfersWithUser(c context.Context, username string) ([]*db.Transfer, error)
+
+	GetSentAmountSince(c context.Context, fromUsername string, since time.Time) (int32, error)
+	GetSentToUserAmountSince(c context.Context, fromUsername, toUsername string, since time.Time) (int32, error)
+	// GetReservedAmount sums transfers waiting for approval or fraud review,
+	// toUsername is optional.
+	GetReservedAmount(c context.Context, fromUsername, toUsername string) (int32, error)
+
+	// transfer graph
+	GetRecipientsSince(c context.Context, fromUsername string, since time.Time) ([]string, error)
+	CountSentSince(c context.Context, fromUsername string, since time.Time) (int32, error)
+	CountNewSendersSince(c context.Context, toUsername, excludeUsername string, since, registeredAfter time.Time) (int32, error)
 }
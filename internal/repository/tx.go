@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// WithTx binds tx to context, postgres repositories
+// run queries with such context in tx.
+func WithTx(c context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(c, txKey{}, tx)
+}
+
+// TxFromContext returns tx bound by WithTx.
+func TxFromContext(c context.Context) (*sql.Tx, bool) {
+	tx, ok := c.Value(txKey{}).(*sql.Tx)
+	return tx, ok
+}
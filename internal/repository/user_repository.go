@@ -17,6 +17,21 @@ type UserRepository interface {
 	GetUser(c context.Context, username string) (*db.User, error)
 
 	GetUserForUpdate(c context.Context, username string) (*db.User, error)
+	// LockTwoUsers locks both users in fixed order, returns
+	// ErrUserNotFound if any of them doesn't exist.
+	LockTwoUsers(c context.Context, firstUsername, secondUsername string) ([]*db.User, error)
 	UpdateBalance(c context.Context, userID int32, newCointCount int32) (*db.User, error)
 	UpdateTwoUsersBalance(c context.Context, fromUsername, toUsername string, coinsAmount int32) ([]*db.User, error)
+	// AddCoins increments user's balance in place.
+	AddCoins(c context.Context, username string, amount int32) (*db.User, error)
+
+	// HoldCoins moves amount from user's balance to held coins,
+	// ReleaseCoins moves it back.
+	HoldCoins(c context.Context, username string, amount int32) (*db.User, error)
+	ReleaseCoins(c context.Context, username string, amount int32) (*db.User, error)
+
+	// UpdatePassword replaces user's password hash.
+	UpdatePassword(c context.Context, username, password string) error
+	// UpdateProfile replaces attributes synced from directory.
+	UpdateProfile(c context.Context, username, displayName, department string) error
 }
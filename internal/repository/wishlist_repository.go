@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+var (
+	ErrWishlistItemExists   = errors.New("item already in wishlist")
+	ErrWishlistItemNotFound = errors.New("item not in wishlist")
+)
+
+type WishlistRepository interface {
+	AddItem(c context.Context, state *models.WishlistState) (*db.Wishlist, error)
+	RemoveItem(c context.Context, username, itemType string) error
+	ListWishlist(c context.Context, username string) ([]*db.Wishlist, error)
+	// ListWithCoins returns every wishlist entry with current balance of its user.
+	ListWithCoins(c context.Context) ([]*db.ListWishlistsWithCoinsRow, error)
+	UpdateState(c context.Context, state *models.WishlistState) error
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrInvalidAPIKey     = errors.New("invalid api key")
+	ErrScopeMissing      = errors.New("api key scope missing")
+	ErrAPIKeyRateLimited = errors.New("api key rate limit exceeded")
+)
+
+const (
+	apiKeyPrefix = "shop_"
+	// apiKeyShownLen is length of key beginning stored in clear
+	// to tell keys apart in listings.
+	apiKeyShownLen = len(apiKeyPrefix) + 8
+
+	apiKeyRateWindow = time.Minute
+)
+
+func (s *Service) apiKeysEnabled() bool {
+	return s.apiKeyRepo != nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func toAPIKeyModel(k *db.ApiKey) *models.APIKey {
+	res := &models.APIKey{
+		ID:        k.KeyID,
+		Name:      k.Name,
+		Username:  k.Username,
+		Prefix:    k.Prefix,
+		Scopes:    strings.Fields(k.Scopes),
+		RateLimit: k.RateLimit,
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt,
+	}
+	if k.RevokedAt.Valid {
+		res.RevokedAt = &k.RevokedAt.Time
+	}
+	return res
+}
+
+// CreateAPIKey issues key acting as key.Username within key.Scopes.
+// Key is returned only here, only its hash is stored.
+func (s *Service) CreateAPIKey(c context.Context, adminUsername string, key *models.NewAPIKey) (*models.APIKey, error) {
+	if !s.apiKeysEnabled() {
+		return nil, apperror.NewNotFound("api keys disabled", ErrFeatureDisabled)
+	}
+	if key.Name == "" {
+		return nil, apperror.NewBadReq("name required", nil)
+	}
+	if key.Username == "" {
+		return nil, apperror.NewBadReq("username required", nil)
+	}
+	if len(key.Scopes) == 0 {
+		return nil, apperror.NewBadReq("at least one scope required", nil)
+	}
+	for _, scope := range key.Scopes {
+		if !slices.Contains(models.Scopes, scope) {
+			return nil, apperror.NewBadReq(fmt.Sprintf("unknown scope %q", scope), nil)
+		}
+	}
+	if key.RateLimit < 0 {
+		return nil, apperror.NewBadReq("rate limit can't be negative", nil)
+	}
+	if key.RateLimit == 0 {
+		key.RateLimit = s.apiKeyRateLimit
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, apperror.NewInternal("failed to generate api key", err)
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(buf)
+
+	k, err := s.apiKeyRepo.CreateKey(c, key, hashAPIKey(secret), secret[:apiKeyShownLen], adminUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to create api key", err)
+	}
+
+	res := toAPIKeyModel(k)
+	res.Key = secret
+	return res, nil
+}
+
+// ListAPIKeys returns every key, revoked included.
+func (s *Service) ListAPIKeys(c context.Context) ([]*models.APIKey, error) {
+	if !s.apiKeysEnabled() {
+		return nil, apperror.NewNotFound("api keys disabled", ErrFeatureDisabled)
+	}
+
+	keys, err := s.apiKeyRepo.ListKeys(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get api keys", err)
+	}
+
+	res := make([]*models.APIKey, len(keys))
+	for i, k := range keys {
+		res[i] = toAPIKeyModel(k)
+	}
+	return res, nil
+}
+
+// RevokeAPIKey makes key stop working immediately.
+func (s *Service) RevokeAPIKey(c context.Context, keyID int32) error {
+	if !s.apiKeysEnabled() {
+		return apperror.NewNotFound("api keys disabled", ErrFeatureDisabled)
+	}
+
+	if err := s.apiKeyRepo.RevokeKey(c, keyID); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return apperror.NewNotFound("api key not found", err)
+		}
+		return apperror.NewInternal("failed to revoke api key", err)
+	}
+	return nil
+}
+
+// CheckAPIKey returns username key acts as if key is active,
+// has scope and is within its rate limit. Empty scope means
+// request isn't allowed for keys at all.
+func (s *Service) CheckAPIKey(c context.Context, key, scope string) (string, error) {
+	if !s.apiKeysEnabled() {
+		return "", apperror.NewUnauthorized("api keys disabled", ErrFeatureDisabled)
+	}
+
+	k, err := s.apiKeyRepo.GetKeyByHash(c, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return "", apperror.NewUnauthorized("invalid api key", ErrInvalidAPIKey)
+		}
+		return "", apperror.NewInternal("failed to get api key", err)
+	}
+	if k.RevokedAt.Valid {
+		return "", apperror.NewUnauthorized("invalid api key", ErrInvalidAPIKey)
+	}
+
+	if scope == "" || !slices.Contains(strings.Fields(k.Scopes), scope) {
+		return "", apperror.NewForbidden("api key has no access to this request", ErrScopeMissing)
+	}
+
+	count, ttl, err := s.rateLimitRepo.Hit(c, fmt.Sprintf("apikey:%d", k.KeyID), apiKeyRateWindow)
+	if err != nil {
+		return "", apperror.NewInternal("failed to check api key rate limit", err)
+	}
+	if count > int64(k.RateLimit) {
+		return "", apperror.NewTooManyRequests("api key rate limit exceeded", ErrAPIKeyRateLimited).
+			WithRetryAfter(ttl)
+	}
+
+	return k.Username, nil
+}
+
+// GrantCoins credits user with coins on behalf of admin or
+// integration, user is notified.
+func (s *Service) GrantCoins(c context.Context, grantedBy, username string, amount int32, reason string) error {
+	if amount <= 0 {
+		return apperror.NewBadReq("grant amount must be positive", nil)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to grant coins", err)
+	}
+	defer tx.Rollback()
+
+	if err = s.creditCoins(c, username, amount); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s granted you %d coins", grantedBy, amount)
+	if reason != "" {
+		message += ": " + reason
+	}
+	if err = s.notify(c, username, models.NotificationGrant, message); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/myacey/avito-shop/internal/repository/memrepo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil,
+		WithAPIKeys(keyRepo, nil, 60))
+
+	testCases := []struct {
+		name         string
+		key          models.NewAPIKey
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK Default Rate Limit",
+			key:  models.NewAPIKey{Name: "hr-bot", Username: "hr", Scopes: []string{models.ScopeGrantsWrite}},
+			mockBehavior: func() {
+				keyRepo.EXPECT().
+					CreateKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "admin").
+					DoAndReturn(func(_ context.Context, key *models.NewAPIKey, keyHash, prefix, createdBy string) (*db.ApiKey, error) {
+						require.Equal(t, int32(60), key.RateLimit)
+						require.Len(t, keyHash, 64)
+						require.True(t, strings.HasPrefix(prefix, apiKeyPrefix))
+						return &db.ApiKey{KeyID: 1, KeyHash: keyHash, Prefix: prefix, Name: key.Name, Username: key.Username,
+							Scopes: strings.Join(key.Scopes, " "), RateLimit: key.RateLimit, CreatedBy: createdBy}, nil
+					})
+			},
+		},
+		{
+			name:   "Err Unknown Scope",
+			key:    models.NewAPIKey{Name: "hr-bot", Username: "hr", Scopes: []string{"admin:*"}},
+			expErr: apperror.NewBadReq(`unknown scope "admin:*"`, nil),
+		},
+		{
+			name:   "Err No Scopes",
+			key:    models.NewAPIKey{Name: "hr-bot", Username: "hr"},
+			expErr: apperror.NewBadReq("at least one scope required", nil),
+		},
+		{
+			name:   "Err Negative Rate Limit",
+			key:    models.NewAPIKey{Name: "hr-bot", Username: "hr", Scopes: []string{models.ScopeReadUsers}, RateLimit: -1},
+			expErr: apperror.NewBadReq("rate limit can't be negative", nil),
+		},
+		{
+			name: "Err User Not Found",
+			key:  models.NewAPIKey{Name: "hr-bot", Username: "ghost", Scopes: []string{models.ScopeReadUsers}},
+			mockBehavior: func() {
+				keyRepo.EXPECT().
+					CreateKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "admin").
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expErr: apperror.NewNotFound("user not found", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.mockBehavior != nil {
+				tc.mockBehavior()
+			}
+
+			key, err := srv.CreateAPIKey(context.Background(), "admin", &tc.key)
+			if tc.expErr != nil {
+				require.Equal(t, tc.expErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, key.Prefix, key.Key[:apiKeyShownLen])
+			require.Equal(t, []string{models.ScopeGrantsWrite}, key.Scopes)
+		})
+	}
+}
+
+func TestCheckAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	now := mockNow
+	rateLimitRepo := memrepo.NewMemoryRateLimitRepo(func() time.Time { return now })
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil,
+		WithAPIKeys(keyRepo, rateLimitRepo, 60))
+
+	const secret = "shop_secret"
+	key := &db.ApiKey{KeyID: 1, Username: "hr", Scopes: "read:users grants:write", RateLimit: 2}
+	keyRepo.EXPECT().
+		GetKeyByHash(gomock.Any(), hashAPIKey(secret)).
+		Return(key, nil).
+		AnyTimes()
+
+	username, err := srv.CheckAPIKey(context.Background(), secret, models.ScopeGrantsWrite)
+	require.NoError(t, err)
+	require.Equal(t, "hr", username)
+
+	// missing scope and routes without scope
+	_, err = srv.CheckAPIKey(context.Background(), secret, models.ScopeTransfersWrite)
+	require.Equal(t, apperror.NewForbidden("api key has no access to this request", ErrScopeMissing), err)
+	_, err = srv.CheckAPIKey(context.Background(), secret, "")
+	require.Equal(t, apperror.NewForbidden("api key has no access to this request", ErrScopeMissing), err)
+
+	// second request of minute is the last allowed
+	_, err = srv.CheckAPIKey(context.Background(), secret, models.ScopeReadUsers)
+	require.NoError(t, err)
+
+	now = now.Add(20 * time.Second)
+	_, err = srv.CheckAPIKey(context.Background(), secret, models.ScopeReadUsers)
+	require.Equal(t, apperror.NewTooManyRequests("api key rate limit exceeded", ErrAPIKeyRateLimited).
+		WithRetryAfter(40*time.Second), err)
+
+	// new window
+	now = now.Add(40 * time.Second)
+	_, err = srv.CheckAPIKey(context.Background(), secret, models.ScopeReadUsers)
+	require.NoError(t, err)
+
+	// unknown and revoked keys
+	keyRepo.EXPECT().
+		GetKeyByHash(gomock.Any(), hashAPIKey("shop_unknown")).
+		Return(nil, repository.ErrAPIKeyNotFound)
+	_, err = srv.CheckAPIKey(context.Background(), "shop_unknown", models.ScopeReadUsers)
+	require.Equal(t, apperror.NewUnauthorized("invalid api key", ErrInvalidAPIKey), err)
+
+	keyRepo.EXPECT().
+		GetKeyByHash(gomock.Any(), hashAPIKey("shop_revoked")).
+		Return(&db.ApiKey{KeyID: 2, Scopes: "read:users", RateLimit: 2, RevokedAt: sql.NullTime{Time: mockNow, Valid: true}}, nil)
+	_, err = srv.CheckAPIKey(context.Background(), "shop_revoked", models.ScopeReadUsers)
+	require.Equal(t, apperror.NewUnauthorized("invalid api key", ErrInvalidAPIKey), err)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil,
+		WithAPIKeys(keyRepo, nil, 60))
+
+	keyRepo.EXPECT().RevokeKey(gomock.Any(), int32(1)).Return(nil)
+	require.NoError(t, srv.RevokeAPIKey(context.Background(), 1))
+
+	keyRepo.EXPECT().RevokeKey(gomock.Any(), int32(2)).Return(repository.ErrAPIKeyNotFound)
+	err := srv.RevokeAPIKey(context.Background(), 2)
+	require.Equal(t, apperror.NewNotFound("api key not found", repository.ErrAPIKeyNotFound), err)
+
+	srv = NewService(nil, nil, nil, nil, nil, nil, nil, nil)
+	err = srv.RevokeAPIKey(context.Background(), 1)
+	require.Equal(t, apperror.NewNotFound("api keys disabled", ErrFeatureDisabled), err)
+}
+
+func TestGrantCoins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithNotifications(notificationRepo))
+
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		AddCoins(gomock.Any(), mockUser1.Username, int32(100)).
+		Return(&mockUser1, nil)
+	notificationRepo.EXPECT().
+		CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationGrant, "hr granted you 100 coins: hackathon").
+		Return(&db.Notification{}, nil)
+	mock.ExpectCommit()
+
+	require.NoError(t, srv.GrantCoins(context.Background(), "hr", mockUser1.Username, 100, "hackathon"))
+
+	err = srv.GrantCoins(context.Background(), "hr", mockUser1.Username, 0, "")
+	require.Equal(t, apperror.NewBadReq("grant amount must be positive", nil), err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrAuctionClosed = errors.New("auction closed")
+	ErrBidTooLow     = errors.New("bid too low")
+)
+
+func (s *Service) auctionsEnabled() bool {
+	return s.auctionRepo != nil
+}
+
+func toAuctionModel(a *db.Auction, bids []*db.Bid) *models.Auction {
+	res := &models.Auction{
+		ID:       a.AuctionID,
+		Item:     a.ItemType,
+		Quantity: a.Quantity,
+		MinBid:   a.MinBid,
+		Status:   a.Status,
+		EndsAt:   a.EndsAt,
+	}
+	for _, b := range bids {
+		res.Bids = append(res.Bids, toBidModel(b))
+	}
+
+	return res
+}
+
+func toBidModel(b *db.Bid) *models.Bid {
+	return &models.Bid{
+		ID:       b.BidID,
+		Username: b.Username,
+		Amount:   b.Amount,
+		Status:   b.Status,
+	}
+}
+
+// holdCoins reserves coins on user's balance.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) holdCoins(c context.Context, username string, amount int32) error {
+	dbUsr, err := s.userRepo.GetUserForUpdate(c, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NewNotFound("user not found", err)
+		}
+		return apperror.NewInternal("failed to get user", err)
+	}
+	if dbUsr.Coins < amount {
+		return apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+	}
+
+	if _, err = s.userRepo.HoldCoins(c, username, amount); err != nil {
+		return apperror.NewInternal("failed to hold coins", err)
+	}
+	return nil
+}
+
+// CreateAuction starts an auction for quantity units of item,
+// every winner gets one unit.
+func (s *Service) CreateAuction(c context.Context, adminUsername, itemName string, quantity, minBid int32, endsAt time.Time) (*models.Auction, error) {
+	if !s.auctionsEnabled() {
+		return nil, apperror.NewNotFound("auctions disabled", ErrFeatureDisabled)
+	}
+	if quantity <= 0 {
+		return nil, apperror.NewBadReq("auction quantity must be positive", nil)
+	}
+	if minBid <= 0 {
+		return nil, apperror.NewBadReq("min bid must be positive", nil)
+	}
+	if !endsAt.After(s.now()) {
+		return nil, apperror.NewBadReq("auction must end in future", nil)
+	}
+
+	item, err := s.getItemInfo(c, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+	if len(item.Variants) > 0 {
+		return nil, apperror.NewBadReq("items with variants can't be auctioned", ErrInvalidVariant)
+	}
+
+	a, err := s.auctionRepo.CreateAuction(c, itemName, quantity, minBid, adminUsername, endsAt)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create auction", err)
+	}
+
+	return toAuctionModel(a, nil), nil
+}
+
+// GetAuctions returns open auctions.
+func (s *Service) GetAuctions(c context.Context) ([]*models.Auction, error) {
+	if !s.auctionsEnabled() {
+		return nil, apperror.NewNotFound("auctions disabled", ErrFeatureDisabled)
+	}
+
+	auctions, err := s.auctionRepo.ListOpen(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get auctions", err)
+	}
+
+	res := make([]*models.Auction, len(auctions))
+	for i, a := range auctions {
+		res[i] = toAuctionModel(a, nil)
+	}
+
+	return res, nil
+}
+
+// GetAuction returns auction with its leading bids.
+func (s *Service) GetAuction(c context.Context, auctionID int32) (*models.Auction, error) {
+	if !s.auctionsEnabled() {
+		return nil, apperror.NewNotFound("auctions disabled", ErrFeatureDisabled)
+	}
+
+	a, err := s.auctionRepo.GetAuction(c, auctionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAuctionNotFound) {
+			return nil, apperror.NewNotFound("auction not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get auction", err)
+	}
+
+	bids, err := s.auctionRepo.GetActiveBids(c, auctionID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get bids", err)
+	}
+
+	return toAuctionModel(a, bids), nil
+}
+
+// PlaceBid holds bid amount on user's coins. User has one bid per
+// auction, raising it holds only the difference. Only quantity
+// highest bids stay active, the lowest one is outbid and released.
+func (s *Service) PlaceBid(c context.Context, username string, auctionID, amount int32) (*models.Bid, error) {
+	if !s.auctionsEnabled() {
+		return nil, apperror.NewNotFound("auctions disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to place bid", err)
+	}
+	defer tx.Rollback()
+
+	// auction lock serializes bids
+	a, err := s.auctionRepo.GetAuctionForUpdate(c, auctionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAuctionNotFound) {
+			return nil, apperror.NewNotFound("auction not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get auction", err)
+	}
+	if a.Status != models.AuctionOpen || !s.now().Before(a.EndsAt) {
+		return nil, apperror.NewBadReq("auction closed", ErrAuctionClosed)
+	}
+	if amount < a.MinBid {
+		return nil, apperror.NewBadReq("bid too low", ErrBidTooLow).
+			WithDetails(map[string]int32{"minBid": a.MinBid})
+	}
+
+	bids, err := s.auctionRepo.GetActiveBids(c, auctionID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get bids", err)
+	}
+
+	var own *db.Bid
+	others := make([]*db.Bid, 0, len(bids))
+	for _, b := range bids {
+		if b.Username == username {
+			own = b
+		} else {
+			others = append(others, b)
+		}
+	}
+
+	if own != nil && amount <= own.Amount {
+		return nil, apperror.NewBadReq("bid must be higher than current one", ErrBidTooLow).
+			WithDetails(map[string]int32{"minBid": own.Amount + 1})
+	}
+	// lowest bid that would stay in winners
+	var outbid *db.Bid
+	if len(others) >= int(a.Quantity) {
+		outbid = others[a.Quantity-1]
+		if amount <= outbid.Amount {
+			return nil, apperror.NewBadReq("bid too low", ErrBidTooLow).
+				WithDetails(map[string]int32{"minBid": outbid.Amount + 1})
+		}
+	}
+
+	var bid *db.Bid
+	if own != nil {
+		if err = s.holdCoins(c, username, amount-own.Amount); err != nil {
+			return nil, err
+		}
+		bid, err = s.auctionRepo.UpdateBidAmount(c, own.BidID, amount)
+	} else {
+		if err = s.holdCoins(c, username, amount); err != nil {
+			return nil, err
+		}
+		bid, err = s.auctionRepo.CreateBid(c, auctionID, username, amount)
+	}
+	if err != nil {
+		return nil, apperror.NewInternal("failed to save bid", err)
+	}
+
+	// raised bid doesn't change number of active bids
+	if outbid != nil && own == nil {
+		if _, err = s.userRepo.ReleaseCoins(c, outbid.Username, outbid.Amount); err != nil {
+			return nil, apperror.NewInternal("failed to release coins", err)
+		}
+		if err = s.auctionRepo.SetBidStatus(c, outbid.BidID, models.BidOutbid); err != nil {
+			return nil, apperror.NewInternal("failed to update bid", err)
+		}
+		text := fmt.Sprintf("your bid of %d coins for %s was outbid", outbid.Amount, a.ItemType)
+		if err = s.notify(c, outbid.Username, models.NotificationAuction, text); err != nil {
+			return nil, err
+		}
+	}
+
+	return toBidModel(bid), tx.Commit()
+}
+
+// settleBid charges winning bid and gives winner the item.
+// Winner who reached item's purchase limit gets coins back instead.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) settleBid(c context.Context, a *db.Auction, b *db.Bid) error {
+	if _, err := s.userRepo.ReleaseCoins(c, b.Username, b.Amount); err != nil {
+		return apperror.NewInternal("failed to release coins", err)
+	}
+
+	winner, err := s.lockUser(c, b.Username)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkPurchaseLimit(c, b.Username, a.ItemType, 1)
+	if errors.Is(err, ErrPurchaseLimitReached) {
+		if err = s.auctionRepo.SetBidStatus(c, b.BidID, models.BidRejected); err != nil {
+			return apperror.NewInternal("failed to update bid", err)
+		}
+		text := fmt.Sprintf("your winning bid for %s was rejected: purchase limit reached", a.ItemType)
+		return s.notify(c, b.Username, models.NotificationAuction, text)
+	}
+	if err != nil {
+		return err
+	}
+
+	lots, err := s.chargeUser(c, winner, b.Amount)
+	if err != nil {
+		return err
+	}
+	if err = s.inventoryRepo.AddItemToInventory(c, winner.UserID, a.ItemType, ""); err != nil {
+		return apperror.NewInternal("failed to add item to inventory", err)
+	}
+	if s.ordersEnabled() {
+		_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+			Username: b.Username,
+			Item:     a.ItemType,
+			Price:    b.Amount,
+			CoinLots: lots,
+		})
+		if err != nil {
+			return apperror.NewInternal("failed to create order", err)
+		}
+	}
+
+	if err = s.auctionRepo.SetBidStatus(c, b.BidID, models.BidWon); err != nil {
+		return apperror.NewInternal("failed to update bid", err)
+	}
+	text := fmt.Sprintf("you won %s for %d coins", a.ItemType, b.Amount)
+	return s.notify(c, b.Username, models.NotificationAuction, text)
+}
+
+// settleAuction charges winners of ended auction in its own transaction.
+// Auction closed by concurrent worker is skipped.
+// returns apperror.
+func (s *Service) settleAuction(c context.Context, auctionID int32) error {
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to settle auction", err)
+	}
+	defer tx.Rollback()
+
+	a, err := s.auctionRepo.GetAuctionForUpdate(c, auctionID)
+	if err != nil {
+		return apperror.NewInternal("failed to get auction", err)
+	}
+	if a.Status != models.AuctionOpen {
+		return nil
+	}
+
+	bids, err := s.auctionRepo.GetActiveBids(c, a.AuctionID)
+	if err != nil {
+		return apperror.NewInternal("failed to get bids", err)
+	}
+	for _, b := range bids {
+		if err = s.settleBid(c, a, b); err != nil {
+			return err
+		}
+	}
+
+	if err = s.auctionRepo.CloseAuction(c, a.AuctionID, models.AuctionClosed); err != nil {
+		return apperror.NewInternal("failed to close auction", err)
+	}
+	return tx.Commit()
+}
+
+// failAuction marks auction that can't be settled as failed and
+// returns held coins of its active bids in the same transaction.
+// returns apperror.
+func (s *Service) failAuction(c context.Context, auctionID int32) error {
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to fail auction", err)
+	}
+	defer tx.Rollback()
+
+	a, err := s.auctionRepo.GetAuctionForUpdate(c, auctionID)
+	if err != nil {
+		return apperror.NewInternal("failed to get auction", err)
+	}
+	if a.Status != models.AuctionOpen {
+		return nil
+	}
+
+	bids, err := s.auctionRepo.GetActiveBids(c, a.AuctionID)
+	if err != nil {
+		return apperror.NewInternal("failed to get bids", err)
+	}
+	for _, b := range bids {
+		if _, err = s.userRepo.ReleaseCoins(c, b.Username, b.Amount); err != nil {
+			return apperror.NewInternal("failed to release coins", err)
+		}
+		if err = s.auctionRepo.SetBidStatus(c, b.BidID, models.BidReleased); err != nil {
+			return apperror.NewInternal("failed to update bid", err)
+		}
+	}
+
+	if err = s.auctionRepo.CloseAuction(c, a.AuctionID, models.AuctionFailed); err != nil {
+		return apperror.NewInternal("failed to close auction", err)
+	}
+	return tx.Commit()
+}
+
+// CloseAuctions settles every ended auction. Auction that can't be
+// settled is marked failed and its bids are released, so it doesn't
+// block the others and isn't retried. Runs periodically by worker.
+func (s *Service) CloseAuctions(c context.Context) error {
+	if !s.auctionsEnabled() {
+		return nil
+	}
+
+	ended, err := s.auctionRepo.GetEndedAuctions(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to get ended auctions", err)
+	}
+
+	var errs []error
+	for _, a := range ended {
+		if err = s.settleAuction(c, a.AuctionID); err == nil {
+			continue
+		}
+		if markErr := s.failAuction(c, a.AuctionID); markErr != nil {
+			err = errors.Join(err, markErr)
+		}
+		errs = append(errs, fmt.Errorf("auction %d: %w", a.AuctionID, err))
+	}
+	if len(ended) > 0 {
+		log.Printf("auctions: closed %d auctions, %d failed", len(ended)-len(errs), len(errs))
+	}
+
+	if len(errs) > 0 {
+		return apperror.NewInternal("failed to settle auctions", errors.Join(errs...))
+	}
+	return nil
+}
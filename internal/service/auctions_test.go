@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceBid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	auctionRepo := mocks.NewMockAuctionRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithAuctions(auctionRepo), WithNotifications(notificationRepo))
+
+	open := &db.Auction{
+		AuctionID: 1,
+		ItemType:  "hoody",
+		Quantity:  1,
+		MinBid:    100,
+		Status:    models.AuctionOpen,
+		EndsAt:    mockNow.Add(time.Hour),
+	}
+	leading := &db.Bid{BidID: 5, AuctionID: 1, Username: mockUser2.Username, Amount: 150, Status: models.BidActive}
+
+	testCases := []struct {
+		name         string
+		amount       int32
+		mockBehavior func()
+		expBid       *models.Bid
+		expErr       error
+	}{
+		{
+			name:   "OK Outbids Leader",
+			amount: 200,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				auctionRepo.EXPECT().
+					GetActiveBids(gomock.Any(), int32(1)).
+					Return([]*db.Bid{leading}, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(200)).
+					Return(&mockUser1, nil)
+				auctionRepo.EXPECT().
+					CreateBid(gomock.Any(), int32(1), mockUser1.Username, int32(200)).
+					Return(&db.Bid{BidID: 6, Username: mockUser1.Username, Amount: 200, Status: models.BidActive}, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser2.Username, int32(150)).
+					Return(&mockUser2, nil)
+				auctionRepo.EXPECT().
+					SetBidStatus(gomock.Any(), int32(5), models.BidOutbid).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser2.Username, models.NotificationAuction, "your bid of 150 coins for hoody was outbid").
+					Return(&db.Notification{}, nil)
+				mock.ExpectCommit()
+			},
+			expBid: &models.Bid{ID: 6, Username: mockUser1.Username, Amount: 200, Status: models.BidActive},
+		},
+		{
+			name:   "OK Raise Own Bid",
+			amount: 200,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				auctionRepo.EXPECT().
+					GetActiveBids(gomock.Any(), int32(1)).
+					Return([]*db.Bid{{BidID: 6, Username: mockUser1.Username, Amount: 120}}, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				// only difference is held
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(80)).
+					Return(&mockUser1, nil)
+				auctionRepo.EXPECT().
+					UpdateBidAmount(gomock.Any(), int32(6), int32(200)).
+					Return(&db.Bid{BidID: 6, Username: mockUser1.Username, Amount: 200, Status: models.BidActive}, nil)
+				mock.ExpectCommit()
+			},
+			expBid: &models.Bid{ID: 6, Username: mockUser1.Username, Amount: 200, Status: models.BidActive},
+		},
+		{
+			name:   "Err Below Leader",
+			amount: 150,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				auctionRepo.EXPECT().
+					GetActiveBids(gomock.Any(), int32(1)).
+					Return([]*db.Bid{leading}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("bid too low", ErrBidTooLow).
+				WithDetails(map[string]int32{"minBid": 151}),
+		},
+		{
+			name:   "Err Below Min Bid",
+			amount: 50,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("bid too low", ErrBidTooLow).
+				WithDetails(map[string]int32{"minBid": 100}),
+		},
+		{
+			name:   "Err Ended",
+			amount: 200,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				ended := *open
+				ended.EndsAt = mockNow
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(&ended, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("auction closed", ErrAuctionClosed),
+		},
+		{
+			name:   "Err Not Enough Money",
+			amount: 2000,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				auctionRepo.EXPECT().
+					GetActiveBids(gomock.Any(), int32(1)).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough money", ErrNotEnoughMoney),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			bid, err := srv.PlaceBid(context.Background(), mockUser1.Username, 1, tc.amount)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expBid, bid)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCloseAuctions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	auctionRepo := mocks.NewMockAuctionRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithAuctions(auctionRepo), WithNotifications(notificationRepo),
+		WithOrders(orderRepo), WithPurchaseLimits(limitRepo))
+
+	hoody := &db.Auction{AuctionID: 1, ItemType: "hoody", Quantity: 1, Status: models.AuctionOpen}
+	cup := &db.Auction{AuctionID: 2, ItemType: "cup", Quantity: 1, Status: models.AuctionOpen}
+	limit := &db.PurchaseLimit{ItemType: "hoody", Lifetime: 1}
+
+	expectRelease := func(auction *db.Auction, bid *db.Bid) {
+		mock.ExpectBegin()
+		auctionRepo.EXPECT().
+			GetAuctionForUpdate(gomock.Any(), auction.AuctionID).
+			Return(auction, nil)
+		auctionRepo.EXPECT().
+			GetActiveBids(gomock.Any(), auction.AuctionID).
+			Return([]*db.Bid{bid}, nil)
+		userRepo.EXPECT().
+			ReleaseCoins(gomock.Any(), bid.Username, bid.Amount).
+			Return(&mockUser1, nil)
+		userRepo.EXPECT().
+			GetUserForUpdate(gomock.Any(), bid.Username).
+			Return(&mockUser1, nil)
+	}
+
+	// held coins of bids of failed auction, must be all released
+	held := map[string]int32{}
+	expectFail := func(auction *db.Auction, bids []*db.Bid) {
+		mock.ExpectBegin()
+		auctionRepo.EXPECT().
+			GetAuctionForUpdate(gomock.Any(), auction.AuctionID).
+			Return(auction, nil)
+		auctionRepo.EXPECT().
+			GetActiveBids(gomock.Any(), auction.AuctionID).
+			Return(bids, nil)
+		for _, b := range bids {
+			held[b.Username] += b.Amount
+			userRepo.EXPECT().
+				ReleaseCoins(gomock.Any(), b.Username, b.Amount).
+				DoAndReturn(func(_ context.Context, username string, amount int32) (*db.User, error) {
+					held[username] -= amount
+					return &db.User{Username: username, HeldCoins: held[username]}, nil
+				})
+			auctionRepo.EXPECT().
+				SetBidStatus(gomock.Any(), b.BidID, models.BidReleased).
+				Return(nil)
+		}
+		auctionRepo.EXPECT().
+			CloseAuction(gomock.Any(), auction.AuctionID, models.AuctionFailed).
+			Return(nil)
+		mock.ExpectCommit()
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       bool
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				auctionRepo.EXPECT().
+					GetEndedAuctions(gomock.Any(), mockNow).
+					Return([]*db.Auction{hoody}, nil)
+				expectRelease(hoody, &db.Bid{BidID: 6, Username: mockUser1.Username, Amount: 200})
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "hoody").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "hoody", mockNow).
+					Return(int32(0), int32(0), nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-200).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "hoody", "").
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Item: "hoody", Price: 200}).
+					Return(&db.Order{}, nil)
+				auctionRepo.EXPECT().
+					SetBidStatus(gomock.Any(), int32(6), models.BidWon).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationAuction, "you won hoody for 200 coins").
+					Return(&db.Notification{}, nil)
+				auctionRepo.EXPECT().
+					CloseAuction(gomock.Any(), int32(1), models.AuctionClosed).
+					Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "OK Limit Reached",
+			mockBehavior: func() {
+				auctionRepo.EXPECT().
+					GetEndedAuctions(gomock.Any(), mockNow).
+					Return([]*db.Auction{hoody}, nil)
+				expectRelease(hoody, &db.Bid{BidID: 6, Username: mockUser1.Username, Amount: 200})
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "hoody").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "hoody", mockNow).
+					Return(int32(1), int32(1), nil)
+				auctionRepo.EXPECT().
+					SetBidStatus(gomock.Any(), int32(6), models.BidRejected).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationAuction,
+						"your winning bid for hoody was rejected: purchase limit reached").
+					Return(&db.Notification{}, nil)
+				auctionRepo.EXPECT().
+					CloseAuction(gomock.Any(), int32(1), models.AuctionClosed).
+					Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Failed Auction Doesn't Block Others",
+			mockBehavior: func() {
+				auctionRepo.EXPECT().
+					GetEndedAuctions(gomock.Any(), mockNow).
+					Return([]*db.Auction{hoody, cup}, nil)
+				expectRelease(hoody, &db.Bid{BidID: 6, Username: mockUser1.Username, Amount: 200})
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "hoody").
+					Return(nil, ErrMock)
+				mock.ExpectRollback()
+				expectFail(hoody, []*db.Bid{
+					{BidID: 6, Username: mockUser1.Username, Amount: 200},
+					{BidID: 8, Username: mockUser2.Username, Amount: 150},
+				})
+
+				expectRelease(cup, &db.Bid{BidID: 7, Username: mockUser1.Username, Amount: 20})
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "cup").
+					Return(nil, repository.ErrNoPurchaseLimit)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-20).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "cup", "").
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Item: "cup", Price: 20}).
+					Return(&db.Order{}, nil)
+				auctionRepo.EXPECT().
+					SetBidStatus(gomock.Any(), int32(7), models.BidWon).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationAuction, "you won cup for 20 coins").
+					Return(&db.Notification{}, nil)
+				auctionRepo.EXPECT().
+					CloseAuction(gomock.Any(), int32(2), models.AuctionClosed).
+					Return(nil)
+				mock.ExpectCommit()
+			},
+			expErr: true,
+		},
+		{
+			name: "Skip Closed By Other Worker",
+			mockBehavior: func() {
+				auctionRepo.EXPECT().
+					GetEndedAuctions(gomock.Any(), mockNow).
+					Return([]*db.Auction{hoody}, nil)
+				mock.ExpectBegin()
+				auctionRepo.EXPECT().
+					GetAuctionForUpdate(gomock.Any(), int32(1)).
+					Return(&db.Auction{AuctionID: 1, Status: models.AuctionClosed}, nil)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.CloseAuctions(context.Background())
+			if tc.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+			for username, coins := range held {
+				require.Zero(t, coins, "held coins of %s", username)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+const maxBundleNameLen = 50
+
+var ErrBundleInactive = errors.New("bundle is not sold anymore")
+
+func (s *Service) bundlesEnabled() bool {
+	return s.bundleRepo != nil
+}
+
+func toBundleModel(b *db.Bundle, items []*db.BundleItem) *models.Bundle {
+	res := &models.Bundle{
+		Name:   b.Name,
+		Price:  b.Price,
+		Active: b.Active,
+		Items:  make([]*models.BundleItem, 0, len(items)),
+	}
+	for _, bi := range items {
+		res.Items = append(res.Items, &models.BundleItem{
+			Item:     bi.ItemType,
+			Variant:  bi.Variant.String,
+			Quantity: bi.Quantity,
+		})
+	}
+
+	return res
+}
+
+// GetBundles returns bundles on sale.
+func (s *Service) GetBundles(c context.Context) ([]*models.Bundle, error) {
+	if !s.bundlesEnabled() {
+		return nil, apperror.NewNotFound("bundles disabled", ErrFeatureDisabled)
+	}
+
+	bundles, err := s.bundleRepo.ListActiveBundles(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get bundles", err)
+	}
+	items, err := s.bundleRepo.ListActiveBundleItems(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get bundle items", err)
+	}
+
+	itemsByBundle := make(map[string][]*db.BundleItem, len(bundles))
+	for _, bi := range items {
+		itemsByBundle[bi.BundleName] = append(itemsByBundle[bi.BundleName], bi)
+	}
+
+	res := make([]*models.Bundle, len(bundles))
+	for i, b := range bundles {
+		res[i] = toBundleModel(b, itemsByBundle[b.Name])
+	}
+
+	return res, nil
+}
+
+// BuyBundle charges bundle price once, takes components with
+// variants from stock and adds every component to user's inventory.
+func (s *Service) BuyBundle(c context.Context, username, name string) error {
+	if !s.bundlesEnabled() {
+		return apperror.NewNotFound("bundles disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to buy bundle", err)
+	}
+	defer tx.Rollback()
+
+	b, err := s.bundleRepo.GetBundle(c, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrBundleNotFound) {
+			return apperror.NewNotFound("bundle not found", err)
+		}
+		return apperror.NewInternal("failed to get bundle", err)
+	}
+	if !b.Active {
+		return apperror.NewBadReq("bundle is not sold anymore", ErrBundleInactive)
+	}
+
+	items, err := s.bundleRepo.GetBundleItems(c, name)
+	if err != nil {
+		return apperror.NewInternal("failed to get bundle items", err)
+	}
+	// bundled units count towards purchase limits of their items
+	dbUsr, err := s.lockUser(c, username)
+	if err != nil {
+		return err
+	}
+	for _, bi := range items {
+		if err = s.checkPurchaseLimit(c, username, bi.ItemType, bi.Quantity); err != nil {
+			return err
+		}
+	}
+	lots, err := s.chargeUser(c, dbUsr, b.Price)
+	if err != nil {
+		return err
+	}
+
+	for _, bi := range items {
+		if !bi.Variant.Valid {
+			continue
+		}
+		if err = s.takeVariant(c, bi.Variant.String, bi.Quantity); err != nil {
+			return err
+		}
+	}
+
+	for _, bi := range items {
+		if err = s.inventoryRepo.AddItems(c, dbUsr.UserID, bi.ItemType, bi.Variant.String, bi.Quantity); err != nil {
+			return apperror.NewInternal("failed to add item to inventory", err)
+		}
+	}
+
+	if s.ordersEnabled() {
+		_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+			Username: username,
+			Bundle:   name,
+			Price:    b.Price,
+			CoinLots: lots,
+		})
+		if err != nil {
+			return apperror.NewInternal("failed to create order", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkBundleItem validates bundle component.
+// returns apperror.
+func (s *Service) checkBundleItem(c context.Context, bi *models.BundleItem) error {
+	if bi.Quantity <= 0 {
+		return apperror.NewBadReq("bundle item quantity must be positive", nil)
+	}
+
+	item, err := s.getItemInfo(c, bi.Item)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return apperror.NewBadReq("invalid item name", err)
+		}
+		return apperror.NewInternal("failed to get item info", err)
+	}
+	if err = checkVariant(item, bi.Variant); err != nil {
+		return err
+	}
+	if bi.Variant != "" && !hasVariant(item, bi.Variant) {
+		return apperror.NewBadReq("invalid variant", ErrInvalidVariant)
+	}
+
+	return nil
+}
+
+// CreateBundle validates components and saves bundle.
+func (s *Service) CreateBundle(c context.Context, adminUsername string, bundle *models.NewBundle) (*models.Bundle, error) {
+	if !s.bundlesEnabled() {
+		return nil, apperror.NewNotFound("bundles disabled", ErrFeatureDisabled)
+	}
+	if bundle.Name == "" || len(bundle.Name) > maxBundleNameLen {
+		return nil, apperror.NewBadReq("invalid bundle name", nil)
+	}
+	if bundle.Price <= 0 {
+		return nil, apperror.NewBadReq("bundle price must be positive", nil)
+	}
+	if len(bundle.Items) == 0 {
+		return nil, apperror.NewBadReq("bundle must contain items", nil)
+	}
+	for _, bi := range bundle.Items {
+		if err := s.checkBundleItem(c, bi); err != nil {
+			return nil, err
+		}
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create bundle", err)
+	}
+	defer tx.Rollback()
+
+	b, err := s.bundleRepo.CreateBundle(c, bundle.Name, bundle.Price, adminUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrBundleExists) {
+			return nil, apperror.NewBadReq("bundle already exists", err)
+		}
+		return nil, apperror.NewInternal("failed to create bundle", err)
+	}
+
+	items := make([]*db.BundleItem, len(bundle.Items))
+	for i, bi := range bundle.Items {
+		items[i], err = s.bundleRepo.AddBundleItem(c, b.Name, bi.Item, bi.Variant, bi.Quantity)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to add bundle item", err)
+		}
+	}
+
+	return toBundleModel(b, items), tx.Commit()
+}
+
+// DeactivateBundle stops selling bundle, bought bundles stay in orders.
+func (s *Service) DeactivateBundle(c context.Context, name string) (*models.Bundle, error) {
+	if !s.bundlesEnabled() {
+		return nil, apperror.NewNotFound("bundles disabled", ErrFeatureDisabled)
+	}
+
+	b, err := s.bundleRepo.DeactivateBundle(c, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrBundleNotFound) {
+			return nil, apperror.NewNotFound("bundle not found", err)
+		}
+		return nil, apperror.NewInternal("failed to deactivate bundle", err)
+	}
+
+	items, err := s.bundleRepo.GetBundleItems(c, name)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get bundle items", err)
+	}
+
+	return toBundleModel(b, items), nil
+}
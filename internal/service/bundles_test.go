@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuyBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	bundleRepo := mocks.NewMockBundleRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithOrders(orderRepo), WithBundles(bundleRepo))
+
+	welcome := &db.Bundle{Name: "welcome-pack", Price: 90, Active: true}
+	items := []*db.BundleItem{
+		{ItemType: "t-shirt", Variant: sql.NullString{String: "tshirt-m", Valid: true}, Quantity: 1},
+		{ItemType: "cup", Quantity: 1},
+		{ItemType: "pen", Quantity: 2},
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				bundleRepo.EXPECT().
+					GetBundle(gomock.Any(), "welcome-pack").
+					Return(welcome, nil)
+				bundleRepo.EXPECT().
+					GetBundleItems(gomock.Any(), "welcome-pack").
+					Return(items, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				// bundle price is charged once
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-90).
+					Return(&mockUser1, nil)
+				storeRepo.EXPECT().
+					TakeVariantStock(gomock.Any(), "tshirt-m", int32(1)).
+					Return(nil)
+				inventoryRepo.EXPECT().
+					AddItems(gomock.Any(), mockUser1.UserID, "t-shirt", "tshirt-m", int32(1)).
+					Return(nil)
+				inventoryRepo.EXPECT().
+					AddItems(gomock.Any(), mockUser1.UserID, "cup", "", int32(1)).
+					Return(nil)
+				inventoryRepo.EXPECT().
+					AddItems(gomock.Any(), mockUser1.UserID, "pen", "", int32(2)).
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Bundle: "welcome-pack", Price: 90}).
+					Return(&db.Order{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Component Out Of Stock",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				bundleRepo.EXPECT().
+					GetBundle(gomock.Any(), "welcome-pack").
+					Return(welcome, nil)
+				bundleRepo.EXPECT().
+					GetBundleItems(gomock.Any(), "welcome-pack").
+					Return(items, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-90).
+					Return(&mockUser1, nil)
+				storeRepo.EXPECT().
+					TakeVariantStock(gomock.Any(), "tshirt-m", int32(1)).
+					Return(repository.ErrVariantOutOfStock)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("variant out of stock", ErrOutOfStock).
+				WithDetails(map[string]string{"sku": "tshirt-m"}),
+		},
+		{
+			name: "Err Inactive",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				bundleRepo.EXPECT().
+					GetBundle(gomock.Any(), "welcome-pack").
+					Return(&db.Bundle{Name: "welcome-pack", Price: 90}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("bundle is not sold anymore", ErrBundleInactive),
+		},
+		{
+			name: "Err Not Found",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				bundleRepo.EXPECT().
+					GetBundle(gomock.Any(), "welcome-pack").
+					Return(nil, repository.ErrBundleNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("bundle not found", repository.ErrBundleNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.BuyBundle(context.Background(), mockUser1.Username, "welcome-pack")
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	bundleRepo := mocks.NewMockBundleRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, nil, nil, nil, storeRepo, nil, nil, nil, WithBundles(bundleRepo))
+
+	tshirt := &models.Item{Type: "t-shirt", Price: 80, CurrentPrice: 80}
+	tshirtVariants := []*db.ItemVariant{{Sku: "tshirt-m", ItemType: "t-shirt", Size: "M"}}
+
+	storeRepo.EXPECT().
+		GetItemInfo(gomock.Any(), "t-shirt", gomock.Any()).
+		Return(tshirt, nil)
+	storeRepo.EXPECT().
+		ListItemVariants(gomock.Any(), "t-shirt").
+		Return(tshirtVariants, nil)
+	storeRepo.EXPECT().
+		GetItemInfo(gomock.Any(), "cup", gomock.Any()).
+		Return(&models.Item{Type: "cup", Price: 20, CurrentPrice: 20}, nil)
+	storeRepo.EXPECT().
+		ListItemVariants(gomock.Any(), "cup").
+		Return(nil, nil)
+	mock.ExpectBegin()
+	bundleRepo.EXPECT().
+		CreateBundle(gomock.Any(), "welcome-pack", int32(90), "admin").
+		Return(&db.Bundle{Name: "welcome-pack", Price: 90, Active: true}, nil)
+	bundleRepo.EXPECT().
+		AddBundleItem(gomock.Any(), "welcome-pack", "t-shirt", "tshirt-m", int32(1)).
+		Return(&db.BundleItem{ItemType: "t-shirt", Variant: sql.NullString{String: "tshirt-m", Valid: true}, Quantity: 1}, nil)
+	bundleRepo.EXPECT().
+		AddBundleItem(gomock.Any(), "welcome-pack", "cup", "", int32(1)).
+		Return(&db.BundleItem{ItemType: "cup", Quantity: 1}, nil)
+	mock.ExpectCommit()
+
+	b, err := srv.CreateBundle(context.Background(), "admin", &models.NewBundle{
+		Name:  "welcome-pack",
+		Price: 90,
+		Items: []*models.BundleItem{
+			{Item: "t-shirt", Variant: "tshirt-m", Quantity: 1},
+			{Item: "cup", Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &models.Bundle{
+		Name:   "welcome-pack",
+		Price:  90,
+		Active: true,
+		Items: []*models.BundleItem{
+			{Item: "t-shirt", Variant: "tshirt-m", Quantity: 1},
+			{Item: "cup", Quantity: 1},
+		},
+	}, b)
+
+	// component with variants needs sku
+	storeRepo.EXPECT().
+		GetItemInfo(gomock.Any(), "t-shirt", gomock.Any()).
+		Return(tshirt, nil)
+	storeRepo.EXPECT().
+		ListItemVariants(gomock.Any(), "t-shirt").
+		Return(tshirtVariants, nil)
+	_, err = srv.CreateBundle(context.Background(), "admin", &models.NewBundle{
+		Name:  "welcome-pack",
+		Price: 90,
+		Items: []*models.BundleItem{{Item: "t-shirt", Quantity: 1}},
+	})
+	require.Equal(t, apperror.NewBadReq("variant required", ErrVariantRequired).WithDetails(tshirt.Variants), err)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+type ExpiredEntry struct {
+	Amount    int32     `json:"amount"`
+	ExpiredAt time.Time `json:"expiredAt"`
+}
+
+func (s *Service) coinLotsEnabled() bool {
+	return s.coinLotRepo != nil
+}
+
+// lotExpiry returns expiry time for coins granted right now.
+func (s *Service) lotExpiry() time.Time {
+	return s.now().AddDate(0, s.coinLifetimeMonths, 0)
+}
+
+// grantCoins creates a new lot for user.
+// returns apperror.
+func (s *Service) grantCoins(c context.Context, userID, amount int32) error {
+	if _, err := s.coinLotRepo.GrantCoins(c, userID, amount, s.lotExpiry()); err != nil {
+		return apperror.NewInternal("failed to grant coins", err)
+	}
+	return nil
+}
+
+// consumeCoins spends user's oldest lots first and returns spent parts of lots.
+// returns apperror.
+func (s *Service) consumeCoins(c context.Context, userID, amount int32) ([]*models.CoinLot, error) {
+	lots, err := s.coinLotRepo.ConsumeCoins(c, userID, amount)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotEnoughCoins) {
+			return nil, apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+		}
+		return nil, apperror.NewInternal("failed to spend coins", err)
+	}
+	return lots, nil
+}
+
+// moveCoinLots consumes sender's lots and grants a new lot to receiver.
+// users is the result of balance update with both users.
+// returns apperror.
+func (s *Service) moveCoinLots(c context.Context, users []*db.User, fromUsername, toUsername string, amount int32) error {
+	var from, to *db.User
+	for _, u := range users {
+		switch u.Username {
+		case fromUsername:
+			from = u
+		case toUsername:
+			to = u
+		}
+	}
+	if from == nil || to == nil {
+		return apperror.NewNotFound(fmt.Sprintf("users not found: %s, %s", fromUsername, toUsername), repository.ErrUserNotFound)
+	}
+
+	if _, err := s.consumeCoins(c, from.UserID, amount); err != nil {
+		return err
+	}
+	return s.grantCoins(c, to.UserID, amount)
+}
+
+// fillExpiringSoon is helper func to fill lots that expire within notice period.
+// returns apperror.
+func (s *Service) fillExpiringSoon(c context.Context, usr *models.User) error {
+	lots, err := s.coinLotRepo.GetExpiringLots(c, usr.ID, s.now().Add(s.coinExpiryNotice))
+	if err != nil {
+		return apperror.NewInternal("failed to get expiring coins", err)
+	}
+
+	usr.ExpiringSoon = make([]*models.CoinLot, len(lots))
+	for i, v := range lots {
+		usr.ExpiringSoon[i] = &models.CoinLot{Amount: v.Amount, ExpiresAt: v.ExpiresAt}
+	}
+	return nil
+}
+
+// fillExpiredEntries adds burned coins to user's history.
+// returns apperror.
+func (s *Service) fillExpiredEntries(c context.Context, history map[string]interface{}, username string) error {
+	expirations, err := s.coinLotRepo.GetExpirations(c, username)
+	if err != nil {
+		return apperror.NewInternal("failed to get expired coins", err)
+	}
+
+	expired := make([]*ExpiredEntry, len(expirations))
+	for i, v := range expirations {
+		expired[i] = &ExpiredEntry{Amount: v.Amount, ExpiredAt: v.ExpiredAt}
+	}
+	history["expired"] = expired
+	return nil
+}
+
+// ExpireCoins burns expired lots, coins held by auction bids,
+// approvals or preorders aren't burned. Runs periodically by worker.
+func (s *Service) ExpireCoins(c context.Context) error {
+	if !s.coinLotsEnabled() {
+		return nil
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to expire coins", err)
+	}
+	defer tx.Rollback()
+
+	expired, err := s.coinLotRepo.ExpireLots(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to expire coins", err)
+	}
+	if len(expired) > 0 {
+		log.Printf("coin expiry: burned lots of %d users", len(expired))
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockNow = time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+func mockClock() time.Time { return mockNow }
+
+func TestSendCoinWithLots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	lotRepo := mocks.NewMockCoinLotRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithCoinLots(lotRepo, 12, 24*time.Hour))
+
+	testCases := []struct {
+		name         string
+		amount       int32
+		mockBehavior func(amount int32)
+		expErr       error
+	}{
+		{
+			name:   "OK",
+			amount: 100,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				lotRepo.EXPECT().
+					ConsumeCoins(gomock.Any(), mockUser1.UserID, amount).
+					Return([]*models.CoinLot{{Amount: amount, ExpiresAt: mockNow}}, nil)
+				lotRepo.EXPECT().
+					GrantCoins(gomock.Any(), mockUser2.UserID, amount, mockNow.AddDate(1, 0, 0)).
+					Return(&db.CoinLot{}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return(nil, nil)
+				mock.ExpectCommit()
+			},
+			expErr: nil,
+		},
+		{
+			name:   "Err Not Enough Coins In Lots",
+			amount: 100,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				lotRepo.EXPECT().
+					ConsumeCoins(gomock.Any(), mockUser1.UserID, amount).
+					Return(nil, repository.ErrNotEnoughCoins)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough money", ErrNotEnoughMoney),
+		},
+		{
+			name:   "Err Receiver Not Found",
+			amount: 100,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("users not found: mockuser1, mockuser2", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.amount)
+
+			_, err := srv.SendCoin(context.Background(), mockUser1.Username, mockUser2.Username, tc.amount)
+
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestGetFullUserInfoWithLots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	lotRepo := mocks.NewMockCoinLotRepository(ctrl)
+
+	var dummyDB *sql.DB = nil
+
+	srv := NewService(dummyDB, userRepo, transferRepo, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithCoinLots(lotRepo, 12, 24*time.Hour))
+
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	inventoryRepo.EXPECT().
+		GetInventory(gomock.Any(), mockUser1.UserID).
+		Return(nil, repository.ErrNoInventoryItems)
+	transferRepo.EXPECT().
+		GetTransfersWithUser(gomock.Any(), mockUser1.Username).
+		Return(nil, repository.ErrNoTransfers)
+	lotRepo.EXPECT().
+		GetExpirations(gomock.Any(), mockUser1.Username).
+		Return([]*db.CoinExpiration{{Username: mockUser1.Username, Amount: 40, ExpiredAt: mockNow}}, nil)
+	lotRepo.EXPECT().
+		GetExpiringLots(gomock.Any(), mockUser1.UserID, mockNow.Add(24*time.Hour)).
+		Return([]*db.CoinLot{{UserID: mockUser1.UserID, Amount: 60, ExpiresAt: mockNow.Add(time.Hour)}}, nil)
+
+	usr, err := srv.GetFullUserInfo(context.Background(), mockUser1.Username)
+	require.NoError(t, err)
+
+	require.Equal(t, []*models.CoinLot{{Amount: 60, ExpiresAt: mockNow.Add(time.Hour)}}, usr.ExpiringSoon)
+	require.Equal(t, []*ExpiredEntry{{Amount: 40, ExpiredAt: mockNow}}, usr.EntryHistory.(map[string]interface{})["expired"])
+}
+
+func TestExpireCoins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lotRepo := mocks.NewMockCoinLotRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, nil, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithCoinLots(lotRepo, 12, 24*time.Hour))
+
+	mock.ExpectBegin()
+	lotRepo.EXPECT().
+		ExpireLots(gomock.Any(), mockNow).
+		Return([]*db.CoinExpiration{{Username: mockUser1.Username, Amount: 10}}, nil)
+	mock.ExpectCommit()
+	require.NoError(t, srv.ExpireCoins(context.Background()))
+
+	mock.ExpectBegin()
+	lotRepo.EXPECT().
+		ExpireLots(gomock.Any(), mockNow).
+		Return(nil, ErrMock)
+	mock.ExpectRollback()
+	require.Equal(t, apperror.NewInternal("failed to expire coins", ErrMock), srv.ExpireCoins(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
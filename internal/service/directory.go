@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/authn"
+)
+
+// provisionDirectoryUser creates local user for directory user
+// on first login, password stays in directory.
+// returns apperror.
+func (s *Service) provisionDirectoryUser(c context.Context, username string) (*db.User, error) {
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create user", err)
+	}
+	defer tx.Rollback()
+
+	usr, err := s.createUserWithoutPassword(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return usr, tx.Commit()
+}
+
+// syncProfile saves attributes of user changed in directory.
+// Login doesn't fail if it can't, profile is synced next time.
+func (s *Service) syncProfile(c context.Context, dbUsr *db.User, profile *authn.Profile) {
+	if dbUsr.DisplayName == profile.DisplayName && dbUsr.Department == profile.Department {
+		return
+	}
+
+	if err := s.userRepo.UpdateProfile(c, dbUsr.Username, profile.DisplayName, profile.Department); err != nil {
+		log.Printf("failed to sync profile of %s: %v", dbUsr.Username, err)
+	}
+}
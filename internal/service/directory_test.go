@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/authn"
+	"github.com/myacey/avito-shop/internal/ldap/ldaptest"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	dir, err := ldaptest.NewServer()
+	require.NoError(t, err)
+	defer dir.Close()
+	dir.AddEntry("uid=alice,ou=people,dc=corp,dc=test", "Corp-passw0rd", map[string][]string{
+		"uid":              {"alice"},
+		"displayName":      {"Alice Smith"},
+		"departmentNumber": {"Logistics"},
+	})
+
+	// directory is in-process, so plaintext is fine
+	authenticator, err := authn.NewLDAP(authn.LDAPConfig{URL: dir.URL(), Insecure: true, BaseDN: "dc=corp,dc=test"})
+	require.NoError(t, err)
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, sessionRepo, jwtToken, hashGen,
+		WithAuthenticator(authenticator),
+	)
+
+	expectToken := func(username string) {
+		jwtToken.EXPECT().
+			CreateToken(username).
+			Return("valid", nil)
+		sessionRepo.EXPECT().
+			CreateToken(gomock.Any(), username, "valid", sessionKeyTTL).
+			Return(nil)
+	}
+	alice := &db.User{UserID: 3, Username: "alice", Coins: 1000}
+
+	// first login creates user without password and syncs profile
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(nil, repository.ErrUserNotFound)
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		CreateUser(gomock.Any(), "alice", "").
+		Return(alice, nil)
+	mock.ExpectCommit()
+	userRepo.EXPECT().
+		UpdateProfile(gomock.Any(), "alice", "Alice Smith", "Logistics").
+		Return(nil)
+	expectToken("alice")
+
+	token, err := srv.AuthorizeUser(context.Background(), "alice", "Corp-passw0rd", "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// profile is up to date, password hash isn't touched
+	synced := *alice
+	synced.DisplayName, synced.Department = "Alice Smith", "Logistics"
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(&synced, nil)
+	expectToken("alice")
+
+	_, err = srv.AuthorizeUser(context.Background(), "alice", "Corp-passw0rd", "10.0.0.1")
+	require.NoError(t, err)
+
+	// wrong password
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(&synced, nil)
+
+	_, err = srv.AuthorizeUser(context.Background(), "alice", "wrong", "10.0.0.1")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+
+	// local users unknown to directory can't log in, nor sign up
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+
+	_, err = srv.AuthorizeUser(context.Background(), mockUser1.Username, mockUser1.Password, "10.0.0.1")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "bob").
+		Return(nil, repository.ErrUserNotFound)
+
+	_, err = srv.AuthorizeUser(context.Background(), "bob", "Corp-passw0rd", "10.0.0.1")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
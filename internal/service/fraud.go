@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/fraud"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrCaseResolved = errors.New("fraud case already resolved")
+
+func (s *Service) fraudEnabled() bool {
+	return s.fraudDetector != nil
+}
+
+// checkFraud runs fraud rules against transfer.
+// returns apperror.
+func (s *Service) checkFraud(c context.Context, fromUsername, toUsername string, amount int32) (*fraud.Verdict, error) {
+	verdict, err := s.fraudDetector.Analyze(c, fraud.Transfer{
+		From:   fromUsername,
+		To:     toUsername,
+		Amount: amount,
+		At:     s.now(),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to check transfer", err)
+	}
+
+	return verdict, nil
+}
+
+// holdSuspiciousTransfer saves transfer for review and holds
+// sender's coins until the case is resolved.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) holdSuspiciousTransfer(c context.Context, fromUsername, toUsername string, amount int32, verdict *fraud.Verdict) (*models.TransferResult, error) {
+	if err := s.holdTransferCoins(c, fromUsername, toUsername, amount); err != nil {
+		return nil, err
+	}
+
+	fc, err := s.fraudCaseRepo.CreateCase(c, fromUsername, toUsername, amount, fraud.ActionHold.String(), verdict.Reasons(), 0)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to hold transfer", err)
+	}
+
+	return &models.TransferResult{Status: models.TransferHeld, CaseID: fc.CaseID}, nil
+}
+
+// flagSuspiciousTransfer saves transfer for review, transfer
+// is nil if it waits for approval.
+// returns apperror.
+func (s *Service) flagSuspiciousTransfer(c context.Context, fromUsername, toUsername string, amount int32, transfer *db.Transfer, verdict *fraud.Verdict) error {
+	var transferID int32
+	if transfer != nil {
+		transferID = transfer.TransferID
+	}
+
+	_, err := s.fraudCaseRepo.CreateCase(c, fromUsername, toUsername, amount,
+		fraud.ActionFlag.String(), verdict.Reasons(), transferID)
+	if err != nil {
+		return apperror.NewInternal("failed to flag transfer", err)
+	}
+
+	return nil
+}
+
+func toFraudCaseModel(fc *db.FraudCase) *models.FraudCase {
+	res := &models.FraudCase{
+		ID:         fc.CaseID,
+		FromUser:   fc.FromUsername,
+		ToUser:     fc.ToUsername,
+		Amount:     fc.Amount,
+		Action:     fc.Action,
+		Reasons:    fc.Reasons,
+		Status:     fc.Status,
+		CreatedAt:  fc.CreatedAt,
+		ResolvedBy: fc.ResolvedBy.String,
+	}
+	if fc.TransferID.Valid {
+		res.TransferID = &fc.TransferID.Int32
+	}
+	if fc.ResolvedAt.Valid {
+		res.ResolvedAt = &fc.ResolvedAt.Time
+	}
+
+	return res
+}
+
+// ListFraudCases returns cases with status for admin review.
+func (s *Service) ListFraudCases(c context.Context, status string) ([]*models.FraudCase, error) {
+	if !s.fraudEnabled() {
+		return nil, apperror.NewNotFound("fraud detection disabled", ErrFeatureDisabled)
+	}
+
+	switch status {
+	case "":
+		status = models.FraudCaseOpen
+	case models.FraudCaseOpen, models.FraudCaseApproved, models.FraudCaseRejected:
+	default:
+		return nil, apperror.NewBadReq("invalid case status", nil)
+	}
+
+	cases, err := s.fraudCaseRepo.ListCases(c, status)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get fraud cases", err)
+	}
+
+	res := make([]*models.FraudCase, len(cases))
+	for i, fc := range cases {
+		res[i] = toFraudCaseModel(fc)
+	}
+
+	return res, nil
+}
+
+// ResolveFraudCase closes the case. Held coins are returned to sender,
+// approving a held case then sends them as a regular transfer,
+// rejecting it drops the transfer.
+func (s *Service) ResolveFraudCase(c context.Context, caseID int32, adminUsername string, approve bool) (*models.FraudCase, error) {
+	if !s.fraudEnabled() {
+		return nil, apperror.NewNotFound("fraud detection disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to resolve case", err)
+	}
+	defer tx.Rollback()
+
+	fc, err := s.fraudCaseRepo.GetCaseForUpdate(c, caseID)
+	if err != nil {
+		if errors.Is(err, repository.ErrFraudCaseNotFound) {
+			return nil, apperror.NewNotFound("fraud case not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get fraud case", err)
+	}
+	if fc.Status != models.FraudCaseOpen {
+		return nil, apperror.NewBadReq("fraud case already resolved", ErrCaseResolved)
+	}
+
+	status := models.FraudCaseRejected
+	if approve {
+		status = models.FraudCaseApproved
+	}
+
+	var transferID int32
+	if fc.Action == fraud.ActionHold.String() {
+		if approve {
+			if _, _, err = s.lockTwoUsers(c, fc.FromUsername, fc.ToUsername); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = s.userRepo.ReleaseCoins(c, fc.FromUsername, fc.Amount); err != nil {
+			return nil, apperror.NewInternal("failed to release coins", err)
+		}
+		if approve {
+			if transferID, err = s.approveHeldTransfer(c, fc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resolved, err := s.fraudCaseRepo.ResolveCase(c, caseID, status, adminUsername, transferID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to resolve case", err)
+	}
+
+	return toFraudCaseModel(resolved), tx.Commit()
+}
+
+// approveHeldTransfer passes held transfer through transfer limits and
+// approval threshold like a new one. Returns 0 if transfer waits for
+// finance approval. Users must be locked by lockTwoUsers.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) approveHeldTransfer(c context.Context, fc *db.FraudCase) (int32, error) {
+	if s.transferLimitsEnabled() {
+		if err := s.checkTransferLimits(c, fc.FromUsername, fc.ToUsername, fc.Amount, fc.Amount); err != nil {
+			return 0, err
+		}
+	}
+
+	if s.approvalRequired(fc.Amount) {
+		_, err := s.requestApproval(c, fc.FromUsername, fc.ToUsername, fc.Amount)
+		return 0, err
+	}
+
+	transfer, err := s.transferCoins(c, fc.FromUsername, fc.ToUsername, fc.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return transfer.TransferID, nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/fraud"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFraudRule struct {
+	finding *fraud.Finding
+	err     error
+}
+
+func (r *stubFraudRule) Name() string { return "stub" }
+
+func (r *stubFraudRule) Check(_ context.Context, _ fraud.Transfer) (*fraud.Finding, error) {
+	return r.finding, r.err
+}
+
+func TestSendCoinWithFraudDetector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	caseRepo := mocks.NewMockFraudCaseRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	rule := &stubFraudRule{}
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithFraudDetector(fraud.NewDetector(rule), caseRepo))
+
+	transfer := &db.Transfer{TransferID: 7, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, Amount: 100}
+
+	testCases := []struct {
+		name         string
+		finding      *fraud.Finding
+		err          error
+		mockBehavior func()
+		expRes       *models.TransferResult
+		expErr       error
+	}{
+		{
+			name:    "OK Clean",
+			finding: nil,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return(transfer, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.TransferResult{Status: models.TransferCompleted},
+		},
+		{
+			name:    "OK Flagged",
+			finding: &fraud.Finding{Rule: "stub", Action: fraud.ActionFlag, Reason: "odd"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return(transfer, nil)
+				caseRepo.EXPECT().
+					CreateCase(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100), "flagged", "stub: odd", transfer.TransferID).
+					Return(&db.FraudCase{CaseID: 1}, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.TransferResult{Status: models.TransferCompleted},
+		},
+		{
+			name:    "OK Held",
+			finding: &fraud.Finding{Rule: "stub", Action: fraud.ActionHold, Reason: "cycle"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(100)).
+					Return(&mockUser1, nil)
+				caseRepo.EXPECT().
+					CreateCase(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100), "held", "stub: cycle", int32(0)).
+					Return(&db.FraudCase{CaseID: 2}, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.TransferResult{Status: models.TransferHeld, CaseID: 2},
+		},
+		{
+			name:    "Err Held Not Enough Money",
+			finding: &fraud.Finding{Rule: "stub", Action: fraud.ActionHold, Reason: "cycle"},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&db.User{Username: mockUser1.Username, Coins: 50}, &mockUser2}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough money", ErrNotEnoughMoney),
+		},
+		{
+			name:    "Err Unknown Sender",
+			finding: nil,
+			err:     repository.ErrUserNotFound,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("user not found", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule.finding, rule.err = tc.finding, tc.err
+			tc.mockBehavior()
+
+			res, err := srv.SendCoin(context.Background(), mockUser1.Username, mockUser2.Username, 100)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestResolveFraudCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	caseRepo := mocks.NewMockFraudCaseRepository(ctrl)
+	approvalRepo := mocks.NewMockTransferApprovalRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithFraudDetector(fraud.NewDetector(), caseRepo),
+		WithTransferApprovals(approvalRepo, 500, time.Hour))
+
+	heldCase := &db.FraudCase{CaseID: 1, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, Amount: 100, Action: "held", Status: models.FraudCaseOpen}
+	largeCase := &db.FraudCase{CaseID: 1, FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, Amount: 600, Action: "held", Status: models.FraudCaseOpen}
+
+	testCases := []struct {
+		name         string
+		approve      bool
+		mockBehavior func()
+		expCase      *models.FraudCase
+		expErr       error
+	}{
+		{
+			name:    "OK Approve Held",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				caseRepo.EXPECT().
+					GetCaseForUpdate(gomock.Any(), int32(1)).
+					Return(heldCase, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(100)).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(100)).
+					Return(&db.Transfer{TransferID: 9}, nil)
+				caseRepo.EXPECT().
+					ResolveCase(gomock.Any(), int32(1), models.FraudCaseApproved, "admin", int32(9)).
+					Return(&db.FraudCase{CaseID: 1, Status: models.FraudCaseApproved, TransferID: sql.NullInt32{Int32: 9, Valid: true}}, nil)
+				mock.ExpectCommit()
+			},
+			expCase: &models.FraudCase{ID: 1, Status: models.FraudCaseApproved, TransferID: func() *int32 { v := int32(9); return &v }()},
+		},
+		{
+			name:    "OK Approve Held Above Threshold",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				caseRepo.EXPECT().
+					GetCaseForUpdate(gomock.Any(), int32(1)).
+					Return(largeCase, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(600)).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&db.User{Username: mockUser1.Username, Coins: 1000}, &mockUser2}, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(600)).
+					Return(&mockUser1, nil)
+				approvalRepo.EXPECT().
+					CreateApproval(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(600), mockNow.Add(time.Hour)).
+					Return(&db.TransferApproval{ApprovalID: 3}, nil)
+				caseRepo.EXPECT().
+					ResolveCase(gomock.Any(), int32(1), models.FraudCaseApproved, "admin", int32(0)).
+					Return(&db.FraudCase{CaseID: 1, Status: models.FraudCaseApproved}, nil)
+				mock.ExpectCommit()
+			},
+			expCase: &models.FraudCase{ID: 1, Status: models.FraudCaseApproved},
+		},
+		{
+			name:    "OK Reject Held",
+			approve: false,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				caseRepo.EXPECT().
+					GetCaseForUpdate(gomock.Any(), int32(1)).
+					Return(heldCase, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(100)).
+					Return(&mockUser1, nil)
+				caseRepo.EXPECT().
+					ResolveCase(gomock.Any(), int32(1), models.FraudCaseRejected, "admin", int32(0)).
+					Return(&db.FraudCase{CaseID: 1, Status: models.FraudCaseRejected}, nil)
+				mock.ExpectCommit()
+			},
+			expCase: &models.FraudCase{ID: 1, Status: models.FraudCaseRejected},
+		},
+		{
+			name:    "Err Already Resolved",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				caseRepo.EXPECT().
+					GetCaseForUpdate(gomock.Any(), int32(1)).
+					Return(&db.FraudCase{CaseID: 1, Status: models.FraudCaseRejected}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("fraud case already resolved", ErrCaseResolved),
+		},
+		{
+			name:    "Err Not Found",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				caseRepo.EXPECT().
+					GetCaseForUpdate(gomock.Any(), int32(1)).
+					Return(nil, repository.ErrFraudCaseNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("fraud case not found", repository.ErrFraudCaseNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			fc, err := srv.ResolveFraudCase(context.Background(), 1, "admin", tc.approve)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expCase, fc)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrSelfGift = errors.New("cannot gift to yourself")
+
+const maxGiftMessageLen = 200
+
+type GiftSentEntry struct {
+	ToUser  string `json:"toUser"`
+	Item    string `json:"item"`
+	Message string `json:"message,omitempty"`
+}
+
+type GiftReceivedEntry struct {
+	FromUser string `json:"fromUser"`
+	Item     string `json:"item"`
+	Message  string `json:"message,omitempty"`
+}
+
+func (s *Service) giftsEnabled() bool {
+	return s.giftRepo != nil
+}
+
+// fillGiftEntries adds sent and received gifts to user's history.
+// returns apperror.
+func (s *Service) fillGiftEntries(c context.Context, history map[string]interface{}, username string) error {
+	gifts, err := s.giftRepo.GetGiftsWithUser(c, username)
+	if err != nil {
+		return apperror.NewInternal("failed to get gifts", err)
+	}
+
+	sent := make([]*GiftSentEntry, 0, len(gifts))
+	received := make([]*GiftReceivedEntry, 0, len(gifts))
+	for _, g := range gifts {
+		if g.ToUsername == username {
+			received = append(received, &GiftReceivedEntry{FromUser: g.FromUsername, Item: g.ItemType, Message: g.Message})
+		} else if g.FromUsername == username {
+			sent = append(sent, &GiftSentEntry{ToUser: g.ToUsername, Item: g.ItemType, Message: g.Message})
+		}
+	}
+	history["giftsSent"] = sent
+	history["giftsReceived"] = received
+
+	return nil
+}
+
+// BuyGift charges buyer and adds an item to recipient's inventory.
+func (s *Service) BuyGift(c context.Context, fromUsername, toUsername, itemName, message string) error {
+	if !s.giftsEnabled() {
+		return apperror.NewNotFound("gifts disabled", ErrFeatureDisabled)
+	}
+	if fromUsername == toUsername {
+		return apperror.NewBadReq("cannot gift to yourself", ErrSelfGift)
+	}
+	if utf8.RuneCountInString(message) > maxGiftMessageLen {
+		return apperror.NewBadReq(fmt.Sprintf("gift message longer than %d symbols", maxGiftMessageLen), nil)
+	}
+
+	itemToBuy, err := s.getItemInfo(c, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return apperror.NewBadReq("invalid item name", err)
+		}
+		return apperror.NewInternal("failed to get item info", err)
+	}
+	if len(itemToBuy.Variants) > 0 {
+		return apperror.NewBadReq("items with variants can't be gifted", ErrInvalidVariant)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to buy gift", err)
+	}
+	defer tx.Rollback()
+
+	// gift is buyer's purchase, it counts towards buyer's limits;
+	// both users are locked in fixed order, so gifts in both
+	// directions and transfers between them can't deadlock
+	dbUsr, recipient, err := s.lockTwoUsers(c, fromUsername, toUsername)
+	if err != nil {
+		return err
+	}
+	if err = s.checkPurchaseLimit(c, fromUsername, itemName, 1); err != nil {
+		return err
+	}
+	lots, err := s.chargeUser(c, dbUsr, itemToBuy.CurrentPrice)
+	if err != nil {
+		return err
+	}
+
+	if err = s.inventoryRepo.AddItemToInventory(c, recipient.UserID, itemName, ""); err != nil {
+		return apperror.NewInternal("failed to add item to inventory", err)
+	}
+
+	if _, err = s.giftRepo.CreateGift(c, fromUsername, toUsername, itemName, message); err != nil {
+		return apperror.NewInternal("failed to save gift", err)
+	}
+
+	if s.ordersEnabled() {
+		_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+			Username:   fromUsername,
+			Item:       itemName,
+			Recipient:  toUsername,
+			Price:      itemToBuy.CurrentPrice,
+			ScheduleID: itemToBuy.ScheduleID,
+			CoinLots:   lots,
+		})
+		if err != nil {
+			return apperror.NewInternal("failed to create order", err)
+		}
+	}
+
+	text := fmt.Sprintf("%s sent you a gift: %s", fromUsername, itemName)
+	if message != "" {
+		text += ". " + message
+	}
+	if err = s.notify(c, toUsername, models.NotificationGift, text); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuyGift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	giftRepo := mocks.NewMockGiftRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithGifts(giftRepo), WithNotifications(notificationRepo))
+
+	testCases := []struct {
+		name         string
+		toUsername   string
+		message      string
+		mockBehavior func(toUsername, message string)
+		expErr       error
+	}{
+		{
+			name:       "OK",
+			toUsername: mockUser2.Username,
+			message:    "happy birthday",
+			mockBehavior: func(toUsername, message string) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), mockItem.Type, gomock.Any()).
+					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), mockItem.Type).
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, toUsername).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-mockItem.CurrentPrice).
+					Return(nil, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser2.UserID, mockItem.Type, "").
+					Return(nil)
+				giftRepo.EXPECT().
+					CreateGift(gomock.Any(), mockUser1.Username, toUsername, mockItem.Type, message).
+					Return(&db.Gift{}, nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), toUsername, models.NotificationGift, "mockuser1 sent you a gift: mockitem. happy birthday").
+					Return(&db.Notification{}, nil)
+				mock.ExpectCommit()
+			},
+			expErr: nil,
+		},
+		{
+			name:         "Err Self Gift",
+			toUsername:   mockUser1.Username,
+			mockBehavior: func(toUsername, message string) {},
+			expErr:       apperror.NewBadReq("cannot gift to yourself", ErrSelfGift),
+		},
+		{
+			name:         "Err Long Message",
+			toUsername:   mockUser2.Username,
+			message:      strings.Repeat("a", maxGiftMessageLen+1),
+			mockBehavior: func(toUsername, message string) {},
+			expErr:       apperror.NewBadReq("gift message longer than 200 symbols", nil),
+		},
+		{
+			name:       "Err Recipient Not Found",
+			toUsername: "unknown",
+			mockBehavior: func(toUsername, message string) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), mockItem.Type, gomock.Any()).
+					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), mockItem.Type).
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, toUsername).
+					Return(nil, repository.ErrUserNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("users not found: mockuser1, unknown", repository.ErrUserNotFound),
+		},
+		{
+			name:       "Err Not Enough Money",
+			toUsername: mockUser2.Username,
+			mockBehavior: func(toUsername, message string) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), mockItem.Type, gomock.Any()).
+					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), mockItem.Type).
+					Return(nil, nil)
+				mock.ExpectBegin()
+				poor := mockUser1
+				poor.Coins = 0
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, toUsername).
+					Return([]*db.User{&poor, &mockUser2}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough money", ErrNotEnoughMoney),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.toUsername, tc.message)
+
+			err := srv.BuyGift(context.Background(), mockUser1.Username, tc.toUsername, mockItem.Type, tc.message)
+
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFillGiftEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	giftRepo := mocks.NewMockGiftRepository(ctrl)
+	srv := &Service{giftRepo: giftRepo}
+
+	giftRepo.EXPECT().
+		GetGiftsWithUser(gomock.Any(), mockUser1.Username).
+		Return([]*db.Gift{
+			{FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, ItemType: "cup", Message: "hi"},
+			{FromUsername: mockUser2.Username, ToUsername: mockUser1.Username, ItemType: "pen"},
+		}, nil)
+
+	history := map[string]interface{}{}
+	require.NoError(t, srv.fillGiftEntries(context.Background(), history, mockUser1.Username))
+	require.Equal(t, []*GiftSentEntry{{ToUser: mockUser2.Username, Item: "cup", Message: "hi"}}, history["giftsSent"])
+	require.Equal(t, []*GiftReceivedEntry{{FromUser: mockUser2.Username, Item: "pen"}}, history["giftsReceived"])
+}
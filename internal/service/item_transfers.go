@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrSelfItemTransfer = errors.New("cannot send items to yourself")
+
+type ItemSentEntry struct {
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int32  `json:"quantity"`
+}
+
+type ItemReceivedEntry struct {
+	FromUser string `json:"fromUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int32  `json:"quantity"`
+}
+
+func (s *Service) itemTransfersEnabled() bool {
+	return s.itemTransferRepo != nil
+}
+
+// fillItemEntries adds sent and received items to user's history.
+// returns apperror.
+func (s *Service) fillItemEntries(c context.Context, history map[string]interface{}, username string) error {
+	transfers, err := s.itemTransferRepo.GetItemTransfersWithUser(c, username)
+	if err != nil {
+		return apperror.NewInternal("failed to get item transfers", err)
+	}
+
+	sent := make([]*ItemSentEntry, 0, len(transfers))
+	received := make([]*ItemReceivedEntry, 0, len(transfers))
+	for _, t := range transfers {
+		if t.ToUsername == username {
+			received = append(received, &ItemReceivedEntry{FromUser: t.FromUsername, Item: t.ItemType, Variant: t.Variant.String, Quantity: t.Quantity})
+		} else if t.FromUsername == username {
+			sent = append(sent, &ItemSentEntry{ToUser: t.ToUsername, Item: t.ItemType, Variant: t.Variant.String, Quantity: t.Quantity})
+		}
+	}
+	history["itemsSent"] = sent
+	history["itemsReceived"] = received
+
+	return nil
+}
+
+// removeItems takes owned items out of user's inventory,
+// variant is SKU or empty for items without variants.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) removeItems(c context.Context, userID int32, itemName, variant string, quantity int32) error {
+	if err := s.inventoryRepo.RemoveItems(c, userID, itemName, variant, quantity); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrItemNotOwned):
+			return apperror.NewBadReq("item not in inventory", err)
+		case errors.Is(err, repository.ErrNotEnoughItems):
+			return apperror.NewBadReq("not enough items in inventory", err)
+		}
+		return apperror.NewInternal("failed to remove items", err)
+	}
+	return nil
+}
+
+// SendItem moves owned items from one user's inventory to another's,
+// variant is SKU of items with variants.
+func (s *Service) SendItem(c context.Context, fromUsername, toUsername, itemName, variant string, quantity int32) error {
+	if !s.itemTransfersEnabled() {
+		return apperror.NewNotFound("item transfers disabled", ErrFeatureDisabled)
+	}
+	if quantity <= 0 {
+		return apperror.NewBadReq("send items quantity must be positive", nil)
+	}
+	if fromUsername == toUsername {
+		return apperror.NewBadReq("cannot send items to yourself", ErrSelfItemTransfer)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to send items", err)
+	}
+	defer tx.Rollback()
+
+	sender, err := s.userRepo.GetUser(c, fromUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NewNotFound("user not found", err)
+		}
+		return apperror.NewInternal("failed to get user", err)
+	}
+
+	recipient, err := s.userRepo.GetUser(c, toUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NewNotFound("recipient not found", err)
+		}
+		return apperror.NewInternal("failed to get recipient", err)
+	}
+
+	if err = s.removeItems(c, sender.UserID, itemName, variant, quantity); err != nil {
+		return err
+	}
+
+	if err = s.inventoryRepo.AddItems(c, recipient.UserID, itemName, variant, quantity); err != nil {
+		return apperror.NewInternal("failed to add items to inventory", err)
+	}
+
+	if _, err = s.itemTransferRepo.CreateItemTransfer(c, fromUsername, toUsername, itemName, variant, quantity); err != nil {
+		return apperror.NewInternal("failed to save item transfer", err)
+	}
+
+	return tx.Commit()
+}
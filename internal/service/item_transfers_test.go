@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	itemTransferRepo := mocks.NewMockItemTransferRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithItemTransfers(itemTransferRepo))
+
+	testCases := []struct {
+		name         string
+		toUsername   string
+		quantity     int32
+		mockBehavior func(toUsername string, quantity int32)
+		expErr       error
+	}{
+		{
+			name:       "OK",
+			toUsername: mockUser2.Username,
+			quantity:   2,
+			mockBehavior: func(toUsername string, quantity int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), toUsername).
+					Return(&mockUser2, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, mockItem.Type, "", quantity).
+					Return(nil)
+				inventoryRepo.EXPECT().
+					AddItems(gomock.Any(), mockUser2.UserID, mockItem.Type, "", quantity).
+					Return(nil)
+				itemTransferRepo.EXPECT().
+					CreateItemTransfer(gomock.Any(), mockUser1.Username, toUsername, mockItem.Type, "", quantity).
+					Return(&db.ItemTransfer{}, nil)
+				mock.ExpectCommit()
+			},
+			expErr: nil,
+		},
+		{
+			name:         "Err Quantity",
+			toUsername:   mockUser2.Username,
+			quantity:     0,
+			mockBehavior: func(toUsername string, quantity int32) {},
+			expErr:       apperror.NewBadReq("send items quantity must be positive", nil),
+		},
+		{
+			name:         "Err Self Transfer",
+			toUsername:   mockUser1.Username,
+			quantity:     1,
+			mockBehavior: func(toUsername string, quantity int32) {},
+			expErr:       apperror.NewBadReq("cannot send items to yourself", ErrSelfItemTransfer),
+		},
+		{
+			name:       "Err Not Owned",
+			toUsername: mockUser2.Username,
+			quantity:   1,
+			mockBehavior: func(toUsername string, quantity int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), toUsername).
+					Return(&mockUser2, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, mockItem.Type, "", quantity).
+					Return(repository.ErrItemNotOwned)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("item not in inventory", repository.ErrItemNotOwned),
+		},
+		{
+			name:       "Err Not Enough Items",
+			toUsername: mockUser2.Username,
+			quantity:   5,
+			mockBehavior: func(toUsername string, quantity int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), toUsername).
+					Return(&mockUser2, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, mockItem.Type, "", quantity).
+					Return(repository.ErrNotEnoughItems)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough items in inventory", repository.ErrNotEnoughItems),
+		},
+		{
+			name:       "Err Recipient Not Found",
+			toUsername: "unknown",
+			quantity:   1,
+			mockBehavior: func(toUsername string, quantity int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), toUsername).
+					Return(nil, repository.ErrUserNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("recipient not found", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.toUsername, tc.quantity)
+
+			err := srv.SendItem(context.Background(), mockUser1.Username, tc.toUsername, mockItem.Type, "", tc.quantity)
+
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFillItemEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	itemTransferRepo := mocks.NewMockItemTransferRepository(ctrl)
+
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil,
+		WithItemTransfers(itemTransferRepo)).(*Service)
+
+	itemTransferRepo.EXPECT().
+		GetItemTransfersWithUser(gomock.Any(), mockUser1.Username).
+		Return([]*db.ItemTransfer{
+			{FromUsername: mockUser1.Username, ToUsername: mockUser2.Username, ItemType: "hoody", Quantity: 1, Variant: sql.NullString{String: "hoody-m", Valid: true}},
+			{FromUsername: mockUser2.Username, ToUsername: mockUser1.Username, ItemType: "cup", Quantity: 2},
+		}, nil)
+
+	history := map[string]interface{}{}
+	err := srv.fillItemEntries(context.Background(), history, mockUser1.Username)
+
+	require.NoError(t, err)
+	require.Equal(t, []*ItemSentEntry{{ToUser: mockUser2.Username, Item: "hoody", Variant: "hoody-m", Quantity: 1}}, history["itemsSent"])
+	require.Equal(t, []*ItemReceivedEntry{{FromUser: mockUser2.Username, Item: "cup", Quantity: 2}}, history["itemsReceived"])
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+)
+
+var ErrLoginLocked = errors.New("login locked")
+
+func (s *Service) loginThrottleEnabled() bool {
+	return s.loginAttemptRepo != nil
+}
+
+func loginUserKey(username string) string {
+	return "user:" + username
+}
+
+func loginIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// loginBackoff returns lock time after failures, 0 while
+// failures are within free attempts.
+func loginBackoff(failures, free int64, base, maxDelay time.Duration) time.Duration {
+	extra := failures - free
+	if extra <= 0 {
+		return 0
+	}
+
+	d := base
+	for i := int64(1); i < extra && d < maxDelay; i++ {
+		d *= 2
+	}
+	return min(d, maxDelay)
+}
+
+// checkLoginLock returns apperror with retry time if username or IP is locked.
+func (s *Service) checkLoginLock(c context.Context, username, ip string) error {
+	if !s.loginThrottleEnabled() {
+		return nil
+	}
+
+	keys := []string{loginUserKey(username)}
+	if ip != "" {
+		keys = append(keys, loginIPKey(ip))
+	}
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		ttl, err := s.loginAttemptRepo.LockTTL(c, key)
+		if err != nil {
+			return apperror.NewInternal("failed to check login lock", err)
+		}
+		retryAfter = max(retryAfter, ttl)
+	}
+
+	if retryAfter > 0 {
+		return apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+			WithRetryAfter(retryAfter)
+	}
+	return nil
+}
+
+// loginFailed counts failure of username and IP,
+// locks them once free attempts are spent.
+// returns apperror.
+func (s *Service) loginFailed(c context.Context, username, ip string) error {
+	if !s.loginThrottleEnabled() {
+		return nil
+	}
+
+	type counter struct {
+		key  string
+		free int64
+	}
+	counters := []counter{{loginUserKey(username), s.loginThrottle.UserFreeAttempts}}
+	if ip != "" {
+		counters = append(counters, counter{loginIPKey(ip), s.loginThrottle.IPFreeAttempts})
+	}
+
+	for _, cnt := range counters {
+		failures, err := s.loginAttemptRepo.AddFailure(c, cnt.key, s.loginThrottle.Window)
+		if err != nil {
+			return apperror.NewInternal("failed to count login failure", err)
+		}
+
+		backoff := loginBackoff(failures, cnt.free, s.loginThrottle.BaseDelay, s.loginThrottle.MaxDelay)
+		if backoff == 0 {
+			continue
+		}
+		if err = s.loginAttemptRepo.Lock(c, cnt.key, backoff); err != nil {
+			return apperror.NewInternal("failed to lock login", err)
+		}
+		log.Printf("login: %s locked for %s after %d failures", cnt.key, backoff, failures)
+	}
+
+	return nil
+}
+
+// loginSucceeded forgets failures of username. IP failures are kept,
+// otherwise one known account would reset them.
+// returns apperror.
+func (s *Service) loginSucceeded(c context.Context, username string) error {
+	if !s.loginThrottleEnabled() {
+		return nil
+	}
+
+	if err := s.loginAttemptRepo.ResetFailures(c, loginUserKey(username)); err != nil {
+		return apperror.NewInternal("failed to reset login failures", err)
+	}
+	return nil
+}
+
+// UnlockUser drops login lock and failures of username.
+func (s *Service) UnlockUser(c context.Context, username string) error {
+	if !s.loginThrottleEnabled() {
+		return apperror.NewNotFound("login throttling disabled", ErrFeatureDisabled)
+	}
+
+	key := loginUserKey(username)
+	if err := s.loginAttemptRepo.Unlock(c, key); err != nil {
+		return apperror.NewInternal("failed to unlock login", err)
+	}
+	if err := s.loginAttemptRepo.ResetFailures(c, key); err != nil {
+		return apperror.NewInternal("failed to reset login failures", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/authn"
+	"github.com/myacey/avito-shop/internal/hasher"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository/memrepo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginBackoff(t *testing.T) {
+	testCases := []struct {
+		failures int64
+		exp      time.Duration
+	}{
+		{failures: 3, exp: 0},
+		{failures: 4, exp: time.Second},
+		{failures: 5, exp: 2 * time.Second},
+		{failures: 7, exp: 8 * time.Second},
+		{failures: 100, exp: time.Minute},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.exp, loginBackoff(tc.failures, 3, time.Second, time.Minute))
+	}
+}
+
+func TestLoginThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	now := mockNow
+	clock := func() time.Time { return now }
+	attemptRepo := memrepo.NewMemoryLoginAttemptRepo(clock)
+
+	srv := NewService(nil, userRepo, nil, nil, nil, sessionRepo, jwtToken, hashGen,
+		WithClock(clock),
+		WithLoginThrottle(attemptRepo, models.LoginThrottle{
+			UserFreeAttempts: 2,
+			IPFreeAttempts:   10,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Minute,
+			Window:           time.Hour,
+		}))
+
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil).
+		AnyTimes()
+	hashGen.EXPECT().
+		Compare(gomock.Any(), mockUser1.Password, "wrong").
+		Return(hasher.ErrDontCompare).
+		AnyTimes()
+
+	login := func(password string) (string, error) {
+		return srv.AuthorizeUser(context.Background(), mockUser1.Username, password, "10.0.0.1")
+	}
+
+	// free attempts
+	for i := 0; i < 2; i++ {
+		_, err := login("wrong")
+		require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+	}
+
+	// third failure locks user for base delay
+	_, err := login("wrong")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+
+	_, err = login(mockUser1.Password)
+	require.Equal(t, apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+		WithRetryAfter(time.Second), err)
+
+	// next failure after lock doubles it
+	now = now.Add(time.Second)
+	_, err = login("wrong")
+	require.Error(t, err)
+	_, err = login(mockUser1.Password)
+	require.Equal(t, apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+		WithRetryAfter(2*time.Second), err)
+
+	// admin unlock lets user in and resets failures
+	require.NoError(t, srv.UnlockUser(context.Background(), mockUser1.Username))
+
+	hashGen.EXPECT().
+		Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
+		Return(nil)
+	hashGen.EXPECT().
+		NeedsRehash(mockUser1.Password).
+		Return(false)
+	jwtToken.EXPECT().
+		CreateToken(mockUser1.Username).
+		Return("valid", nil)
+	sessionRepo.EXPECT().
+		CreateToken(gomock.Any(), mockUser1.Username, "valid", sessionKeyTTL).
+		Return(nil)
+
+	token, err := login(mockUser1.Password)
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// failures are forgotten after unlock
+	_, err = login("wrong")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+	_, err = login("wrong")
+	require.Equal(t, apperror.NewNotFound("user not found", authn.ErrInvalidCredentials), err)
+	ttl, err := attemptRepo.LockTTL(context.Background(), loginUserKey(mockUser1.Username))
+	require.NoError(t, err)
+	require.Zero(t, ttl)
+}
+
+func TestLoginThrottleIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	attemptRepo := memrepo.NewMemoryLoginAttemptRepo(mockClock)
+	srv := NewService(nil, userRepo, nil, nil, nil, nil, nil, hashGen,
+		WithClock(mockClock),
+		WithLoginThrottle(attemptRepo, models.LoginThrottle{
+			UserFreeAttempts: 10,
+			IPFreeAttempts:   1,
+			BaseDelay:        time.Minute,
+			MaxDelay:         time.Hour,
+			Window:           time.Hour,
+		}))
+
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), gomock.Any()).
+		Return(&mockUser1, nil).
+		Times(2)
+	hashGen.EXPECT().
+		Compare(gomock.Any(), gomock.Any(), "wrong").
+		Return(hasher.ErrDontCompare).
+		Times(2)
+
+	// guessing different usernames from one IP
+	_, err := srv.AuthorizeUser(context.Background(), "alice", "wrong", "10.0.0.1")
+	require.Error(t, err)
+	_, err = srv.AuthorizeUser(context.Background(), "bob", "wrong", "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = srv.AuthorizeUser(context.Background(), "carol", "wrong", "10.0.0.1")
+	require.Equal(t, apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+		WithRetryAfter(time.Minute), err)
+}
+
+func TestChangePasswordThrottle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	attemptRepo := memrepo.NewMemoryLoginAttemptRepo(mockClock)
+
+	srv := NewService(nil, userRepo, nil, nil, nil, nil, nil, hashGen,
+		WithClock(mockClock),
+		WithLoginThrottle(attemptRepo, models.LoginThrottle{
+			UserFreeAttempts: 1,
+			IPFreeAttempts:   10,
+			BaseDelay:        time.Second,
+			MaxDelay:         time.Minute,
+			Window:           time.Hour,
+		}))
+
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil).
+		Times(2)
+	hashGen.EXPECT().
+		Compare(gomock.Any(), mockUser1.Password, "wrong").
+		Return(hasher.ErrDontCompare).
+		Times(2)
+
+	change := func(oldPassword string) error {
+		_, err := srv.ChangePassword(context.Background(), mockUser1.Username, oldPassword, "newpassword", "10.0.0.1")
+		return err
+	}
+
+	require.Equal(t, apperror.NewForbidden("invalid old password", ErrInvalidPassword), change("wrong"))
+	require.Equal(t, apperror.NewForbidden("invalid old password", ErrInvalidPassword), change("wrong"))
+
+	// guessing old password locks login too
+	require.Equal(t, apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+		WithRetryAfter(time.Second), change(mockUser1.Password))
+	_, err := srv.AuthorizeUser(context.Background(), mockUser1.Username, mockUser1.Password, "10.0.0.1")
+	require.Equal(t, apperror.NewTooManyRequests("too many failed login attempts", ErrLoginLocked).
+		WithRetryAfter(time.Second), err)
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrListingClosed    = errors.New("listing is not active")
+	ErrListingExpired   = errors.New("listing expired")
+	ErrOwnListing       = errors.New("cannot buy own listing")
+	ErrNotListingSeller = errors.New("not listing seller")
+)
+
+func (s *Service) marketplaceEnabled() bool {
+	return s.listingRepo != nil
+}
+
+// marketFee returns marketplace cut from listing price.
+func (s *Service) marketFee(price int32) int32 {
+	return price * s.marketFeePercent / 100
+}
+
+// creditCoins adds coins to user's balance.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) creditCoins(c context.Context, username string, amount int32) error {
+	dbUsr, err := s.userRepo.AddCoins(c, username, amount)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NewNotFound("user not found", err)
+		}
+		return apperror.NewInternal("failed to update balance", err)
+	}
+
+	if s.coinLotsEnabled() {
+		return s.grantCoins(c, dbUsr.UserID, amount)
+	}
+	return nil
+}
+
+// returnListingItems gives escrowed items back to seller.
+// returns apperror.
+func (s *Service) returnListingItems(c context.Context, l *db.Listing) error {
+	seller, err := s.userRepo.GetUser(c, l.SellerUsername)
+	if err != nil {
+		return apperror.NewInternal("failed to get seller", err)
+	}
+
+	if err = s.inventoryRepo.AddItems(c, seller.UserID, l.ItemType, l.Variant.String, l.Quantity); err != nil {
+		return apperror.NewInternal("failed to return items", err)
+	}
+	return nil
+}
+
+// closeListing sets final status of active listing, so only
+// one of concurrent buy or cancel calls gets it.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) closeListing(c context.Context, listingID int32, status, buyerUsername string) (*db.Listing, error) {
+	l, err := s.listingRepo.CloseListing(c, listingID, status, buyerUsername)
+	if err == nil {
+		return l, nil
+	}
+	if !errors.Is(err, repository.ErrListingNotActive) {
+		return nil, apperror.NewInternal("failed to close listing", err)
+	}
+
+	if _, err = s.listingRepo.GetListing(c, listingID); err != nil {
+		if errors.Is(err, repository.ErrListingNotFound) {
+			return nil, apperror.NewNotFound("listing not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get listing", err)
+	}
+	return nil, apperror.NewBadReq("listing is not active", ErrListingClosed)
+}
+
+func toListingModel(l *db.Listing) *models.Listing {
+	return &models.Listing{
+		ID:        l.ListingID,
+		Seller:    l.SellerUsername,
+		Item:      l.ItemType,
+		Variant:   l.Variant.String,
+		Quantity:  l.Quantity,
+		Price:     l.Price,
+		Status:    l.Status,
+		Buyer:     l.BuyerUsername.String,
+		CreatedAt: l.CreatedAt,
+		ExpiresAt: l.ExpiresAt,
+	}
+}
+
+// GetListings returns active listings, optionally of one item.
+func (s *Service) GetListings(c context.Context, itemName string) ([]*models.Listing, error) {
+	if !s.marketplaceEnabled() {
+		return nil, apperror.NewNotFound("marketplace disabled", ErrFeatureDisabled)
+	}
+
+	listings, err := s.listingRepo.ListActive(c, itemName, s.now())
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get listings", err)
+	}
+
+	res := make([]*models.Listing, len(listings))
+	for i, l := range listings {
+		res[i] = toListingModel(l)
+	}
+
+	return res, nil
+}
+
+// ListItem puts owned items on sale, they're kept out
+// of seller's inventory until listing is closed.
+// variant is SKU of items with variants.
+func (s *Service) ListItem(c context.Context, sellerUsername, itemName, variant string, quantity, price int32) (*models.Listing, error) {
+	if !s.marketplaceEnabled() {
+		return nil, apperror.NewNotFound("marketplace disabled", ErrFeatureDisabled)
+	}
+	if quantity <= 0 {
+		return nil, apperror.NewBadReq("listing quantity must be positive", nil)
+	}
+	if price <= 0 {
+		return nil, apperror.NewBadReq("listing price must be positive", nil)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to list item", err)
+	}
+	defer tx.Rollback()
+
+	seller, err := s.userRepo.GetUser(c, sellerUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get user", err)
+	}
+
+	if err = s.removeItems(c, seller.UserID, itemName, variant, quantity); err != nil {
+		return nil, err
+	}
+
+	l, err := s.listingRepo.CreateListing(c, sellerUsername, itemName, variant, quantity, price, s.now().Add(s.listingTTL))
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create listing", err)
+	}
+
+	return toListingModel(l), tx.Commit()
+}
+
+// BuyListing pays seller (minus marketplace fee) and moves
+// escrowed items to buyer's inventory.
+func (s *Service) BuyListing(c context.Context, buyerUsername string, listingID int32) (*models.Listing, error) {
+	if !s.marketplaceEnabled() {
+		return nil, apperror.NewNotFound("marketplace disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to buy listing", err)
+	}
+	defer tx.Rollback()
+
+	l, err := s.closeListing(c, listingID, models.ListingSold, buyerUsername)
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(l.ExpiresAt) {
+		return nil, apperror.NewBadReq("listing expired", ErrListingExpired)
+	}
+	if l.SellerUsername == buyerUsername {
+		return nil, apperror.NewBadReq("cannot buy own listing", ErrOwnListing)
+	}
+
+	buyer, err := s.payForItem(c, buyerUsername, l.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if income := l.Price - s.marketFee(l.Price); income > 0 {
+		if err = s.creditCoins(c, l.SellerUsername, income); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.inventoryRepo.AddItems(c, buyer.UserID, l.ItemType, l.Variant.String, l.Quantity); err != nil {
+		return nil, apperror.NewInternal("failed to add items to inventory", err)
+	}
+
+	text := fmt.Sprintf("%s bought your %s (x%d) for %d coins", buyerUsername, l.ItemType, l.Quantity, l.Price)
+	if err = s.notify(c, l.SellerUsername, models.NotificationSale, text); err != nil {
+		return nil, err
+	}
+
+	return toListingModel(l), tx.Commit()
+}
+
+// CancelListing returns escrowed items to seller.
+func (s *Service) CancelListing(c context.Context, sellerUsername string, listingID int32) (*models.Listing, error) {
+	if !s.marketplaceEnabled() {
+		return nil, apperror.NewNotFound("marketplace disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to cancel listing", err)
+	}
+	defer tx.Rollback()
+
+	l, err := s.closeListing(c, listingID, models.ListingCancelled, "")
+	if err != nil {
+		return nil, err
+	}
+	if l.SellerUsername != sellerUsername {
+		return nil, apperror.NewForbidden("not your listing", ErrNotListingSeller)
+	}
+
+	if err = s.returnListingItems(c, l); err != nil {
+		return nil, err
+	}
+
+	return toListingModel(l), tx.Commit()
+}
+
+// ExpireListings returns items of listings nobody bought in time.
+// Runs periodically by worker.
+func (s *Service) ExpireListings(c context.Context) error {
+	if !s.marketplaceEnabled() {
+		return nil
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to expire listings", err)
+	}
+	defer tx.Rollback()
+
+	expired, err := s.listingRepo.ExpireListings(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to expire listings", err)
+	}
+
+	for _, l := range expired {
+		if err = s.returnListingItems(c, l); err != nil {
+			return err
+		}
+	}
+	if len(expired) > 0 {
+		log.Printf("marketplace: expired %d listings", len(expired))
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+const listingTTL = 7 * 24 * time.Hour
+
+func TestListItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	listingRepo := mocks.NewMockListingRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithMarketplace(listingRepo, 10, listingTTL))
+
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	inventoryRepo.EXPECT().
+		RemoveItems(gomock.Any(), mockUser1.UserID, "socks", "socks-m", int32(2)).
+		Return(nil)
+	listingRepo.EXPECT().
+		CreateListing(gomock.Any(), mockUser1.Username, "socks", "socks-m", int32(2), int32(15), mockNow.Add(listingTTL)).
+		Return(&db.Listing{ListingID: 1, SellerUsername: mockUser1.Username, ItemType: "socks", Variant: sql.NullString{String: "socks-m", Valid: true}, Quantity: 2, Price: 15, Status: models.ListingActive}, nil)
+	mock.ExpectCommit()
+
+	l, err := srv.ListItem(context.Background(), mockUser1.Username, "socks", "socks-m", 2, 15)
+	require.NoError(t, err)
+	require.Equal(t, &models.Listing{ID: 1, Seller: mockUser1.Username, Item: "socks", Variant: "socks-m", Quantity: 2, Price: 15, Status: models.ListingActive}, l)
+
+	_, err = srv.ListItem(context.Background(), mockUser1.Username, "socks", "", 2, 0)
+	require.Equal(t, apperror.NewBadReq("listing price must be positive", nil), err)
+}
+
+func TestBuyListing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	listingRepo := mocks.NewMockListingRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithMarketplace(listingRepo, 10, listingTTL), WithNotifications(notificationRepo))
+
+	sold := &db.Listing{
+		ListingID:      1,
+		SellerUsername: mockUser2.Username,
+		ItemType:       "socks",
+		Variant:        sql.NullString{String: "socks-m", Valid: true},
+		Quantity:       2,
+		Price:          100,
+		Status:         models.ListingSold,
+		BuyerUsername:  sql.NullString{String: mockUser1.Username, Valid: true},
+		ExpiresAt:      mockNow.Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name         string
+		buyer        string
+		mockBehavior func()
+		expListing   *models.Listing
+		expErr       error
+	}{
+		{
+			name:  "OK",
+			buyer: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				listingRepo.EXPECT().
+					CloseListing(gomock.Any(), int32(1), models.ListingSold, mockUser1.Username).
+					Return(sold, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-100).
+					Return(&mockUser1, nil)
+				// seller gets price minus 10% fee
+				userRepo.EXPECT().
+					AddCoins(gomock.Any(), mockUser2.Username, int32(90)).
+					Return(&mockUser2, nil)
+				inventoryRepo.EXPECT().
+					AddItems(gomock.Any(), mockUser1.UserID, "socks", "socks-m", int32(2)).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser2.Username, models.NotificationSale, "mockuser1 bought your socks (x2) for 100 coins").
+					Return(&db.Notification{}, nil)
+				mock.ExpectCommit()
+			},
+			expListing: &models.Listing{
+				ID:        1,
+				Seller:    mockUser2.Username,
+				Item:      "socks",
+				Variant:   "socks-m",
+				Quantity:  2,
+				Price:     100,
+				Status:    models.ListingSold,
+				Buyer:     mockUser1.Username,
+				ExpiresAt: mockNow.Add(time.Hour),
+			},
+		},
+		{
+			name:  "Err Own Listing",
+			buyer: mockUser2.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				listingRepo.EXPECT().
+					CloseListing(gomock.Any(), int32(1), models.ListingSold, mockUser2.Username).
+					Return(sold, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("cannot buy own listing", ErrOwnListing),
+		},
+		{
+			name:  "Err Expired",
+			buyer: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expired := *sold
+				expired.ExpiresAt = mockNow
+				listingRepo.EXPECT().
+					CloseListing(gomock.Any(), int32(1), models.ListingSold, mockUser1.Username).
+					Return(&expired, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("listing expired", ErrListingExpired),
+		},
+		{
+			name:  "Err Sold",
+			buyer: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				listingRepo.EXPECT().
+					CloseListing(gomock.Any(), int32(1), models.ListingSold, mockUser1.Username).
+					Return(nil, repository.ErrListingNotActive)
+				listingRepo.EXPECT().
+					GetListing(gomock.Any(), int32(1)).
+					Return(&db.Listing{ListingID: 1, Status: models.ListingSold}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("listing is not active", ErrListingClosed),
+		},
+		{
+			name:  "Err Not Found",
+			buyer: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				listingRepo.EXPECT().
+					CloseListing(gomock.Any(), int32(1), models.ListingSold, mockUser1.Username).
+					Return(nil, repository.ErrListingNotActive)
+				listingRepo.EXPECT().
+					GetListing(gomock.Any(), int32(1)).
+					Return(nil, repository.ErrListingNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("listing not found", repository.ErrListingNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			l, err := srv.BuyListing(context.Background(), tc.buyer, 1)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expListing, l)
+		})
+	}
+}
+
+func TestCancelListing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	listingRepo := mocks.NewMockListingRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithMarketplace(listingRepo, 0, listingTTL))
+
+	cancelled := &db.Listing{ListingID: 1, SellerUsername: mockUser1.Username, ItemType: "socks", Variant: sql.NullString{String: "socks-m", Valid: true}, Quantity: 2, Status: models.ListingCancelled}
+
+	// only seller can cancel
+	mock.ExpectBegin()
+	listingRepo.EXPECT().
+		CloseListing(gomock.Any(), int32(1), models.ListingCancelled, "").
+		Return(cancelled, nil)
+	mock.ExpectRollback()
+	_, err = srv.CancelListing(context.Background(), mockUser2.Username, 1)
+	require.Equal(t, apperror.NewForbidden("not your listing", ErrNotListingSeller), err)
+
+	mock.ExpectBegin()
+	listingRepo.EXPECT().
+		CloseListing(gomock.Any(), int32(1), models.ListingCancelled, "").
+		Return(cancelled, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	inventoryRepo.EXPECT().
+		AddItems(gomock.Any(), mockUser1.UserID, "socks", "socks-m", int32(2)).
+		Return(nil)
+	mock.ExpectCommit()
+	l, err := srv.CancelListing(context.Background(), mockUser1.Username, 1)
+	require.NoError(t, err)
+	require.Equal(t, models.ListingCancelled, l.Status)
+
+	// already sold or cancelled by concurrent call
+	mock.ExpectBegin()
+	listingRepo.EXPECT().
+		CloseListing(gomock.Any(), int32(1), models.ListingCancelled, "").
+		Return(nil, repository.ErrListingNotActive)
+	listingRepo.EXPECT().
+		GetListing(gomock.Any(), int32(1)).
+		Return(&db.Listing{ListingID: 1, Status: models.ListingSold}, nil)
+	mock.ExpectRollback()
+	_, err = srv.CancelListing(context.Background(), mockUser1.Username, 1)
+	require.Equal(t, apperror.NewBadReq("listing is not active", ErrListingClosed), err)
+}
+
+func TestExpireListings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	listingRepo := mocks.NewMockListingRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithMarketplace(listingRepo, 0, listingTTL))
+
+	mock.ExpectBegin()
+	listingRepo.EXPECT().
+		ExpireListings(gomock.Any(), mockNow).
+		Return([]*db.Listing{{ListingID: 4, SellerUsername: mockUser2.Username, ItemType: "cup", Quantity: 1}}, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser2.Username).
+		Return(&mockUser2, nil)
+	inventoryRepo.EXPECT().
+		AddItems(gomock.Any(), mockUser2.UserID, "cup", "", int32(1)).
+		Return(nil)
+	mock.ExpectCommit()
+
+	require.NoError(t, srv.ExpireListings(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+)
+
+const notificationsLimit = 50
+
+func (s *Service) notificationsEnabled() bool {
+	return s.notificationRepo != nil
+}
+
+// notify saves notification for user, does nothing
+// if notifications are disabled.
+// returns apperror.
+func (s *Service) notify(c context.Context, username, kind, message string) error {
+	if !s.notificationsEnabled() {
+		return nil
+	}
+
+	if _, err := s.notificationRepo.CreateNotification(c, username, kind, message); err != nil {
+		return apperror.NewInternal("failed to notify user", err)
+	}
+	return nil
+}
+
+// GetNotifications returns user's latest notifications.
+func (s *Service) GetNotifications(c context.Context, username string) ([]*models.Notification, error) {
+	if !s.notificationsEnabled() {
+		return nil, apperror.NewNotFound("notifications disabled", ErrFeatureDisabled)
+	}
+
+	notifications, err := s.notificationRepo.ListNotifications(c, username, notificationsLimit)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get notifications", err)
+	}
+
+	res := make([]*models.Notification, len(notifications))
+	for i, n := range notifications {
+		res[i] = &models.Notification{
+			ID:        n.NotificationID,
+			Kind:      n.Kind,
+			Message:   n.Message,
+			CreatedAt: n.CreatedAt,
+			Read:      n.ReadAt.Valid,
+		}
+	}
+
+	return res, nil
+}
+
+// ReadNotifications marks all user's notifications as read.
+func (s *Service) ReadNotifications(c context.Context, username string) error {
+	if !s.notificationsEnabled() {
+		return apperror.NewNotFound("notifications disabled", ErrFeatureDisabled)
+	}
+
+	if err := s.notificationRepo.MarkAllRead(c, username); err != nil {
+		return apperror.NewInternal("failed to read notifications", err)
+	}
+	return nil
+}
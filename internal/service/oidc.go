@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/oidc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+const (
+	// oidcStateTTL is how long user has to log in at identity provider.
+	oidcStateTTL = 10 * time.Minute
+	// maxOIDCUsernameSuffix bounds search of free username for new external user.
+	maxOIDCUsernameSuffix = 100
+)
+
+var ErrInvalidOIDCLogin = errors.New("invalid oidc login")
+
+func (s *Service) oidcEnabled() bool {
+	return s.identityProvider != nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// oidcUsername picks local username for identity,
+// email is used if provider has no preferred username.
+func oidcUsername(identity *oidc.Identity) string {
+	if identity.PreferredUsername != "" {
+		return identity.PreferredUsername
+	}
+	local, _, _ := strings.Cut(identity.Email, "@")
+	return local
+}
+
+// sanitizeOIDCUsername drops characters which usernames usually
+// can't have, e.g. spaces and non-latin letters of display names.
+func sanitizeOIDCUsername(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// fitUsername cuts name, so name with suffix fits username policy.
+func (s *Service) fitUsername(name, suffix string) string {
+	if s.credentialPolicy == nil {
+		return name + suffix
+	}
+	return s.credentialPolicy.FitUsername(name, suffix)
+}
+
+// StartOIDCLogin returns URL of identity provider's login page.
+func (s *Service) StartOIDCLogin(c context.Context) (string, error) {
+	if !s.oidcEnabled() {
+		return "", apperror.NewNotFound("oidc login disabled", ErrFeatureDisabled)
+	}
+
+	state, err := randomHex(16)
+	if err != nil {
+		return "", apperror.NewInternal("failed to generate state", err)
+	}
+	nonce, err := randomHex(16)
+	if err != nil {
+		return "", apperror.NewInternal("failed to generate nonce", err)
+	}
+
+	if err = s.oidcStateRepo.SaveState(c, state, nonce, oidcStateTTL); err != nil {
+		return "", apperror.NewInternal("failed to save state", err)
+	}
+
+	return s.identityProvider.AuthCodeURL(state, nonce), nil
+}
+
+// FinishOIDCLogin exchanges code from identity provider and returns
+// token of linked user. User is created on first login, external
+// users have no password.
+func (s *Service) FinishOIDCLogin(c context.Context, code, state string) (string, error) {
+	if !s.oidcEnabled() {
+		return "", apperror.NewNotFound("oidc login disabled", ErrFeatureDisabled)
+	}
+
+	nonce, err := s.oidcStateRepo.ConsumeState(c, state)
+	if err != nil {
+		if errors.Is(err, repository.ErrOIDCStateNotFound) {
+			return "", apperror.NewBadReq("invalid or expired login state", ErrInvalidOIDCLogin)
+		}
+		return "", apperror.NewInternal("failed to get state", err)
+	}
+
+	identity, err := s.identityProvider.Exchange(c, code, nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrExchange) || errors.Is(err, oidc.ErrInvalidToken) {
+			return "", apperror.NewUnauthorized("identity provider rejected login", err)
+		}
+		return "", apperror.NewInternal("failed to exchange code", err)
+	}
+
+	linked, err := s.externalIdentityRepo.GetIdentity(c, identity.Issuer, identity.Subject)
+	if err == nil {
+		return s.issueToken(c, linked.Username)
+	}
+	if !errors.Is(err, repository.ErrExternalIdentityNotFound) {
+		return "", apperror.NewInternal("failed to get external identity", err)
+	}
+
+	username, err := s.provisionExternalUser(c, identity)
+	if errors.Is(err, repository.ErrExternalIdentityExists) || errors.Is(err, repository.ErrUserAlreadyExists) {
+		// concurrent first login may have linked identity already
+		linked, getErr := s.externalIdentityRepo.GetIdentity(c, identity.Issuer, identity.Subject)
+		if getErr == nil {
+			return s.issueToken(c, linked.Username)
+		}
+		if !errors.Is(getErr, repository.ErrExternalIdentityNotFound) {
+			return "", apperror.NewInternal("failed to get external identity", getErr)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return s.issueToken(c, username)
+}
+
+// freeOIDCUsername returns name or, if it is taken by another
+// user, name with the lowest free numeric suffix. Name breaking
+// username policy is cleaned and cut to fit, generated name is
+// used if it still can't be a username.
+// returns apperror.
+func (s *Service) freeOIDCUsername(c context.Context, name string) (string, error) {
+	if s.checkNewUsername(name) != nil {
+		name = s.fitUsername(sanitizeOIDCUsername(name), "")
+	}
+	if s.checkNewUsername(name) != nil {
+		generated, err := randomHex(4)
+		if err != nil {
+			return "", apperror.NewInternal("failed to generate username", err)
+		}
+		name = s.fitUsername("user"+generated, "")
+		if err = s.checkNewUsername(name); err != nil {
+			return "", err
+		}
+	}
+
+	for i := 1; i <= maxOIDCUsernameSuffix; i++ {
+		username := name
+		if i > 1 {
+			username = s.fitUsername(name, strconv.Itoa(i))
+		}
+		// cut name with suffix may be reserved
+		if s.checkNewUsername(username) != nil {
+			continue
+		}
+
+		_, err := s.userRepo.GetUser(c, username)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return username, nil
+		}
+		if err != nil {
+			return "", apperror.NewInternal("failed to get user", err)
+		}
+	}
+
+	return "", apperror.NewConflict("username already taken", repository.ErrUserAlreadyExists)
+}
+
+// provisionExternalUser creates user with start coins for identity.
+// Local user with the same name is never taken over, new user
+// gets a numeric suffix instead.
+// returns apperror.
+func (s *Service) provisionExternalUser(c context.Context, identity *oidc.Identity) (string, error) {
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return "", apperror.NewInternal("failed to create user", err)
+	}
+	defer tx.Rollback()
+
+	username, err := s.freeOIDCUsername(c, oidcUsername(identity))
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = s.createUserWithoutPassword(c, username); err != nil {
+		return "", err
+	}
+
+	if _, err = s.externalIdentityRepo.CreateIdentity(c, identity.Issuer, identity.Subject, username); err != nil {
+		if errors.Is(err, repository.ErrExternalIdentityExists) {
+			return "", apperror.NewConflict("identity already linked", err)
+		}
+		return "", apperror.NewInternal("failed to link external identity", err)
+	}
+
+	return username, tx.Commit()
+}
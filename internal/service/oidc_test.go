@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/oidc"
+	"github.com/myacey/avito-shop/internal/oidc/oidctest"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/myacey/avito-shop/internal/repository/memrepo"
+	"github.com/stretchr/testify/require"
+)
+
+// loginAtProvider follows redirect to provider's login page
+// and returns code and state provider sent user back with.
+func loginAtProvider(t *testing.T, authURL string) (code, state string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	back, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return back.Query().Get("code"), back.Query().Get("state")
+}
+
+func TestOIDCLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	identityRepo := mocks.NewMockExternalIdentityRepository(ctrl)
+
+	idp := oidctest.NewProvider("shop", "secret")
+	defer idp.Close()
+
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "shop",
+		ClientSecret: "secret",
+		RedirectURL:  "http://shop.test/api/oidc/callback",
+	}, idp.Client())
+	require.NoError(t, err)
+
+	now := mockNow
+	clock := func() time.Time { return now }
+	stateRepo := memrepo.NewMemoryOIDCStateRepo(clock)
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, sessionRepo, jwtToken, nil,
+		WithClock(clock),
+		WithOIDC(provider, stateRepo, identityRepo),
+	)
+
+	login := func() (code, state string) {
+		authURL, err := srv.StartOIDCLogin(context.Background())
+		require.NoError(t, err)
+		return loginAtProvider(t, authURL)
+	}
+	expectToken := func(username string) {
+		jwtToken.EXPECT().
+			CreateToken(username).
+			Return("valid", nil)
+		sessionRepo.EXPECT().
+			CreateToken(gomock.Any(), username, "valid", sessionKeyTTL).
+			Return(nil)
+	}
+
+	// first login creates user without password
+	idp.SetUser(oidctest.User{Subject: "emp-1", PreferredUsername: "alice"})
+	code, state := login()
+
+	identityRepo.EXPECT().
+		GetIdentity(gomock.Any(), idp.Issuer(), "emp-1").
+		Return(nil, repository.ErrExternalIdentityNotFound)
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "alice").
+		Return(nil, repository.ErrUserNotFound)
+	userRepo.EXPECT().
+		CreateUser(gomock.Any(), "alice", "").
+		Return(&db.User{UserID: 3, Username: "alice", Coins: 1000}, nil)
+	identityRepo.EXPECT().
+		CreateIdentity(gomock.Any(), idp.Issuer(), "emp-1", "alice").
+		Return(&db.ExternalIdentity{Issuer: idp.Issuer(), Subject: "emp-1", Username: "alice"}, nil)
+	mock.ExpectCommit()
+	expectToken("alice")
+
+	token, err := srv.FinishOIDCLogin(context.Background(), code, state)
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// state can't be used twice
+	_, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.Equal(t, apperror.NewBadReq("invalid or expired login state", ErrInvalidOIDCLogin), err)
+
+	// next login finds linked user, even if provider renamed them
+	idp.SetUser(oidctest.User{Subject: "emp-1", PreferredUsername: "alice.smith"})
+	code, state = login()
+
+	identityRepo.EXPECT().
+		GetIdentity(gomock.Any(), idp.Issuer(), "emp-1").
+		Return(&db.ExternalIdentity{Issuer: idp.Issuer(), Subject: "emp-1", Username: "alice"}, nil)
+	expectToken("alice")
+
+	token, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// local user with the same name isn't taken over, new user gets a suffix
+	idp.SetUser(oidctest.User{Subject: "emp-2", Email: "bob@corp.test"})
+	code, state = login()
+
+	identityRepo.EXPECT().
+		GetIdentity(gomock.Any(), idp.Issuer(), "emp-2").
+		Return(nil, repository.ErrExternalIdentityNotFound)
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "bob").
+		Return(&db.User{Username: "bob"}, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "bob2").
+		Return(&db.User{Username: "bob2"}, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "bob3").
+		Return(nil, repository.ErrUserNotFound)
+	userRepo.EXPECT().
+		CreateUser(gomock.Any(), "bob3", "").
+		Return(&db.User{UserID: 4, Username: "bob3", Coins: 1000}, nil)
+	identityRepo.EXPECT().
+		CreateIdentity(gomock.Any(), idp.Issuer(), "emp-2", "bob3").
+		Return(&db.ExternalIdentity{Issuer: idp.Issuer(), Subject: "emp-2", Username: "bob3"}, nil)
+	mock.ExpectCommit()
+	expectToken("bob3")
+
+	token, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// concurrent first login of the same identity created user first
+	idp.SetUser(oidctest.User{Subject: "emp-3", PreferredUsername: "carol"})
+	code, state = login()
+
+	identityRepo.EXPECT().
+		GetIdentity(gomock.Any(), idp.Issuer(), "emp-3").
+		Return(nil, repository.ErrExternalIdentityNotFound)
+	mock.ExpectBegin()
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), "carol").
+		Return(nil, repository.ErrUserNotFound)
+	userRepo.EXPECT().
+		CreateUser(gomock.Any(), "carol", "").
+		Return(nil, repository.ErrUserAlreadyExists)
+	mock.ExpectRollback()
+	identityRepo.EXPECT().
+		GetIdentity(gomock.Any(), idp.Issuer(), "emp-3").
+		Return(&db.ExternalIdentity{Issuer: idp.Issuer(), Subject: "emp-3", Username: "carol"}, nil)
+	expectToken("carol")
+
+	token, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.NoError(t, err)
+	require.Equal(t, "valid", token)
+
+	// login started too long ago
+	code, state = login()
+	now = now.Add(oidcStateTTL)
+	_, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.Equal(t, apperror.NewBadReq("invalid or expired login state", ErrInvalidOIDCLogin), err)
+
+	// code from provider is bound to nonce of its own login
+	code, _ = login()
+	_, state = login()
+	_, err = srv.FinishOIDCLogin(context.Background(), code, state)
+	require.ErrorIs(t, err, oidc.ErrInvalidToken)
+	require.Equal(t, http.StatusUnauthorized, err.(*apperror.AppError).HTTPCode)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFreeOIDCUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+
+	policy, err := credentials.NewPolicy(credentials.Config{
+		UsernameMinLength: 3,
+		UsernameMaxLength: 10,
+		UsernamePattern:   `[a-zA-Z0-9_.-]+`,
+		ReservedUsernames: []string{"root", "alexandra2"},
+	})
+	require.NoError(t, err)
+
+	srv := NewService(nil, userRepo, nil, nil, nil, nil, nil, nil,
+		WithCredentialPolicy(policy)).(*Service)
+
+	generated := regexp.MustCompile(`^user[0-9a-f]{6}$`)
+
+	testCases := []struct {
+		name         string
+		input        string
+		mockBehavior func()
+		expUsername  string
+		expPattern   *regexp.Regexp
+	}{
+		{
+			name:  "OK",
+			input: "alice",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "alice").
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expUsername: "alice",
+		},
+		{
+			name:  "Forbidden Characters",
+			input: "Alice Smith!",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "AliceSmith").
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expUsername: "AliceSmith",
+		},
+		{
+			// name is cut to fit suffix, alexandra2 is reserved
+			name:  "Too Long With Suffix",
+			input: "alexandra.smith",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "alexandra.").
+					Return(&db.User{Username: "alexandra."}, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "alexandra2").
+					Times(0)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "alexandra3").
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expUsername: "alexandra3",
+		},
+		{
+			name:  "Reserved",
+			input: "root",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expPattern: generated,
+		},
+		{
+			name:  "No Allowed Characters",
+			input: "иван",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expPattern: generated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			username, err := srv.freeOIDCUsername(context.Background(), tc.input)
+			require.NoError(t, err)
+			if tc.expPattern != nil {
+				require.Regexp(t, tc.expPattern, username)
+			} else {
+				require.Equal(t, tc.expUsername, username)
+			}
+		})
+	}
+}
+
+func TestOIDCLoginDisabled(t *testing.T) {
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, err := srv.StartOIDCLogin(context.Background())
+	require.Equal(t, apperror.NewNotFound("oidc login disabled", ErrFeatureDisabled), err)
+
+	_, err = srv.FinishOIDCLogin(context.Background(), "code", "state")
+	require.Equal(t, apperror.NewNotFound("oidc login disabled", ErrFeatureDisabled), err)
+}
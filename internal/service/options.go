@@ -0,0 +1,217 @@
+package service
+
+import (
+	"time"
+
+	"github.com/myacey/avito-shop/internal/authn"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/fraud"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/oidc"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+// Option configures optional Service features.
+type Option func(*Service)
+
+// WithClock replaces time.Now, used by time-dependent features.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithCoinLots enables coin expiry: every grant becomes a lot that
+// expires after lifetimeMonths, lots expiring within notice are
+// reported in user info.
+func WithCoinLots(lr repository.CoinLotRepository, lifetimeMonths int, notice time.Duration) Option {
+	return func(s *Service) {
+		s.coinLotRepo = lr
+		s.coinLifetimeMonths = lifetimeMonths
+		s.coinExpiryNotice = notice
+	}
+}
+
+// WithTransferLimits enables transfer limits checked in SendCoin,
+// defaults can be overridden per user by admins.
+func WithTransferLimits(lr repository.TransferLimitRepository, defaults models.TransferLimits) Option {
+	return func(s *Service) {
+		s.transferLimitRepo = lr
+		s.defaultTransferLimits = defaults
+	}
+}
+
+// WithFraudDetector enables fraud rules in SendCoin, suspicious
+// transfers are saved as cases for admin review.
+func WithFraudDetector(d *fraud.Detector, fr repository.FraudCaseRepository) Option {
+	return func(s *Service) {
+		s.fraudDetector = d
+		s.fraudCaseRepo = fr
+	}
+}
+
+// WithTransferApprovals makes transfers above threshold wait for
+// finance approval, held coins are released after timeout.
+func WithTransferApprovals(ar repository.TransferApprovalRepository, threshold int32, timeout time.Duration) Option {
+	return func(s *Service) {
+		s.approvalRepo = ar
+		s.approvalThreshold = threshold
+		s.approvalTimeout = timeout
+	}
+}
+
+// WithNotifications enables user notifications about events
+// like received gifts.
+func WithNotifications(nr repository.NotificationRepository) Option {
+	return func(s *Service) {
+		s.notificationRepo = nr
+	}
+}
+
+// WithGifts enables buying items for other users.
+func WithGifts(gr repository.GiftRepository) Option {
+	return func(s *Service) {
+		s.giftRepo = gr
+	}
+}
+
+// WithItemTransfers enables sending owned items to other users.
+func WithItemTransfers(itr repository.ItemTransferRepository) Option {
+	return func(s *Service) {
+		s.itemTransferRepo = itr
+	}
+}
+
+// WithMarketplace enables reselling items between users,
+// feePercent of every sale is burned, listings expire after ttl.
+func WithMarketplace(lr repository.ListingRepository, feePercent int32, ttl time.Duration) Option {
+	return func(s *Service) {
+		s.listingRepo = lr
+		s.marketFeePercent = feePercent
+		s.listingTTL = ttl
+	}
+}
+
+// WithAuctions enables timed auctions for limited items.
+func WithAuctions(ar repository.AuctionRepository) Option {
+	return func(s *Service) {
+		s.auctionRepo = ar
+	}
+}
+
+// WithOrders records every purchase as order.
+func WithOrders(or repository.OrderRepository) Option {
+	return func(s *Service) {
+		s.orderRepo = or
+	}
+}
+
+// WithPickupLocations lets users choose office where they pick
+// orders up, orders can't be ready for pickup without location.
+func WithPickupLocations(locations []string) Option {
+	return func(s *Service) {
+		s.pickupLocations = locations
+	}
+}
+
+// WithPromoCodes enables promo codes at purchase time.
+// Requires orders to be enabled.
+func WithPromoCodes(pr repository.PromoCodeRepository) Option {
+	return func(s *Service) {
+		s.promoCodeRepo = pr
+	}
+}
+
+// WithBundles enables bundles of items sold for one price.
+func WithBundles(br repository.BundleRepository) Option {
+	return func(s *Service) {
+		s.bundleRepo = br
+	}
+}
+
+// WithPurchaseLimits enables per-user caps on items bought from store.
+// Requires orders to be enabled.
+func WithPurchaseLimits(lr repository.PurchaseLimitRepository) Option {
+	return func(s *Service) {
+		s.purchaseLimitRepo = lr
+	}
+}
+
+// WithWishlists enables wishlists, users are notified
+// about affordable, discounted and restocked items.
+func WithWishlists(wr repository.WishlistRepository) Option {
+	return func(s *Service) {
+		s.wishlistRepo = wr
+	}
+}
+
+// WithPreorders enables preorders of upcoming stock,
+// coins are held until stock arrives.
+func WithPreorders(pr repository.PreorderRepository) Option {
+	return func(s *Service) {
+		s.preorderRepo = pr
+	}
+}
+
+// WithRaffles enables raffles with tickets paid in coins.
+func WithRaffles(rr repository.RaffleRepository) Option {
+	return func(s *Service) {
+		s.raffleRepo = rr
+	}
+}
+
+// WithPasswordResets enables admin-issued password reset tokens
+// valid for ttl.
+func WithPasswordResets(pr repository.PasswordResetRepository, ttl time.Duration) Option {
+	return func(s *Service) {
+		s.passwordResetRepo = pr
+		s.passwordResetTTL = ttl
+	}
+}
+
+// WithCredentialPolicy validates username and password of new users
+// and new passwords against policy.
+func WithCredentialPolicy(p *credentials.Policy) Option {
+	return func(s *Service) {
+		s.credentialPolicy = p
+	}
+}
+
+// WithLoginThrottle enables brute-force protection of login,
+// failed attempts are counted per username and per IP.
+func WithLoginThrottle(lr repository.LoginAttemptRepository, throttle models.LoginThrottle) Option {
+	return func(s *Service) {
+		s.loginAttemptRepo = lr
+		s.loginThrottle = throttle
+	}
+}
+
+// WithOIDC enables login with external identity provider,
+// users are linked to provider's subjects.
+func WithOIDC(ip oidc.IdentityProvider, sr repository.OIDCStateRepository, ir repository.ExternalIdentityRepository) Option {
+	return func(s *Service) {
+		s.identityProvider = ip
+		s.oidcStateRepo = sr
+		s.externalIdentityRepo = ir
+	}
+}
+
+// WithAuthenticator replaces local password check, e.g. with
+// directory. Users known only to authenticator are created
+// on first login.
+func WithAuthenticator(a authn.Authenticator) Option {
+	return func(s *Service) {
+		s.authenticator = a
+	}
+}
+
+// WithAPIKeys enables API keys for bots and integrations,
+// keys without own rate limit get defaultRateLimit requests
+// per minute.
+func WithAPIKeys(kr repository.APIKeyRepository, rr repository.RateLimitRepository, defaultRateLimit int32) Option {
+	return func(s *Service) {
+		s.apiKeyRepo = kr
+		s.rateLimitRepo = rr
+		s.apiKeyRateLimit = defaultRateLimit
+	}
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrInvalidOrderStatus     = errors.New("invalid order status")
+	ErrInvalidPickupLocation  = errors.New("invalid pickup location")
+	ErrPickupLocationRequired = errors.New("pickup location required")
+	ErrPickupLocationLocked   = errors.New("pickup location can't be changed")
+)
+
+// orderTransitions lists statuses order can be moved to,
+// delivered and cancelled orders are final.
+var orderTransitions = map[string][]string{
+	models.OrderPlaced: {models.OrderPacked, models.OrderCancelled},
+	models.OrderPacked: {models.OrderReady, models.OrderCancelled},
+	models.OrderReady:  {models.OrderDelivered, models.OrderCancelled},
+}
+
+func (s *Service) pickupLocationsEnabled() bool {
+	return len(s.pickupLocations) > 0
+}
+
+func toOrderModel(o *db.Order) *models.Order {
+	return &models.Order{
+		ID:             o.OrderID,
+		Username:       o.Username,
+		Recipient:      o.RecipientUsername.String,
+		Item:           o.ItemType.String,
+		Bundle:         o.Bundle.String,
+		Variant:        o.Variant.String,
+		Price:          o.Price,
+		Discount:       o.Discount,
+		PromoCode:      o.PromoCode.String,
+		Status:         o.Status,
+		PickupLocation: o.PickupLocation,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
+
+func toOrderModels(orders []*db.Order) []*models.Order {
+	res := make([]*models.Order, len(orders))
+	for i, o := range orders {
+		res[i] = toOrderModel(o)
+	}
+	return res
+}
+
+// getOrderForUpdate locks order.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) getOrderForUpdate(c context.Context, orderID int32) (*db.Order, error) {
+	o, err := s.orderRepo.GetOrderForUpdate(c, orderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, apperror.NewNotFound("order not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get order", err)
+	}
+	return o, nil
+}
+
+// getUserOrderForUpdate locks order of user,
+// orders of other users are not found.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) getUserOrderForUpdate(c context.Context, username string, orderID int32) (*db.Order, error) {
+	o, err := s.getOrderForUpdate(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Username != username {
+		return nil, apperror.NewNotFound("order not found", repository.ErrOrderNotFound)
+	}
+	return o, nil
+}
+
+// checkOrderTransition checks order can be moved to status.
+// returns apperror.
+func checkOrderTransition(o *db.Order, status string) error {
+	if !slices.Contains(orderTransitions[o.Status], status) {
+		return apperror.NewBadReq(fmt.Sprintf("order can't be moved from %s to %s", o.Status, status), ErrInvalidOrderStatus)
+	}
+	return nil
+}
+
+// refundOrder returns price of order to user. Coins go back to lots
+// with the expiry they had when order was paid, so cancelling doesn't
+// prolong coins' life. Part of price without saved lots, e.g. paid before
+// lots were tracked, expires as if it was granted when order was made.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) refundOrder(c context.Context, o *db.Order) error {
+	dbUsr, err := s.userRepo.AddCoins(c, o.Username, o.Price)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return apperror.NewNotFound("user not found", err)
+		}
+		return apperror.NewInternal("failed to update balance", err)
+	}
+	if !s.coinLotsEnabled() {
+		return nil
+	}
+
+	lots, err := s.orderRepo.GetOrderCoinLots(c, o.OrderID)
+	if err != nil {
+		return apperror.NewInternal("failed to get order coin lots", err)
+	}
+
+	rest := o.Price
+	for _, lot := range lots {
+		if _, err = s.coinLotRepo.GrantCoins(c, dbUsr.UserID, lot.Amount, lot.ExpiresAt); err != nil {
+			return apperror.NewInternal("failed to return coins", err)
+		}
+		rest -= lot.Amount
+	}
+	if rest <= 0 {
+		return nil
+	}
+
+	expiresAt := o.CreatedAt.AddDate(0, s.coinLifetimeMonths, 0)
+	if _, err = s.coinLotRepo.GrantCoins(c, dbUsr.UserID, rest, expiresAt); err != nil {
+		return apperror.NewInternal("failed to return coins", err)
+	}
+	return nil
+}
+
+// takeBackOrderItems removes ordered items from inventory of user who got
+// them (gift recipient or buyer) and puts variants back to stock.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) takeBackOrderItems(c context.Context, o *db.Order) error {
+	owner := o.Username
+	if o.RecipientUsername.Valid {
+		owner = o.RecipientUsername.String
+	}
+	dbUsr, err := s.userRepo.GetUser(c, owner)
+	if err != nil {
+		return apperror.NewInternal("failed to get user", err)
+	}
+
+	items := []*db.BundleItem{{ItemType: o.ItemType.String, Variant: o.Variant, Quantity: 1}}
+	if o.Bundle.Valid {
+		if !s.bundlesEnabled() {
+			return apperror.NewNotFound("bundles disabled", ErrFeatureDisabled)
+		}
+		items, err = s.bundleRepo.GetBundleItems(c, o.Bundle.String)
+		if err != nil {
+			return apperror.NewInternal("failed to get bundle items", err)
+		}
+	}
+
+	for _, bi := range items {
+		if err = s.removeItems(c, dbUsr.UserID, bi.ItemType, bi.Variant.String, bi.Quantity); err != nil {
+			return err
+		}
+		if !bi.Variant.Valid {
+			continue
+		}
+		if err = s.returnVariant(c, bi.Variant.String, bi.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancelOrder refunds order, takes ordered items back and releases
+// promo code used for it.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) cancelOrder(c context.Context, o *db.Order) (*db.Order, error) {
+	if err := checkOrderTransition(o, models.OrderCancelled); err != nil {
+		return nil, err
+	}
+
+	if err := s.takeBackOrderItems(c, o); err != nil {
+		return nil, err
+	}
+	if o.Price > 0 {
+		if err := s.refundOrder(c, o); err != nil {
+			return nil, err
+		}
+	}
+	if o.PromoCode.Valid && s.promoCodesEnabled() {
+		if err := s.promoCodeRepo.ReleaseUse(c, o.PromoCode.String, o.Username); err != nil {
+			return nil, apperror.NewInternal("failed to release promo code", err)
+		}
+	}
+
+	cancelled, err := s.orderRepo.UpdateStatus(c, o.OrderID, models.OrderCancelled)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to update order", err)
+	}
+
+	return cancelled, nil
+}
+
+// GetOrders returns user's orders, latest first.
+func (s *Service) GetOrders(c context.Context, username string) ([]*models.Order, error) {
+	if !s.ordersEnabled() {
+		return nil, apperror.NewNotFound("orders disabled", ErrFeatureDisabled)
+	}
+
+	orders, err := s.orderRepo.ListUserOrders(c, username)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get orders", err)
+	}
+
+	return toOrderModels(orders), nil
+}
+
+// GetPickupLocations returns offices where orders can be picked up.
+func (s *Service) GetPickupLocations(c context.Context) ([]string, error) {
+	if !s.ordersEnabled() || !s.pickupLocationsEnabled() {
+		return nil, apperror.NewNotFound("pickup locations disabled", ErrFeatureDisabled)
+	}
+
+	return s.pickupLocations, nil
+}
+
+// SetPickupLocation selects office where user picks order up,
+// location can be changed until order is ready.
+func (s *Service) SetPickupLocation(c context.Context, username string, orderID int32, location string) (*models.Order, error) {
+	if !s.ordersEnabled() || !s.pickupLocationsEnabled() {
+		return nil, apperror.NewNotFound("pickup locations disabled", ErrFeatureDisabled)
+	}
+	if !slices.Contains(s.pickupLocations, location) {
+		return nil, apperror.NewBadReq("invalid pickup location", ErrInvalidPickupLocation).
+			WithDetails(s.pickupLocations)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to set pickup location", err)
+	}
+	defer tx.Rollback()
+
+	o, err := s.getUserOrderForUpdate(c, username, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != models.OrderPlaced && o.Status != models.OrderPacked {
+		return nil, apperror.NewBadReq("pickup location can't be changed after order is ready", ErrPickupLocationLocked)
+	}
+
+	o, err = s.orderRepo.SetPickupLocation(c, orderID, location)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to set pickup location", err)
+	}
+
+	return toOrderModel(o), tx.Commit()
+}
+
+// CancelOrder cancels user's not delivered order,
+// coins are refunded and items are taken back from inventory.
+func (s *Service) CancelOrder(c context.Context, username string, orderID int32) (*models.Order, error) {
+	if !s.ordersEnabled() {
+		return nil, apperror.NewNotFound("orders disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to cancel order", err)
+	}
+	defer tx.Rollback()
+
+	o, err := s.getUserOrderForUpdate(c, username, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err = s.cancelOrder(c, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderModel(o), tx.Commit()
+}
+
+// ListOrders returns orders with status for shop team,
+// empty status returns every order.
+func (s *Service) ListOrders(c context.Context, status string) ([]*models.Order, error) {
+	if !s.ordersEnabled() {
+		return nil, apperror.NewNotFound("orders disabled", ErrFeatureDisabled)
+	}
+
+	orders, err := s.orderRepo.ListOrders(c, status)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get orders", err)
+	}
+
+	return toOrderModels(orders), nil
+}
+
+// UpdateOrderStatus moves order to next status, user is notified
+// when order is ready or cancelled. Cancelled orders are refunded.
+func (s *Service) UpdateOrderStatus(c context.Context, orderID int32, status string) (*models.Order, error) {
+	if !s.ordersEnabled() {
+		return nil, apperror.NewNotFound("orders disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to update order", err)
+	}
+	defer tx.Rollback()
+
+	o, err := s.getOrderForUpdate(c, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+	switch status {
+	case models.OrderCancelled:
+		if o, err = s.cancelOrder(c, o); err != nil {
+			return nil, err
+		}
+		text = fmt.Sprintf("your order #%d was cancelled, %d coins refunded", o.OrderID, o.Price)
+	default:
+		if err = checkOrderTransition(o, status); err != nil {
+			return nil, err
+		}
+		if status == models.OrderReady && o.PickupLocation == "" && s.pickupLocationsEnabled() {
+			return nil, apperror.NewBadReq("pickup location required", ErrPickupLocationRequired)
+		}
+
+		o, err = s.orderRepo.UpdateStatus(c, orderID, status)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to update order", err)
+		}
+		if status == models.OrderReady {
+			text = fmt.Sprintf("your order #%d is ready for pickup", o.OrderID)
+			if o.PickupLocation != "" {
+				text += " at " + o.PickupLocation
+			}
+		}
+	}
+
+	if text != "" {
+		if err = s.notify(c, o.Username, models.NotificationOrder, text); err != nil {
+			return nil, err
+		}
+	}
+
+	return toOrderModel(o), tx.Commit()
+}
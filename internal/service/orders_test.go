@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, nil, nil, nil, nil, nil, nil, nil,
+		WithOrders(orderRepo), WithPickupLocations([]string{"moscow", "spb"}), WithNotifications(notificationRepo))
+
+	order := func(status, location string) *db.Order {
+		return &db.Order{
+			OrderID:        1,
+			Username:       mockUser1.Username,
+			ItemType:       sql.NullString{String: "cup", Valid: true},
+			Price:          20,
+			Status:         status,
+			PickupLocation: location,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		status       string
+		mockBehavior func()
+		expRes       *models.Order
+		expErr       error
+	}{
+		{
+			name:   "OK Packed",
+			status: models.OrderPacked,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(order(models.OrderPlaced, ""), nil)
+				orderRepo.EXPECT().
+					UpdateStatus(gomock.Any(), int32(1), models.OrderPacked).
+					Return(order(models.OrderPacked, ""), nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.Order{ID: 1, Username: mockUser1.Username, Item: "cup", Price: 20, Status: models.OrderPacked},
+		},
+		{
+			name:   "OK Ready",
+			status: models.OrderReady,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(order(models.OrderPacked, "spb"), nil)
+				orderRepo.EXPECT().
+					UpdateStatus(gomock.Any(), int32(1), models.OrderReady).
+					Return(order(models.OrderReady, "spb"), nil)
+				notificationRepo.EXPECT().
+					CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationOrder, "your order #1 is ready for pickup at spb").
+					Return(&db.Notification{}, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.Order{ID: 1, Username: mockUser1.Username, Item: "cup", Price: 20, Status: models.OrderReady, PickupLocation: "spb"},
+		},
+		{
+			name:   "Err Ready Without Location",
+			status: models.OrderReady,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(order(models.OrderPacked, ""), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("pickup location required", ErrPickupLocationRequired),
+		},
+		{
+			name:   "Err Skip Status",
+			status: models.OrderDelivered,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(order(models.OrderPlaced, ""), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("order can't be moved from placed to delivered", ErrInvalidOrderStatus),
+		},
+		{
+			name:   "Err Cancel Delivered",
+			status: models.OrderCancelled,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(order(models.OrderDelivered, "spb"), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("order can't be moved from delivered to cancelled", ErrInvalidOrderStatus),
+		},
+		{
+			name:   "Err Not Found",
+			status: models.OrderPacked,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(nil, repository.ErrOrderNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("order not found", repository.ErrOrderNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			res, err := srv.UpdateOrderStatus(context.Background(), 1, tc.status)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCancelOrderWithLots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	lotRepo := mocks.NewMockCoinLotRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithCoinLots(lotRepo, 12, 24*time.Hour))
+
+	createdAt := mockNow.AddDate(0, -1, 0)
+	soon := mockNow.Add(time.Hour)
+	later := mockNow.AddDate(0, 6, 0)
+
+	mock.ExpectBegin()
+	orderRepo.EXPECT().
+		GetOrderForUpdate(gomock.Any(), int32(1)).
+		Return(&db.Order{
+			OrderID:   1,
+			Username:  mockUser1.Username,
+			ItemType:  sql.NullString{String: "cup", Valid: true},
+			Price:     300,
+			Status:    models.OrderPlaced,
+			CreatedAt: createdAt,
+		}, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	inventoryRepo.EXPECT().
+		RemoveItems(gomock.Any(), mockUser1.UserID, "cup", "", int32(1)).
+		Return(nil)
+	userRepo.EXPECT().
+		AddCoins(gomock.Any(), mockUser1.Username, int32(300)).
+		Return(&mockUser1, nil)
+	// coins keep expiry of lots the order was paid from
+	orderRepo.EXPECT().
+		GetOrderCoinLots(gomock.Any(), int32(1)).
+		Return([]*db.OrderCoinLot{
+			{OrderID: 1, Amount: 200, ExpiresAt: soon},
+			{OrderID: 1, Amount: 50, ExpiresAt: later},
+		}, nil)
+	lotRepo.EXPECT().
+		GrantCoins(gomock.Any(), mockUser1.UserID, int32(200), soon).
+		Return(&db.CoinLot{}, nil)
+	lotRepo.EXPECT().
+		GrantCoins(gomock.Any(), mockUser1.UserID, int32(50), later).
+		Return(&db.CoinLot{}, nil)
+	// untracked part expires as if granted with the order
+	lotRepo.EXPECT().
+		GrantCoins(gomock.Any(), mockUser1.UserID, int32(50), createdAt.AddDate(1, 0, 0)).
+		Return(&db.CoinLot{}, nil)
+	orderRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int32(1), models.OrderCancelled).
+		Return(&db.Order{OrderID: 1, Status: models.OrderCancelled}, nil)
+	mock.ExpectCommit()
+
+	_, err = srv.CancelOrder(context.Background(), mockUser1.Username, 1)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	promoCodeRepo := mocks.NewMockPromoCodeRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithOrders(orderRepo), WithPromoCodes(promoCodeRepo))
+
+	promoOrder := &db.Order{
+		OrderID:   1,
+		Username:  mockUser1.Username,
+		ItemType:  sql.NullString{String: "pen", Valid: true},
+		Price:     9,
+		PromoCode: sql.NullString{String: "SALE10", Valid: true},
+		Status:    models.OrderPlaced,
+	}
+
+	giftOrder := &db.Order{
+		OrderID:           1,
+		Username:          mockUser1.Username,
+		RecipientUsername: sql.NullString{String: mockUser2.Username, Valid: true},
+		ItemType:          sql.NullString{String: "cup", Valid: true},
+		Price:             20,
+		Status:            models.OrderPlaced,
+	}
+
+	hoodyOrder := &db.Order{
+		OrderID:  1,
+		Username: mockUser1.Username,
+		ItemType: sql.NullString{String: "hoody", Valid: true},
+		Variant:  sql.NullString{String: "hoody-m", Valid: true},
+		Price:    300,
+		Status:   models.OrderPacked,
+	}
+
+	testCases := []struct {
+		name         string
+		username     string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name:     "OK",
+			username: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(hoodyOrder, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, "hoody", "hoody-m", int32(1)).
+					Return(nil)
+				storeRepo.EXPECT().
+					AddVariantStock(gomock.Any(), "hoody-m", int32(1)).
+					Return(nil)
+				userRepo.EXPECT().
+					AddCoins(gomock.Any(), mockUser1.Username, int32(300)).
+					Return(&mockUser1, nil)
+				orderRepo.EXPECT().
+					UpdateStatus(gomock.Any(), int32(1), models.OrderCancelled).
+					Return(&db.Order{OrderID: 1, Status: models.OrderCancelled}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "OK Promo Code Released",
+			username: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(promoOrder, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, "pen", "", int32(1)).
+					Return(nil)
+				userRepo.EXPECT().
+					AddCoins(gomock.Any(), mockUser1.Username, int32(9)).
+					Return(&mockUser1, nil)
+				promoCodeRepo.EXPECT().
+					ReleaseUse(gomock.Any(), "SALE10", mockUser1.Username).
+					Return(nil)
+				orderRepo.EXPECT().
+					UpdateStatus(gomock.Any(), int32(1), models.OrderCancelled).
+					Return(&db.Order{OrderID: 1, Status: models.OrderCancelled}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "OK Gift Taken From Recipient",
+			username: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(giftOrder, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser2.Username).
+					Return(&mockUser2, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser2.UserID, "cup", "", int32(1)).
+					Return(nil)
+				userRepo.EXPECT().
+					AddCoins(gomock.Any(), mockUser1.Username, int32(20)).
+					Return(&mockUser1, nil)
+				orderRepo.EXPECT().
+					UpdateStatus(gomock.Any(), int32(1), models.OrderCancelled).
+					Return(&db.Order{OrderID: 1, Status: models.OrderCancelled}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "Err Item Given Away",
+			username: mockUser1.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(hoodyOrder, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					RemoveItems(gomock.Any(), mockUser1.UserID, "hoody", "hoody-m", int32(1)).
+					Return(repository.ErrItemNotOwned)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("item not in inventory", repository.ErrItemNotOwned),
+		},
+		{
+			name:     "Err Other User",
+			username: mockUser2.Username,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				orderRepo.EXPECT().
+					GetOrderForUpdate(gomock.Any(), int32(1)).
+					Return(hoodyOrder, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("order not found", repository.ErrOrderNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := srv.CancelOrder(context.Background(), tc.username, 1)
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
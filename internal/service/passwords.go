@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/hasher"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrInvalidResetToken  = errors.New("invalid password reset token")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// hasherBusyRetryAfter is suggested to clients rejected by hashing limiter.
+const hasherBusyRetryAfter = time.Second
+
+func invalidCredentials(errs credentials.FieldErrors) error {
+	return apperror.NewBadReq("invalid credentials", ErrInvalidCredentials).WithDetails(errs)
+}
+
+// checkNewPassword applies password policy, without policy
+// password only has to be set.
+// returns apperror.
+func (s *Service) checkNewPassword(username, password string) error {
+	var rules []string
+	if s.credentialPolicy != nil {
+		rules = s.credentialPolicy.CheckPassword(username, password)
+	} else if password == "" {
+		rules = []string{"is required"}
+	}
+
+	if len(rules) > 0 {
+		return invalidCredentials(credentials.FieldErrors{credentials.FieldPassword: rules})
+	}
+	return nil
+}
+
+// checkNewUsername applies username policy, without policy
+// username only has to be set.
+// returns apperror.
+func (s *Service) checkNewUsername(username string) error {
+	var rules []string
+	if s.credentialPolicy != nil {
+		rules = s.credentialPolicy.CheckUsername(username)
+	} else if username == "" {
+		rules = []string{"is required"}
+	}
+
+	if len(rules) > 0 {
+		return invalidCredentials(credentials.FieldErrors{credentials.FieldUsername: rules})
+	}
+	return nil
+}
+
+func (s *Service) passwordResetsEnabled() bool {
+	return s.passwordResetRepo != nil
+}
+
+// hasherBusy is returned when hashing limiter
+// didn't let request in time.
+func hasherBusy(err error) error {
+	return apperror.NewServiceUnavailable("server is busy, try again later", err).
+		WithRetryAfter(hasherBusyRetryAfter)
+}
+
+// hashPassword returns apperror.
+func (s *Service) hashPassword(c context.Context, password string) (string, error) {
+	hashedPassword, err := s.hasher.Generate(c, password)
+	if err != nil {
+		if errors.Is(err, hasher.ErrToLong) {
+			return "", apperror.NewBadReq("password too long", err)
+		}
+		if errors.Is(err, hasher.ErrBusy) {
+			return "", hasherBusy(err)
+		}
+		return "", apperror.NewInternal("failed to generate password hash", err)
+	}
+	return hashedPassword, nil
+}
+
+// rehashPassword moves password of logged in user to current hashing
+// algorithm and parameters. Login doesn't fail if it can't, user will
+// be rehashed next time.
+func (s *Service) rehashPassword(c context.Context, dbUsr *db.User, password string) {
+	if !s.hasher.NeedsRehash(dbUsr.Password) {
+		return
+	}
+
+	hashedPassword, err := s.hasher.Generate(c, password)
+	if err != nil {
+		log.Printf("failed to rehash password of %s: %v", dbUsr.Username, err)
+		return
+	}
+	if err = s.userRepo.UpdatePassword(c, dbUsr.Username, hashedPassword); err != nil {
+		log.Printf("failed to save rehashed password of %s: %v", dbUsr.Username, err)
+	}
+}
+
+// issueToken starts new session of user. Only the latest token is
+// kept in session repository, so every other session is revoked.
+// returns apperror.
+func (s *Service) issueToken(c context.Context, username string) (string, error) {
+	token, err := s.tokenMaker.CreateToken(username)
+	if err != nil {
+		return "", apperror.NewInternal("failed to create token", err)
+	}
+
+	if err = s.sessionRepo.CreateToken(c, username, token, sessionKeyTTL); err != nil {
+		return "", apperror.NewInternal("failed to save token", err)
+	}
+	return token, nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChangePassword replaces user's password if old one matches and
+// returns new token, other sessions of user are revoked. Wrong old
+// passwords are throttled like failed logins.
+func (s *Service) ChangePassword(c context.Context, username, oldPassword, newPassword, ip string) (string, error) {
+	if err := s.checkNewPassword(username, newPassword); err != nil {
+		return "", err
+	}
+	if err := s.checkLoginLock(c, username, ip); err != nil {
+		return "", err
+	}
+
+	dbUsr, err := s.userRepo.GetUser(c, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return "", apperror.NewNotFound("user not found", err)
+		}
+		return "", apperror.NewInternal("failed to get user", err)
+	}
+
+	if err = s.hasher.Compare(c, dbUsr.Password, oldPassword); err != nil {
+		if errors.Is(err, hasher.ErrDontCompare) {
+			if lockErr := s.loginFailed(c, username, ip); lockErr != nil {
+				return "", lockErr
+			}
+			return "", apperror.NewForbidden("invalid old password", ErrInvalidPassword)
+		}
+		if errors.Is(err, hasher.ErrBusy) {
+			return "", hasherBusy(err)
+		}
+		return "", apperror.NewInternal("failed to compare passwords", err)
+	}
+	if err = s.loginSucceeded(c, username); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := s.hashPassword(c, newPassword)
+	if err != nil {
+		return "", err
+	}
+	if err = s.userRepo.UpdatePassword(c, username, hashedPassword); err != nil {
+		return "", apperror.NewInternal("failed to update password", err)
+	}
+
+	return s.issueToken(c, username)
+}
+
+// CreatePasswordReset issues one-time token user can set new password
+// with. Earlier unused tokens of user stop working.
+func (s *Service) CreatePasswordReset(c context.Context, adminUsername, username string) (*models.PasswordReset, error) {
+	if !s.passwordResetsEnabled() {
+		return nil, apperror.NewNotFound("password resets disabled", ErrFeatureDisabled)
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, apperror.NewInternal("failed to generate reset token", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create password reset", err)
+	}
+	defer tx.Rollback()
+
+	if err = s.passwordResetRepo.InvalidateResets(c, username); err != nil {
+		return nil, apperror.NewInternal("failed to invalidate password resets", err)
+	}
+
+	expiresAt := s.now().Add(s.passwordResetTTL)
+	if _, err = s.passwordResetRepo.CreateReset(c, hashResetToken(token), username, adminUsername, expiresAt); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to create password reset", err)
+	}
+
+	res := &models.PasswordReset{
+		Username:  username,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, apperror.NewInternal("failed to create password reset", err)
+	}
+	return res, nil
+}
+
+// ResetPassword sets new password by reset token and returns new
+// token, other sessions of user are revoked.
+func (s *Service) ResetPassword(c context.Context, token, newPassword string) (string, error) {
+	if !s.passwordResetsEnabled() {
+		return "", apperror.NewNotFound("password resets disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return "", apperror.NewInternal("failed to reset password", err)
+	}
+	defer tx.Rollback()
+
+	// token is spent before anything else, so
+	// concurrent requests can't use it twice
+	reset, err := s.passwordResetRepo.UseReset(c, hashResetToken(token), s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrPasswordResetNotFound) {
+			return "", apperror.NewBadReq("invalid reset token", ErrInvalidResetToken)
+		}
+		return "", apperror.NewInternal("failed to use password reset", err)
+	}
+	if err = s.checkNewPassword(reset.Username, newPassword); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := s.hashPassword(c, newPassword)
+	if err != nil {
+		return "", err
+	}
+	if err = s.userRepo.UpdatePassword(c, reset.Username, hashedPassword); err != nil {
+		return "", apperror.NewInternal("failed to update password", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", apperror.NewInternal("failed to reset password", err)
+	}
+
+	return s.issueToken(c, reset.Username)
+}
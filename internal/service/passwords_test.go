@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/hasher"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	srv := NewService(nil, userRepo, nil, nil, nil, sessionRepo, jwtToken, hashGen)
+
+	testCases := []struct {
+		name         string
+		oldPassword  string
+		mockBehavior func()
+		expToken     string
+		expErr       error
+	}{
+		{
+			name:        "OK",
+			oldPassword: mockUser1.Password,
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				hashGen.EXPECT().
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
+					Return(nil)
+				hashGen.EXPECT().
+					Generate(gomock.Any(), "newpassword").
+					Return("newhash", nil)
+				userRepo.EXPECT().
+					UpdatePassword(gomock.Any(), mockUser1.Username, "newhash").
+					Return(nil)
+				jwtToken.EXPECT().
+					CreateToken(mockUser1.Username).
+					Return("valid", nil)
+				// overwrites token of every other session
+				sessionRepo.EXPECT().
+					CreateToken(gomock.Any(), mockUser1.Username, "valid", sessionKeyTTL).
+					Return(nil)
+			},
+			expToken: "valid",
+		},
+		{
+			name:        "Err Invalid Old Password",
+			oldPassword: "wrong",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				hashGen.EXPECT().
+					Compare(gomock.Any(), mockUser1.Password, "wrong").
+					Return(hasher.ErrDontCompare)
+			},
+			expErr: apperror.NewForbidden("invalid old password", ErrInvalidPassword),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			token, err := srv.ChangePassword(context.Background(), mockUser1.Username, tc.oldPassword, "newpassword", "10.0.0.1")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expToken, token)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+	resetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, sessionRepo, jwtToken, hashGen,
+		WithClock(mockClock), WithPasswordResets(resetRepo, time.Hour))
+
+	tokenHash := hashResetToken("resettoken")
+	reset := &db.PasswordReset{TokenHash: tokenHash, Username: mockUser1.Username, ExpiresAt: mockNow.Add(time.Hour)}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expToken     string
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				resetRepo.EXPECT().
+					UseReset(gomock.Any(), tokenHash, mockNow).
+					Return(reset, nil)
+				hashGen.EXPECT().
+					Generate(gomock.Any(), "newpassword").
+					Return("newhash", nil)
+				userRepo.EXPECT().
+					UpdatePassword(gomock.Any(), mockUser1.Username, "newhash").
+					Return(nil)
+				mock.ExpectCommit()
+				jwtToken.EXPECT().
+					CreateToken(mockUser1.Username).
+					Return("valid", nil)
+				sessionRepo.EXPECT().
+					CreateToken(gomock.Any(), mockUser1.Username, "valid", sessionKeyTTL).
+					Return(nil)
+			},
+			expToken: "valid",
+		},
+		{
+			name: "Err Used Expired Or Unknown",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				resetRepo.EXPECT().
+					UseReset(gomock.Any(), tokenHash, mockNow).
+					Return(nil, repository.ErrPasswordResetNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("invalid reset token", ErrInvalidResetToken),
+		},
+		{
+			name: "Err Hasher Busy Keeps Token",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				resetRepo.EXPECT().
+					UseReset(gomock.Any(), tokenHash, mockNow).
+					Return(reset, nil)
+				hashGen.EXPECT().
+					Generate(gomock.Any(), "newpassword").
+					Return("", hasher.ErrBusy)
+				mock.ExpectRollback()
+			},
+			expErr: hasherBusy(hasher.ErrBusy),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			token, err := srv.ResetPassword(context.Background(), "resettoken", "newpassword")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expToken, token)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCredentialPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	sessionRepo := mocks.NewMockSessionRepository(ctrl)
+	jwtToken := mocks.NewMockTokenMakerInterface(ctrl)
+	hashGen := mocks.NewMockHasher(ctrl)
+
+	policy, err := credentials.NewPolicy(credentials.Config{UsernameMinLength: 3, PasswordMinLength: 8})
+	require.NoError(t, err)
+
+	srv := NewService(nil, userRepo, nil, nil, nil, sessionRepo, jwtToken, hashGen,
+		WithCredentialPolicy(policy))
+
+	testCases := []struct {
+		name         string
+		username     string
+		password     string
+		mockBehavior func()
+		expToken     string
+		expErr       error
+	}{
+		{
+			name:         "Err Empty",
+			mockBehavior: func() {},
+			expErr: apperror.NewBadReq("invalid credentials", ErrInvalidCredentials).
+				WithDetails(credentials.FieldErrors{
+					credentials.FieldUsername: {"is required"},
+					credentials.FieldPassword: {"is required"},
+				}),
+		},
+		{
+			name:     "Err Weak New User",
+			username: "newuser",
+			password: "short",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "newuser").
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expErr: apperror.NewBadReq("invalid credentials", ErrInvalidCredentials).
+				WithDetails(credentials.FieldErrors{
+					credentials.FieldPassword: {"must be at least 8 characters"},
+				}),
+		},
+		{
+			// policy applies only to new credentials
+			name:     "OK Existing User",
+			username: mockUser1.Username,
+			password: "short",
+			mockBehavior: func() {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				hashGen.EXPECT().
+					Compare(gomock.Any(), mockUser1.Password, "short").
+					Return(nil)
+				hashGen.EXPECT().
+					NeedsRehash(mockUser1.Password).
+					Return(false)
+				jwtToken.EXPECT().
+					CreateToken(mockUser1.Username).
+					Return("valid", nil)
+				sessionRepo.EXPECT().
+					CreateToken(gomock.Any(), mockUser1.Username, "valid", sessionKeyTTL).
+					Return(nil)
+			},
+			expToken: "valid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			token, err := srv.AuthorizeUser(context.Background(), tc.username, tc.password, "127.0.0.1")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expToken, token)
+		})
+	}
+}
+
+func TestCreatePasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, nil, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithPasswordResets(resetRepo, time.Hour))
+
+	commitErr := errors.New("connection lost")
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				resetRepo.EXPECT().
+					InvalidateResets(gomock.Any(), mockUser1.Username).
+					Return(nil)
+				resetRepo.EXPECT().
+					CreateReset(gomock.Any(), gomock.Any(), mockUser1.Username, "admin", mockNow.Add(time.Hour)).
+					Return(&db.PasswordReset{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Commit",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				resetRepo.EXPECT().
+					InvalidateResets(gomock.Any(), mockUser1.Username).
+					Return(nil)
+				resetRepo.EXPECT().
+					CreateReset(gomock.Any(), gomock.Any(), mockUser1.Username, "admin", mockNow.Add(time.Hour)).
+					Return(&db.PasswordReset{}, nil)
+				mock.ExpectCommit().WillReturnError(commitErr)
+			},
+			expErr: apperror.NewInternal("failed to create password reset", commitErr),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			res, err := srv.CreatePasswordReset(context.Background(), "admin", mockUser1.Username)
+			require.Equal(t, tc.expErr, err)
+			if tc.expErr != nil {
+				// token of rolled back reset must not be shown
+				require.Nil(t, res)
+			} else {
+				require.NotEmpty(t, res.Token)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
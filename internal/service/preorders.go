@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrPreorderClosed = errors.New("preorders closed")
+
+func (s *Service) preordersEnabled() bool {
+	return s.preorderRepo != nil
+}
+
+func toPreorderBatchModel(b *db.PreorderBatch, demand int32) *models.PreorderBatch {
+	return &models.PreorderBatch{
+		ID:        b.BatchID,
+		Item:      b.ItemType,
+		Variant:   b.Variant.String,
+		Price:     b.Price,
+		Status:    b.Status,
+		Demand:    demand,
+		ExpiresAt: b.ExpiresAt,
+	}
+}
+
+func toPreorderModel(p *db.Preorder) *models.Preorder {
+	return &models.Preorder{
+		ID:        p.PreorderID,
+		BatchID:   p.BatchID,
+		Username:  p.Username,
+		Status:    p.Status,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// setPreorderStatus moves pending preorder to status.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) setPreorderStatus(c context.Context, preorderID int32, status string) error {
+	if err := s.preorderRepo.SetPreorderStatus(c, preorderID, status); err != nil {
+		if errors.Is(err, repository.ErrPreorderNotPending) {
+			return apperror.NewBadReq("preorder is not pending", err)
+		}
+		return apperror.NewInternal("failed to update preorder", err)
+	}
+	return nil
+}
+
+// fulfillPreorder converts held coins to purchase of batch item.
+// Returns false if released coins don't cover the price, e.g. balance
+// went negative after preorder was made, or user has reached purchase
+// limit of item since, preorder is released then.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) fulfillPreorder(c context.Context, b *db.PreorderBatch, p *db.Preorder) (bool, error) {
+	if _, err := s.userRepo.ReleaseCoins(c, p.Username, b.Price); err != nil {
+		return false, apperror.NewInternal("failed to release coins", err)
+	}
+
+	dbUsr, err := s.lockUser(c, p.Username)
+	if err != nil {
+		return false, err
+	}
+	err = s.checkPurchaseLimit(c, p.Username, b.ItemType, 1)
+	limitReached := errors.Is(err, ErrPurchaseLimitReached)
+	if err != nil && !limitReached {
+		return false, err
+	}
+	if limitReached || dbUsr.Coins < b.Price {
+		if err = s.setPreorderStatus(c, p.PreorderID, models.PreorderReleased); err != nil {
+			return false, err
+		}
+		text := fmt.Sprintf("your preorder of %s couldn't be paid, %d coins released", b.ItemType, b.Price)
+		if limitReached {
+			text = fmt.Sprintf("your preorder of %s exceeds purchase limit, %d coins released", b.ItemType, b.Price)
+		}
+		return false, s.notify(c, p.Username, models.NotificationPreorder, text)
+	}
+
+	if err = s.setPreorderStatus(c, p.PreorderID, models.PreorderFulfilled); err != nil {
+		return false, err
+	}
+	lots, err := s.chargeUser(c, dbUsr, b.Price)
+	if err != nil {
+		return false, err
+	}
+	if err = s.inventoryRepo.AddItemToInventory(c, dbUsr.UserID, b.ItemType, b.Variant.String); err != nil {
+		return false, apperror.NewInternal("failed to add item to inventory", err)
+	}
+
+	if s.ordersEnabled() {
+		_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+			Username: p.Username,
+			Item:     b.ItemType,
+			Variant:  b.Variant.String,
+			Price:    b.Price,
+			CoinLots: lots,
+		})
+		if err != nil {
+			return false, apperror.NewInternal("failed to create order", err)
+		}
+	}
+
+	text := fmt.Sprintf("your preorder of %s arrived, %d coins charged", b.ItemType, b.Price)
+	return true, s.notify(c, p.Username, models.NotificationPreorder, text)
+}
+
+// releasePreorder gives held coins back to user.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) releasePreorder(c context.Context, b *db.PreorderBatch, p *db.Preorder, status string) error {
+	if err := s.setPreorderStatus(c, p.PreorderID, status); err != nil {
+		return err
+	}
+	if _, err := s.userRepo.ReleaseCoins(c, p.Username, b.Price); err != nil {
+		return apperror.NewInternal("failed to release coins", err)
+	}
+
+	if status != models.PreorderReleased {
+		return nil
+	}
+	text := fmt.Sprintf("your preorder of %s wasn't fulfilled, %d coins released", b.ItemType, b.Price)
+	return s.notify(c, p.Username, models.NotificationPreorder, text)
+}
+
+// closeBatch sets final status of open batch, so stock
+// arrival and expiry can't allocate one batch twice.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) closeBatch(c context.Context, batchID int32, status string) (*db.PreorderBatch, error) {
+	b, err := s.preorderRepo.CloseBatch(c, batchID, status)
+	if err == nil {
+		return b, nil
+	}
+	if !errors.Is(err, repository.ErrPreorderBatchNotOpen) {
+		return nil, apperror.NewInternal("failed to close preorder batch", err)
+	}
+
+	if _, err = s.preorderRepo.GetBatch(c, batchID); err != nil {
+		if errors.Is(err, repository.ErrPreorderBatchNotFound) {
+			return nil, apperror.NewNotFound("preorder batch not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get preorder batch", err)
+	}
+	return nil, apperror.NewBadReq("preorders closed", ErrPreorderClosed)
+}
+
+// GetPreorderBatches returns batches open for preorders.
+func (s *Service) GetPreorderBatches(c context.Context) ([]*models.PreorderBatch, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+
+	batches, err := s.preorderRepo.ListOpenBatches(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get preorder batches", err)
+	}
+
+	res := make([]*models.PreorderBatch, len(batches))
+	for i, b := range batches {
+		demand, err := s.preorderRepo.CountPending(c, b.BatchID)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to count preorders", err)
+		}
+		res[i] = toPreorderBatchModel(b, demand)
+	}
+
+	return res, nil
+}
+
+// Preorder holds batch price on user's balance until stock arrives.
+func (s *Service) Preorder(c context.Context, username string, batchID int32) (*models.Preorder, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to preorder", err)
+	}
+	defer tx.Rollback()
+
+	// batch lock keeps preorders from racing with stock arrival
+	b, err := s.preorderRepo.GetBatchForUpdate(c, batchID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPreorderBatchNotFound) {
+			return nil, apperror.NewNotFound("preorder batch not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get preorder batch", err)
+	}
+	if b.Status != models.PreorderBatchOpen || !s.now().Before(b.ExpiresAt) {
+		return nil, apperror.NewBadReq("preorders closed", ErrPreorderClosed)
+	}
+
+	if err = s.holdCoins(c, username, b.Price); err != nil {
+		return nil, err
+	}
+	// user is locked by holdCoins, so concurrent purchases can't pass the limit
+	if err = s.checkPurchaseLimit(c, username, b.ItemType, 1); err != nil {
+		return nil, err
+	}
+
+	p, err := s.preorderRepo.CreatePreorder(c, batchID, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrPreorderExists) {
+			return nil, apperror.NewBadReq("already preordered", err)
+		}
+		return nil, apperror.NewInternal("failed to create preorder", err)
+	}
+
+	return toPreorderModel(p), tx.Commit()
+}
+
+// GetPreorders returns user's preorders, latest first.
+func (s *Service) GetPreorders(c context.Context, username string) ([]*models.Preorder, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+
+	preorders, err := s.preorderRepo.ListUserPreorders(c, username)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get preorders", err)
+	}
+
+	res := make([]*models.Preorder, len(preorders))
+	for i, p := range preorders {
+		res[i] = toPreorderModel(p)
+	}
+
+	return res, nil
+}
+
+// CancelPreorder cancels user's pending preorder, held coins are released.
+func (s *Service) CancelPreorder(c context.Context, username string, preorderID int32) (*models.Preorder, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to cancel preorder", err)
+	}
+	defer tx.Rollback()
+
+	p, err := s.preorderRepo.GetPreorderForUpdate(c, preorderID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPreorderNotFound) {
+			return nil, apperror.NewNotFound("preorder not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get preorder", err)
+	}
+	if p.Username != username {
+		return nil, apperror.NewNotFound("preorder not found", repository.ErrPreorderNotFound)
+	}
+	if p.Status != models.PreorderPending {
+		return nil, apperror.NewBadReq("preorder is not pending", nil)
+	}
+
+	// preorder lock is enough, stock arrival skips
+	// preorders which are not pending anymore
+	b, err := s.preorderRepo.GetBatch(c, p.BatchID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get preorder batch", err)
+	}
+	if err = s.releasePreorder(c, b, p, models.PreorderCancelled); err != nil {
+		return nil, err
+	}
+
+	p.Status = models.PreorderCancelled
+	return toPreorderModel(p), tx.Commit()
+}
+
+// CreatePreorderBatch opens preorders for upcoming stock of item,
+// holds are released if stock doesn't arrive until expiresAt.
+func (s *Service) CreatePreorderBatch(c context.Context, adminUsername string, batch *models.NewPreorderBatch) (*models.PreorderBatch, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+	if batch.Price <= 0 {
+		return nil, apperror.NewBadReq("preorder price must be positive", nil)
+	}
+	if !batch.ExpiresAt.After(s.now()) {
+		return nil, apperror.NewBadReq("preorders must expire in future", nil)
+	}
+
+	item, err := s.getItemInfo(c, batch.Item)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+	if err = checkVariant(item, batch.Variant); err != nil {
+		return nil, err
+	}
+	if batch.Variant != "" && !hasVariant(item, batch.Variant) {
+		return nil, apperror.NewBadReq("invalid variant", ErrInvalidVariant)
+	}
+
+	b, err := s.preorderRepo.CreateBatch(c, batch.Item, batch.Variant, batch.Price, adminUsername, batch.ExpiresAt)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create preorder batch", err)
+	}
+
+	return toPreorderBatchModel(b, 0), nil
+}
+
+// MarkPreorderStockArrived fulfills pending preorders in order they
+// were made while stock lasts, the rest are released. Units left after
+// preorders are added to variant stock. Batch is closed first, so
+// repeated calls fail instead of allocating stock again.
+func (s *Service) MarkPreorderStockArrived(c context.Context, batchID, quantity int32) (*models.PreorderAllocation, error) {
+	if !s.preordersEnabled() {
+		return nil, apperror.NewNotFound("preorders disabled", ErrFeatureDisabled)
+	}
+	if quantity < 0 {
+		return nil, apperror.NewBadReq("quantity must not be negative", nil)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to allocate preorders", err)
+	}
+	defer tx.Rollback()
+
+	b, err := s.closeBatch(c, batchID, models.PreorderBatchArrived)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.preorderRepo.GetPendingPreorders(c, batchID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get preorders", err)
+	}
+
+	res := &models.PreorderAllocation{BatchID: batchID}
+	for _, p := range pending {
+		if res.Fulfilled < quantity {
+			fulfilled, err := s.fulfillPreorder(c, b, p)
+			if err != nil {
+				return nil, err
+			}
+			if fulfilled {
+				res.Fulfilled++
+			} else {
+				// unit goes to the next preorder
+				res.Released++
+			}
+			continue
+		}
+
+		if err = s.releasePreorder(c, b, p, models.PreorderReleased); err != nil {
+			return nil, err
+		}
+		res.Released++
+	}
+
+	if left := quantity - res.Fulfilled; left > 0 && b.Variant.Valid {
+		if err = s.returnVariant(c, b.Variant.String, left); err != nil {
+			return nil, err
+		}
+		res.Restocked = left
+	}
+
+	return res, tx.Commit()
+}
+
+// ExpirePreorders releases holds of batches which stock didn't arrive.
+// Runs periodically by worker.
+func (s *Service) ExpirePreorders(c context.Context) error {
+	if !s.preordersEnabled() {
+		return nil
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to expire preorders", err)
+	}
+	defer tx.Rollback()
+
+	expired, err := s.preorderRepo.GetExpiredBatches(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to get expired preorder batches", err)
+	}
+
+	for _, b := range expired {
+		if _, err = s.preorderRepo.CloseBatch(c, b.BatchID, models.PreorderBatchExpired); err != nil {
+			return apperror.NewInternal("failed to close preorder batch", err)
+		}
+
+		pending, err := s.preorderRepo.GetPendingPreorders(c, b.BatchID)
+		if err != nil {
+			return apperror.NewInternal("failed to get preorders", err)
+		}
+		for _, p := range pending {
+			if err = s.releasePreorder(c, b, p, models.PreorderReleased); err != nil {
+				return err
+			}
+		}
+	}
+	if len(expired) > 0 {
+		log.Printf("preorders: expired %d batches", len(expired))
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	preorderRepo := mocks.NewMockPreorderRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithPreorders(preorderRepo))
+
+	batch := &db.PreorderBatch{BatchID: 1, ItemType: "hoody", Price: 300, Status: models.PreorderBatchOpen, ExpiresAt: mockNow.Add(time.Hour)}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					GetBatchForUpdate(gomock.Any(), int32(1)).
+					Return(batch, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(300)).
+					Return(&mockUser1, nil)
+				preorderRepo.EXPECT().
+					CreatePreorder(gomock.Any(), int32(1), mockUser1.Username).
+					Return(&db.Preorder{PreorderID: 1, BatchID: 1, Username: mockUser1.Username, Status: models.PreorderPending}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Already Preordered",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					GetBatchForUpdate(gomock.Any(), int32(1)).
+					Return(batch, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, int32(300)).
+					Return(&mockUser1, nil)
+				preorderRepo.EXPECT().
+					CreatePreorder(gomock.Any(), int32(1), mockUser1.Username).
+					Return(nil, repository.ErrPreorderExists)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("already preordered", repository.ErrPreorderExists),
+		},
+		{
+			name: "Err Expired",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expired := *batch
+				expired.ExpiresAt = mockNow
+				preorderRepo.EXPECT().
+					GetBatchForUpdate(gomock.Any(), int32(1)).
+					Return(&expired, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("preorders closed", ErrPreorderClosed),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := srv.Preorder(context.Background(), mockUser1.Username, 1)
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMarkPreorderStockArrived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	preorderRepo := mocks.NewMockPreorderRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithPreorders(preorderRepo))
+
+	fulfill := func(b *db.PreorderBatch, p *db.Preorder, usr *db.User) {
+		userRepo.EXPECT().
+			ReleaseCoins(gomock.Any(), usr.Username, b.Price).
+			Return(usr, nil)
+		userRepo.EXPECT().
+			GetUserForUpdate(gomock.Any(), usr.Username).
+			Return(usr, nil)
+		userRepo.EXPECT().
+			UpdateBalance(gomock.Any(), usr.UserID, usr.Coins-b.Price).
+			Return(usr, nil)
+		inventoryRepo.EXPECT().
+			AddItemToInventory(gomock.Any(), usr.UserID, b.ItemType, b.Variant.String).
+			Return(nil)
+		preorderRepo.EXPECT().
+			SetPreorderStatus(gomock.Any(), p.PreorderID, models.PreorderFulfilled).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		name         string
+		quantity     int32
+		mockBehavior func()
+		expRes       *models.PreorderAllocation
+		expErr       error
+	}{
+		{
+			name:     "OK First Come First Served",
+			quantity: 1,
+			mockBehavior: func() {
+				b := &db.PreorderBatch{BatchID: 1, ItemType: "hoody", Price: 300, Status: models.PreorderBatchOpen}
+				first := &db.Preorder{PreorderID: 1, BatchID: 1, Username: mockUser1.Username}
+				second := &db.Preorder{PreorderID: 2, BatchID: 1, Username: mockUser2.Username}
+
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					CloseBatch(gomock.Any(), int32(1), models.PreorderBatchArrived).
+					Return(b, nil)
+				preorderRepo.EXPECT().
+					GetPendingPreorders(gomock.Any(), int32(1)).
+					Return([]*db.Preorder{first, second}, nil)
+				fulfill(b, first, &mockUser1)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser2.Username, int32(300)).
+					Return(&mockUser2, nil)
+				preorderRepo.EXPECT().
+					SetPreorderStatus(gomock.Any(), int32(2), models.PreorderReleased).
+					Return(nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.PreorderAllocation{BatchID: 1, Fulfilled: 1, Released: 1},
+		},
+		{
+			name:     "OK Leftover Restocked",
+			quantity: 3,
+			mockBehavior: func() {
+				b := &db.PreorderBatch{
+					BatchID:  1,
+					ItemType: "hoody",
+					Variant:  sql.NullString{String: "hoody-m", Valid: true},
+					Price:    300,
+					Status:   models.PreorderBatchOpen,
+				}
+				first := &db.Preorder{PreorderID: 1, BatchID: 1, Username: mockUser1.Username}
+
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					CloseBatch(gomock.Any(), int32(1), models.PreorderBatchArrived).
+					Return(b, nil)
+				preorderRepo.EXPECT().
+					GetPendingPreorders(gomock.Any(), int32(1)).
+					Return([]*db.Preorder{first}, nil)
+				fulfill(b, first, &mockUser1)
+				storeRepo.EXPECT().
+					AddVariantStock(gomock.Any(), "hoody-m", int32(2)).
+					Return(nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.PreorderAllocation{BatchID: 1, Fulfilled: 1, Restocked: 2},
+		},
+		{
+			name:     "OK Unit Of Unpaid Preorder Goes Next",
+			quantity: 1,
+			mockBehavior: func() {
+				b := &db.PreorderBatch{BatchID: 1, ItemType: "hoody", Price: 300, Status: models.PreorderBatchOpen}
+				first := &db.Preorder{PreorderID: 1, BatchID: 1, Username: mockUser1.Username}
+				second := &db.Preorder{PreorderID: 2, BatchID: 1, Username: mockUser2.Username}
+				broke := mockUser1
+				broke.Coins = 100
+
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					CloseBatch(gomock.Any(), int32(1), models.PreorderBatchArrived).
+					Return(b, nil)
+				preorderRepo.EXPECT().
+					GetPendingPreorders(gomock.Any(), int32(1)).
+					Return([]*db.Preorder{first, second}, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(300)).
+					Return(&broke, nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&broke, nil)
+				preorderRepo.EXPECT().
+					SetPreorderStatus(gomock.Any(), int32(1), models.PreorderReleased).
+					Return(nil)
+				fulfill(b, second, &mockUser2)
+				mock.ExpectCommit()
+			},
+			expRes: &models.PreorderAllocation{BatchID: 1, Fulfilled: 1, Released: 1},
+		},
+		{
+			name:     "Err Closed",
+			quantity: 1,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				preorderRepo.EXPECT().
+					CloseBatch(gomock.Any(), int32(1), models.PreorderBatchArrived).
+					Return(nil, repository.ErrPreorderBatchNotOpen)
+				preorderRepo.EXPECT().
+					GetBatch(gomock.Any(), int32(1)).
+					Return(&db.PreorderBatch{BatchID: 1, Status: models.PreorderBatchArrived}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("preorders closed", ErrPreorderClosed),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			res, err := srv.MarkPreorderStockArrived(context.Background(), 1, tc.quantity)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
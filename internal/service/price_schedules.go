@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrScheduleOverlap = errors.New("price schedule overlaps existing one")
+
+func toPriceScheduleModel(ps *db.PriceSchedule) *models.PriceSchedule {
+	res := &models.PriceSchedule{
+		ID:        ps.ScheduleID,
+		Item:      ps.ItemType,
+		SalePrice: ps.SalePrice,
+		StartsAt:  ps.StartsAt,
+		EndsAt:    ps.EndsAt,
+		CreatedBy: ps.CreatedBy,
+	}
+	if ps.CancelledAt.Valid {
+		res.CancelledAt = &ps.CancelledAt.Time
+	}
+
+	return res
+}
+
+// GetCatalog returns every item with its regular and current price,
+// limited items show how many of them user can still buy.
+func (s *Service) GetCatalog(c context.Context, username string) ([]*models.Item, error) {
+	items, err := s.listItems(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get items", err)
+	}
+
+	if s.purchaseLimitsEnabled() {
+		if err = s.applyPurchaseLimits(c, username, items); err != nil {
+			return nil, apperror.NewInternal("failed to get purchase limits", err)
+		}
+	}
+
+	return items, nil
+}
+
+// CreatePriceSchedule sets sale price of item for period.
+// Item can't have two sales at the same time.
+func (s *Service) CreatePriceSchedule(c context.Context, adminUsername string, schedule *models.NewPriceSchedule) (*models.PriceSchedule, error) {
+	if !schedule.EndsAt.After(schedule.StartsAt) {
+		return nil, apperror.NewBadReq("sale must end after start", nil)
+	}
+	if !schedule.EndsAt.After(s.now()) {
+		return nil, apperror.NewBadReq("sale must end in future", nil)
+	}
+
+	item, err := s.storeRepo.GetItemInfo(c, schedule.Item, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+	if schedule.SalePrice <= 0 || schedule.SalePrice >= item.Price {
+		return nil, apperror.NewBadReq("sale price must be positive and below regular price", nil)
+	}
+
+	ps, err := s.storeRepo.CreatePriceSchedule(c, adminUsername, schedule)
+	if err != nil {
+		if errors.Is(err, repository.ErrScheduleOverlap) {
+			return nil, apperror.NewBadReq("sale overlaps existing one", ErrScheduleOverlap)
+		}
+		return nil, apperror.NewInternal("failed to create price schedule", err)
+	}
+
+	return toPriceScheduleModel(ps), nil
+}
+
+// ListPriceSchedules returns price history of item,
+// empty itemName returns history of every item.
+func (s *Service) ListPriceSchedules(c context.Context, itemName string) ([]*models.PriceSchedule, error) {
+	schedules, err := s.storeRepo.ListPriceSchedules(c, itemName)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get price schedules", err)
+	}
+
+	res := make([]*models.PriceSchedule, len(schedules))
+	for i, ps := range schedules {
+		res[i] = toPriceScheduleModel(ps)
+	}
+
+	return res, nil
+}
+
+// CancelPriceSchedule stops sale, schedule is kept in price history.
+func (s *Service) CancelPriceSchedule(c context.Context, scheduleID int32) (*models.PriceSchedule, error) {
+	ps, err := s.storeRepo.CancelPriceSchedule(c, scheduleID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPriceScheduleNotFound) {
+			return nil, apperror.NewNotFound("price schedule not found", err)
+		}
+		return nil, apperror.NewInternal("failed to cancel price schedule", err)
+	}
+
+	return toPriceScheduleModel(ps), nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePriceSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+
+	srv := NewService(nil, nil, nil, nil, storeRepo, nil, nil, nil, WithClock(mockClock))
+
+	hoody := &models.Item{Type: "hoody", Price: 300, CurrentPrice: 300}
+	week := models.NewPriceSchedule{Item: "hoody", SalePrice: 210, StartsAt: mockNow, EndsAt: mockNow.Add(7 * 24 * time.Hour)}
+
+	testCases := []struct {
+		name         string
+		schedule     models.NewPriceSchedule
+		mockBehavior func(schedule *models.NewPriceSchedule)
+		expSchedule  *models.PriceSchedule
+		expErr       error
+	}{
+		{
+			name:     "OK",
+			schedule: week,
+			mockBehavior: func(schedule *models.NewPriceSchedule) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", mockNow).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					CreatePriceSchedule(gomock.Any(), "admin", schedule).
+					Return(&db.PriceSchedule{ScheduleID: 1, ItemType: "hoody", SalePrice: 210, StartsAt: schedule.StartsAt, EndsAt: schedule.EndsAt, CreatedBy: "admin"}, nil)
+			},
+			expSchedule: &models.PriceSchedule{ID: 1, Item: "hoody", SalePrice: 210, StartsAt: week.StartsAt, EndsAt: week.EndsAt, CreatedBy: "admin"},
+		},
+		{
+			name:     "Err Overlap",
+			schedule: week,
+			mockBehavior: func(schedule *models.NewPriceSchedule) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", mockNow).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					CreatePriceSchedule(gomock.Any(), "admin", schedule).
+					Return(nil, repository.ErrScheduleOverlap)
+			},
+			expErr: apperror.NewBadReq("sale overlaps existing one", ErrScheduleOverlap),
+		},
+		{
+			name:     "Err Price Above Regular",
+			schedule: models.NewPriceSchedule{Item: "hoody", SalePrice: 300, StartsAt: week.StartsAt, EndsAt: week.EndsAt},
+			mockBehavior: func(schedule *models.NewPriceSchedule) {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", mockNow).
+					Return(hoody, nil)
+			},
+			expErr: apperror.NewBadReq("sale price must be positive and below regular price", nil),
+		},
+		{
+			name:         "Err Ended",
+			schedule:     models.NewPriceSchedule{Item: "hoody", SalePrice: 210, StartsAt: mockNow.Add(-2 * time.Hour), EndsAt: mockNow.Add(-time.Hour)},
+			mockBehavior: func(schedule *models.NewPriceSchedule) {},
+			expErr:       apperror.NewBadReq("sale must end in future", nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(&tc.schedule)
+
+			ps, err := srv.CreatePriceSchedule(context.Background(), "admin", &tc.schedule)
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expSchedule, ps)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+const maxPromoCodeLen = 32
+
+var (
+	ErrInvalidPromoCode       = errors.New("invalid promo code")
+	ErrPromoCodeInactive      = errors.New("promo code is not active")
+	ErrPromoCodeNotApplicable = errors.New("promo code is not applicable to item")
+	ErrPromoCodeExhausted     = errors.New("promo code usage limit reached")
+	ErrPromoCodeUsed          = errors.New("promo code already used")
+)
+
+func (s *Service) ordersEnabled() bool {
+	return s.orderRepo != nil
+}
+
+// promo codes usage is tracked by orders.
+func (s *Service) promoCodesEnabled() bool {
+	return s.promoCodeRepo != nil && s.ordersEnabled()
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func toPromoCodeModel(p *db.PromoCode) *models.PromoCode {
+	res := &models.PromoCode{
+		Code:      p.Code,
+		Kind:      p.Kind,
+		Value:     p.Value,
+		Item:      p.ItemType.String,
+		MaxUses:   p.MaxUses,
+		UsedCount: p.UsedCount,
+		StartsAt:  p.StartsAt,
+		Disabled:  p.Disabled,
+		CreatedBy: p.CreatedBy,
+	}
+	if p.EndsAt.Valid {
+		res.EndsAt = &p.EndsAt.Time
+	}
+
+	return res
+}
+
+// promoDiscount returns discount of code for price,
+// discount never exceeds price.
+func promoDiscount(p *db.PromoCode, price int32) int32 {
+	if p.Kind == models.PromoPercent {
+		return price * p.Value / 100
+	}
+	return min(p.Value, price)
+}
+
+// applyPromoCode counts a use of code by user and returns discount for
+// item. Use is counted with conditional updates before user is charged,
+// so concurrent purchases can't exceed code's limits.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) applyPromoCode(c context.Context, username, itemName string, price int32, code string) (int32, error) {
+	p, err := s.promoCodeRepo.UsePromoCode(c, normalizePromoCode(code), itemName, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrPromoCodeUnavailable) {
+			return 0, s.promoCodeUnavailable(c, itemName, code)
+		}
+		return 0, apperror.NewInternal("failed to use promo code", err)
+	}
+
+	if err = s.promoCodeRepo.ClaimUse(c, p.Code, username); err != nil {
+		if errors.Is(err, repository.ErrPromoCodeUseClaimed) {
+			return 0, apperror.NewBadReq("promo code already used", ErrPromoCodeUsed)
+		}
+		return 0, apperror.NewInternal("failed to use promo code", err)
+	}
+
+	return promoDiscount(p, price), nil
+}
+
+// promoCodeUnavailable explains why code can't be applied to item.
+// returns apperror.
+func (s *Service) promoCodeUnavailable(c context.Context, itemName, code string) error {
+	p, err := s.promoCodeRepo.GetPromoCode(c, normalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrPromoCodeNotFound) {
+			return apperror.NewBadReq("invalid promo code", ErrInvalidPromoCode)
+		}
+		return apperror.NewInternal("failed to get promo code", err)
+	}
+
+	now := s.now()
+	if p.Disabled || now.Before(p.StartsAt) || (p.EndsAt.Valid && !now.Before(p.EndsAt.Time)) {
+		return apperror.NewBadReq("promo code is not active", ErrPromoCodeInactive)
+	}
+	if p.ItemType.Valid && p.ItemType.String != itemName {
+		return apperror.NewBadReq("promo code is not applicable to item", ErrPromoCodeNotApplicable)
+	}
+	return apperror.NewBadReq("promo code usage limit reached", ErrPromoCodeExhausted)
+}
+
+// CreatePromoCode validates and saves new promo code.
+// Code is case-insensitive, promo starts now if StartsAt is omitted.
+func (s *Service) CreatePromoCode(c context.Context, adminUsername string, promo *models.NewPromoCode) (*models.PromoCode, error) {
+	if !s.promoCodesEnabled() {
+		return nil, apperror.NewNotFound("promo codes disabled", ErrFeatureDisabled)
+	}
+
+	promo.Code = normalizePromoCode(promo.Code)
+	if promo.Code == "" || len(promo.Code) > maxPromoCodeLen {
+		return nil, apperror.NewBadReq("invalid promo code", ErrInvalidPromoCode)
+	}
+	switch promo.Kind {
+	case models.PromoPercent:
+		if promo.Value <= 0 || promo.Value > 100 {
+			return nil, apperror.NewBadReq("percent must be between 1 and 100", nil)
+		}
+	case models.PromoFixed:
+		if promo.Value <= 0 {
+			return nil, apperror.NewBadReq("discount must be positive", nil)
+		}
+	default:
+		return nil, apperror.NewBadReq("invalid promo code kind", nil)
+	}
+	if promo.MaxUses < 0 {
+		return nil, apperror.NewBadReq("max uses must not be negative", nil)
+	}
+	if promo.StartsAt == nil {
+		now := s.now()
+		promo.StartsAt = &now
+	}
+	if promo.EndsAt != nil && !promo.EndsAt.After(*promo.StartsAt) {
+		return nil, apperror.NewBadReq("promo code must end after start", nil)
+	}
+
+	if promo.Item != "" {
+		if _, err := s.storeRepo.GetItemInfo(c, promo.Item, s.now()); err != nil {
+			if errors.Is(err, repository.ErrInvalidItemName) {
+				return nil, apperror.NewBadReq("invalid item name", err)
+			}
+			return nil, apperror.NewInternal("failed to get item info", err)
+		}
+	}
+
+	p, err := s.promoCodeRepo.CreatePromoCode(c, adminUsername, promo)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromoCodeExists) {
+			return nil, apperror.NewBadReq("promo code already exists", err)
+		}
+		return nil, apperror.NewInternal("failed to create promo code", err)
+	}
+
+	return toPromoCodeModel(p), nil
+}
+
+// ListPromoCodes returns every promo code including disabled ones.
+func (s *Service) ListPromoCodes(c context.Context) ([]*models.PromoCode, error) {
+	if !s.promoCodesEnabled() {
+		return nil, apperror.NewNotFound("promo codes disabled", ErrFeatureDisabled)
+	}
+
+	codes, err := s.promoCodeRepo.ListPromoCodes(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get promo codes", err)
+	}
+
+	res := make([]*models.PromoCode, len(codes))
+	for i, p := range codes {
+		res[i] = toPromoCodeModel(p)
+	}
+
+	return res, nil
+}
+
+// DisablePromoCode stops code from being applied. Code stays
+// in history of orders.
+func (s *Service) DisablePromoCode(c context.Context, code string) (*models.PromoCode, error) {
+	if !s.promoCodesEnabled() {
+		return nil, apperror.NewNotFound("promo codes disabled", ErrFeatureDisabled)
+	}
+
+	p, err := s.promoCodeRepo.DisablePromoCode(c, normalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrPromoCodeNotFound) {
+			return nil, apperror.NewNotFound("promo code not found", err)
+		}
+		return nil, apperror.NewInternal("failed to disable promo code", err)
+	}
+
+	return toPromoCodeModel(p), nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuyItemWithPromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	promoCodeRepo := mocks.NewMockPromoCodeRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPromoCodes(promoCodeRepo))
+
+	hoody := &models.Item{Type: "hoody", Price: 300, CurrentPrice: 300}
+	promo := db.PromoCode{
+		Code:     "HOODY30",
+		Kind:     models.PromoPercent,
+		Value:    30,
+		ItemType: sql.NullString{String: "hoody", Valid: true},
+		MaxUses:  10,
+		StartsAt: mockNow.Add(-time.Hour),
+		EndsAt:   sql.NullTime{Time: mockNow.Add(time.Hour), Valid: true},
+	}
+
+	// promo code use is counted before user is charged
+	expectBuy := func() {
+		mock.ExpectBegin()
+		storeRepo.EXPECT().
+			GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+			Return(hoody, nil)
+		storeRepo.EXPECT().
+			ListItemVariants(gomock.Any(), "hoody").
+			Return(nil, nil)
+		userRepo.EXPECT().
+			GetUserForUpdate(gomock.Any(), mockUser1.Username).
+			Return(&mockUser1, nil)
+	}
+	// code can't be used, the reason is taken from the code
+	expectUnavailable := func(p *db.PromoCode, err error) {
+		expectBuy()
+		promoCodeRepo.EXPECT().
+			UsePromoCode(gomock.Any(), "HOODY30", "hoody", mockNow).
+			Return(nil, repository.ErrPromoCodeUnavailable)
+		promoCodeRepo.EXPECT().
+			GetPromoCode(gomock.Any(), "HOODY30").
+			Return(p, err)
+		mock.ExpectRollback()
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				expectBuy()
+				promoCodeRepo.EXPECT().
+					UsePromoCode(gomock.Any(), "HOODY30", "hoody", mockNow).
+					Return(&promo, nil)
+				promoCodeRepo.EXPECT().
+					ClaimUse(gomock.Any(), "HOODY30", mockUser1.Username).
+					Return(nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-210).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "hoody", "").
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{
+						Username:  mockUser1.Username,
+						Item:      "hoody",
+						Price:     210,
+						Discount:  90,
+						PromoCode: "HOODY30",
+					}).
+					Return(&db.Order{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Not Found",
+			mockBehavior: func() {
+				expectUnavailable(nil, repository.ErrPromoCodeNotFound)
+			},
+			expErr: apperror.NewBadReq("invalid promo code", ErrInvalidPromoCode),
+		},
+		{
+			name: "Err Expired",
+			mockBehavior: func() {
+				expired := promo
+				expired.EndsAt.Time = mockNow
+				expectUnavailable(&expired, nil)
+			},
+			expErr: apperror.NewBadReq("promo code is not active", ErrPromoCodeInactive),
+		},
+		{
+			name: "Err Other Item",
+			mockBehavior: func() {
+				other := promo
+				other.ItemType.String = "cup"
+				expectUnavailable(&other, nil)
+			},
+			expErr: apperror.NewBadReq("promo code is not applicable to item", ErrPromoCodeNotApplicable),
+		},
+		{
+			name: "Err Exhausted",
+			mockBehavior: func() {
+				exhausted := promo
+				exhausted.UsedCount = exhausted.MaxUses
+				expectUnavailable(&exhausted, nil)
+			},
+			expErr: apperror.NewBadReq("promo code usage limit reached", ErrPromoCodeExhausted),
+		},
+		{
+			name: "Err Already Used",
+			mockBehavior: func() {
+				expectBuy()
+				promoCodeRepo.EXPECT().
+					UsePromoCode(gomock.Any(), "HOODY30", "hoody", mockNow).
+					Return(&promo, nil)
+				promoCodeRepo.EXPECT().
+					ClaimUse(gomock.Any(), "HOODY30", mockUser1.Username).
+					Return(repository.ErrPromoCodeUseClaimed)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("promo code already used", ErrPromoCodeUsed),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.BuyItem(context.Background(), mockUser1.Username, "hoody", "", "hoody30")
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPromoDiscount(t *testing.T) {
+	require.Equal(t, int32(3), promoDiscount(&db.PromoCode{Kind: models.PromoPercent, Value: 30}, 10))
+	require.Equal(t, int32(50), promoDiscount(&db.PromoCode{Kind: models.PromoFixed, Value: 50}, 300))
+	// fixed discount doesn't make item free with coins back
+	require.Equal(t, int32(10), promoDiscount(&db.PromoCode{Kind: models.PromoFixed, Value: 50}, 10))
+}
+
+func TestCreatePromoCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	promoCodeRepo := mocks.NewMockPromoCodeRepository(ctrl)
+
+	srv := NewService(nil, nil, nil, nil, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPromoCodes(promoCodeRepo))
+
+	past := mockNow.Add(-time.Hour)
+
+	testCases := []struct {
+		name         string
+		promo        models.NewPromoCode
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name:  "OK",
+			promo: models.NewPromoCode{Code: " hoody30 ", Kind: models.PromoPercent, Value: 30, Item: "hoody"},
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(&models.Item{Type: "hoody", Price: 300, CurrentPrice: 300}, nil)
+				promoCodeRepo.EXPECT().
+					CreatePromoCode(gomock.Any(), "admin", &models.NewPromoCode{
+						Code: "HOODY30", Kind: models.PromoPercent, Value: 30, Item: "hoody", StartsAt: &mockNow,
+					}).
+					Return(&db.PromoCode{Code: "HOODY30"}, nil)
+			},
+		},
+		{
+			name:         "Err Percent",
+			promo:        models.NewPromoCode{Code: "BIG", Kind: models.PromoPercent, Value: 150},
+			mockBehavior: func() {},
+			expErr:       apperror.NewBadReq("percent must be between 1 and 100", nil),
+		},
+		{
+			name:         "Err Kind",
+			promo:        models.NewPromoCode{Code: "BIG", Kind: "free", Value: 1},
+			mockBehavior: func() {},
+			expErr:       apperror.NewBadReq("invalid promo code kind", nil),
+		},
+		{
+			name:         "Err Ends Before Start",
+			promo:        models.NewPromoCode{Code: "OLD", Kind: models.PromoFixed, Value: 10, EndsAt: &past},
+			mockBehavior: func() {},
+			expErr:       apperror.NewBadReq("promo code must end after start", nil),
+		},
+		{
+			name:  "Err Exists",
+			promo: models.NewPromoCode{Code: "SALE", Kind: models.PromoFixed, Value: 10},
+			mockBehavior: func() {
+				promoCodeRepo.EXPECT().
+					CreatePromoCode(gomock.Any(), "admin", gomock.Any()).
+					Return(nil, repository.ErrPromoCodeExists)
+			},
+			expErr: apperror.NewBadReq("promo code already exists", repository.ErrPromoCodeExists),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			_, err := srv.CreatePromoCode(context.Background(), "admin", &tc.promo)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrPurchaseLimitReached = errors.New("purchase limit reached")
+
+// purchases are counted by orders.
+func (s *Service) purchaseLimitsEnabled() bool {
+	return s.purchaseLimitRepo != nil && s.ordersEnabled()
+}
+
+func toPurchaseLimitModel(l *db.PurchaseLimit) *models.PurchaseLimit {
+	return &models.PurchaseLimit{
+		Item:       l.ItemType,
+		Lifetime:   l.Lifetime,
+		PerPeriod:  l.PerPeriod,
+		PeriodDays: l.PeriodDays,
+	}
+}
+
+// remainingPurchases returns how many items user can still buy
+// under limit, the tightest of lifetime and period caps wins.
+func (s *Service) remainingPurchases(c context.Context, username string, limit *db.PurchaseLimit) (int32, error) {
+	since := s.now().AddDate(0, 0, -int(limit.PeriodDays))
+	total, inPeriod, err := s.orderRepo.CountItemOrders(c, username, limit.ItemType, since)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := int32(-1)
+	if limit.Lifetime > 0 {
+		remaining = max(limit.Lifetime-total, 0)
+	}
+	if limit.PerPeriod > 0 {
+		left := max(limit.PerPeriod-inPeriod, 0)
+		if remaining < 0 || left < remaining {
+			remaining = left
+		}
+	}
+
+	return remaining, nil
+}
+
+// checkPurchaseLimit rejects purchase of quantity items if it
+// exceeds user's limit of item.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) checkPurchaseLimit(c context.Context, username, itemName string, quantity int32) error {
+	if !s.purchaseLimitsEnabled() {
+		return nil
+	}
+
+	limit, err := s.purchaseLimitRepo.GetPurchaseLimit(c, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoPurchaseLimit) {
+			return nil
+		}
+		return apperror.NewInternal("failed to get purchase limit", err)
+	}
+
+	remaining, err := s.remainingPurchases(c, username, limit)
+	if err != nil {
+		return apperror.NewInternal("failed to count orders", err)
+	}
+	if remaining >= 0 && remaining < quantity {
+		return apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+			WithDetails(toPurchaseLimitModel(limit))
+	}
+
+	return nil
+}
+
+// applyPurchaseLimits fills limits of catalog items and
+// how many of them user can still buy.
+func (s *Service) applyPurchaseLimits(c context.Context, username string, items []*models.Item) error {
+	limits, err := s.purchaseLimitRepo.ListPurchaseLimits(c)
+	if err != nil {
+		return err
+	}
+
+	byItem := make(map[string]*db.PurchaseLimit, len(limits))
+	for _, l := range limits {
+		byItem[l.ItemType] = l
+	}
+
+	for _, item := range items {
+		limit, ok := byItem[item.Type]
+		if !ok {
+			continue
+		}
+
+		remaining, err := s.remainingPurchases(c, username, limit)
+		if err != nil {
+			return err
+		}
+		item.PurchaseLimit = toPurchaseLimitModel(limit)
+		item.Remaining = &remaining
+	}
+
+	return nil
+}
+
+// SetPurchaseLimit creates or replaces purchase limit of item.
+func (s *Service) SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*models.PurchaseLimit, error) {
+	if !s.purchaseLimitsEnabled() {
+		return nil, apperror.NewNotFound("purchase limits disabled", ErrFeatureDisabled)
+	}
+	if limit.Lifetime < 0 || limit.PerPeriod < 0 {
+		return nil, apperror.NewBadReq("limits can't be negative", nil)
+	}
+	if limit.Lifetime == 0 && limit.PerPeriod == 0 {
+		return nil, apperror.NewBadReq("lifetime or perPeriod limit required", nil)
+	}
+	if limit.PerPeriod > 0 && limit.PeriodDays <= 0 {
+		return nil, apperror.NewBadReq("periodDays must be positive", nil)
+	}
+	if limit.PerPeriod == 0 {
+		limit.PeriodDays = 0
+	}
+
+	l, err := s.purchaseLimitRepo.SetPurchaseLimit(c, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to set purchase limit", err)
+	}
+
+	return toPurchaseLimitModel(l), nil
+}
+
+// DeletePurchaseLimit removes purchase limit of item.
+func (s *Service) DeletePurchaseLimit(c context.Context, itemName string) error {
+	if !s.purchaseLimitsEnabled() {
+		return apperror.NewNotFound("purchase limits disabled", ErrFeatureDisabled)
+	}
+
+	err := s.purchaseLimitRepo.DeletePurchaseLimit(c, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoPurchaseLimit) {
+			return apperror.NewNotFound("purchase limit not found", err)
+		}
+		return apperror.NewInternal("failed to delete purchase limit", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuyItemPurchaseLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPurchaseLimits(limitRepo))
+
+	pinkHoody := &models.Item{Type: "pink-hoody", Price: 500, CurrentPrice: 500}
+	limit := &db.PurchaseLimit{ItemType: "pink-hoody", Lifetime: 1}
+	weekly := &db.PurchaseLimit{ItemType: "pink-hoody", Lifetime: 5, PerPeriod: 2, PeriodDays: 7}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "pink-hoody", gomock.Any()).
+					Return(pinkHoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "pink-hoody").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "pink-hoody").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "pink-hoody", mockNow).
+					Return(int32(0), int32(0), nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-500).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "pink-hoody", "").
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Item: "pink-hoody", Price: 500}).
+					Return(&db.Order{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Lifetime Limit",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "pink-hoody", gomock.Any()).
+					Return(pinkHoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "pink-hoody").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "pink-hoody").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "pink-hoody", mockNow).
+					Return(int32(1), int32(0), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+				WithDetails(&models.PurchaseLimit{Item: "pink-hoody", Lifetime: 1}),
+		},
+		{
+			name: "Err Period Limit",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "pink-hoody", gomock.Any()).
+					Return(pinkHoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "pink-hoody").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "pink-hoody").
+					Return(weekly, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "pink-hoody", mockNow.AddDate(0, 0, -7)).
+					Return(int32(3), int32(2), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+				WithDetails(&models.PurchaseLimit{Item: "pink-hoody", Lifetime: 5, PerPeriod: 2, PeriodDays: 7}),
+		},
+		{
+			name: "OK No Limit",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "pink-hoody", gomock.Any()).
+					Return(pinkHoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "pink-hoody").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-500).
+					Return(&mockUser1, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "pink-hoody").
+					Return(nil, repository.ErrNoPurchaseLimit)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "pink-hoody", "").
+					Return(nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Item: "pink-hoody", Price: 500}).
+					Return(&db.Order{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.BuyItem(context.Background(), mockUser1.Username, "pink-hoody", "", "")
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetCatalogPurchaseLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+
+	srv := NewService(nil, nil, nil, nil, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPurchaseLimits(limitRepo))
+
+	storeRepo.EXPECT().
+		ListItems(gomock.Any(), gomock.Any()).
+		Return([]*models.Item{
+			{Type: "cup", Price: 20, CurrentPrice: 20},
+			{Type: "pink-hoody", Price: 500, CurrentPrice: 500},
+		}, nil)
+	storeRepo.EXPECT().
+		ListAllItemVariants(gomock.Any()).
+		Return(nil, nil)
+	limitRepo.EXPECT().
+		ListPurchaseLimits(gomock.Any()).
+		Return([]*db.PurchaseLimit{{ItemType: "pink-hoody", Lifetime: 3, PerPeriod: 1, PeriodDays: 30}}, nil)
+	orderRepo.EXPECT().
+		CountItemOrders(gomock.Any(), mockUser1.Username, "pink-hoody", mockNow.AddDate(0, 0, -30)).
+		Return(int32(1), int32(0), nil)
+
+	items, err := srv.GetCatalog(context.Background(), mockUser1.Username)
+	require.NoError(t, err)
+
+	remaining := int32(1)
+	require.Equal(t, []*models.Item{
+		{Type: "cup", Price: 20, CurrentPrice: 20},
+		{
+			Type:          "pink-hoody",
+			Price:         500,
+			CurrentPrice:  500,
+			PurchaseLimit: &models.PurchaseLimit{Item: "pink-hoody", Lifetime: 3, PerPeriod: 1, PeriodDays: 30},
+			Remaining:     &remaining,
+		},
+	}, items)
+}
+
+func TestBuyBundlePurchaseLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+	bundleRepo := mocks.NewMockBundleRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPurchaseLimits(limitRepo), WithBundles(bundleRepo))
+
+	// two pens fit into lifetime limit of 3 only if none were bought
+	mock.ExpectBegin()
+	bundleRepo.EXPECT().
+		GetBundle(gomock.Any(), "welcome-pack").
+		Return(&db.Bundle{Name: "welcome-pack", Price: 90, Active: true}, nil)
+	bundleRepo.EXPECT().
+		GetBundleItems(gomock.Any(), "welcome-pack").
+		Return([]*db.BundleItem{{ItemType: "cup", Quantity: 1}, {ItemType: "pen", Quantity: 2}}, nil)
+	userRepo.EXPECT().
+		GetUserForUpdate(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	limitRepo.EXPECT().
+		GetPurchaseLimit(gomock.Any(), "cup").
+		Return(nil, repository.ErrNoPurchaseLimit)
+	limitRepo.EXPECT().
+		GetPurchaseLimit(gomock.Any(), "pen").
+		Return(&db.PurchaseLimit{ItemType: "pen", Lifetime: 3}, nil)
+	orderRepo.EXPECT().
+		CountItemOrders(gomock.Any(), mockUser1.Username, "pen", mockNow).
+		Return(int32(2), int32(0), nil)
+	mock.ExpectRollback()
+
+	err = srv.BuyBundle(context.Background(), mockUser1.Username, "welcome-pack")
+
+	require.Equal(t, apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+		WithDetails(&models.PurchaseLimit{Item: "pen", Lifetime: 3}), err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuyGiftPurchaseLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+	giftRepo := mocks.NewMockGiftRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPurchaseLimits(limitRepo), WithGifts(giftRepo))
+
+	cup := &models.Item{Type: "cup", Price: 20, CurrentPrice: 20}
+	limit := &db.PurchaseLimit{ItemType: "cup", Lifetime: 1}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "cup", gomock.Any()).
+					Return(cup, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "cup").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "cup").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "cup", mockNow).
+					Return(int32(0), int32(0), nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-20).
+					Return(&mockUser1, nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser2.UserID, "cup", "").
+					Return(nil)
+				giftRepo.EXPECT().
+					CreateGift(gomock.Any(), mockUser1.Username, mockUser2.Username, "cup", "").
+					Return(&db.Gift{}, nil)
+				// gift is counted as buyer's order
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), &models.NewOrder{
+						Username: mockUser1.Username, Item: "cup", Price: 20, Recipient: mockUser2.Username,
+					}).
+					Return(&db.Order{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Err Limit",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "cup", gomock.Any()).
+					Return(cup, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "cup").
+					Return(nil, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				limitRepo.EXPECT().
+					GetPurchaseLimit(gomock.Any(), "cup").
+					Return(limit, nil)
+				orderRepo.EXPECT().
+					CountItemOrders(gomock.Any(), mockUser1.Username, "cup", mockNow).
+					Return(int32(1), int32(0), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+				WithDetails(&models.PurchaseLimit{Item: "cup", Lifetime: 1}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.BuyGift(context.Background(), mockUser1.Username, mockUser2.Username, "cup", "")
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPreorderPurchaseLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+	limitRepo := mocks.NewMockPurchaseLimitRepository(ctrl)
+	preorderRepo := mocks.NewMockPreorderRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithOrders(orderRepo), WithPurchaseLimits(limitRepo), WithPreorders(preorderRepo))
+
+	batch := &db.PreorderBatch{BatchID: 1, ItemType: "hoody", Price: 300, Status: models.PreorderBatchOpen, ExpiresAt: mockNow.Add(time.Hour)}
+	limit := &db.PurchaseLimit{ItemType: "hoody", Lifetime: 1}
+
+	t.Run("Err Limit On Preorder", func(t *testing.T) {
+		mock.ExpectBegin()
+		preorderRepo.EXPECT().
+			GetBatchForUpdate(gomock.Any(), int32(1)).
+			Return(batch, nil)
+		userRepo.EXPECT().
+			GetUserForUpdate(gomock.Any(), mockUser1.Username).
+			Return(&mockUser1, nil)
+		userRepo.EXPECT().
+			HoldCoins(gomock.Any(), mockUser1.Username, int32(300)).
+			Return(&mockUser1, nil)
+		limitRepo.EXPECT().
+			GetPurchaseLimit(gomock.Any(), "hoody").
+			Return(limit, nil)
+		orderRepo.EXPECT().
+			CountItemOrders(gomock.Any(), mockUser1.Username, "hoody", mockNow).
+			Return(int32(1), int32(0), nil)
+		mock.ExpectRollback()
+
+		_, err := srv.Preorder(context.Background(), mockUser1.Username, 1)
+		require.Equal(t, apperror.NewConflict("purchase limit reached", ErrPurchaseLimitReached).
+			WithDetails(&models.PurchaseLimit{Item: "hoody", Lifetime: 1}), err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("OK Released On Arrival", func(t *testing.T) {
+		// user bought hoody after preorder was made
+		p := &db.Preorder{PreorderID: 1, BatchID: 1, Username: mockUser1.Username}
+
+		mock.ExpectBegin()
+		preorderRepo.EXPECT().
+			CloseBatch(gomock.Any(), int32(1), models.PreorderBatchArrived).
+			Return(batch, nil)
+		preorderRepo.EXPECT().
+			GetPendingPreorders(gomock.Any(), int32(1)).
+			Return([]*db.Preorder{p}, nil)
+		userRepo.EXPECT().
+			ReleaseCoins(gomock.Any(), mockUser1.Username, int32(300)).
+			Return(&mockUser1, nil)
+		userRepo.EXPECT().
+			GetUserForUpdate(gomock.Any(), mockUser1.Username).
+			Return(&mockUser1, nil)
+		limitRepo.EXPECT().
+			GetPurchaseLimit(gomock.Any(), "hoody").
+			Return(limit, nil)
+		orderRepo.EXPECT().
+			CountItemOrders(gomock.Any(), mockUser1.Username, "hoody", mockNow).
+			Return(int32(1), int32(0), nil)
+		preorderRepo.EXPECT().
+			SetPreorderStatus(gomock.Any(), int32(1), models.PreorderReleased).
+			Return(nil)
+		mock.ExpectCommit()
+
+		res, err := srv.MarkPreorderStockArrived(context.Background(), 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, &models.PreorderAllocation{BatchID: 1, Released: 1}, res)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
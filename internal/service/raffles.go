@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrRaffleClosed       = errors.New("raffle closed")
+	ErrTicketLimitReached = errors.New("ticket limit reached")
+)
+
+func (s *Service) rafflesEnabled() bool {
+	return s.raffleRepo != nil
+}
+
+func toRaffleModel(r *db.Raffle, tickets int32, winners []*db.RaffleTicket) *models.Raffle {
+	res := &models.Raffle{
+		ID:          r.RaffleID,
+		Item:        r.ItemType,
+		Prizes:      r.Prizes,
+		TicketPrice: r.TicketPrice,
+		MaxTickets:  r.MaxTickets,
+		Tickets:     tickets,
+		SeedHash:    r.SeedHash,
+		Status:      r.Status,
+		DrawsAt:     r.DrawsAt,
+	}
+	// seed is secret until draw, otherwise winners could be predicted
+	if r.Status == models.RaffleDrawn {
+		res.Seed = r.Seed
+	}
+	for _, t := range winners {
+		res.Winners = append(res.Winners, toRaffleTicketModel(t))
+	}
+
+	return res
+}
+
+func toRaffleTicketModel(t *db.RaffleTicket) *models.RaffleTicket {
+	return &models.RaffleTicket{
+		ID:        t.TicketID,
+		RaffleID:  t.RaffleID,
+		Username:  t.Username,
+		Price:     t.Price,
+		Won:       t.Won,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// newRaffleSeed returns random hex seed and its sha256 commitment.
+func newRaffleSeed() (seed, seedHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	seed = hex.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(seed))
+
+	return seed, hex.EncodeToString(sum[:]), nil
+}
+
+// drawRaffleWinners picks winners from tickets ordered by id. In round i
+// winning index is first 8 bytes of sha256("<seed>:<i>") as big-endian
+// uint64 modulo remaining tickets, then all tickets of the winner are
+// removed, so every user wins at most one prize.
+func drawRaffleWinners(seed string, tickets []*db.RaffleTicket, prizes int32) []*db.RaffleTicket {
+	pool := append([]*db.RaffleTicket(nil), tickets...)
+
+	var winners []*db.RaffleTicket
+	for round := 0; round < int(prizes) && len(pool) > 0; round++ {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d", seed, round)))
+		winner := pool[binary.BigEndian.Uint64(sum[:8])%uint64(len(pool))]
+		winners = append(winners, winner)
+
+		rest := pool[:0]
+		for _, t := range pool {
+			if t.Username != winner.Username {
+				rest = append(rest, t)
+			}
+		}
+		pool = rest
+	}
+
+	return winners
+}
+
+// CreateRaffle starts selling tickets for raffle of prizes units of item.
+// Seed is generated now and only its hash is shown until draw.
+func (s *Service) CreateRaffle(c context.Context, adminUsername string, raffle *models.NewRaffle) (*models.Raffle, error) {
+	if !s.rafflesEnabled() {
+		return nil, apperror.NewNotFound("raffles disabled", ErrFeatureDisabled)
+	}
+	if raffle.Prizes <= 0 {
+		return nil, apperror.NewBadReq("prizes must be positive", nil)
+	}
+	if raffle.TicketPrice <= 0 {
+		return nil, apperror.NewBadReq("ticket price must be positive", nil)
+	}
+	if raffle.MaxTickets < 0 {
+		return nil, apperror.NewBadReq("max tickets can't be negative", nil)
+	}
+	if !raffle.DrawsAt.After(s.now()) {
+		return nil, apperror.NewBadReq("raffle must be drawn in future", nil)
+	}
+
+	item, err := s.getItemInfo(c, raffle.Item)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+	if len(item.Variants) > 0 {
+		return nil, apperror.NewBadReq("items with variants can't be raffled", ErrInvalidVariant)
+	}
+
+	seed, seedHash, err := newRaffleSeed()
+	if err != nil {
+		return nil, apperror.NewInternal("failed to generate seed", err)
+	}
+
+	r, err := s.raffleRepo.CreateRaffle(c, raffle, seed, seedHash, adminUsername)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create raffle", err)
+	}
+
+	return toRaffleModel(r, 0, nil), nil
+}
+
+// GetRaffles returns raffles, latest first.
+func (s *Service) GetRaffles(c context.Context) ([]*models.Raffle, error) {
+	if !s.rafflesEnabled() {
+		return nil, apperror.NewNotFound("raffles disabled", ErrFeatureDisabled)
+	}
+
+	raffles, err := s.raffleRepo.ListRaffles(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get raffles", err)
+	}
+
+	res := make([]*models.Raffle, len(raffles))
+	for i, r := range raffles {
+		tickets, err := s.raffleRepo.CountTickets(c, r.RaffleID)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to count tickets", err)
+		}
+		res[i] = toRaffleModel(r, tickets, nil)
+	}
+
+	return res, nil
+}
+
+// GetRaffle returns raffle with its winners if it's drawn.
+func (s *Service) GetRaffle(c context.Context, raffleID int32) (*models.Raffle, error) {
+	if !s.rafflesEnabled() {
+		return nil, apperror.NewNotFound("raffles disabled", ErrFeatureDisabled)
+	}
+
+	r, err := s.raffleRepo.GetRaffle(c, raffleID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRaffleNotFound) {
+			return nil, apperror.NewNotFound("raffle not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get raffle", err)
+	}
+
+	tickets, err := s.raffleRepo.ListTickets(c, raffleID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get tickets", err)
+	}
+
+	var winners []*db.RaffleTicket
+	for _, t := range tickets {
+		if t.Won {
+			winners = append(winners, t)
+		}
+	}
+
+	return toRaffleModel(r, int32(len(tickets)), winners), nil
+}
+
+// GetRaffleTickets returns every ticket of raffle in purchase order,
+// with revealed seed it's enough to recompute winners.
+func (s *Service) GetRaffleTickets(c context.Context, raffleID int32) ([]*models.RaffleTicket, error) {
+	if !s.rafflesEnabled() {
+		return nil, apperror.NewNotFound("raffles disabled", ErrFeatureDisabled)
+	}
+
+	if _, err := s.raffleRepo.GetRaffle(c, raffleID); err != nil {
+		if errors.Is(err, repository.ErrRaffleNotFound) {
+			return nil, apperror.NewNotFound("raffle not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get raffle", err)
+	}
+
+	tickets, err := s.raffleRepo.ListTickets(c, raffleID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get tickets", err)
+	}
+
+	res := make([]*models.RaffleTicket, len(tickets))
+	for i, t := range tickets {
+		res[i] = toRaffleTicketModel(t)
+	}
+
+	return res, nil
+}
+
+// BuyRaffleTickets charges user for quantity tickets of open raffle.
+func (s *Service) BuyRaffleTickets(c context.Context, username string, raffleID, quantity int32) ([]*models.RaffleTicket, error) {
+	if !s.rafflesEnabled() {
+		return nil, apperror.NewNotFound("raffles disabled", ErrFeatureDisabled)
+	}
+	if quantity <= 0 {
+		return nil, apperror.NewBadReq("quantity must be positive", nil)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to buy tickets", err)
+	}
+	defer tx.Rollback()
+
+	// raffle lock keeps ticket sales from racing with draw
+	r, err := s.raffleRepo.GetRaffleForUpdate(c, raffleID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRaffleNotFound) {
+			return nil, apperror.NewNotFound("raffle not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get raffle", err)
+	}
+	if r.Status != models.RaffleOpen || !s.now().Before(r.DrawsAt) {
+		return nil, apperror.NewBadReq("raffle closed", ErrRaffleClosed)
+	}
+
+	if r.MaxTickets > 0 {
+		bought, err := s.raffleRepo.CountUserTickets(c, raffleID, username)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to count tickets", err)
+		}
+		if bought+quantity > r.MaxTickets {
+			return nil, apperror.NewConflict("ticket limit reached", ErrTicketLimitReached).
+				WithDetails(map[string]int32{"remaining": max(r.MaxTickets-bought, 0)})
+		}
+	}
+
+	total := int64(r.TicketPrice) * int64(quantity)
+	if total > math.MaxInt32 {
+		return nil, apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+	}
+	if _, err = s.payForItem(c, username, int32(total)); err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.RaffleTicket, quantity)
+	for i := range res {
+		t, err := s.raffleRepo.CreateTicket(c, raffleID, username, r.TicketPrice)
+		if err != nil {
+			return nil, apperror.NewInternal("failed to create ticket", err)
+		}
+		res[i] = toRaffleTicketModel(t)
+	}
+
+	return res, tx.Commit()
+}
+
+// drawRaffle picks winners of due raffle and gives them the prize
+// in its own transaction. Raffle drawn by concurrent worker is skipped.
+// returns apperror.
+func (s *Service) drawRaffle(c context.Context, raffleID int32) error {
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to draw raffle", err)
+	}
+	defer tx.Rollback()
+
+	r, err := s.raffleRepo.GetRaffleForUpdate(c, raffleID)
+	if err != nil {
+		return apperror.NewInternal("failed to get raffle", err)
+	}
+	if r.Status != models.RaffleOpen {
+		return nil
+	}
+
+	tickets, err := s.raffleRepo.ListTickets(c, r.RaffleID)
+	if err != nil {
+		return apperror.NewInternal("failed to get tickets", err)
+	}
+
+	for _, t := range drawRaffleWinners(r.Seed, tickets, r.Prizes) {
+		winner, err := s.userRepo.GetUser(c, t.Username)
+		if err != nil {
+			return apperror.NewInternal("failed to get user", err)
+		}
+		if err = s.inventoryRepo.AddItemToInventory(c, winner.UserID, r.ItemType, ""); err != nil {
+			return apperror.NewInternal("failed to add item to inventory", err)
+		}
+
+		// prize is tracked as free order, tickets are paid already
+		if s.ordersEnabled() {
+			_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+				Username: t.Username,
+				Item:     r.ItemType,
+			})
+			if err != nil {
+				return apperror.NewInternal("failed to create order", err)
+			}
+		}
+
+		if err = s.raffleRepo.SetTicketWon(c, t.TicketID); err != nil {
+			return apperror.NewInternal("failed to update ticket", err)
+		}
+		text := fmt.Sprintf("your ticket #%d won %s in raffle #%d", t.TicketID, r.ItemType, r.RaffleID)
+		if err = s.notify(c, t.Username, models.NotificationRaffle, text); err != nil {
+			return err
+		}
+	}
+
+	if err = s.raffleRepo.SetDrawn(c, r.RaffleID); err != nil {
+		return apperror.NewInternal("failed to update raffle", err)
+	}
+	return tx.Commit()
+}
+
+// DrawRaffles draws every raffle which draw time has come, each in
+// its own transaction, so one failed raffle doesn't block the others.
+// Runs periodically by worker.
+func (s *Service) DrawRaffles(c context.Context) error {
+	if !s.rafflesEnabled() {
+		return nil
+	}
+
+	due, err := s.raffleRepo.GetDueRaffles(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to get due raffles", err)
+	}
+
+	var errs []error
+	for _, r := range due {
+		if err = s.drawRaffle(c, r.RaffleID); err != nil {
+			errs = append(errs, fmt.Errorf("raffle %d: %w", r.RaffleID, err))
+		}
+	}
+	if len(due) > 0 {
+		log.Printf("raffles: drew %d raffles, %d failed", len(due)-len(errs), len(errs))
+	}
+
+	if len(errs) > 0 {
+		return apperror.NewInternal("failed to draw raffles", errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrawRaffleWinners(t *testing.T) {
+	tickets := []*db.RaffleTicket{
+		{TicketID: 1, Username: "a"},
+		{TicketID: 2, Username: "a"},
+		{TicketID: 3, Username: "b"},
+		{TicketID: 4, Username: "c"},
+		{TicketID: 5, Username: "c"},
+	}
+
+	winners := drawRaffleWinners("seed", tickets, 2)
+	require.Len(t, winners, 2)
+	require.NotEqual(t, winners[0].Username, winners[1].Username)
+	// same seed and tickets give same winners
+	require.Equal(t, winners, drawRaffleWinners("seed", tickets, 2))
+
+	// every user wins at most once
+	winners = drawRaffleWinners("seed", tickets, 10)
+	require.Len(t, winners, 3)
+
+	require.Empty(t, drawRaffleWinners("seed", nil, 1))
+}
+
+func TestBuyRaffleTickets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	raffleRepo := mocks.NewMockRaffleRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithRaffles(raffleRepo))
+
+	open := &db.Raffle{
+		RaffleID:    1,
+		ItemType:    "hoody",
+		Prizes:      1,
+		TicketPrice: 10,
+		MaxTickets:  3,
+		Status:      models.RaffleOpen,
+		DrawsAt:     mockNow.Add(time.Hour),
+	}
+	ticket := &db.RaffleTicket{TicketID: 1, RaffleID: 1, Username: mockUser1.Username, Price: 10}
+
+	testCases := []struct {
+		name         string
+		quantity     int32
+		mockBehavior func()
+		expLen       int
+		expErr       error
+	}{
+		{
+			name:     "OK",
+			quantity: 2,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				raffleRepo.EXPECT().
+					GetRaffleForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				raffleRepo.EXPECT().
+					CountUserTickets(gomock.Any(), int32(1), mockUser1.Username).
+					Return(int32(1), nil)
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-20).
+					Return(&mockUser1, nil)
+				raffleRepo.EXPECT().
+					CreateTicket(gomock.Any(), int32(1), mockUser1.Username, int32(10)).
+					Return(ticket, nil).
+					Times(2)
+				mock.ExpectCommit()
+			},
+			expLen: 2,
+		},
+		{
+			name:     "Err Ticket Limit",
+			quantity: 2,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				raffleRepo.EXPECT().
+					GetRaffleForUpdate(gomock.Any(), int32(1)).
+					Return(open, nil)
+				raffleRepo.EXPECT().
+					CountUserTickets(gomock.Any(), int32(1), mockUser1.Username).
+					Return(int32(2), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewConflict("ticket limit reached", ErrTicketLimitReached).
+				WithDetails(map[string]int32{"remaining": 1}),
+		},
+		{
+			name:     "Err Closed",
+			quantity: 1,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				drawn := *open
+				drawn.Status = models.RaffleDrawn
+				raffleRepo.EXPECT().
+					GetRaffleForUpdate(gomock.Any(), int32(1)).
+					Return(&drawn, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("raffle closed", ErrRaffleClosed),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			tickets, err := srv.BuyRaffleTickets(context.Background(), mockUser1.Username, 1, tc.quantity)
+			require.Equal(t, tc.expErr, err)
+			require.Len(t, tickets, tc.expLen)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDrawRaffles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	raffleRepo := mocks.NewMockRaffleRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+	orderRepo := mocks.NewMockOrderRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, nil, nil, nil, nil,
+		WithClock(mockClock), WithRaffles(raffleRepo), WithNotifications(notificationRepo), WithOrders(orderRepo))
+
+	due := &db.Raffle{RaffleID: 1, ItemType: "hoody", Prizes: 2, Seed: "seed", Status: models.RaffleOpen}
+	drawn := &db.Raffle{RaffleID: 2, ItemType: "cup", Prizes: 1, Seed: "seed", Status: models.RaffleDrawn}
+	// single participant can win only one prize
+	tickets := []*db.RaffleTicket{
+		{TicketID: 1, RaffleID: 1, Username: mockUser1.Username},
+		{TicketID: 2, RaffleID: 1, Username: mockUser1.Username},
+	}
+
+	raffleRepo.EXPECT().
+		GetDueRaffles(gomock.Any(), mockNow).
+		Return([]*db.Raffle{due, drawn}, nil)
+	mock.ExpectBegin()
+	raffleRepo.EXPECT().
+		GetRaffleForUpdate(gomock.Any(), int32(1)).
+		Return(due, nil)
+	raffleRepo.EXPECT().
+		ListTickets(gomock.Any(), int32(1)).
+		Return(tickets, nil)
+	userRepo.EXPECT().
+		GetUser(gomock.Any(), mockUser1.Username).
+		Return(&mockUser1, nil)
+	inventoryRepo.EXPECT().
+		AddItemToInventory(gomock.Any(), mockUser1.UserID, "hoody", "").
+		Return(nil)
+	orderRepo.EXPECT().
+		CreateOrder(gomock.Any(), &models.NewOrder{Username: mockUser1.Username, Item: "hoody"}).
+		Return(&db.Order{}, nil)
+	raffleRepo.EXPECT().
+		SetTicketWon(gomock.Any(), gomock.Any()).
+		Return(nil)
+	notificationRepo.EXPECT().
+		CreateNotification(gomock.Any(), mockUser1.Username, models.NotificationRaffle, gomock.Any()).
+		Return(&db.Notification{}, nil)
+	raffleRepo.EXPECT().
+		SetDrawn(gomock.Any(), int32(1)).
+		Return(nil)
+	mock.ExpectCommit()
+	// drawn by concurrent worker
+	mock.ExpectBegin()
+	raffleRepo.EXPECT().
+		GetRaffleForUpdate(gomock.Any(), int32(2)).
+		Return(drawn, nil)
+	mock.ExpectRollback()
+
+	require.NoError(t, srv.DrawRaffles(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,10 +7,15 @@ import (
 	"fmt"
 	"time"
 
+	db "github.com/myacey/avito-shop/db/sqlc"
 	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/authn"
+	"github.com/myacey/avito-shop/internal/credentials"
+	"github.com/myacey/avito-shop/internal/fraud"
 	"github.com/myacey/avito-shop/internal/hasher"
 	"github.com/myacey/avito-shop/internal/jwttoken"
 	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/oidc"
 	"github.com/myacey/avito-shop/internal/repository"
 )
 
@@ -18,24 +23,153 @@ var (
 	ErrInvalidPassword = errors.New("invalid password")
 	ErrNotEnoughMoney  = errors.New("not enough money on account")
 	ErrInvalidToken    = errors.New("invalid auth token")
+	ErrFeatureDisabled = errors.New("feature disabled")
 )
 
 const sessionKeyTTL = time.Duration(24 * time.Hour)
 
 type Interface interface {
 	// /api/auth
-	AuthorizeUser(c context.Context, username, password string) (string, error)
+	AuthorizeUser(c context.Context, username, password, ip string) (string, error)
 
 	CheckAuthToken(c context.Context, token string) (string, error)
+	CheckAPIKey(c context.Context, key, scope string) (string, error)
+
+	// /api/oidc
+	StartOIDCLogin(c context.Context) (string, error)
+	FinishOIDCLogin(c context.Context, code, state string) (string, error)
+
+	// /api/password
+	ChangePassword(c context.Context, username, oldPassword, newPassword, ip string) (string, error)
+	ResetPassword(c context.Context, token, newPassword string) (string, error)
 
 	// /api/info
 	GetFullUserInfo(c context.Context, username string) (*models.User, error)
 
 	// /api/sendCoin
-	SendCoin(c context.Context, fromUsername string, toUsername string, amount int32) error
+	SendCoin(c context.Context, fromUsername string, toUsername string, amount int32) (*models.TransferResult, error)
+
+	// /api/items
+	GetCatalog(c context.Context, username string) ([]*models.Item, error)
 
 	// /api/buy/{item}
-	BuyItem(c context.Context, username string, itemName string) error
+	BuyItem(c context.Context, username, itemName, sku, promoCode string) error
+
+	// /api/sendItem
+	SendItem(c context.Context, fromUsername, toUsername, itemName, variant string, quantity int32) error
+
+	// /api/gift
+	BuyGift(c context.Context, fromUsername, toUsername, itemName, message string) error
+
+	// /api/market/listings
+	GetListings(c context.Context, itemName string) ([]*models.Listing, error)
+	ListItem(c context.Context, sellerUsername, itemName, variant string, quantity, price int32) (*models.Listing, error)
+	BuyListing(c context.Context, buyerUsername string, listingID int32) (*models.Listing, error)
+	CancelListing(c context.Context, sellerUsername string, listingID int32) (*models.Listing, error)
+
+	// /api/auctions
+	GetAuctions(c context.Context) ([]*models.Auction, error)
+	GetAuction(c context.Context, auctionID int32) (*models.Auction, error)
+	PlaceBid(c context.Context, username string, auctionID, amount int32) (*models.Bid, error)
+
+	// /api/bundles
+	GetBundles(c context.Context) ([]*models.Bundle, error)
+	BuyBundle(c context.Context, username, name string) error
+
+	// /api/orders
+	GetOrders(c context.Context, username string) ([]*models.Order, error)
+	GetPickupLocations(c context.Context) ([]string, error)
+	SetPickupLocation(c context.Context, username string, orderID int32, location string) (*models.Order, error)
+	CancelOrder(c context.Context, username string, orderID int32) (*models.Order, error)
+
+	// /api/preorders
+	GetPreorderBatches(c context.Context) ([]*models.PreorderBatch, error)
+	Preorder(c context.Context, username string, batchID int32) (*models.Preorder, error)
+	GetPreorders(c context.Context, username string) ([]*models.Preorder, error)
+	CancelPreorder(c context.Context, username string, preorderID int32) (*models.Preorder, error)
+
+	// /api/raffles
+	GetRaffles(c context.Context) ([]*models.Raffle, error)
+	GetRaffle(c context.Context, raffleID int32) (*models.Raffle, error)
+	GetRaffleTickets(c context.Context, raffleID int32) ([]*models.RaffleTicket, error)
+	BuyRaffleTickets(c context.Context, username string, raffleID, quantity int32) ([]*models.RaffleTicket, error)
+
+	// /api/wishlist
+	GetWishlist(c context.Context, username string) ([]*models.WishlistItem, error)
+	AddToWishlist(c context.Context, username, itemName string) (*models.WishlistItem, error)
+	RemoveFromWishlist(c context.Context, username, itemName string) error
+
+	// /api/notifications
+	GetNotifications(c context.Context, username string) ([]*models.Notification, error)
+	ReadNotifications(c context.Context, username string) error
+
+	// /api/admin/limits/{username}
+	GetTransferLimits(c context.Context, username string) (*models.TransferLimits, error)
+	SetTransferLimitOverride(c context.Context, username string, override *models.TransferLimitOverride) (*models.TransferLimits, error)
+	DeleteTransferLimitOverride(c context.Context, username string) error
+
+	// /api/admin/users/{username}
+	CreatePasswordReset(c context.Context, adminUsername, username string) (*models.PasswordReset, error)
+	UnlockUser(c context.Context, username string) error
+	GrantCoins(c context.Context, grantedBy, username string, amount int32, reason string) error
+
+	// /api/admin/api-keys
+	CreateAPIKey(c context.Context, adminUsername string, key *models.NewAPIKey) (*models.APIKey, error)
+	ListAPIKeys(c context.Context) ([]*models.APIKey, error)
+	RevokeAPIKey(c context.Context, keyID int32) error
+
+	// /api/admin/auctions
+	CreateAuction(c context.Context, adminUsername, itemName string, quantity, minBid int32, endsAt time.Time) (*models.Auction, error)
+
+	// /api/admin/items/{item}/variants
+	CreateVariant(c context.Context, itemName string, variant *models.NewVariant) (*models.Variant, error)
+	SetVariantStock(c context.Context, sku string, stock int32) (*models.Variant, error)
+
+	// /api/admin/items/{item}/limit
+	SetPurchaseLimit(c context.Context, limit *models.PurchaseLimit) (*models.PurchaseLimit, error)
+	DeletePurchaseLimit(c context.Context, itemName string) error
+
+	// /api/admin/preorder-batches
+	CreatePreorderBatch(c context.Context, adminUsername string, batch *models.NewPreorderBatch) (*models.PreorderBatch, error)
+	MarkPreorderStockArrived(c context.Context, batchID, quantity int32) (*models.PreorderAllocation, error)
+
+	// /api/admin/raffles
+	CreateRaffle(c context.Context, adminUsername string, raffle *models.NewRaffle) (*models.Raffle, error)
+
+	// /api/admin/bundles
+	CreateBundle(c context.Context, adminUsername string, bundle *models.NewBundle) (*models.Bundle, error)
+	DeactivateBundle(c context.Context, name string) (*models.Bundle, error)
+
+	// /api/admin/orders
+	ListOrders(c context.Context, status string) ([]*models.Order, error)
+	UpdateOrderStatus(c context.Context, orderID int32, status string) (*models.Order, error)
+
+	// /api/admin/promo-codes
+	CreatePromoCode(c context.Context, adminUsername string, promo *models.NewPromoCode) (*models.PromoCode, error)
+	ListPromoCodes(c context.Context) ([]*models.PromoCode, error)
+	DisablePromoCode(c context.Context, code string) (*models.PromoCode, error)
+
+	// /api/admin/price-schedules
+	CreatePriceSchedule(c context.Context, adminUsername string, schedule *models.NewPriceSchedule) (*models.PriceSchedule, error)
+	ListPriceSchedules(c context.Context, itemName string) ([]*models.PriceSchedule, error)
+	CancelPriceSchedule(c context.Context, scheduleID int32) (*models.PriceSchedule, error)
+
+	// /api/admin/fraud/cases
+	ListFraudCases(c context.Context, status string) ([]*models.FraudCase, error)
+	ResolveFraudCase(c context.Context, caseID int32, adminUsername string, approve bool) (*models.FraudCase, error)
+
+	// /api/finance/approvals
+	ListTransferApprovals(c context.Context, status string) ([]*models.TransferApproval, error)
+	ResolveTransferApproval(c context.Context, approvalID int32, adminUsername string, approve bool) (*models.TransferApproval, error)
+
+	// workers
+	ExpireCoins(c context.Context) error
+	ReleaseExpiredHolds(c context.Context) error
+	ExpireListings(c context.Context) error
+	CloseAuctions(c context.Context) error
+	NotifyWishlists(c context.Context) error
+	ExpirePreorders(c context.Context) error
+	DrawRaffles(c context.Context) error
 }
 
 type Service struct {
@@ -49,7 +183,64 @@ type Service struct {
 	tokenMaker  jwttoken.TokenMakerInterface
 	sessionRepo repository.SessionRepository
 
-	hasher hasher.Hasher
+	hasher           hasher.Hasher
+	authenticator    authn.Authenticator
+	credentialPolicy *credentials.Policy
+
+	now func() time.Time
+
+	coinLotRepo        repository.CoinLotRepository
+	coinLifetimeMonths int
+	coinExpiryNotice   time.Duration
+
+	transferLimitRepo     repository.TransferLimitRepository
+	defaultTransferLimits models.TransferLimits
+
+	fraudDetector *fraud.Detector
+	fraudCaseRepo repository.FraudCaseRepository
+
+	approvalRepo      repository.TransferApprovalRepository
+	approvalThreshold int32
+	approvalTimeout   time.Duration
+
+	giftRepo         repository.GiftRepository
+	notificationRepo repository.NotificationRepository
+
+	itemTransferRepo repository.ItemTransferRepository
+
+	listingRepo      repository.ListingRepository
+	marketFeePercent int32
+	listingTTL       time.Duration
+
+	auctionRepo repository.AuctionRepository
+
+	orderRepo       repository.OrderRepository
+	pickupLocations []string
+	promoCodeRepo   repository.PromoCodeRepository
+
+	bundleRepo repository.BundleRepository
+
+	purchaseLimitRepo repository.PurchaseLimitRepository
+
+	wishlistRepo repository.WishlistRepository
+
+	preorderRepo repository.PreorderRepository
+
+	raffleRepo repository.RaffleRepository
+
+	passwordResetRepo repository.PasswordResetRepository
+	passwordResetTTL  time.Duration
+
+	loginAttemptRepo repository.LoginAttemptRepository
+	loginThrottle    models.LoginThrottle
+
+	identityProvider     oidc.IdentityProvider
+	oidcStateRepo        repository.OIDCStateRepository
+	externalIdentityRepo repository.ExternalIdentityRepository
+
+	apiKeyRepo      repository.APIKeyRepository
+	rateLimitRepo   repository.RateLimitRepository
+	apiKeyRateLimit int32
 }
 
 func NewService(
@@ -61,8 +252,9 @@ func NewService(
 	rsr repository.SessionRepository,
 	tokMaker jwttoken.TokenMakerInterface,
 	hasher hasher.Hasher,
+	opts ...Option,
 ) Interface {
-	return &Service{
+	s := &Service{
 		dbConn:        dbConn,
 		userRepo:      ur,
 		transferRepo:  tr,
@@ -71,23 +263,50 @@ func NewService(
 		sessionRepo:   rsr,
 		tokenMaker:    tokMaker,
 		hasher:        hasher,
+		authenticator: authn.NewLocal(hasher),
+		now:           time.Now,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-func (s *Service) createUser(c context.Context, username, password string) (string, error) {
-	hashedPassword, err := s.hasher.Generate(password)
+// beginTx starts transaction, repository calls with
+// returned context run in it.
+func (s *Service) beginTx(c context.Context) (context.Context, *sql.Tx, error) {
+	tx, err := s.dbConn.BeginTx(c, nil)
 	if err != nil {
-		if errors.Is(err, hasher.ErrToLong) {
-			return "", apperror.NewBadReq("password too long", err)
+		return c, nil, err
+	}
+	return repository.WithTx(c, tx), tx, nil
+}
+
+func (s *Service) createUser(c context.Context, username, password string) (string, error) {
+	if s.credentialPolicy != nil {
+		if errs := s.credentialPolicy.Check(username, password); errs != nil {
+			return "", invalidCredentials(errs)
 		}
-		return "", apperror.NewInternal("failed to generate password hash", err)
 	}
 
-	_, err = s.userRepo.CreateUser(c, username, string(hashedPassword))
+	hashedPassword, err := s.hashPassword(c, password)
+	if err != nil {
+		return "", err
+	}
+
+	usr, err := s.userRepo.CreateUser(c, username, hashedPassword)
 	if err != nil {
 		return "", apperror.NewInternal("failed to create user", err)
 	}
 
+	// start coins are the first lot
+	if s.coinLotsEnabled() {
+		if err = s.grantCoins(c, usr.UserID, usr.Coins); err != nil {
+			return "", err
+		}
+	}
+
 	// create auth token
 	token, err := s.tokenMaker.CreateToken(username)
 	if err != nil {
@@ -100,41 +319,82 @@ func (s *Service) createUser(c context.Context, username, password string) (stri
 	return token, nil
 }
 
+// createUserWithoutPassword creates user with start coins
+// who can't log in with password.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) createUserWithoutPassword(c context.Context, username string) (*db.User, error) {
+	// empty password never matches hash
+	usr, err := s.userRepo.CreateUser(c, username, "")
+	if err != nil {
+		if errors.Is(err, repository.ErrUserAlreadyExists) {
+			return nil, apperror.NewConflict("username already taken", err)
+		}
+		return nil, apperror.NewInternal("failed to create user", err)
+	}
+
+	if s.coinLotsEnabled() {
+		if err = s.grantCoins(c, usr.UserID, usr.Coins); err != nil {
+			return nil, err
+		}
+	}
+	return usr, nil
+}
+
 // Authorization checks user credentials, creates new dbUser if needed.
-func (s *Service) AuthorizeUser(c context.Context, username, password string) (string, error) {
+func (s *Service) AuthorizeUser(c context.Context, username, password, ip string) (string, error) {
+	if errs := credentials.Required(username, password); errs != nil {
+		return "", invalidCredentials(errs)
+	}
+	if err := s.checkLoginLock(c, username, ip); err != nil {
+		return "", err
+	}
+
 	dbUsr, err := s.userRepo.GetUser(c, username)
 
 	// unknown error
 	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
 		return "", apperror.NewInternal("failed to get user", err)
 	}
-
-	// user dont exists -> generate new one
 	if errors.Is(err, repository.ErrUserNotFound) {
-		return s.createUser(c, username, password)
+		dbUsr = nil
 	}
 
-	// User found, check him
-	// check password
-	if err = s.hasher.Compare(dbUsr.Password, password); err != nil {
-		if errors.Is(err, hasher.ErrDontCompare) {
+	profile, err := s.authenticator.Authenticate(c, username, password, dbUsr)
+	if err != nil {
+		// user dont exists -> generate new one
+		if errors.Is(err, authn.ErrUnknownUser) {
+			return s.createUser(c, username, password)
+		}
+		if errors.Is(err, authn.ErrInvalidCredentials) {
+			if lockErr := s.loginFailed(c, username, ip); lockErr != nil {
+				return "", lockErr
+			}
 			return "", apperror.NewNotFound("user not found", err)
 		}
-		return "", apperror.NewInternal("failed to compare passwords", err)
+		if errors.Is(err, hasher.ErrBusy) {
+			return "", hasherBusy(err)
+		}
+		return "", apperror.NewInternal("failed to authenticate user", err)
 	}
 
-	// generate token
-	newToken, err := s.tokenMaker.CreateToken(username)
-	if err != nil {
-		return "", apperror.NewInternal("failed to create token", err)
+	// known only to directory
+	if dbUsr == nil {
+		if dbUsr, err = s.provisionDirectoryUser(c, username); err != nil {
+			return "", err
+		}
 	}
 
-	// save token
-	if err = s.sessionRepo.CreateToken(c, username, newToken, sessionKeyTTL); err != nil {
-		return "", apperror.NewInternal("failed to save token", err)
+	if err = s.loginSucceeded(c, username); err != nil {
+		return "", err
+	}
+	if profile == nil {
+		s.rehashPassword(c, dbUsr, password)
+	} else {
+		s.syncProfile(c, dbUsr, profile)
 	}
 
-	return newToken, nil
+	return s.issueToken(c, username)
 }
 
 // CheckAuthToken extracts username from jwt payload, gets a dbToken
@@ -191,6 +451,7 @@ func (s *Service) fillInventory(c context.Context, usr *models.User, userID int3
 		usr.Inventory[i] = &models.InventoryItem{
 			Type:     v.ItemType,
 			Quantity: v.Quantity,
+			Variant:  v.Variant,
 		}
 	}
 
@@ -218,6 +479,21 @@ func (s *Service) fillEntries(c context.Context, usr *models.User, username stri
 	}
 	m["received"] = income
 	m["sent"] = outcome
+	if s.coinLotsEnabled() {
+		if err = s.fillExpiredEntries(c, m, username); err != nil {
+			return err
+		}
+	}
+	if s.giftsEnabled() {
+		if err = s.fillGiftEntries(c, m, username); err != nil {
+			return err
+		}
+	}
+	if s.itemTransfersEnabled() {
+		if err = s.fillItemEntries(c, m, username); err != nil {
+			return err
+		}
+	}
 	usr.EntryHistory = m
 
 	return nil
@@ -236,7 +512,10 @@ func (s *Service) GetFullUserInfo(c context.Context, username string) (*models.U
 	usr := &models.User{
 		ID:           dbUsr.UserID,
 		Username:     dbUsr.Username,
+		DisplayName:  dbUsr.DisplayName,
+		Department:   dbUsr.Department,
 		Coins:        dbUsr.Coins,
+		HeldCoins:    dbUsr.HeldCoins,
 		Inventory:    nil,
 		EntryHistory: nil,
 	}
@@ -249,74 +528,256 @@ func (s *Service) GetFullUserInfo(c context.Context, username string) (*models.U
 		return nil, err
 	}
 
+	if s.coinLotsEnabled() {
+		if err = s.fillExpiringSoon(c, usr); err != nil {
+			return nil, err
+		}
+	}
+
 	return usr, nil
 }
 
 // SendCoins runs a transacion to create new transaction and update user's coins.
-func (s *Service) SendCoin(c context.Context, fromUsername string, toUsername string, amount int32) error {
+// Suspicious transfers may be held for admin review instead,
+// large ones wait for finance approval.
+func (s *Service) SendCoin(c context.Context, fromUsername string, toUsername string, amount int32) (*models.TransferResult, error) {
 	if amount <= 0 {
-		return apperror.NewBadReq("send coins amont must be positive", nil)
+		return nil, apperror.NewBadReq("send coins amont must be positive", nil)
 	}
 
-	tx, err := s.dbConn.BeginTx(c, nil)
+	c, tx, err := s.beginTx(c)
 	if err != nil {
-		return apperror.NewInternal("failed to send coins", err)
+		return nil, apperror.NewInternal("failed to send coins", err)
 	}
 	defer tx.Rollback()
 
-	_, err = s.userRepo.UpdateTwoUsersBalance(c, fromUsername, toUsername, amount)
+	// sender is locked till commit, so concurrent transfers
+	// are checked against limits one by one
+	if _, _, err = s.lockTwoUsers(c, fromUsername, toUsername); err != nil {
+		return nil, err
+	}
+
+	if s.transferLimitsEnabled() {
+		if err = s.checkTransferLimits(c, fromUsername, toUsername, amount, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	var verdict *fraud.Verdict
+	if s.fraudEnabled() {
+		verdict, err = s.checkFraud(c, fromUsername, toUsername, amount)
+		if err != nil {
+			return nil, err
+		}
+		if verdict.Action == fraud.ActionHold {
+			res, err := s.holdSuspiciousTransfer(c, fromUsername, toUsername, amount, verdict)
+			if err != nil {
+				return nil, err
+			}
+			return res, tx.Commit()
+		}
+	}
+
+	res := &models.TransferResult{Status: models.TransferCompleted}
+	var transfer *db.Transfer
+	if s.approvalRequired(amount) {
+		res, err = s.requestApproval(c, fromUsername, toUsername, amount)
+	} else {
+		transfer, err = s.transferCoins(c, fromUsername, toUsername, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if verdict != nil && verdict.Action == fraud.ActionFlag {
+		if err = s.flagSuspiciousTransfer(c, fromUsername, toUsername, amount, transfer, verdict); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, tx.Commit()
+}
+
+// transferCoins updates both balances and saves transfer.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) transferCoins(c context.Context, fromUsername string, toUsername string, amount int32) (*db.Transfer, error) {
+	usrs, err := s.userRepo.UpdateTwoUsersBalance(c, fromUsername, toUsername, amount)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return apperror.NewNotFound(fmt.Sprintf("users not found: %s, %s", fromUsername, toUsername), err)
+			return nil, apperror.NewNotFound(fmt.Sprintf("users not found: %s, %s", fromUsername, toUsername), err)
 		}
-		return apperror.NewInternal("failed to make money transaction", err)
+		return nil, apperror.NewInternal("failed to make money transaction", err)
 	}
-	_, err = s.transferRepo.CreateMoneyTransfer(c, fromUsername, toUsername, amount)
+
+	// received coins become a new lot
+	if s.coinLotsEnabled() {
+		if err = s.moveCoinLots(c, usrs, fromUsername, toUsername, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	transfer, err := s.transferRepo.CreateMoneyTransfer(c, fromUsername, toUsername, amount)
 	if err != nil {
-		return apperror.NewInternal("failed to create transfer", err)
+		return nil, apperror.NewInternal("failed to create transfer", err)
 	}
 
-	return tx.Commit()
+	return transfer, nil
 }
 
-// BuyItem adds an item to user's inventory.
-func (s *Service) BuyItem(c context.Context, username, itemName string) error {
-	itemToBuy, err := s.storeRepo.GetItemInfo(c, itemName)
+// BuyItem adds an item to user's inventory. Items with
+// variants require sku, optional promoCode discounts the price.
+func (s *Service) BuyItem(c context.Context, username, itemName, sku, promoCode string) error {
+	if promoCode != "" && !s.promoCodesEnabled() {
+		return apperror.NewNotFound("promo codes disabled", ErrFeatureDisabled)
+	}
+
+	itemToBuy, err := s.getItemInfo(c, itemName)
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidItemName) {
 			return apperror.NewBadReq("invalid item name", err)
 		}
 		return apperror.NewInternal("failed to get item info", err)
 	}
+	if err = checkVariant(itemToBuy, sku); err != nil {
+		return err
+	}
 
-	tx, err := s.dbConn.BeginTx(c, nil)
+	c, tx, err := s.beginTx(c)
 	if err != nil {
 		return apperror.NewInternal("failed to buy item", err)
 	}
 	defer tx.Rollback()
 
-	dbUsr, err := s.userRepo.GetUserForUpdate(c, username)
+	// buyer row is locked till commit, so concurrent purchases
+	// of the same user are checked against limit one by one
+	dbUsr, err := s.lockUser(c, username)
 	if err != nil {
-		if errors.Is(err, repository.ErrUserNotFound) {
-			return apperror.NewNotFound("user not found", err)
+		return err
+	}
+	if err = s.checkPurchaseLimit(c, username, itemName, 1); err != nil {
+		return err
+	}
+
+	price := itemToBuy.CurrentPrice
+	if sku != "" {
+		if price, err = variantPrice(itemToBuy, sku); err != nil {
+			return err
 		}
-		return apperror.NewInternal("failed to get user", err)
 	}
 
-	newCoinsCount := dbUsr.Coins - int32(itemToBuy.ItemPrice)
-	if newCoinsCount < 0 {
-		return apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+	var discount int32
+	if promoCode != "" {
+		discount, err = s.applyPromoCode(c, username, itemName, price, promoCode)
+		if err != nil {
+			return err
+		}
 	}
 
-	_, err = s.userRepo.UpdateBalance(c, dbUsr.UserID, newCoinsCount)
+	lots, err := s.chargeUser(c, dbUsr, price-discount)
 	if err != nil {
-		return apperror.NewInternal("failed to update balance", err)
+		return err
+	}
+	if sku != "" {
+		if err = s.takeVariant(c, sku, 1); err != nil {
+			return err
+		}
 	}
 
-	err = s.inventoryRepo.AddItemToInventory(c, dbUsr.UserID, itemName)
+	err = s.inventoryRepo.AddItemToInventory(c, dbUsr.UserID, itemName, sku)
 	if err != nil {
 		return apperror.NewInternal("failed to add item to inventory", err)
 	}
 
+	if s.ordersEnabled() {
+		_, err = s.orderRepo.CreateOrder(c, &models.NewOrder{
+			Username:   username,
+			Item:       itemName,
+			Variant:    sku,
+			Price:      price - discount,
+			Discount:   discount,
+			PromoCode:  normalizePromoCode(promoCode),
+			ScheduleID: itemToBuy.ScheduleID,
+			CoinLots:   lots,
+		})
+		if err != nil {
+			return apperror.NewInternal("failed to create order", err)
+		}
+	}
+
 	return tx.Commit()
 }
+
+// payForItem charges user for purchase.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) payForItem(c context.Context, username string, price int32) (*db.User, error) {
+	dbUsr, err := s.lockUser(c, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.chargeUser(c, dbUsr, price); err != nil {
+		return nil, err
+	}
+	return dbUsr, nil
+}
+
+// lockUser gets user, row stays locked till end of transaction.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) lockUser(c context.Context, username string) (*db.User, error) {
+	dbUsr, err := s.userRepo.GetUserForUpdate(c, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get user", err)
+	}
+	return dbUsr, nil
+}
+
+// lockTwoUsers gets sender and recipient, rows are locked in fixed
+// order and stay locked till end of transaction, so transactions
+// between the same users in both directions don't deadlock.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) lockTwoUsers(c context.Context, fromUsername, toUsername string) (from, to *db.User, err error) {
+	usrs, err := s.userRepo.LockTwoUsers(c, fromUsername, toUsername)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, nil, apperror.NewNotFound(fmt.Sprintf("users not found: %s, %s", fromUsername, toUsername), err)
+		}
+		return nil, nil, apperror.NewInternal("failed to get users", err)
+	}
+
+	for _, u := range usrs {
+		if u.Username == fromUsername {
+			from = u
+		}
+		if u.Username == toUsername {
+			to = u
+		}
+	}
+	return from, to, nil
+}
+
+// chargeUser takes price from user locked by lockUser and returns
+// coin lots it was paid from, they are saved with order.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) chargeUser(c context.Context, dbUsr *db.User, price int32) ([]*models.CoinLot, error) {
+	newCoinsCount := dbUsr.Coins - price
+	if newCoinsCount < 0 {
+		return nil, apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+	}
+
+	if _, err := s.userRepo.UpdateBalance(c, dbUsr.UserID, newCoinsCount); err != nil {
+		return nil, apperror.NewInternal("failed to update balance", err)
+	}
+
+	if s.coinLotsEnabled() {
+		return s.consumeCoins(c, dbUsr.UserID, price)
+	}
+	return nil, nil
+}
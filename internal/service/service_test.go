@@ -12,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	db "github.com/myacey/avito-shop/db/sqlc"
 	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/authn"
 	"github.com/myacey/avito-shop/internal/hasher"
 	"github.com/myacey/avito-shop/internal/jwttoken"
 	"github.com/myacey/avito-shop/internal/mocks"
@@ -25,7 +26,7 @@ var (
 	mockUser1 = db.User{UserID: 1, Username: "mockuser1", Password: "mockpassword", Coins: 1000}
 	mockUser2 = db.User{UserID: 2, Username: "mockuser2", Password: "mockpassword", Coins: 1000}
 
-	mockItem = &db.Item{ItemID: 1, ItemType: "mockitem", ItemPrice: 10}
+	mockItem = &models.Item{Type: "mockitem", Price: 10, CurrentPrice: 10}
 	// Inventory
 	mockInventory1  = &db.Inventory{InventoryID: 1, UserID: mockUser1.UserID, ItemType: "mockInventory1", Quantity: 10}
 	mockInventory2  = &db.Inventory{InventoryID: 2, UserID: mockUser1.UserID, ItemType: "mockInventory2", Quantity: 10}
@@ -73,7 +74,40 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				hashGen.EXPECT().
-					Compare(mockUser1.Password, mockUser1.Password).
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
+					Return(nil)
+				hashGen.EXPECT().
+					NeedsRehash(mockUser1.Password).
+					Return(false)
+				jwtToken.EXPECT().
+					CreateToken(username).
+					Return("valid", nil)
+				sessionRepo.EXPECT().
+					CreateToken(gomock.Any(), username, "valid", 24*time.Hour).
+					Return(nil)
+			},
+			expToken: "valid",
+			expErr:   nil,
+		},
+		{
+			name:     "OK Login Rehash",
+			username: mockUser1.Username,
+			password: mockUser1.Password,
+			mockBehavior: func(username, password string) {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), username).
+					Return(&mockUser1, nil)
+				hashGen.EXPECT().
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
+					Return(nil)
+				hashGen.EXPECT().
+					NeedsRehash(mockUser1.Password).
+					Return(true)
+				hashGen.EXPECT().
+					Generate(gomock.Any(), password).
+					Return("$argon2id$newhash", nil)
+				userRepo.EXPECT().
+					UpdatePassword(gomock.Any(), username, "$argon2id$newhash").
 					Return(nil)
 				jwtToken.EXPECT().
 					CreateToken(username).
@@ -94,7 +128,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return(mockUser1.Password, nil)
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), username, password).
@@ -118,7 +152,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return(mockUser1.Password, nil)
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), username, password).
@@ -136,7 +170,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return("", hasher.ErrToLong)
 			},
 			expToken: "",
@@ -151,7 +185,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return("", ErrMock)
 			},
 			expToken: "",
@@ -166,7 +200,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return(mockUser1.Password, nil)
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), username, password).
@@ -187,7 +221,7 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(nil, repository.ErrUserNotFound)
 				hashGen.EXPECT().
-					Generate(mockUser1.Password).
+					Generate(gomock.Any(), mockUser1.Password).
 					Return(mockUser1.Password, nil)
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), username, password).
@@ -223,8 +257,11 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				hashGen.EXPECT().
-					Compare(mockUser1.Password, mockUser1.Password).
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
 					Return(nil)
+				hashGen.EXPECT().
+					NeedsRehash(mockUser1.Password).
+					Return(false)
 				jwtToken.EXPECT().
 					CreateToken(username).
 					Return("", ErrMock)
@@ -241,8 +278,11 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				hashGen.EXPECT().
-					Compare(mockUser1.Password, mockUser1.Password).
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
 					Return(nil)
+				hashGen.EXPECT().
+					NeedsRehash(mockUser1.Password).
+					Return(false)
 				jwtToken.EXPECT().
 					CreateToken(username).
 					Return("valid", nil)
@@ -262,11 +302,11 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				hashGen.EXPECT().
-					Compare(mockUser1.Password, mockUser1.Password).
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
 					Return(hasher.ErrDontCompare)
 			},
 			expToken: "",
-			expErr:   apperror.NewNotFound("user not found", hasher.ErrDontCompare),
+			expErr:   apperror.NewNotFound("user not found", authn.ErrInvalidCredentials),
 		},
 		{
 			name:     "Err Unknown Compare Password Found User",
@@ -277,11 +317,26 @@ func TestAuthorizeUser(t *testing.T) {
 					GetUser(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				hashGen.EXPECT().
-					Compare(mockUser1.Password, mockUser1.Password).
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
 					Return(ErrMock)
 			},
 			expToken: "",
-			expErr:   apperror.NewInternal("failed to compare passwords", ErrMock),
+			expErr:   apperror.NewInternal("failed to authenticate user", ErrMock),
+		},
+		{
+			name:     "Err Hasher Busy",
+			username: mockUser1.Username,
+			password: mockUser1.Password,
+			mockBehavior: func(username, password string) {
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), username).
+					Return(&mockUser1, nil)
+				hashGen.EXPECT().
+					Compare(gomock.Any(), mockUser1.Password, mockUser1.Password).
+					Return(hasher.ErrBusy)
+			},
+			expToken: "",
+			expErr:   apperror.NewServiceUnavailable("server is busy, try again later", hasher.ErrBusy).WithRetryAfter(time.Second),
 		},
 	}
 
@@ -289,7 +344,7 @@ func TestAuthorizeUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.username, tc.password)
 
-			tok, err := srv.AuthorizeUser(context.Background(), tc.username, tc.password)
+			tok, err := srv.AuthorizeUser(context.Background(), tc.username, tc.password, "127.0.0.1")
 
 			require.Equal(t, tc.expToken, tok)
 			require.Equal(t, tc.expErr, err)
@@ -478,8 +533,8 @@ func TestGetFullUserInfo(t *testing.T) {
 				Username: mockUser1.Username,
 				Coins:    mockUser1.Coins,
 				Inventory: []*models.InventoryItem{
-					{mockInventory1.ItemType, mockInventory1.Quantity},
-					{mockInventory2.ItemType, mockInventory2.Quantity},
+					{Type: mockInventory1.ItemType, Quantity: mockInventory1.Quantity},
+					{Type: mockInventory2.ItemType, Quantity: mockInventory2.Quantity},
 				},
 				EntryHistory: map[string]interface{}{
 					"sent":     []*OutcomeEntry{{mockUser2.Username, tx1.Amount}},
@@ -587,6 +642,9 @@ func TestSendCoin(t *testing.T) {
 			toUsername:   mockUser2.Username,
 			amount:       tx1.Amount,
 			mockBehavior: func(fromUsername, toUsername string, amount int32) {
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), fromUsername, toUsername).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
 				userRepo.EXPECT().
 					UpdateTwoUsersBalance(gomock.Any(), fromUsername, toUsername, amount).
 					Return([]*db.User{}, nil)
@@ -623,19 +681,36 @@ func TestSendCoin(t *testing.T) {
 			amount:       tx1.Amount,
 			mockBehavior: func(fromUsername, toUsername string, amount int32) {
 				userRepo.EXPECT().
-					UpdateTwoUsersBalance(gomock.Any(), fromUsername, toUsername, amount).
-					Return([]*db.User{}, repository.ErrUserNotFound)
+					LockTwoUsers(gomock.Any(), fromUsername, toUsername).
+					Return(nil, repository.ErrUserNotFound)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 			},
 			expErr: apperror.NewNotFound(fmt.Sprintf("users not found: %s, %s", mockUser1.Username, mockUser2.Username), repository.ErrUserNotFound),
 		},
+		{
+			name:         "Err Lock Users",
+			fromUsername: mockUser1.Username,
+			toUsername:   mockUser2.Username,
+			amount:       tx1.Amount,
+			mockBehavior: func(fromUsername, toUsername string, amount int32) {
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), fromUsername, toUsername).
+					Return(nil, ErrMock)
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewInternal("failed to get users", ErrMock),
+		},
 		{
 			name:         "Err Unknown",
 			fromUsername: mockUser1.Username,
 			toUsername:   mockUser2.Username,
 			amount:       tx1.Amount,
 			mockBehavior: func(fromUsername, toUsername string, amount int32) {
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), fromUsername, toUsername).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
 				userRepo.EXPECT().
 					UpdateTwoUsersBalance(gomock.Any(), fromUsername, toUsername, amount).
 					Return([]*db.User{}, ErrMock)
@@ -650,6 +725,9 @@ func TestSendCoin(t *testing.T) {
 			toUsername:   mockUser2.Username,
 			amount:       tx1.Amount,
 			mockBehavior: func(fromUsername, toUsername string, amount int32) {
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), fromUsername, toUsername).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
 				userRepo.EXPECT().
 					UpdateTwoUsersBalance(gomock.Any(), fromUsername, toUsername, amount).
 					Return([]*db.User{}, nil)
@@ -667,7 +745,7 @@ func TestSendCoin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.fromUsername, tc.toUsername, tc.amount)
 
-			err := srv.SendCoin(context.Background(), tc.fromUsername, tc.toUsername, tc.amount)
+			_, err := srv.SendCoin(context.Background(), tc.fromUsername, tc.toUsername, tc.amount)
 
 			require.Equal(t, tc.expErr, err)
 		})
@@ -704,18 +782,21 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectCommit()
 				userRepo.EXPECT().
 					GetUserForUpdate(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				userRepo.EXPECT().
-					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-int32(mockItem.ItemPrice)).
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-mockItem.CurrentPrice).
 					Return(nil, nil)
 				inventoryRepo.EXPECT().
-					AddItemToInventory(gomock.Any(), mockUser1.UserID, itemName).
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, itemName, "").
 					Return(nil)
 			},
 			expErr: nil,
@@ -725,7 +806,7 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(nil, repository.ErrInvalidItemName)
 			},
 			expErr: apperror.NewBadReq("invalid item name", repository.ErrInvalidItemName),
@@ -735,7 +816,7 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(nil, ErrMock)
 			},
 			expErr: apperror.NewInternal("failed to get item info", ErrMock),
@@ -745,8 +826,11 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin().WillReturnError(ErrMock)
 			},
 			expErr: apperror.NewInternal("failed to buy item", ErrMock),
@@ -756,8 +840,11 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 				userRepo.EXPECT().
@@ -771,8 +858,11 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 				userRepo.EXPECT().
@@ -786,8 +876,11 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 				userRepo.EXPECT().
@@ -801,15 +894,18 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 				userRepo.EXPECT().
 					GetUserForUpdate(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				userRepo.EXPECT().
-					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-int32(mockItem.ItemPrice)).
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-mockItem.CurrentPrice).
 					Return(nil, ErrMock)
 			},
 			expErr: apperror.NewInternal("failed to update balance", ErrMock),
@@ -819,18 +915,21 @@ func TestBuyItem(t *testing.T) {
 			username: mockUser1.Username,
 			mockBehavior: func(username, itemName string) {
 				storeRepo.EXPECT().
-					GetItemInfo(gomock.Any(), itemName).
+					GetItemInfo(gomock.Any(), itemName, gomock.Any()).
 					Return(mockItem, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), itemName).
+					Return(nil, nil)
 				mock.ExpectBegin()
 				mock.ExpectRollback()
 				userRepo.EXPECT().
 					GetUserForUpdate(gomock.Any(), username).
 					Return(&mockUser1, nil)
 				userRepo.EXPECT().
-					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-int32(mockItem.ItemPrice)).
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-mockItem.CurrentPrice).
 					Return(nil, nil)
 				inventoryRepo.EXPECT().
-					AddItemToInventory(gomock.Any(), mockUser1.UserID, itemName).
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, itemName, "").
 					Return(ErrMock)
 			},
 			expErr: apperror.NewInternal("failed to add item to inventory", ErrMock),
@@ -841,7 +940,7 @@ func TestBuyItem(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.username, tc.itemName)
 
-			err := srv.BuyItem(context.Background(), tc.username, tc.itemName)
+			err := srv.BuyItem(context.Background(), tc.username, tc.itemName, "", "")
 
 			require.Equal(t, tc.expErr, err)
 		})
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrApprovalResolved = errors.New("transfer approval already resolved")
+	ErrApprovalExpired  = errors.New("transfer approval expired")
+)
+
+func (s *Service) approvalsEnabled() bool {
+	return s.approvalRepo != nil
+}
+
+// approvalRequired checks if transfer must wait for finance admin.
+func (s *Service) approvalRequired(amount int32) bool {
+	return s.approvalsEnabled() && amount > s.approvalThreshold
+}
+
+// requestApproval holds sender's coins until transfer is resolved.
+// Coin lots are consumed only when transfer completes.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) requestApproval(c context.Context, fromUsername, toUsername string, amount int32) (*models.TransferResult, error) {
+	if err := s.holdTransferCoins(c, fromUsername, toUsername, amount); err != nil {
+		return nil, err
+	}
+
+	a, err := s.approvalRepo.CreateApproval(c, fromUsername, toUsername, amount, s.now().Add(s.approvalTimeout))
+	if err != nil {
+		return nil, apperror.NewInternal("failed to create transfer approval", err)
+	}
+
+	return &models.TransferResult{Status: models.TransferPending, ApprovalID: a.ApprovalID}, nil
+}
+
+// holdTransferCoins moves amount of sender's coins to held ones
+// until postponed transfer is resolved. Both users are locked, sender's
+// balance is read again as coins may have been released in this transaction.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) holdTransferCoins(c context.Context, fromUsername, toUsername string, amount int32) error {
+	dbUsr, _, err := s.lockTwoUsers(c, fromUsername, toUsername)
+	if err != nil {
+		return err
+	}
+	if dbUsr.Coins < amount {
+		return apperror.NewBadReq("not enough money", ErrNotEnoughMoney)
+	}
+
+	if _, err = s.userRepo.HoldCoins(c, fromUsername, amount); err != nil {
+		return apperror.NewInternal("failed to hold coins", err)
+	}
+
+	return nil
+}
+
+// releaseHold returns held coins to sender's balance.
+// returns apperror.
+func (s *Service) releaseHold(c context.Context, a *db.TransferApproval) error {
+	if _, err := s.userRepo.ReleaseCoins(c, a.FromUsername, a.Amount); err != nil {
+		return apperror.NewInternal("failed to release coins", err)
+	}
+	return nil
+}
+
+func toTransferApprovalModel(a *db.TransferApproval) *models.TransferApproval {
+	res := &models.TransferApproval{
+		ID:         a.ApprovalID,
+		FromUser:   a.FromUsername,
+		ToUser:     a.ToUsername,
+		Amount:     a.Amount,
+		Status:     a.Status,
+		CreatedAt:  a.CreatedAt,
+		ExpiresAt:  a.ExpiresAt,
+		ResolvedBy: a.ResolvedBy.String,
+	}
+	if a.TransferID.Valid {
+		res.TransferID = &a.TransferID.Int32
+	}
+	if a.ResolvedAt.Valid {
+		res.ResolvedAt = &a.ResolvedAt.Time
+	}
+
+	return res
+}
+
+// ListTransferApprovals returns approvals with status for finance review.
+func (s *Service) ListTransferApprovals(c context.Context, status string) ([]*models.TransferApproval, error) {
+	if !s.approvalsEnabled() {
+		return nil, apperror.NewNotFound("transfer approvals disabled", ErrFeatureDisabled)
+	}
+
+	switch status {
+	case "":
+		status = models.ApprovalPending
+	case models.ApprovalPending, models.ApprovalApproved, models.ApprovalRejected, models.ApprovalExpired:
+	default:
+		return nil, apperror.NewBadReq("invalid approval status", nil)
+	}
+
+	approvals, err := s.approvalRepo.ListApprovals(c, status)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get transfer approvals", err)
+	}
+
+	res := make([]*models.TransferApproval, len(approvals))
+	for i, a := range approvals {
+		res[i] = toTransferApprovalModel(a)
+	}
+
+	return res, nil
+}
+
+// ResolveTransferApproval releases held coins and, if approved,
+// completes the transfer.
+func (s *Service) ResolveTransferApproval(c context.Context, approvalID int32, adminUsername string, approve bool) (*models.TransferApproval, error) {
+	if !s.approvalsEnabled() {
+		return nil, apperror.NewNotFound("transfer approvals disabled", ErrFeatureDisabled)
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to resolve approval", err)
+	}
+	defer tx.Rollback()
+
+	a, err := s.approvalRepo.GetApprovalForUpdate(c, approvalID)
+	if err != nil {
+		if errors.Is(err, repository.ErrApprovalNotFound) {
+			return nil, apperror.NewNotFound("transfer approval not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get transfer approval", err)
+	}
+	if a.Status != models.ApprovalPending {
+		return nil, apperror.NewBadReq("transfer approval already resolved", ErrApprovalResolved)
+	}
+	if approve && !s.now().Before(a.ExpiresAt) {
+		return nil, apperror.NewBadReq("transfer approval expired", ErrApprovalExpired)
+	}
+
+	if approve {
+		if _, _, err = s.lockTwoUsers(c, a.FromUsername, a.ToUsername); err != nil {
+			return nil, err
+		}
+		// limits could be lowered while transfer was waiting
+		if s.transferLimitsEnabled() {
+			if err = s.checkTransferLimits(c, a.FromUsername, a.ToUsername, a.Amount, a.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = s.releaseHold(c, a); err != nil {
+		return nil, err
+	}
+
+	status := models.ApprovalRejected
+	var transferID int32
+	if approve {
+		status = models.ApprovalApproved
+		transfer, err := s.transferCoins(c, a.FromUsername, a.ToUsername, a.Amount)
+		if err != nil {
+			return nil, err
+		}
+		transferID = transfer.TransferID
+	}
+
+	resolved, err := s.approvalRepo.ResolveApproval(c, approvalID, status, adminUsername, transferID)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to resolve approval", err)
+	}
+
+	return toTransferApprovalModel(resolved), tx.Commit()
+}
+
+// ReleaseExpiredHolds returns coins of approvals nobody resolved in time.
+// Runs periodically by worker.
+func (s *Service) ReleaseExpiredHolds(c context.Context) error {
+	if !s.approvalsEnabled() {
+		return nil
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to release holds", err)
+	}
+	defer tx.Rollback()
+
+	expired, err := s.approvalRepo.GetExpiredApprovals(c, s.now())
+	if err != nil {
+		return apperror.NewInternal("failed to get expired approvals", err)
+	}
+
+	for _, a := range expired {
+		if err = s.releaseHold(c, a); err != nil {
+			return err
+		}
+		if _, err = s.approvalRepo.ResolveApproval(c, a.ApprovalID, models.ApprovalExpired, "", 0); err != nil {
+			return apperror.NewInternal("failed to expire approval", err)
+		}
+	}
+	if len(expired) > 0 {
+		log.Printf("transfer approvals: released %d expired holds", len(expired))
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+const approvalTimeout = 72 * time.Hour
+
+func TestSendCoinWithApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	approvalRepo := mocks.NewMockTransferApprovalRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithTransferApprovals(approvalRepo, 500, approvalTimeout))
+
+	testCases := []struct {
+		name         string
+		amount       int32
+		mockBehavior func(amount int32)
+		expRes       *models.TransferResult
+		expErr       error
+	}{
+		{
+			name:   "OK Below Threshold",
+			amount: 500,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return(&db.Transfer{TransferID: 1}, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.TransferResult{Status: models.TransferCompleted},
+		},
+		{
+			name:   "OK Pending",
+			amount: 501,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				userRepo.EXPECT().
+					HoldCoins(gomock.Any(), mockUser1.Username, amount).
+					Return(&mockUser1, nil)
+				approvalRepo.EXPECT().
+					CreateApproval(gomock.Any(), mockUser1.Username, mockUser2.Username, amount, mockNow.Add(approvalTimeout)).
+					Return(&db.TransferApproval{ApprovalID: 3}, nil)
+				mock.ExpectCommit()
+			},
+			expRes: &models.TransferResult{Status: models.TransferPending, ApprovalID: 3},
+		},
+		{
+			name:   "Err Not Enough Money",
+			amount: mockUser1.Coins + 1,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("not enough money", ErrNotEnoughMoney),
+		},
+		{
+			name:   "Err Receiver Not Found",
+			amount: 501,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, repository.ErrUserNotFound)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewNotFound("users not found: mockuser1, mockuser2", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.amount)
+
+			res, err := srv.SendCoin(context.Background(), mockUser1.Username, mockUser2.Username, tc.amount)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestResolveTransferApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	approvalRepo := mocks.NewMockTransferApprovalRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithTransferApprovals(approvalRepo, 500, approvalTimeout))
+
+	pending := &db.TransferApproval{
+		ApprovalID:   1,
+		FromUsername: mockUser1.Username,
+		ToUsername:   mockUser2.Username,
+		Amount:       700,
+		Status:       models.ApprovalPending,
+		ExpiresAt:    mockNow.Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name         string
+		approve      bool
+		mockBehavior func()
+		expApproval  *models.TransferApproval
+		expErr       error
+	}{
+		{
+			name:    "OK Approve",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				approvalRepo.EXPECT().
+					GetApprovalForUpdate(gomock.Any(), int32(1)).
+					Return(pending, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(700)).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(700)).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, int32(700)).
+					Return(&db.Transfer{TransferID: 9}, nil)
+				approvalRepo.EXPECT().
+					ResolveApproval(gomock.Any(), int32(1), models.ApprovalApproved, "finance", int32(9)).
+					Return(&db.TransferApproval{ApprovalID: 1, Status: models.ApprovalApproved, TransferID: sql.NullInt32{Int32: 9, Valid: true}}, nil)
+				mock.ExpectCommit()
+			},
+			expApproval: &models.TransferApproval{ID: 1, Status: models.ApprovalApproved, TransferID: func() *int32 { v := int32(9); return &v }()},
+		},
+		{
+			name:    "OK Reject",
+			approve: false,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				approvalRepo.EXPECT().
+					GetApprovalForUpdate(gomock.Any(), int32(1)).
+					Return(pending, nil)
+				userRepo.EXPECT().
+					ReleaseCoins(gomock.Any(), mockUser1.Username, int32(700)).
+					Return(&mockUser1, nil)
+				approvalRepo.EXPECT().
+					ResolveApproval(gomock.Any(), int32(1), models.ApprovalRejected, "finance", int32(0)).
+					Return(&db.TransferApproval{ApprovalID: 1, Status: models.ApprovalRejected}, nil)
+				mock.ExpectCommit()
+			},
+			expApproval: &models.TransferApproval{ID: 1, Status: models.ApprovalRejected},
+		},
+		{
+			name:    "Err Expired",
+			approve: true,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expired := *pending
+				expired.ExpiresAt = mockNow
+				approvalRepo.EXPECT().
+					GetApprovalForUpdate(gomock.Any(), int32(1)).
+					Return(&expired, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("transfer approval expired", ErrApprovalExpired),
+		},
+		{
+			name:    "Err Already Resolved",
+			approve: false,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				approvalRepo.EXPECT().
+					GetApprovalForUpdate(gomock.Any(), int32(1)).
+					Return(&db.TransferApproval{ApprovalID: 1, Status: models.ApprovalExpired}, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("transfer approval already resolved", ErrApprovalResolved),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			a, err := srv.ResolveTransferApproval(context.Background(), 1, "finance", tc.approve)
+
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expApproval, a)
+		})
+	}
+}
+
+func TestReleaseExpiredHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	approvalRepo := mocks.NewMockTransferApprovalRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithTransferApprovals(approvalRepo, 500, approvalTimeout))
+
+	mock.ExpectBegin()
+	approvalRepo.EXPECT().
+		GetExpiredApprovals(gomock.Any(), mockNow).
+		Return([]*db.TransferApproval{
+			{ApprovalID: 1, FromUsername: mockUser1.Username, Amount: 600},
+			{ApprovalID: 2, FromUsername: mockUser2.Username, Amount: 800},
+		}, nil)
+	userRepo.EXPECT().
+		ReleaseCoins(gomock.Any(), mockUser1.Username, int32(600)).
+		Return(&mockUser1, nil)
+	approvalRepo.EXPECT().
+		ResolveApproval(gomock.Any(), int32(1), models.ApprovalExpired, "", int32(0)).
+		Return(&db.TransferApproval{}, nil)
+	userRepo.EXPECT().
+		ReleaseCoins(gomock.Any(), mockUser2.Username, int32(800)).
+		Return(&mockUser2, nil)
+	approvalRepo.EXPECT().
+		ResolveApproval(gomock.Any(), int32(2), models.ApprovalExpired, "", int32(0)).
+		Return(&db.TransferApproval{}, nil)
+	mock.ExpectCommit()
+
+	require.NoError(t, srv.ReleaseExpiredHolds(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
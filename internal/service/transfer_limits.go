@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var ErrTransferLimit = errors.New("transfer limit exceeded")
+
+func (s *Service) transferLimitsEnabled() bool {
+	return s.transferLimitRepo != nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func startOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// effectiveTransferLimits merges default limits with user's override.
+// returns apperror.
+func (s *Service) effectiveTransferLimits(c context.Context, username string) (*models.TransferLimits, error) {
+	limits := s.defaultTransferLimits
+
+	override, err := s.transferLimitRepo.GetOverride(c, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoLimitOverride) {
+			return &limits, nil
+		}
+		return nil, apperror.NewInternal("failed to get transfer limits", err)
+	}
+
+	if override.PerTransaction.Valid {
+		limits.PerTransaction = override.PerTransaction.Int32
+	}
+	if override.Daily.Valid {
+		limits.Daily = override.Daily.Int32
+	}
+	if override.Monthly.Valid {
+		limits.Monthly = override.Monthly.Int32
+	}
+	if override.PerRecipient.Valid {
+		limits.PerRecipient = override.PerRecipient.Int32
+	}
+
+	return &limits, nil
+}
+
+type periodLimit struct {
+	name    string
+	max     int32
+	resetAt time.Time
+	sent    func() (int32, error)
+}
+
+// sentAmount sums coins sent since time and coins of transfers waiting
+// for approval or fraud review, toUsername is optional.
+func (s *Service) sentAmount(c context.Context, fromUsername, toUsername string, since time.Time) (int32, error) {
+	var sent int32
+	var err error
+	if toUsername == "" {
+		sent, err = s.transferRepo.GetSentAmountSince(c, fromUsername, since)
+	} else {
+		sent, err = s.transferRepo.GetSentToUserAmountSince(c, fromUsername, toUsername, since)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	reserved, err := s.transferRepo.GetReservedAmount(c, fromUsername, toUsername)
+	if err != nil {
+		return 0, err
+	}
+
+	return sent + reserved, nil
+}
+
+// checkTransferLimits checks amount against sender's limits and
+// already sent or reserved coins in current day and month.
+// reserved is the part of amount already waiting for approval or
+// review, it is non-zero when such transfer is resolved.
+// returns apperror with models.LimitExceeded details.
+func (s *Service) checkTransferLimits(c context.Context, fromUsername, toUsername string, amount, reserved int32) error {
+	limits, err := s.effectiveTransferLimits(c, fromUsername)
+	if err != nil {
+		return err
+	}
+
+	if limits.PerTransaction > 0 && amount > limits.PerTransaction {
+		return apperror.NewForbidden("transfer amount exceeds limit", ErrTransferLimit).
+			WithDetails(&models.LimitExceeded{
+				Limit:     "perTransaction",
+				Max:       limits.PerTransaction,
+				Remaining: limits.PerTransaction,
+			})
+	}
+
+	now := s.now()
+	dayStart, monthStart := startOfDay(now), startOfMonth(now)
+	periods := []periodLimit{
+		{
+			name:    "daily",
+			max:     limits.Daily,
+			resetAt: dayStart.AddDate(0, 0, 1),
+			sent: func() (int32, error) {
+				return s.sentAmount(c, fromUsername, "", dayStart)
+			},
+		},
+		{
+			name:    "monthly",
+			max:     limits.Monthly,
+			resetAt: monthStart.AddDate(0, 1, 0),
+			sent: func() (int32, error) {
+				return s.sentAmount(c, fromUsername, "", monthStart)
+			},
+		},
+		{
+			name:    "perRecipient",
+			max:     limits.PerRecipient,
+			resetAt: dayStart.AddDate(0, 0, 1),
+			sent: func() (int32, error) {
+				return s.sentAmount(c, fromUsername, toUsername, dayStart)
+			},
+		},
+	}
+
+	for _, p := range periods {
+		if p.max <= 0 {
+			continue
+		}
+
+		sent, err := p.sent()
+		if err != nil {
+			return apperror.NewInternal("failed to get sent coins", err)
+		}
+		sent -= reserved
+		if sent+amount <= p.max {
+			continue
+		}
+
+		resetAt := p.resetAt
+		return apperror.NewTooManyRequests(p.name+" transfer limit exceeded", ErrTransferLimit).
+			WithDetails(&models.LimitExceeded{
+				Limit:     p.name,
+				Max:       p.max,
+				Remaining: max(p.max-sent, 0),
+				ResetAt:   &resetAt,
+			})
+	}
+
+	return nil
+}
+
+// GetTransferLimits returns effective limits of user.
+func (s *Service) GetTransferLimits(c context.Context, username string) (*models.TransferLimits, error) {
+	if !s.transferLimitsEnabled() {
+		return nil, apperror.NewNotFound("transfer limits disabled", ErrFeatureDisabled)
+	}
+	return s.effectiveTransferLimits(c, username)
+}
+
+// SetTransferLimitOverride replaces user's limits and returns new effective limits.
+func (s *Service) SetTransferLimitOverride(c context.Context, username string, override *models.TransferLimitOverride) (*models.TransferLimits, error) {
+	if !s.transferLimitsEnabled() {
+		return nil, apperror.NewNotFound("transfer limits disabled", ErrFeatureDisabled)
+	}
+
+	for _, v := range []*int32{override.PerTransaction, override.Daily, override.Monthly, override.PerRecipient} {
+		if v != nil && *v < 0 {
+			return nil, apperror.NewBadReq("limits must not be negative", nil)
+		}
+	}
+
+	if _, err := s.transferLimitRepo.SetOverride(c, username, override); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to set transfer limits", err)
+	}
+
+	return s.effectiveTransferLimits(c, username)
+}
+
+// DeleteTransferLimitOverride resets user's limits to defaults.
+func (s *Service) DeleteTransferLimitOverride(c context.Context, username string) error {
+	if !s.transferLimitsEnabled() {
+		return apperror.NewNotFound("transfer limits disabled", ErrFeatureDisabled)
+	}
+
+	if err := s.transferLimitRepo.DeleteOverride(c, username); err != nil {
+		if errors.Is(err, repository.ErrNoLimitOverride) {
+			return apperror.NewNotFound("no limits override for user", err)
+		}
+		return apperror.NewInternal("failed to delete transfer limits", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+var mockTransferLimits = models.TransferLimits{PerTransaction: 100, Daily: 200, Monthly: 500, PerRecipient: 150}
+
+func TestSendCoinWithLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	limitRepo := mocks.NewMockTransferLimitRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithTransferLimits(limitRepo, mockTransferLimits))
+
+	dayStart := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	nextDay := dayStart.AddDate(0, 0, 1)
+
+	testCases := []struct {
+		name         string
+		amount       int32
+		mockBehavior func(amount int32)
+		expErr       error
+	}{
+		{
+			name:   "OK",
+			amount: 50,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(nil, repository.ErrNoLimitOverride)
+				transferRepo.EXPECT().
+					GetSentAmountSince(gomock.Any(), mockUser1.Username, dayStart).
+					Return(int32(100), nil).Times(2) // day and month start are equal
+				transferRepo.EXPECT().
+					GetReservedAmount(gomock.Any(), mockUser1.Username, "").
+					Return(int32(0), nil).Times(2)
+				transferRepo.EXPECT().
+					GetSentToUserAmountSince(gomock.Any(), mockUser1.Username, mockUser2.Username, dayStart).
+					Return(int32(100), nil)
+				transferRepo.EXPECT().
+					GetReservedAmount(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(int32(0), nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return(nil, nil)
+				mock.ExpectCommit()
+			},
+			expErr: nil,
+		},
+		{
+			name:   "Err Per Transaction",
+			amount: 101,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(nil, repository.ErrNoLimitOverride)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewForbidden("transfer amount exceeds limit", ErrTransferLimit).
+				WithDetails(&models.LimitExceeded{Limit: "perTransaction", Max: 100, Remaining: 100}),
+		},
+		{
+			name:   "Err Daily",
+			amount: 50,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(nil, repository.ErrNoLimitOverride)
+				transferRepo.EXPECT().
+					GetSentAmountSince(gomock.Any(), mockUser1.Username, dayStart).
+					Return(int32(180), nil)
+				transferRepo.EXPECT().
+					GetReservedAmount(gomock.Any(), mockUser1.Username, "").
+					Return(int32(0), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewTooManyRequests("daily transfer limit exceeded", ErrTransferLimit).
+				WithDetails(&models.LimitExceeded{Limit: "daily", Max: 200, Remaining: 20, ResetAt: &nextDay}),
+		},
+		{
+			name:   "Err Daily With Pending",
+			amount: 50,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(nil, repository.ErrNoLimitOverride)
+				transferRepo.EXPECT().
+					GetSentAmountSince(gomock.Any(), mockUser1.Username, dayStart).
+					Return(int32(100), nil)
+				transferRepo.EXPECT().
+					GetReservedAmount(gomock.Any(), mockUser1.Username, "").
+					Return(int32(80), nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewTooManyRequests("daily transfer limit exceeded", ErrTransferLimit).
+				WithDetails(&models.LimitExceeded{Limit: "daily", Max: 200, Remaining: 20, ResetAt: &nextDay}),
+		},
+		{
+			name:   "OK Override",
+			amount: 300,
+			mockBehavior: func(amount int32) {
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+					Return(nil, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(&db.TransferLimitOverride{
+						Username:       mockUser1.Username,
+						PerTransaction: sql.NullInt32{Int32: 0, Valid: true},
+						Daily:          sql.NullInt32{Int32: 0, Valid: true},
+						Monthly:        sql.NullInt32{Int32: 0, Valid: true},
+						PerRecipient:   sql.NullInt32{Int32: 0, Valid: true},
+					}, nil)
+				userRepo.EXPECT().
+					UpdateTwoUsersBalance(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return([]*db.User{&mockUser1, &mockUser2}, nil)
+				transferRepo.EXPECT().
+					CreateMoneyTransfer(gomock.Any(), mockUser1.Username, mockUser2.Username, amount).
+					Return(nil, nil)
+				mock.ExpectCommit()
+			},
+			expErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.amount)
+
+			_, err := srv.SendCoin(context.Background(), mockUser1.Username, mockUser2.Username, tc.amount)
+
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestSetTransferLimitOverride(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limitRepo := mocks.NewMockTransferLimitRepository(ctrl)
+
+	srv := NewService(nil, nil, nil, nil, nil, nil, nil, nil, WithTransferLimits(limitRepo, mockTransferLimits))
+
+	daily := int32(1000)
+	negative := int32(-1)
+
+	testCases := []struct {
+		name         string
+		override     *models.TransferLimitOverride
+		mockBehavior func(override *models.TransferLimitOverride)
+		expLimits    *models.TransferLimits
+		expErr       error
+	}{
+		{
+			name:     "OK",
+			override: &models.TransferLimitOverride{Daily: &daily},
+			mockBehavior: func(override *models.TransferLimitOverride) {
+				limitRepo.EXPECT().
+					SetOverride(gomock.Any(), mockUser1.Username, override).
+					Return(&db.TransferLimitOverride{}, nil)
+				limitRepo.EXPECT().
+					GetOverride(gomock.Any(), mockUser1.Username).
+					Return(&db.TransferLimitOverride{Username: mockUser1.Username, Daily: sql.NullInt32{Int32: daily, Valid: true}}, nil)
+			},
+			expLimits: &models.TransferLimits{PerTransaction: 100, Daily: 1000, Monthly: 500, PerRecipient: 150},
+			expErr:    nil,
+		},
+		{
+			name:         "Err Negative",
+			override:     &models.TransferLimitOverride{Monthly: &negative},
+			mockBehavior: func(override *models.TransferLimitOverride) {},
+			expLimits:    nil,
+			expErr:       apperror.NewBadReq("limits must not be negative", nil),
+		},
+		{
+			name:     "Err User Not Found",
+			override: &models.TransferLimitOverride{Daily: &daily},
+			mockBehavior: func(override *models.TransferLimitOverride) {
+				limitRepo.EXPECT().
+					SetOverride(gomock.Any(), mockUser1.Username, override).
+					Return(nil, repository.ErrUserNotFound)
+			},
+			expLimits: nil,
+			expErr:    apperror.NewNotFound("user not found", repository.ErrUserNotFound),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.override)
+
+			limits, err := srv.SetTransferLimitOverride(context.Background(), mockUser1.Username, tc.override)
+
+			require.Equal(t, tc.expLimits, limits)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestResolveTransferApprovalWithLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	transferRepo := mocks.NewMockTransferRepository(ctrl)
+	limitRepo := mocks.NewMockTransferLimitRepository(ctrl)
+	approvalRepo := mocks.NewMockTransferApprovalRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, transferRepo, nil, nil, nil, nil, nil,
+		WithClock(mockClock), WithTransferLimits(limitRepo, mockTransferLimits),
+		WithTransferApprovals(approvalRepo, 500, time.Hour))
+
+	nextDay := time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC)
+
+	// daily limit was lowered while transfer was pending
+	mock.ExpectBegin()
+	approvalRepo.EXPECT().
+		GetApprovalForUpdate(gomock.Any(), int32(1)).
+		Return(&db.TransferApproval{
+			ApprovalID:   1,
+			FromUsername: mockUser1.Username,
+			ToUsername:   mockUser2.Username,
+			Amount:       700,
+			Status:       models.ApprovalPending,
+			ExpiresAt:    mockNow.Add(time.Hour),
+		}, nil)
+	userRepo.EXPECT().
+		LockTwoUsers(gomock.Any(), mockUser1.Username, mockUser2.Username).
+		Return(nil, nil)
+	limitRepo.EXPECT().
+		GetOverride(gomock.Any(), mockUser1.Username).
+		Return(&db.TransferLimitOverride{
+			Username:       mockUser1.Username,
+			PerTransaction: sql.NullInt32{Int32: 0, Valid: true},
+			Daily:          sql.NullInt32{Int32: 800, Valid: true},
+			Monthly:        sql.NullInt32{Int32: 0, Valid: true},
+			PerRecipient:   sql.NullInt32{Int32: 0, Valid: true},
+		}, nil)
+	transferRepo.EXPECT().
+		GetSentAmountSince(gomock.Any(), mockUser1.Username, gomock.Any()).
+		Return(int32(200), nil)
+	transferRepo.EXPECT().
+		GetReservedAmount(gomock.Any(), mockUser1.Username, "").
+		Return(int32(700), nil) // the approval itself
+	mock.ExpectRollback()
+
+	a, err := srv.ResolveTransferApproval(context.Background(), 1, "finance", true)
+
+	require.Nil(t, a)
+	require.Equal(t, apperror.NewTooManyRequests("daily transfer limit exceeded", ErrTransferLimit).
+		WithDetails(&models.LimitExceeded{Limit: "daily", Max: 800, Remaining: 600, ResetAt: &nextDay}), err)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+var (
+	ErrVariantRequired = errors.New("variant required")
+	ErrInvalidVariant  = errors.New("invalid variant")
+	ErrOutOfStock      = errors.New("variant out of stock")
+)
+
+func toVariantModel(v *db.ItemVariant, price int32) *models.Variant {
+	return &models.Variant{
+		SKU:        v.Sku,
+		Size:       v.Size,
+		Color:      v.Color,
+		PriceDelta: v.PriceDelta,
+		Price:      max(price+v.PriceDelta, 0),
+		Stock:      v.Stock,
+	}
+}
+
+// toVariantModels converts variants of item, price is current price of item.
+func toVariantModels(variants []*db.ItemVariant, price int32) []*models.Variant {
+	var res []*models.Variant
+	for _, v := range variants {
+		res = append(res, toVariantModel(v, price))
+	}
+	return res
+}
+
+// getItemInfo returns item with sale price active by now and its variants.
+// returns repository errors.
+func (s *Service) getItemInfo(c context.Context, itemName string) (*models.Item, error) {
+	item, err := s.storeRepo.GetItemInfo(c, itemName, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := s.storeRepo.ListItemVariants(c, itemName)
+	if err != nil {
+		return nil, err
+	}
+	item.Variants = toVariantModels(variants, item.CurrentPrice)
+
+	return item, nil
+}
+
+// listItems returns catalog with sale prices active by now and variants.
+// returns repository errors.
+func (s *Service) listItems(c context.Context) ([]*models.Item, error) {
+	items, err := s.storeRepo.ListItems(c, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := s.storeRepo.ListAllItemVariants(c)
+	if err != nil {
+		return nil, err
+	}
+	byType := make(map[string][]*db.ItemVariant)
+	for _, v := range variants {
+		byType[v.ItemType] = append(byType[v.ItemType], v)
+	}
+	for _, item := range items {
+		item.Variants = toVariantModels(byType[item.Type], item.CurrentPrice)
+	}
+
+	return items, nil
+}
+
+// checkVariant checks that sku is given exactly for items with variants.
+// returns apperror.
+func checkVariant(item *models.Item, sku string) error {
+	if len(item.Variants) > 0 && sku == "" {
+		return apperror.NewBadReq("variant required", ErrVariantRequired).WithDetails(item.Variants)
+	}
+	if len(item.Variants) == 0 && sku != "" {
+		return apperror.NewBadReq("item has no variants", ErrInvalidVariant)
+	}
+	return nil
+}
+
+func hasVariant(item *models.Item, sku string) bool {
+	for _, v := range item.Variants {
+		if v.SKU == sku {
+			return true
+		}
+	}
+	return false
+}
+
+// variantPrice returns price of variant of item,
+// sku must be one of item's variants.
+// returns apperror.
+func variantPrice(item *models.Item, sku string) (int32, error) {
+	for _, v := range item.Variants {
+		if v.SKU == sku {
+			return v.Price, nil
+		}
+	}
+	return 0, apperror.NewBadReq("invalid variant", ErrInvalidVariant)
+}
+
+// takeVariant takes quantity units of variant from stock. Call it
+// after every other check of purchase, so nothing is left to fail
+// while stock is taken.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) takeVariant(c context.Context, sku string, quantity int32) error {
+	if err := s.storeRepo.TakeVariantStock(c, sku, quantity); err != nil {
+		if errors.Is(err, repository.ErrVariantOutOfStock) {
+			return apperror.NewBadReq("variant out of stock", ErrOutOfStock).
+				WithDetails(map[string]string{"sku": sku})
+		}
+		return apperror.NewInternal("failed to update stock", err)
+	}
+	return nil
+}
+
+// returnVariant puts items of variant back to stock.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) returnVariant(c context.Context, sku string, quantity int32) error {
+	if err := s.storeRepo.AddVariantStock(c, sku, quantity); err != nil {
+		return apperror.NewInternal("failed to update stock", err)
+	}
+	return nil
+}
+
+// CreateVariant adds variant to item. Price of variant
+// is item price plus delta and can't drop to zero.
+func (s *Service) CreateVariant(c context.Context, itemName string, variant *models.NewVariant) (*models.Variant, error) {
+	if variant.SKU == "" || len(variant.SKU) > 64 {
+		return nil, apperror.NewBadReq("invalid sku", nil)
+	}
+	if variant.Size == "" && variant.Color == "" {
+		return nil, apperror.NewBadReq("variant must have size or color", nil)
+	}
+	if variant.Stock < 0 {
+		return nil, apperror.NewBadReq("stock must not be negative", nil)
+	}
+
+	item, err := s.storeRepo.GetItemInfo(c, itemName, s.now())
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+	if item.Price+variant.PriceDelta <= 0 {
+		return nil, apperror.NewBadReq("variant price must be positive", nil)
+	}
+
+	v, err := s.storeRepo.CreateVariant(c, itemName, variant)
+	if err != nil {
+		if errors.Is(err, repository.ErrVariantExists) {
+			return nil, apperror.NewBadReq("variant already exists", err)
+		}
+		return nil, apperror.NewInternal("failed to create variant", err)
+	}
+
+	return toVariantModel(v, item.CurrentPrice), nil
+}
+
+// SetVariantStock sets stock of variant, e.g. after delivery.
+func (s *Service) SetVariantStock(c context.Context, sku string, stock int32) (*models.Variant, error) {
+	if stock < 0 {
+		return nil, apperror.NewBadReq("stock must not be negative", nil)
+	}
+
+	v, err := s.storeRepo.SetVariantStock(c, sku, stock)
+	if err != nil {
+		if errors.Is(err, repository.ErrVariantNotFound) {
+			return nil, apperror.NewNotFound("variant not found", err)
+		}
+		return nil, apperror.NewInternal("failed to update stock", err)
+	}
+
+	item, err := s.storeRepo.GetItemInfo(c, v.ItemType, s.now())
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+
+	return toVariantModel(v, item.CurrentPrice), nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuyItemVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	inventoryRepo := mocks.NewMockInventoryRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, userRepo, nil, inventoryRepo, storeRepo, nil, nil, nil)
+
+	hoody := &models.Item{Type: "hoody", Price: 300, CurrentPrice: 300}
+	hoodyVariants := []*db.ItemVariant{
+		{Sku: "hoody-m", ItemType: "hoody", Size: "M", Stock: 2},
+		{Sku: "hoody-xxl", ItemType: "hoody", Size: "XXL", PriceDelta: 20, Stock: 0},
+	}
+
+	testCases := []struct {
+		name         string
+		itemName     string
+		sku          string
+		mockBehavior func()
+		expErr       error
+	}{
+		{
+			name:     "OK",
+			itemName: "hoody",
+			sku:      "hoody-xxl",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-320).
+					Return(&mockUser1, nil)
+				storeRepo.EXPECT().
+					TakeVariantStock(gomock.Any(), "hoody-xxl", int32(1)).
+					Return(nil)
+				inventoryRepo.EXPECT().
+					AddItemToInventory(gomock.Any(), mockUser1.UserID, "hoody", "hoody-xxl").
+					Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "Err Variant Required",
+			itemName: "hoody",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+			},
+			// variants are listed with their prices
+			expErr: apperror.NewBadReq("variant required", ErrVariantRequired).WithDetails([]*models.Variant{
+				{SKU: "hoody-m", Size: "M", Price: 300, Stock: 2},
+				{SKU: "hoody-xxl", Size: "XXL", PriceDelta: 20, Price: 320, Stock: 0},
+			}),
+		},
+		{
+			name:     "Err No Variants",
+			itemName: "cup",
+			sku:      "cup-red",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "cup", gomock.Any()).
+					Return(&models.Item{Type: "cup", Price: 20, CurrentPrice: 20}, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "cup").
+					Return(nil, nil)
+			},
+			expErr: apperror.NewBadReq("item has no variants", ErrInvalidVariant),
+		},
+		{
+			name:     "Err Other Item Variant",
+			itemName: "hoody",
+			sku:      "tshirt-m",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("invalid variant", ErrInvalidVariant),
+		},
+		{
+			name:     "Err Out Of Stock",
+			itemName: "hoody",
+			sku:      "hoody-xxl",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+				mock.ExpectBegin()
+				userRepo.EXPECT().
+					GetUserForUpdate(gomock.Any(), mockUser1.Username).
+					Return(&mockUser1, nil)
+				userRepo.EXPECT().
+					UpdateBalance(gomock.Any(), mockUser1.UserID, mockUser1.Coins-320).
+					Return(&mockUser1, nil)
+				storeRepo.EXPECT().
+					TakeVariantStock(gomock.Any(), "hoody-xxl", int32(1)).
+					Return(repository.ErrVariantOutOfStock)
+				mock.ExpectRollback()
+			},
+			expErr: apperror.NewBadReq("variant out of stock", ErrOutOfStock).
+				WithDetails(map[string]string{"sku": "hoody-xxl"}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := srv.BuyItem(context.Background(), mockUser1.Username, tc.itemName, tc.sku, "")
+			require.Equal(t, tc.expErr, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+)
+
+func (s *Service) wishlistsEnabled() bool {
+	return s.wishlistRepo != nil
+}
+
+// lowestPrice returns cheapest current price of item among its variants.
+func lowestPrice(item *models.Item) int32 {
+	price := item.CurrentPrice
+	for i, v := range item.Variants {
+		if i == 0 || v.Price < price {
+			price = v.Price
+		}
+	}
+
+	return price
+}
+
+// inStock reports if any variant of item is in stock,
+// items without variants are always in stock.
+func inStock(item *models.Item) bool {
+	if len(item.Variants) == 0 {
+		return true
+	}
+	for _, v := range item.Variants {
+		if v.Stock > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toWishlistItem(w *db.Wishlist, item *models.Item) *models.WishlistItem {
+	return &models.WishlistItem{
+		Item:         item.Type,
+		Price:        item.Price,
+		CurrentPrice: item.CurrentPrice,
+		SaleEndsAt:   item.SaleEndsAt,
+		InStock:      inStock(item),
+		AddedAt:      w.CreatedAt,
+	}
+}
+
+// GetWishlist returns items saved by user with current prices.
+func (s *Service) GetWishlist(c context.Context, username string) ([]*models.WishlistItem, error) {
+	if !s.wishlistsEnabled() {
+		return nil, apperror.NewNotFound("wishlists disabled", ErrFeatureDisabled)
+	}
+
+	wishlist, err := s.wishlistRepo.ListWishlist(c, username)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get wishlist", err)
+	}
+
+	items, err := s.listItems(c)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to get items", err)
+	}
+	byType := make(map[string]*models.Item, len(items))
+	for _, item := range items {
+		byType[item.Type] = item
+	}
+
+	res := make([]*models.WishlistItem, 0, len(wishlist))
+	for _, w := range wishlist {
+		if item, ok := byType[w.ItemType]; ok {
+			res = append(res, toWishlistItem(w, item))
+		}
+	}
+
+	return res, nil
+}
+
+// AddToWishlist saves item to user's wishlist. User is notified
+// only about changes happened after item was added.
+func (s *Service) AddToWishlist(c context.Context, username, itemName string) (*models.WishlistItem, error) {
+	if !s.wishlistsEnabled() {
+		return nil, apperror.NewNotFound("wishlists disabled", ErrFeatureDisabled)
+	}
+
+	item, err := s.getItemInfo(c, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidItemName) {
+			return nil, apperror.NewBadReq("invalid item name", err)
+		}
+		return nil, apperror.NewInternal("failed to get item info", err)
+	}
+
+	dbUsr, err := s.userRepo.GetUser(c, username)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apperror.NewNotFound("user not found", err)
+		}
+		return nil, apperror.NewInternal("failed to get user", err)
+	}
+
+	w, err := s.wishlistRepo.AddItem(c, &models.WishlistState{
+		Username:       username,
+		Item:           itemName,
+		Affordable:     dbUsr.Coins >= lowestPrice(item),
+		InStock:        inStock(item),
+		SaleScheduleID: item.ScheduleID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistItemExists) {
+			return nil, apperror.NewBadReq("item already in wishlist", err)
+		}
+		return nil, apperror.NewInternal("failed to add item to wishlist", err)
+	}
+
+	return toWishlistItem(w, item), nil
+}
+
+// RemoveFromWishlist removes item from user's wishlist.
+func (s *Service) RemoveFromWishlist(c context.Context, username, itemName string) error {
+	if !s.wishlistsEnabled() {
+		return apperror.NewNotFound("wishlists disabled", ErrFeatureDisabled)
+	}
+
+	err := s.wishlistRepo.RemoveItem(c, username, itemName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWishlistItemNotFound) {
+			return apperror.NewNotFound("item not in wishlist", err)
+		}
+		return apperror.NewInternal("failed to remove item from wishlist", err)
+	}
+
+	return nil
+}
+
+// notifyWishlist notifies user about changes of wishlist item
+// since previous check and saves new state.
+// Should be called only in transactions.
+// returns apperror.
+func (s *Service) notifyWishlist(c context.Context, w *db.ListWishlistsWithCoinsRow, item *models.Item) error {
+	prev := &models.WishlistState{
+		Username:       w.Username,
+		Item:           w.ItemType,
+		Affordable:     w.Affordable,
+		InStock:        w.InStock,
+		SaleScheduleID: w.SaleScheduleID.Int32,
+	}
+	state := *prev
+	state.Affordable = w.Coins >= lowestPrice(item)
+	state.InStock = inStock(item)
+	if item.ScheduleID != 0 {
+		state.SaleScheduleID = item.ScheduleID
+	}
+	if state == *prev {
+		return nil
+	}
+
+	if state.Affordable && !prev.Affordable {
+		text := fmt.Sprintf("you have enough coins for %s from your wishlist", item.Type)
+		if err := s.notify(c, w.Username, models.NotificationAffordable, text); err != nil {
+			return err
+		}
+	}
+	if state.SaleScheduleID != prev.SaleScheduleID {
+		text := fmt.Sprintf("%s from your wishlist is on sale for %d coins", item.Type, item.CurrentPrice)
+		if err := s.notify(c, w.Username, models.NotificationPriceDrop, text); err != nil {
+			return err
+		}
+	}
+	if state.InStock && !prev.InStock {
+		text := fmt.Sprintf("%s from your wishlist is back in stock", item.Type)
+		if err := s.notify(c, w.Username, models.NotificationRestock, text); err != nil {
+			return err
+		}
+	}
+
+	if err := s.wishlistRepo.UpdateState(c, &state); err != nil {
+		return apperror.NewInternal("failed to update wishlist", err)
+	}
+
+	return nil
+}
+
+// NotifyWishlists notifies users when balance reaches price of
+// wishlist item, item goes on sale or is restocked.
+func (s *Service) NotifyWishlists(c context.Context) error {
+	if !s.wishlistsEnabled() {
+		return nil
+	}
+
+	c, tx, err := s.beginTx(c)
+	if err != nil {
+		return apperror.NewInternal("failed to check wishlists", err)
+	}
+	defer tx.Rollback()
+
+	wishlists, err := s.wishlistRepo.ListWithCoins(c)
+	if err != nil {
+		return apperror.NewInternal("failed to get wishlists", err)
+	}
+	if len(wishlists) == 0 {
+		return nil
+	}
+
+	items, err := s.listItems(c)
+	if err != nil {
+		return apperror.NewInternal("failed to get items", err)
+	}
+	byType := make(map[string]*models.Item, len(items))
+	for _, item := range items {
+		byType[item.Type] = item
+	}
+
+	for _, w := range wishlists {
+		item, ok := byType[w.ItemType]
+		if !ok {
+			log.Printf("wishlists: unknown item %s", w.ItemType)
+			continue
+		}
+		if err = s.notifyWishlist(c, w, item); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
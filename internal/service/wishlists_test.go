@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	db "github.com/myacey/avito-shop/db/sqlc"
+	"github.com/myacey/avito-shop/internal/apperror"
+	"github.com/myacey/avito-shop/internal/mocks"
+	"github.com/myacey/avito-shop/internal/models"
+	"github.com/myacey/avito-shop/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddToWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	wishlistRepo := mocks.NewMockWishlistRepository(ctrl)
+
+	srv := NewService(nil, userRepo, nil, nil, storeRepo, nil, nil, nil, WithWishlists(wishlistRepo))
+
+	hoody := &models.Item{Type: "hoody", Price: 300, CurrentPrice: 300}
+	hoodyVariants := []*db.ItemVariant{{Sku: "hoody-m", ItemType: "hoody", Stock: 0}}
+	rich := db.User{UserID: 1, Username: "rich", Coins: 1000}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func()
+		expRes       *models.WishlistItem
+		expErr       error
+	}{
+		{
+			name: "OK",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "rich").
+					Return(&rich, nil)
+				// already affordable and out of stock,
+				// user is notified only about future changes
+				wishlistRepo.EXPECT().
+					AddItem(gomock.Any(), &models.WishlistState{Username: "rich", Item: "hoody", Affordable: true}).
+					Return(&db.Wishlist{Username: "rich", ItemType: "hoody", CreatedAt: mockNow}, nil)
+			},
+			expRes: &models.WishlistItem{Item: "hoody", Price: 300, CurrentPrice: 300, AddedAt: mockNow},
+		},
+		{
+			name: "Err Exists",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(hoody, nil)
+				storeRepo.EXPECT().
+					ListItemVariants(gomock.Any(), "hoody").
+					Return(hoodyVariants, nil)
+				userRepo.EXPECT().
+					GetUser(gomock.Any(), "rich").
+					Return(&rich, nil)
+				wishlistRepo.EXPECT().
+					AddItem(gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrWishlistItemExists)
+			},
+			expErr: apperror.NewBadReq("item already in wishlist", repository.ErrWishlistItemExists),
+		},
+		{
+			name: "Err Invalid Item",
+			mockBehavior: func() {
+				storeRepo.EXPECT().
+					GetItemInfo(gomock.Any(), "hoody", gomock.Any()).
+					Return(nil, repository.ErrInvalidItemName)
+			},
+			expErr: apperror.NewBadReq("invalid item name", repository.ErrInvalidItemName),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			res, err := srv.AddToWishlist(context.Background(), "rich", "hoody")
+			require.Equal(t, tc.expErr, err)
+			require.Equal(t, tc.expRes, res)
+		})
+	}
+}
+
+func TestNotifyWishlists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mocks.NewMockStoreRepository(ctrl)
+	wishlistRepo := mocks.NewMockWishlistRepository(ctrl)
+	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
+
+	dbConn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer dbConn.Close()
+
+	srv := NewService(dbConn, nil, nil, nil, storeRepo, nil, nil, nil,
+		WithWishlists(wishlistRepo), WithNotifications(notificationRepo))
+
+	mock.ExpectBegin()
+	wishlistRepo.EXPECT().
+		ListWithCoins(gomock.Any()).
+		Return([]*db.ListWishlistsWithCoinsRow{
+			// balance reached price
+			{Username: "alice", ItemType: "cup", InStock: true, Coins: 20},
+			// nothing changed
+			{Username: "bob", ItemType: "cup", InStock: true, Coins: 10},
+			// sale started and variant restocked
+			{Username: "bob", ItemType: "hoody", Coins: 10},
+			// already notified about this sale
+			{Username: "carol", ItemType: "hoody", InStock: true, SaleScheduleID: sql.NullInt32{Int32: 7, Valid: true}},
+		}, nil)
+	storeRepo.EXPECT().
+		ListItems(gomock.Any(), gomock.Any()).
+		Return([]*models.Item{
+			{Type: "cup", Price: 20, CurrentPrice: 20},
+			{
+				Type:         "hoody",
+				Price:        300,
+				CurrentPrice: 200,
+				ScheduleID:   7,
+			},
+		}, nil)
+	storeRepo.EXPECT().
+		ListAllItemVariants(gomock.Any()).
+		Return([]*db.ItemVariant{{Sku: "hoody-m", ItemType: "hoody", Stock: 3}}, nil)
+
+	notificationRepo.EXPECT().
+		CreateNotification(gomock.Any(), "alice", models.NotificationAffordable, "you have enough coins for cup from your wishlist").
+		Return(&db.Notification{}, nil)
+	wishlistRepo.EXPECT().
+		UpdateState(gomock.Any(), &models.WishlistState{Username: "alice", Item: "cup", Affordable: true, InStock: true}).
+		Return(nil)
+
+	notificationRepo.EXPECT().
+		CreateNotification(gomock.Any(), "bob", models.NotificationPriceDrop, "hoody from your wishlist is on sale for 200 coins").
+		Return(&db.Notification{}, nil)
+	notificationRepo.EXPECT().
+		CreateNotification(gomock.Any(), "bob", models.NotificationRestock, "hoody from your wishlist is back in stock").
+		Return(&db.Notification{}, nil)
+	wishlistRepo.EXPECT().
+		UpdateState(gomock.Any(), &models.WishlistState{Username: "bob", Item: "hoody", InStock: true, SaleScheduleID: 7}).
+		Return(nil)
+	mock.ExpectCommit()
+
+	require.NoError(t, srv.NotifyWishlists(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
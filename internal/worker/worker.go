@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a periodic task. Errors are only logged,
+// the job will be called again on next tick.
+type Job func(c context.Context) error
+
+// Run calls job every interval until ctx is done.
+func Run(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Printf("worker %s failed: %v", name, err)
+			}
+		}
+	}
+}
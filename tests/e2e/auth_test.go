@@ -55,7 +55,7 @@ func TestAuthorization(t *testing.T) {
 
 	payload := authReq{
 		Username: "testuser",
-		Password: "testuser",
+		Password: "Test-passw0rd",
 	}
 	payloadBytes, err := json.Marshal(payload)
 	require.NoError(t, err)
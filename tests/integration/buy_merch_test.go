@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -57,6 +58,12 @@ func TestBuyItem(t *testing.T) {
 	mockStore.EXPECT().
 		GetItemFromStore(gomock.Any(), itemType).
 		Return(item, nil)
+	mockStore.EXPECT().
+		GetActivePriceSchedule(gomock.Any(), gomock.Any()).
+		Return(db.PriceSchedule{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		ListItemVariants(gomock.Any(), itemType).
+		Return(nil, nil)
 
 	srv := service.NewService(dbConn, userRepo, transferRepo, inventoryRepo, storeRepo, nil, nil, nil)
 
@@ -69,8 +69,8 @@ func TestGetFullUserInfo(t *testing.T) {
 		Username: "mockuser1",
 		Coins:    mockDBUser.Coins,
 		Inventory: []*models.InventoryItem{
-			{"mockItem1", 10},
-			{"mockItem2", 20},
+			{Type: "mockItem1", Quantity: 10},
+			{Type: "mockItem2", Quantity: 20},
 		},
 		EntryHistory: map[string]interface{}{
 			"received": []*service.IncomeEntry{
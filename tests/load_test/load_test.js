@@ -27,7 +27,7 @@ export default function() {
     // 1. Auth (POST /api/auth)
     let authPayload = JSON.stringify({
         username: username,
-        password: "testpassword",
+        password: "Test-passw0rd",
     });
     let authParams = { headers: { "Content-Type": "application/json"} };
 